@@ -30,25 +30,122 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a list of all games in the system.",
+                "description": "Returns a page of the games that pass the filters, newest first by default, with the number of games that pass them on all pages. Games can be filtered by status, scenario, session and creation date, both dates included, and sorted by creation time or by day (most played first, then newest). The next_cursor of a page fetches the one after it with the same filters and sort; it is left out on the last page. With view=leaderboard it ranks the ended games instead, by profit (highest first, the default), lead_time (median, shortest first), throughput or fixed_date (share of fixed-date cards deployed). Games with equal scores share a rank. The leaderboard can be narrowed to one scenario and to games created between two dates, both included. Profit uses the standard team and card values for every game. Each entry of the leaderboard is a models.LeaderboardEntry.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "games"
                 ],
-                "summary": "List all games",
+                "summary": "List games",
+                "parameters": [
+                    {
+                        "enum": [
+                            "leaderboard"
+                        ],
+                        "type": "string",
+                        "description": "leaderboard to rank ended games",
+                        "name": "view",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "profit",
+                            "lead_time",
+                            "throughput",
+                            "fixed_date"
+                        ],
+                        "type": "string",
+                        "description": "Score to rank by",
+                        "name": "score",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "lobby",
+                            "running",
+                            "paused",
+                            "ended"
+                        ],
+                        "type": "string",
+                        "description": "Only games in this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only games of this scenario",
+                        "name": "scenario",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Only games of this session",
+                        "name": "session",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Only games created on or after this date",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "Only games created on or before this date",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created",
+                            "day"
+                        ],
+                        "type": "string",
+                        "default": "created",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "desc",
+                            "asc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Direction of the sort",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Games per page, up to 200",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "List of games",
+                        "description": "Page of games",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/models.Game"
-                                }
-                            }
+                            "$ref": "#/definitions/models.GamePage"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown view, score, status, sort or order, invalid date, session or cursor, or limit out of range",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "403": {
@@ -71,7 +168,10 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new game using the embedded default board; no request body required",
+                "description": "Creates a new game using the embedded default board. The body is optional; it may set the dice seed to replay a known game: with the same seed, the same players joining in the same order roll the same dice. Without one a random seed is picked. The seed is only returned here, to the game's creator, and to its facilitator. Naming a facilitator creates them as the game's first player; their token comes with the response.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
@@ -79,6 +179,16 @@ const docTemplate = `{
                     "games"
                 ],
                 "summary": "Create a new game",
+                "parameters": [
+                    {
+                        "description": "Optional dice seed and facilitator",
+                        "name": "game",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.createGameRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "201": {
                         "description": "New game created",
@@ -86,6 +196,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.CreateGameResponse"
                         }
                     },
+                    "400": {
+                        "description": "Invalid JSON body",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "403": {
                         "description": "Missing or invalid token",
                         "schema": {
@@ -101,43 +217,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/games/{game_id}/players": {
-            "get": {
+        "/games/import": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a list of players belonging to the given game UUID.",
+                "description": "Creates a new game from an exported JSON document. All rows get fresh UUIDs and events are re-pointed at the new cards; everything is written in a single transaction. The copy of the archived game's facilitator runs the new game, and their token is returned.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "players"
+                    "games"
                 ],
-                "summary": "List all players by game ID",
+                "summary": "Import a game",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Game ID",
-                        "name": "game_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Game archive",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GameExport"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "List of players",
+                    "201": {
+                        "description": "Game imported",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Player"
-                            }
+                            "$ref": "#/definitions/response.CreateGameResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid or missing game ID",
+                        "description": "Invalid JSON or archive",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -148,12 +265,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Players not found",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "405": {
                         "description": "Method not allowed",
                         "schema": {
@@ -176,7 +287,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns the full game record for the given UUID.",
+                "description": "Returns the full game record for the given UUID. The dice seed is only included for the game's facilitator.",
                 "produces": [
                     "application/json"
                 ],
@@ -262,7 +373,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Missing or invalid token",
+                        "description": "Missing or invalid token, or not the facilitator",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -334,7 +445,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Missing or invalid token",
+                        "description": "Missing or invalid token, or not the facilitator",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -351,6 +462,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Game is not running",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -360,21 +477,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/games/{id}/columns": {
-            "get": {
+        "/games/{id}/bot": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns the list of columns (including subcolumns) belonging to the specified game UUID.",
+                "description": "A built-in strategy (strict-wip, maximise-utilisation, highest-value-first or finish-before-start) makes the day's moves and puts a team of bot workers on cards. Their dice come from the game's seed. The work, the moves and their events are stored, and the game moves on to the next day.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "columns"
+                    "games"
                 ],
-                "summary": "List columns by game ID",
+                "summary": "Let a bot play a day",
                 "parameters": [
                     {
                         "type": "string",
@@ -383,26 +503,32 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Strategy and team",
+                        "name": "bot",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.playBotDayRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of columns",
+                        "description": "Day played",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Column"
-                            }
+                            "$ref": "#/definitions/response.BotDayResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid or missing game ID",
+                        "description": "Invalid game ID, strategy or team",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Missing or invalid token",
+                        "description": "Missing or invalid token, or not the facilitator",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -419,6 +545,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Game is not running",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Board cannot be played by a bot",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -428,44 +566,98 @@ const docTemplate = `{
                 }
             }
         },
-        "/players": {
-            "post": {
+        "/games/{id}/cards": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "## CreatePlayer handles the creation of a new player in the game.\n\nIt expects a **POST request with a JSON payload** containing the player's details. The payload **must include a valid GameID and a non-empty Name**. If the request method is not POST, it responds with a \"method not allowed\" error. If the payload is invalid or fails validation, it responds with a \"bad request\" error. On successful creation, it returns the created player data. In case of errors, it responds with appropriate HTTP status codes and error messages.\n",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lists the cards of a game that pass the filters, a page at a time. Each card comes with its column (\"Parent - Sub\" for sub-columns), its blocker and its age: the days since it was selected, up to its deployment. Pass the nextCursor of a page as cursor, with the same filters and sort, to get the next one; the last page has none.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "players"
+                    "cards"
                 ],
-                "summary": "Create a new player",
+                "summary": "Search cards",
                 "parameters": [
                     {
-                        "description": "Player creation payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreatePlayerRequest"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Column ID; a top-level column includes its sub-columns",
+                        "name": "column",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "S",
+                        "description": "Class of service",
+                        "name": "cos",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "high",
+                        "description": "Value estimate",
+                        "name": "value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only blocked cards, or only cards that are not",
+                        "name": "blocked",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only selected cards at least this many days old",
+                        "name": "minAge",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "position",
+                            "age",
+                            "title"
+                        ],
+                        "type": "string",
+                        "default": "position",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the page before",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Cards per page, up to 200",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Created player UUID",
+                        "description": "Page of cards",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/response.CardPageResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid game ID or player name",
+                        "description": "Invalid game or column ID, filter, sort, page size or cursor",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -476,6 +668,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "405": {
                         "description": "Method not allowed",
                         "schema": {
@@ -489,44 +687,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/games/{id}/cards.csv": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes the player identified by the given UUID.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Streams one row per card: title, class of service, value, selected day, deployed day, lead time, estimate and actual per effort type, and the days spent in each column derived from move events.",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "players"
+                    "cards"
                 ],
-                "summary": "Delete a player",
+                "summary": "Export card flow data as CSV",
                 "parameters": [
                     {
-                        "description": "Player deletion payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.DeletePlayerRequest"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Player deleted successfully",
+                        "description": "CSV document",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid or missing player ID",
+                        "description": "Invalid or missing game ID",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -538,7 +734,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Player not found",
+                        "description": "Game not found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -556,14 +752,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/games/{id}/cards/{cardId}/block": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "## UpdatePlayer handles HTTP PUT requests to update a player's information\n\nIt validates the request method, decodes the request payload, and ensures the payload contains valid player data. If the data is valid, it calls the service layer to update the player in the database. In case of errors, it responds with appropriate HTTP status codes and error messages.\n",
+                "description": "Blocks a card on the game's current day, with the reason and the player who blocked it. A blocked card is not worked on and cannot move. With an effort, work put into the card goes into unblocking it until that much is done; without one, the card stays blocked until it is unblocked by hand. The days it spends blocked show in the card flow export and the CFD.",
                 "consumes": [
                     "application/json"
                 ],
@@ -571,29 +769,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "players"
+                    "games"
                 ],
-                "summary": "Update a player",
+                "summary": "Block a card",
                 "parameters": [
                     {
-                        "description": "Player update payload",
-                        "name": "payload",
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Card ID",
+                        "name": "cardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason and unblocking effort",
+                        "name": "blocker",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdatePlayerRequest"
+                            "$ref": "#/definitions/models.BlockCardRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Update successful (empty response)",
+                    "201": {
+                        "description": "Card blocked",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/response.BlockerResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid player ID or name",
+                        "description": "Invalid IDs, body, reason or effort",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -604,12 +818,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Game or card not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "405": {
                         "description": "Method not allowed",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Game is not running, or the card is blocked or deployed already",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -617,23 +843,100 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/players/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns the full player record for the given player UUID.",
+                "description": "Lifts the blocker of a card, whatever unblocking effort it still needed. The card can be worked on again the same day.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "players"
+                    "games"
                 ],
-                "summary": "Get player by ID",
+                "summary": "Unblock a card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Card ID",
+                        "name": "cardId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Card unblocked"
+                    },
+                    "400": {
+                        "description": "Invalid IDs",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Game or card not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Game is not running, or the card is not blocked",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/cards/{cardId}/position": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Puts a card at an index of a column, 0 being the top, and returns the new order of that column. The other cards shift to make room, so each column stays numbered from 0 without gaps; an index past the end puts the card at the bottom. Without a column the card stays in its own. A card put into another column is moved there like in play: the move is logged on the current day and a card leaving the backlog is selected. WIP limits are not checked. Blocked cards can be reordered but not moved, and deployed cards cannot be positioned at all. Placements of a game are applied one at a time; a card moved by another request meanwhile is left alone. Bots pull cards top first, so the order is the team's priority.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Reorder a card",
                 "parameters": [
                     {
                         "type": "string",
@@ -642,17 +945,34 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Card ID",
+                        "name": "cardId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target column and index",
+                        "name": "position",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CardPosition"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Player retrieved successfully",
+                        "description": "New order of the column",
                         "schema": {
-                            "$ref": "#/definitions/models.Player"
+                            "$ref": "#/definitions/response.CardOrderResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid or missing player ID",
+                        "description": "Invalid IDs, body, column or index",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -664,7 +984,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Player not found",
+                        "description": "Game or card not found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -675,6 +995,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Game is not running, or the card is blocked, deployed or was moved meanwhile",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -683,97 +1009,2833 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.updateGameRequest": {
-            "type": "object",
-            "properties": {
-                "day": {
-                    "type": "integer"
+        },
+        "/games/{id}/cfd": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Counts the cards in every leaf column at the end of each day so far, worked out from move events, and annotates the stretches of days cards were blocked, with their reasons.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Get the cumulative flow diagram",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cumulative flow diagram",
+                        "schema": {
+                            "$ref": "#/definitions/response.CFDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or missing game ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/columns": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the list of columns (including subcolumns) belonging to the specified game UUID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "columns"
+                ],
+                "summary": "List columns by game ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of columns",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Column"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or missing game ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/columns/{columnId}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the title or WIP limit of a column, or the sub-columns of a top-level column, while the game is played. Sub-columns are listed in the order wanted: existing ones by ID, new ones by title and type; the ones left out are removed. A column holding cards cannot be removed or split into sub-columns, \"done\" columns get no sub-columns, and a column keeps its active stage as it is. Only the facilitator may edit columns, and not once the game has ended. Every change is logged as a \"wip_changed\" or \"column_changed\" event on the current day.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "columns"
+                ],
+                "summary": "Edit a column",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Column ID",
+                        "name": "columnId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Changes to the column",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ColumnUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Column as updated, with its sub-columns",
+                        "schema": {
+                            "$ref": "#/definitions/response.ColumnResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid IDs, body or change",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token, or not the facilitator",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Game or column not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Game has ended, or the column holds cards",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/end": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ends a game from any other status. An ended game is read-only: it can still be looked at, exported and forked, but not played, joined or handed over.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "End a game",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Game in its new status",
+                        "schema": {
+                            "$ref": "#/definitions/response.GameResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid game ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token, or not the facilitator",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Game has already ended",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a versioned JSON document with the game, effort types, column tree, cards with efforts, players and events. The document is returned as-is (not wrapped in the response envelope) so it can be posted to /games/import unchanged.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Export a game",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Game archive",
+                        "schema": {
+                            "$ref": "#/definitions/models.GameExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or missing game ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token, or not the facilitator",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/facilitator": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Makes another player of the game its facilitator. Only the current facilitator (or a key with the facilitate scope) may do so; the previous facilitator becomes a regular player.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Hand over the facilitator role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New facilitator",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.setFacilitatorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid game ID or body",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token, or not the facilitator",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found, or player not in the game",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Game has ended",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/fork": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new game whose columns, cards, efforts, players and events are copied from the source game at the end of the given day. Without a day the current state is forked. The copy of the source game's facilitator runs the fork, and their token is returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Fork a game",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Source game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Finished day to fork from",
+                        "name": "day",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Forked game created",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreateGameResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid game ID or day",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token, or not the facilitator",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Game or snapshot not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/metrics/aging": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists every card selected but not deployed, oldest first, with its age in days since it was selected, its column, the days since its last move and whether it is blocked. Each card is compared with the lead times of the cards of its class of service deployed so far, or of all cards if none of its class is: on_track up to the median, at_risk up to the 85th percentile and breached beyond it; unknown while nothing is deployed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Get the aging work in progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Aging report",
+                        "schema": {
+                            "$ref": "#/definitions/response.AgingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or missing game ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/metrics/flow-efficiency": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Works out, for every card that has started, the days it spent in active columns and in queue columns from its move events, up to its deployment or the current day, and its flow efficiency: active days over active and queue days. The days are added up by class of service, and for all cards as the total. Cards come least efficient first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "cards"
+                ],
+                "summary": "Get the flow efficiency",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Flow efficiency",
+                        "schema": {
+                            "$ref": "#/definitions/response.FlowEfficiencyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or missing game ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/pause": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves a running game to paused. Gameplay is refused until the game is resumed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Pause a game",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Game in its new status",
+                        "schema": {
+                            "$ref": "#/definitions/response.GameResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid game ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token, or not the facilitator",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Game is not running",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/players": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a list of players belonging to the given game UUID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "List all players by game ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of players",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Player"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or missing game ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Players not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/reset": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Puts the columns, cards and efforts back as they were when the game was created (or imported, or forked), clears its events and sends it back to the lobby on day 1. Players, their tokens and the facilitator stay, so the same group can play again.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Reset a game",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Game as reset",
+                        "schema": {
+                            "$ref": "#/definitions/response.GameResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid game ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token, or not the facilitator",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found, or created before its initial board was kept",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Game has ended",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/resume": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves a paused game back to running.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Resume a game",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Game in its new status",
+                        "schema": {
+                            "$ref": "#/definitions/response.GameResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid game ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token, or not the facilitator",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Game is not paused",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/roll": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the die of the given player working on the given card today. Rolls are derived from the game's seed, so the same player, card and day always give the same value. A player token only rolls for its own player; the player and the card must be of the game.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Roll a die",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Player and card",
+                        "name": "roll",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.rollDiceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Die rolled",
+                        "schema": {
+                            "$ref": "#/definitions/response.DiceRollResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid game ID or body",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token, or another player's die",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Game, player or card not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Game is not running",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}/start": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves a game from the lobby to running. Cards can only be worked on, dice rolled and days advanced while a game is running.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Start a game",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Game in its new status",
+                        "schema": {
+                            "$ref": "#/definitions/response.GameResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid game ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token, or not the facilitator",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Game is not in the lobby",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns every key, revoked and expired ones included, with its scopes and last use. The keys themselves are not stored and not shown.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "keys"
+                ],
+                "summary": "List API keys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIKeysResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid key, or not an admin key",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a key for an integration. Scopes are read (look at games), play (also make moves), facilitate (also create, import and run games) and admin (also manage keys); each includes the ones before it. The key is returned once and cannot be read back.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "keys"
+                ],
+                "summary": "Create an API key",
+                "parameters": [
+                    {
+                        "description": "Name, scopes and optional expiry",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateAPIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewAPIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON, missing name, unknown scope or expiry in the past",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid key, or not an admin key",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Turns a key away from now on. The key stays in the list, marked revoked.",
+                "tags": [
+                    "keys"
+                ],
+                "summary": "Revoke an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid key ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid key, or not an admin key",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Key not found or already revoked",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/keys/{id}/rotate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new key with the name, scopes and expiry of the given one and revokes the old key. The new key is returned once.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "keys"
+                ],
+                "summary": "Rotate an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.NewAPIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid key ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid key, or not an admin key",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Key not found or already revoked",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players": {
+            "post": {
+                "description": "## CreatePlayer handles the creation of a new player in the game.\n\nIt expects a **POST request with a JSON payload** containing the player's details. The payload **must include a valid GameID and a non-empty Name**. If the request method is not POST, it responds with a \"method not allowed\" error. If the payload is invalid or fails validation, it responds with a \"bad request\" error. On successful creation, it returns the player's ID, the game ID and a **token scoped to that player and game**. No Authorization header is needed to join; afterwards the player sends ` + "`" + `Authorization: Bearer \u003ctoken\u003e` + "`" + ` and may only act on their own game. In case of errors, it responds with appropriate HTTP status codes and error messages.\n",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Create a new player",
+                "parameters": [
+                    {
+                        "description": "Player creation payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreatePlayerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Created player and their token",
+                        "schema": {
+                            "$ref": "#/definitions/response.PlayerTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid game ID or player name",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Game has ended",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the player identified by the given UUID. Players may remove themselves; removing another player takes the game's facilitator.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Delete a player",
+                "parameters": [
+                    {
+                        "description": "Player deletion payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DeletePlayerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Player deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or missing player ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Player not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Game has ended",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "## UpdatePlayer handles HTTP PUT requests to update a player's information\n\nIt validates the request method, decodes the request payload, and ensures the payload contains valid player data. If the data is valid, it calls the service layer to update the player in the database. In case of errors, it responds with appropriate HTTP status codes and error messages.\n",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Update a player",
+                "parameters": [
+                    {
+                        "description": "Player update payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdatePlayerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Update successful (empty response)",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid player ID or name",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Player not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Game has ended",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the full player record for the given player UUID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Get player by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Player retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.Player"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or missing player ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Player not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a session and one game with the default board for each of its teams, named \"Team 1\", \"Team 2\" and so on. All games get the same dice seed, the one given or a random one, so that every team faces the same dice: a card rolls alike in every game, and so do the players who joined their games in the same order. The games start in the lobby without a facilitator.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Create a training session",
+                "parameters": [
+                    {
+                        "description": "Name, number of teams and optional dice seed",
+                        "name": "session",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.SessionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON, missing name or invalid number of teams",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid key, or not a facilitate key",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{id}/dashboard": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns, for every team of the session, its game's status and current day with the WIP, cards deployed, throughput, lead time and profit of the days played so far. Profit is worked out with the card values and team of the standard scenario, so that all teams are measured alike.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Compare the teams of a session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.SessionDashboardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid session ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing or invalid key",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "405": {
+                        "description": "Method not allowed",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.createGameRequest": {
+            "type": "object",
+            "properties": {
+                "facilitator": {
+                    "description": "name of a player to create as the facilitator",
+                    "type": "string",
+                    "example": "Grace"
+                },
+                "seed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.playBotDayRequest": {
+            "type": "object",
+            "properties": {
+                "strategy": {
+                    "type": "string",
+                    "example": "strict-wip"
+                },
+                "team": {
+                    "description": "defaults to the standard team",
+                    "type": "string",
+                    "example": "Analysis=2,Development=3,Testing=2"
+                }
+            }
+        },
+        "handlers.rollDiceRequest": {
+            "type": "object",
+            "properties": {
+                "cardId": {
+                    "type": "string"
+                },
+                "playerId": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.setFacilitatorRequest": {
+            "type": "object",
+            "properties": {
+                "playerId": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.updateGameRequest": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5f0c7a1e-3b2d-4c8e-9a6f-1d2e3f4a5b6c"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "LMS"
+                },
+                "prefix": {
+                    "description": "start of the key, to tell keys apart",
+                    "type": "string",
+                    "example": "ksk_Q2x9"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "read",
+                        "play"
+                    ]
+                }
+            }
+        },
+        "models.Aging": {
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "description": "oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AgingCard"
+                    }
+                },
+                "day": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "leadTimes": {
+                    "description": "by class of service",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ClassLeadTime"
+                    }
+                },
+                "total": {
+                    "description": "of all cards; nil until one is deployed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ClassLeadTime"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.AgingCard": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "blocked": {
+                    "type": "boolean"
+                },
+                "cardId": {
+                    "type": "string"
+                },
+                "classOfService": {
+                    "type": "string",
+                    "example": "S"
+                },
+                "column": {
+                    "type": "string",
+                    "example": "Development - In Progress"
+                },
+                "daysInColumn": {
+                    "description": "since its last move",
+                    "type": "integer",
+                    "example": 2
+                },
+                "status": {
+                    "type": "string",
+                    "example": "at_risk"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "S12"
+                }
+            }
+        },
+        "models.BlockCardRequest": {
+            "type": "object",
+            "properties": {
+                "effort": {
+                    "description": "unblocking work needed; 0 for none",
+                    "type": "integer",
+                    "example": 5
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Waiting for the database team"
+                }
+            }
+        },
+        "models.Blocker": {
+            "type": "object",
+            "properties": {
+                "blockedBy": {
+                    "description": "player who blocked the card",
+                    "type": "string",
+                    "example": "Alice"
+                },
+                "day": {
+                    "description": "day the card was blocked",
+                    "type": "integer",
+                    "example": 4
+                },
+                "effort": {
+                    "description": "Effort is the unblocking work still needed. At 0 the blocker stays\nuntil a player lifts it.",
+                    "type": "integer",
+                    "example": 5
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Waiting for the database team"
+                }
+            }
+        },
+        "models.BotDay": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "description": "the day played",
+                    "type": "integer",
+                    "example": 3
+                },
+                "defects": {
+                    "description": "defects found in cards deployed",
+                    "type": "integer",
+                    "example": 0
+                },
+                "deployed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "failed": {
+                    "description": "cards that failed their testing",
+                    "type": "integer",
+                    "example": 1
+                },
+                "moves": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "strategy": {
+                    "type": "string",
+                    "example": "strict-wip"
+                },
+                "wip": {
+                    "description": "cards in progress at the end of the day",
+                    "type": "integer",
+                    "example": 6
+                },
+                "working": {
+                    "description": "workers who did any work",
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "models.CFD": {
+            "type": "object",
+            "properties": {
+                "annotations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CFDAnnotation"
+                    }
+                },
+                "columns": {
+                    "description": "leaf columns in board order",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Options",
+                        "Analysis - In Progress"
+                    ]
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CFDDay"
+                    }
+                }
+            }
+        },
+        "models.CFDAnnotation": {
+            "type": "object",
+            "properties": {
+                "blockedBy": {
+                    "type": "string",
+                    "example": "Alice"
+                },
+                "cardId": {
+                    "type": "string"
+                },
+                "day": {
+                    "description": "day the card was blocked",
+                    "type": "integer",
+                    "example": 4
+                },
+                "days": {
+                    "description": "blocked days, so far if still blocked",
+                    "type": "integer",
+                    "example": 2
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Waiting for the database team"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "S1"
+                },
+                "unblocked": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.CFDDay": {
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        12,
+                        2,
+                        1
+                    ]
+                },
+                "day": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.Card": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "description": "days since selected, to deployment; set by card searches",
+                    "type": "integer"
+                },
+                "blocker": {
+                    "description": "nil unless the card is blocked",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Blocker"
+                        }
+                    ]
+                },
+                "classOfService": {
+                    "type": "string"
+                },
+                "columnId": {
+                    "type": "string"
+                },
+                "columnTitle": {
+                    "type": "string"
+                },
+                "deployedDay": {
+                    "type": "integer"
+                },
+                "efforts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Effort"
+                    }
+                },
+                "gameId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "orderIndex": {
+                    "type": "integer"
+                },
+                "selectedDay": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "valueEstimate": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CardFlowEfficiency": {
+            "type": "object",
+            "properties": {
+                "activeDays": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "cardId": {
+                    "type": "string"
+                },
+                "classOfService": {
+                    "type": "string",
+                    "example": "S"
+                },
+                "deployed": {
+                    "type": "boolean"
+                },
+                "efficiency": {
+                    "type": "number",
+                    "example": 0.38
+                },
+                "queueDays": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "title": {
+                    "type": "string",
+                    "example": "S12"
+                }
+            }
+        },
+        "models.CardOrder": {
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderedCard"
+                    }
+                },
+                "columnId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CardPage": {
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Card"
+                    }
+                },
+                "nextCursor": {
+                    "description": "empty on the last page",
+                    "type": "string",
+                    "example": "eyJzIjoicG9zaXRpb24ifQ"
+                }
+            }
+        },
+        "models.CardPosition": {
+            "type": "object",
+            "properties": {
+                "columnId": {
+                    "description": "the card's own column if left out",
+                    "type": "string"
+                },
+                "index": {
+                    "description": "0 for the top; past the end for the bottom",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "models.ClassFlowEfficiency": {
+            "type": "object",
+            "properties": {
+                "activeDays": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "cards": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "classOfService": {
+                    "type": "string",
+                    "example": "S"
+                },
+                "efficiency": {
+                    "description": "active days over active and queue days",
+                    "type": "number",
+                    "example": 0.3
+                },
+                "queueDays": {
+                    "type": "integer",
+                    "example": 46
+                }
+            }
+        },
+        "models.ClassLeadTime": {
+            "type": "object",
+            "properties": {
+                "classOfService": {
+                    "type": "string",
+                    "example": "S"
+                },
+                "deployed": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "p50": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "p85": {
+                    "type": "integer",
+                    "example": 8
+                }
+            }
+        },
+        "models.Column": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "orderIndex": {
+                    "type": "integer"
+                },
+                "parentId": {
+                    "description": "nil for top-level",
+                    "type": "string"
+                },
+                "subColumns": {
+                    "description": "built in memory",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Column"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "\"active\", queue\", \"done\"",
+                    "type": "string"
+                },
+                "wipLimit": {
+                    "description": "only set if non-zero",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ColumnUpdate": {
+            "type": "object",
+            "properties": {
+                "subColumns": {
+                    "description": "SubColumns are the sub-columns the column should have, in order:\nexisting ones are kept, new ones added and the rest removed. Top-level\ncolumns only.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SubColumnSpec"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Development"
+                },
+                "wipLimit": {
+                    "description": "0 for none; top-level columns only",
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "models.CreateAPIKeyRequest": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string",
+                    "example": "2027-01-01T00:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "LMS"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "read",
+                        "play"
+                    ]
+                }
+            }
+        },
+        "models.CreatePlayerRequest": {
+            "type": "object",
+            "properties": {
+                "game_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John"
+                }
+            }
+        },
+        "models.CreateSessionRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Monday training"
+                },
+                "seed": {
+                    "description": "0 picks a random one",
+                    "type": "integer",
+                    "example": 42
+                },
+                "teams": {
+                    "description": "number of games to create",
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "models.DeletePlayerRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "models.DiceRoll": {
+            "type": "object",
+            "properties": {
+                "cardId": {
+                    "type": "string"
+                },
+                "day": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "playerId": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "models.Effort": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "description": "from DB",
+                    "type": "integer"
+                },
+                "effortType": {
+                    "description": "e.g. \"Development\"",
+                    "type": "string"
+                },
+                "estimate": {
+                    "description": "1–16 initial",
+                    "type": "integer"
+                },
+                "remaining": {
+                    "description": "from DB",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.EffortType": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "orderIndex": {
+                    "description": "to preserve configured order",
+                    "type": "integer"
+                },
+                "title": {
+                    "description": "e.g. \"Analysis\"",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ExportPlayer": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FlowEfficiency": {
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "description": "least efficient first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CardFlowEfficiency"
+                    }
+                },
+                "classes": {
+                    "description": "by class of service",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ClassFlowEfficiency"
+                    }
+                },
+                "day": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "total": {
+                    "description": "of all cards",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ClassFlowEfficiency"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.Game": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "day": {
+                    "type": "integer"
+                },
+                "facilitator_id": {
+                    "description": "FacilitatorID is the player running the game, nil while nobody is.",
+                    "type": "string"
+                },
+                "forked_at_day": {
+                    "description": "day of the parent the fork was taken from",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "parent_game_id": {
+                    "description": "set on forks only",
+                    "type": "string"
+                },
+                "quality": {
+                    "description": "how often work goes wrong",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Quality"
+                        }
+                    ]
+                },
+                "scenario": {
+                    "description": "board the game was created from",
+                    "type": "string",
+                    "example": "default"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GameStatus"
+                        }
+                    ],
+                    "example": "running"
+                }
+            }
+        },
+        "models.GameEvent": {
+            "type": "object",
+            "properties": {
+                "cardId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "day": {
+                    "type": "integer"
+                },
+                "eventType": {
+                    "type": "string"
+                },
+                "gameId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                }
+            }
+        },
+        "models.GameExport": {
+            "type": "object",
+            "properties": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Card"
+                    }
+                },
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Column"
+                    }
+                },
+                "effortTypes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EffortType"
+                    }
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GameEvent"
+                    }
+                },
+                "exportedAt": {
+                    "type": "string"
+                },
+                "game": {
+                    "$ref": "#/definitions/models.Game"
+                },
+                "players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExportPlayer"
+                    }
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.GamePage": {
+            "type": "object",
+            "properties": {
+                "games": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Game"
+                    }
+                },
+                "next_cursor": {
+                    "description": "empty on the last page",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "games that pass the filters, on all pages",
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "models.GameStatus": {
+            "type": "string",
+            "enum": [
+                "lobby",
+                "running",
+                "paused",
+                "ended"
+            ],
+            "x-enum-comments": {
+                "GameEnded": "read-only from now on",
+                "GameLobby": "players join; nothing is played yet",
+                "GameRunning": "the only status in which the game is played"
+            },
+            "x-enum-varnames": [
+                "GameLobby",
+                "GameRunning",
+                "GamePaused",
+                "GameEnded"
+            ]
+        },
+        "models.LeadTime": {
+            "type": "object",
+            "properties": {
+                "mean": {
+                    "type": "number",
+                    "example": 5.5
+                },
+                "p85": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "models.NewAPIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "5f0c7a1e-3b2d-4c8e-9a6f-1d2e3f4a5b6c"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "ksk_Q2x9..."
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "LMS"
+                },
+                "prefix": {
+                    "description": "start of the key, to tell keys apart",
+                    "type": "string",
+                    "example": "ksk_Q2x9"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "read",
+                        "play"
+                    ]
                 }
             }
         },
-        "models.Column": {
+        "models.OrderedCard": {
             "type": "object",
             "properties": {
                 "id": {
                     "type": "string"
                 },
                 "orderIndex": {
-                    "type": "integer"
-                },
-                "parentId": {
-                    "description": "nil for top-level",
-                    "type": "string"
-                },
-                "subColumns": {
-                    "description": "built in memory",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Column"
-                    }
+                    "type": "integer",
+                    "example": 0
                 },
                 "title": {
+                    "type": "string",
+                    "example": "S10"
+                }
+            }
+        },
+        "models.Player": {
+            "type": "object",
+            "properties": {
+                "gameID": {
                     "type": "string"
                 },
-                "type": {
-                    "description": "\"active\", queue\", \"done\"",
+                "id": {
                     "type": "string"
                 },
-                "wipLimit": {
-                    "description": "only set if non-zero",
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "models.CreatePlayerRequest": {
+        "models.PlayerToken": {
             "type": "object",
             "properties": {
-                "game_id": {
+                "gameId": {
+                    "type": "string",
+                    "example": "9b2f8c3e-1d4a-4e7b-8f6a-2c5d7e9f1a3b"
+                },
+                "playerId": {
                     "type": "string",
                     "example": "123e4567-e89b-12d3-a456-426614174000"
                 },
-                "name": {
+                "token": {
                     "type": "string",
-                    "example": "John"
+                    "example": "EjRWeJ..."
                 }
             }
         },
-        "models.DeletePlayerRequest": {
+        "models.Quality": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "defectEffort": {
+                    "description": "share of each estimate of the deployed card the defect needs",
+                    "type": "number",
+                    "example": 0.5
+                },
+                "escapedDefect": {
+                    "description": "chance a deployed card spawns a defect",
+                    "type": "number",
+                    "example": 0.1
+                },
+                "rework": {
+                    "description": "share of its development estimate a failed card needs again",
+                    "type": "number",
+                    "example": 0.5
+                },
+                "testFailure": {
+                    "description": "chance a card fails once its testing is done",
+                    "type": "number",
+                    "example": 0.2
                 }
             }
         },
-        "models.Game": {
+        "models.Session": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "day": {
-                    "type": "integer"
+                "games": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SessionGame"
+                    }
                 },
                 "id": {
+                    "type": "string",
+                    "example": "9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Monday training"
+                },
+                "seed": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "models.SessionDashboard": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Monday training"
+                },
+                "session_id": {
                     "type": "string"
+                },
+                "teams": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TeamStanding"
+                    }
                 }
             }
         },
-        "models.Player": {
+        "models.SessionGame": {
             "type": "object",
             "properties": {
-                "gameID": {
+                "game_id": {
                     "type": "string"
                 },
+                "team": {
+                    "type": "string",
+                    "example": "Team 1"
+                }
+            }
+        },
+        "models.SubColumnSpec": {
+            "type": "object",
+            "properties": {
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "title": {
+                    "description": "of a new sub-column",
+                    "type": "string",
+                    "example": "Review"
+                },
+                "type": {
+                    "description": "of a new sub-column, \"active\" or \"queue\"; the parent's if empty",
+                    "type": "string",
+                    "example": "queue"
+                }
+            }
+        },
+        "models.TeamStanding": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "deployed": {
+                    "description": "cards deployed during the game",
+                    "type": "integer",
+                    "example": 3
+                },
+                "game_id": {
                     "type": "string"
+                },
+                "lead_time": {
+                    "$ref": "#/definitions/models.LeadTime"
+                },
+                "profit": {
+                    "type": "integer",
+                    "example": 850
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GameStatus"
+                        }
+                    ],
+                    "example": "running"
+                },
+                "team": {
+                    "type": "string",
+                    "example": "Team 1"
+                },
+                "throughput": {
+                    "description": "cards deployed per day",
+                    "type": "number",
+                    "example": 0.38
+                },
+                "wip": {
+                    "description": "cards selected but not deployed",
+                    "type": "integer",
+                    "example": 6
                 }
             }
         },
@@ -790,12 +3852,124 @@ const docTemplate = `{
                 }
             }
         },
+        "response.APIKeysResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.APIKey"
+                    }
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "response.AgingResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Aging"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "response.BlockerResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Blocker"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "response.BotDayResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.BotDay"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "response.CFDResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.CFD"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "response.CardOrderResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.CardOrder"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "response.CardPageResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.CardPage"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "response.ColumnResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Column"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
         "response.CreateGameData": {
             "type": "object",
             "properties": {
+                "facilitator": {
+                    "description": "Facilitator is the player created to run the game, if one was asked for.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PlayerToken"
+                        }
+                    ]
+                },
                 "id": {
                     "type": "string",
                     "example": "7d7881cf-8d9f-457f-ac93-aa498ea8c0af"
+                },
+                "seed": {
+                    "description": "only returned to the creator",
+                    "type": "integer",
+                    "example": 8311254879
                 }
             }
         },
@@ -810,6 +3984,18 @@ const docTemplate = `{
                 }
             }
         },
+        "response.DiceRollResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.DiceRoll"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
         "response.ErrorResponse": {
             "type": "object",
             "properties": {
@@ -823,6 +4009,18 @@ const docTemplate = `{
                 }
             }
         },
+        "response.FlowEfficiencyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.FlowEfficiency"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
         "response.GameResponse": {
             "type": "object",
             "properties": {
@@ -834,6 +4032,54 @@ const docTemplate = `{
                     "example": true
                 }
             }
+        },
+        "response.NewAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.NewAPIKey"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "response.PlayerTokenResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.PlayerToken"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "response.SessionDashboardResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.SessionDashboard"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "response.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/models.Session"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -18,21 +18,28 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"flag"
 
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
+	"github.com/Germanicus1/kanban-sim/backend/internal/apikeys"
+	"github.com/Germanicus1/kanban-sim/backend/internal/auth"
+	"github.com/Germanicus1/kanban-sim/backend/internal/cards"
 	"github.com/Germanicus1/kanban-sim/backend/internal/columns"
 	"github.com/Germanicus1/kanban-sim/backend/internal/database"
 	"github.com/Germanicus1/kanban-sim/backend/internal/games"
 	"github.com/Germanicus1/kanban-sim/backend/internal/handlers"
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
 	"github.com/Germanicus1/kanban-sim/backend/internal/players"
 	"github.com/Germanicus1/kanban-sim/backend/internal/server"
+	"github.com/Germanicus1/kanban-sim/backend/internal/sessions"
 
 	"github.com/joho/godotenv"
 )
@@ -45,52 +52,116 @@ func main() {
 
 	// Parse flags
 	migrateOnly := flag.Bool("migrate-only", false, "Run migrations only")
+	storage := flag.String("storage", "database", "Storage backend: database (Postgres or SQLite, see DB_DRIVER) or memory")
 	flag.Parse()
 
-	// Initialize DB
-	db, err := database.InitDB()
-	if err != nil {
-		log.Fatal("Failed to initialize database: ", err)
-	}
-	defer func() {
-		if err := db.Close(); err != nil {
-			log.Printf("Error closing database: %v", err)
+	var (
+		gameRepo    games.Repository
+		playerRepo  players.Repository
+		columnsRepo columns.Repository
+		cardsRepo   cards.Repository
+		keysRepo    apikeys.Repository
+		sessionRepo sessions.Repository
+	)
+
+	switch *storage {
+	case "memory":
+		if *migrateOnly {
+			log.Fatal("-migrate-only needs -storage=database")
+		}
+		// Everything lives in this process and is gone after a restart.
+		log.Println("Using in-memory storage")
+		store := memstore.New()
+		gameRepo = games.NewMemoryRepo(store)
+		playerRepo = players.NewMemoryRepo(store)
+		columnsRepo = columns.NewMemoryRepo(store)
+		cardsRepo = cards.NewMemoryRepo(store)
+		keysRepo = apikeys.NewMemoryRepo(store)
+		sessionRepo = sessions.NewMemoryRepo(store)
+
+	case "database":
+		// Initialize DB
+		db, err := database.InitDB()
+		if err != nil {
+			log.Fatal("Failed to initialize database: ", err)
+		}
+		defer func() {
+			if err := db.Close(); err != nil {
+				log.Printf("Error closing database: %v", err)
+			}
+		}()
+
+		if err := db.Ping(); err != nil {
+			log.Fatal("Failed to ping database: ", err)
 		}
-	}()
 
-	if err := db.Ping(); err != nil {
-		log.Fatal("Failed to ping database: ", err)
-	}
+		// Migrations only
+		if *migrateOnly {
+			log.Println("Running migrations...")
+			if err := database.Migrate(db, "./internal/database/migrations"); err != nil {
+				log.Fatal("Failed to migrate DB: ", err)
+			}
+			return
+		}
 
-	// Migrations only
-	if *migrateOnly {
-		log.Println("Running migrations...")
+		// Auto-migrate on startup
 		if err := database.Migrate(db, "./internal/database/migrations"); err != nil {
 			log.Fatal("Failed to migrate DB: ", err)
 		}
-		return
+
+		gameRepo = games.NewSQLRepo(db)
+		playerRepo = players.NewSQLRepo(db)
+		columnsRepo = columns.NewSQLRepo(db)
+		cardsRepo = cards.NewSQLRepo(db)
+		keysRepo = apikeys.NewSQLRepo(db)
+		sessionRepo = sessions.NewSQLRepo(db)
+
+	default:
+		log.Fatalf("Unknown storage %q, want database or memory", *storage)
 	}
 
-	// Auto-migrate on startup
-	if err := database.Migrate(db, "./internal/database/migrations"); err != nil {
-		log.Fatal("Failed to migrate DB: ", err)
+	// Player tokens are signed with TOKEN_SECRET. Without one they only live
+	// as long as this process.
+	secret := []byte(os.Getenv("TOKEN_SECRET"))
+	if len(secret) == 0 {
+		log.Println("TOKEN_SECRET not set, player tokens will not survive a restart")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			log.Fatal("Failed to generate token secret: ", err)
+		}
 	}
+	signer := auth.NewSigner(secret)
 
 	// Setup services and handlers
-	gameRepo := games.NewSQLRepo(db)
-	playerRepo := players.NewSQLRepo(db)
-	columnsRepo := columns.NewSQLRepo(db)
-
 	gameSvc := games.NewService(gameRepo)
 	playerSvc := players.NewService(playerRepo)
 	columnSvc := columns.NewService(columnsRepo)
+	cardSvc := cards.NewService(cardsRepo)
+	keySvc := apikeys.NewService(keysRepo)
+	sessionSvc := sessions.NewService(sessionRepo, gameSvc)
+
+	// API keys are managed through /keys. Until an admin key exists, make
+	// one, or nobody could create the others.
+	if os.Getenv("API_KEY") != "" {
+		log.Println("API_KEY is no longer used; manage API keys with /keys")
+	}
+	adminKey, err := keySvc.Bootstrap(context.Background())
+	if err != nil {
+		log.Fatal("Failed to set up admin key: ", err)
+	}
+	if adminKey != "" {
+		log.Printf("No admin API key found, created one; it is shown only once: %s", adminKey)
+	}
 
-	gh := handlers.NewGameHandler(gameSvc)
+	gh := handlers.NewGameHandler(gameSvc, signer)
 	ah := handlers.NewAppHandler()
-	ph := handlers.NewPlayerHandler(playerSvc)
+	ph := handlers.NewPlayerHandler(playerSvc, signer)
 	ch := handlers.NewColumnHandler(columnSvc)
+	cdh := handlers.NewCardsHandler(cardSvc)
+	kh := handlers.NewKeysHandler(keySvc)
+	sh := handlers.NewSessionsHandler(sessionSvc)
 
-	publicRouter := server.NewRouter(ah, gh, ph, ch)
+	publicRouter := server.NewRouter(ah, gh, ph, ch, cdh, kh, sh, signer, gameSvc.Plays, gameSvc.FacilitatorOf, keySvc.Authenticate)
 
 	// Configure HTTP server with timeouts
 	srv := &http.Server{
@@ -102,12 +173,27 @@ func main() {
 		IdleTimeout:       120 * time.Second,
 	}
 
+	// Clean up abandoned games in the background, if a policy is set
+	retentionCtx, stopRetention := context.WithCancel(context.Background())
+	retentionDone := make(chan struct{})
+	if policy, every, ok := retentionPolicy(); ok {
+		log.Printf("Retention: soft-deleting games inactive for %v, deleting them %v later, every %v (dry run: %t)",
+			policy.InactiveFor, policy.Grace, every, policy.DryRun)
+		go func() {
+			defer close(retentionDone)
+			gameSvc.RunRetention(retentionCtx, policy, every)
+		}()
+	} else {
+		close(retentionDone)
+	}
+
 	// Graceful shutdown on SIGINT or SIGTERM
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
 		<-quit
 		log.Println("Shutting down server...")
+		stopRetention()
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
 		if err := srv.Shutdown(ctx); err != nil {
@@ -120,4 +206,50 @@ func main() {
 	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		log.Fatalf("ListenAndServe error: %v", err)
 	}
+
+	// let a cleanup under way finish before the database is closed
+	stopRetention()
+	<-retentionDone
+}
+
+// retentionPolicy reads the retention policy from the environment. Games
+// without activity for RETENTION_INACTIVE_DAYS are soft-deleted, and deleted
+// for good RETENTION_GRACE_DAYS (7 by default) later, checked every
+// RETENTION_INTERVAL (1h by default). With RETENTION_DRY_RUN=true nothing
+// is removed, only logged. Without RETENTION_INACTIVE_DAYS games are kept.
+func retentionPolicy() (games.RetentionPolicy, time.Duration, bool) {
+	days := func(name string, def int) time.Duration {
+		v := os.Getenv(name)
+		if v == "" {
+			return time.Duration(def) * 24 * time.Hour
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Fatalf("Invalid %s %q, want a number of days", name, v)
+		}
+		return time.Duration(n) * 24 * time.Hour
+	}
+
+	p := games.RetentionPolicy{InactiveFor: days("RETENTION_INACTIVE_DAYS", 0)}
+	if p.InactiveFor == 0 {
+		return p, 0, false
+	}
+	p.Grace = days("RETENTION_GRACE_DAYS", 7)
+
+	every := time.Hour
+	if v := os.Getenv("RETENTION_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			log.Fatalf("Invalid RETENTION_INTERVAL %q, want a duration such as 30m", v)
+		}
+		every = d
+	}
+	if v := os.Getenv("RETENTION_DRY_RUN"); v != "" {
+		dryRun, err := strconv.ParseBool(v)
+		if err != nil {
+			log.Fatalf("Invalid RETENTION_DRY_RUN %q, want true or false", v)
+		}
+		p.DryRun = dryRun
+	}
+	return p, every, true
 }
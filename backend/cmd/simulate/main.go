@@ -0,0 +1,95 @@
+// Command simulate plays a whole game in memory with an automated strategy
+// and prints how it went. No database or server is involved.
+//
+//	go run ./cmd/simulate -days 20 -strategy strict-wip -seed 7
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/config"
+	"github.com/Germanicus1/kanban-sim/backend/internal/engine"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+)
+
+func main() {
+	scenario := flag.String("scenario", "", "Scenario JSON file (default: the built-in board)")
+	days := flag.Int("days", 20, "Number of days to play")
+	strategy := flag.String("strategy", "strict-wip", "Strategy: "+strings.Join(engine.Strategies(), ", "))
+	seed := flag.Int64("seed", 1, "Dice seed")
+	team := flag.String("team", engine.DefaultTeam, "Team as <effort type>=<count>,...")
+	format := flag.String("format", "table", "Output format: table or json")
+	flag.Parse()
+
+	log.SetFlags(0)
+
+	if *days <= 0 {
+		log.Fatal("days must be positive")
+	}
+	cfg, err := loadScenario(*scenario)
+	if err != nil {
+		log.Fatal(err)
+	}
+	workers, err := engine.ParseTeam(*team)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s, err := engine.StrategyByName(*strategy)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	game, err := engine.New(*cfg, engine.Options{Seed: *seed, Team: workers})
+	if err != nil {
+		log.Fatal(err)
+	}
+	result, err := game.Run(s, *days)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch *format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			log.Fatal(err)
+		}
+	case "table":
+		printTable(os.Stdout, result)
+	default:
+		log.Fatalf("unknown format %q, want table or json", *format)
+	}
+}
+
+func loadScenario(path string) (*models.Board, error) {
+	if path == "" {
+		return config.LoadBoardConfig()
+	}
+	return config.LoadBoardConfigFile(path)
+}
+
+func printTable(w io.Writer, r engine.Result) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "strategy\t%s\n", r.Strategy)
+	fmt.Fprintf(tw, "seed\t%d\n", r.Seed)
+	fmt.Fprintf(tw, "days\t%d\n", r.Days)
+	fmt.Fprintf(tw, "deployed\t%d\n", r.Deployed)
+	fmt.Fprintf(tw, "test failures\t%d\n", r.Failed)
+	fmt.Fprintf(tw, "escaped defects\t%d\n", r.Defects)
+	fmt.Fprintf(tw, "throughput\t%.2f cards/day\n", r.Throughput)
+	fmt.Fprintf(tw, "utilisation\t%.0f%%\n", r.Utilisation*100)
+	fmt.Fprintf(tw, "lead time\tmean %.2f, p50 %d, p85 %d, max %d\n", r.LeadTime.Mean, r.LeadTime.P50, r.LeadTime.P85, r.LeadTime.Max)
+	fmt.Fprintf(tw, "wip\tmean %.2f, max %d\n", r.WIP.Mean, r.WIP.Max)
+	fmt.Fprintf(tw, "revenue\t%d\n", r.Financials.Revenue)
+	fmt.Fprintf(tw, "cost\t%d\n", r.Financials.Cost)
+	fmt.Fprintf(tw, "profit\t%d\n", r.Financials.Profit)
+	tw.Flush()
+}
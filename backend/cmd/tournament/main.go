@@ -0,0 +1,162 @@
+// Command tournament plays every strategy with every WIP limit setting and a
+// range of seeds against one scenario, in parallel, and compares how they
+// fared. No database or server is involved.
+//
+//	go run ./cmd/tournament -runs 50 -wip scenario -wip Development=2 -wip Development=6 -csv results.csv
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/config"
+	"github.com/Germanicus1/kanban-sim/backend/internal/engine"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+)
+
+// wipFlag collects the WIP limit settings given with repeated -wip flags.
+type wipFlag []engine.WIPSetting
+
+func (f *wipFlag) String() string {
+	names := make([]string, 0, len(*f))
+	for _, ws := range *f {
+		names = append(names, ws.Name)
+	}
+	return strings.Join(names, " ")
+}
+
+func (f *wipFlag) Set(spec string) error {
+	ws, err := engine.ParseWIPSetting(spec)
+	if err != nil {
+		return err
+	}
+	*f = append(*f, ws)
+	return nil
+}
+
+func main() {
+	var wips wipFlag
+	scenario := flag.String("scenario", "", "Scenario JSON file (default: the built-in board)")
+	days := flag.Int("days", 20, "Number of days per game")
+	strategies := flag.String("strategies", strings.Join(engine.Strategies(), ","), "Comma-separated strategies to play")
+	flag.Var(&wips, "wip", `WIP limits as <column>=<limit>,...; repeat for more settings ("scenario" keeps the scenario's)`)
+	seed := flag.Int64("seed", 1, "First dice seed")
+	runs := flag.Int("runs", 20, "Seeds per strategy and WIP limit setting, counting up from -seed")
+	team := flag.String("team", engine.DefaultTeam, "Team as <effort type>=<count>,...")
+	parallel := flag.Int("parallel", 0, "Games played at once (default: one per CPU)")
+	jsonOut := flag.String("json", "", "Also write the standings as JSON to this file")
+	csvOut := flag.String("csv", "", "Also write the standings as CSV to this file")
+	flag.Parse()
+
+	log.SetFlags(0)
+
+	cfg, err := loadScenario(*scenario)
+	if err != nil {
+		log.Fatal(err)
+	}
+	workers, err := engine.ParseTeam(*team)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *runs <= 0 {
+		log.Fatal("runs must be positive")
+	}
+	if len(wips) == 0 {
+		wips = wipFlag{{Name: engine.ScenarioLimits}}
+	}
+	seeds := make([]int64, *runs)
+	for i := range seeds {
+		seeds[i] = *seed + int64(i)
+	}
+
+	t := engine.Tournament{
+		Scenario:   *cfg,
+		Team:       workers,
+		Days:       *days,
+		Strategies: strings.Split(*strategies, ","),
+		WIPLimits:  wips,
+		Seeds:      seeds,
+		Parallel:   *parallel,
+	}
+	standings, err := t.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	printTable(os.Stdout, standings)
+	if *jsonOut != "" {
+		if err := writeFile(*jsonOut, standings, writeJSON); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *csvOut != "" {
+		if err := writeFile(*csvOut, standings, writeCSV); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func loadScenario(path string) (*models.Board, error) {
+	if path == "" {
+		return config.LoadBoardConfig()
+	}
+	return config.LoadBoardConfigFile(path)
+}
+
+func writeFile(path string, standings []engine.Standing, write func(io.Writer, []engine.Standing) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f, standings); err != nil {
+		f.Close()
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+func printTable(w io.Writer, standings []engine.Standing) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "strategy\twip limits\truns\tlead time mean\tp50\tp85\tthroughput\tprofit mean\tp15\tp85\t")
+	for _, s := range standings {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%.2f\t%d\t%d\t%.2f\t%.0f\t%.0f\t%.0f\t\n",
+			s.Strategy, s.WIPLimits, s.Runs, s.LeadTime.Mean, s.LeadTime.P50, s.LeadTime.P85,
+			s.Throughput.Mean, s.Profit.Mean, s.Profit.P15, s.Profit.P85)
+	}
+	tw.Flush()
+}
+
+func writeJSON(w io.Writer, standings []engine.Standing) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(standings)
+}
+
+func writeCSV(w io.Writer, standings []engine.Standing) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{
+		"strategy", "wip_limits", "runs",
+		"lead_time_mean", "lead_time_p50", "lead_time_p85", "lead_time_max",
+		"throughput_mean", "throughput_p15", "throughput_p50", "throughput_p85",
+		"profit_mean", "profit_p15", "profit_p50", "profit_p85",
+	})
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	for _, s := range standings {
+		cw.Write([]string{
+			s.Strategy, s.WIPLimits, strconv.Itoa(s.Runs),
+			f(s.LeadTime.Mean), strconv.Itoa(s.LeadTime.P50), strconv.Itoa(s.LeadTime.P85), strconv.Itoa(s.LeadTime.Max),
+			f(s.Throughput.Mean), f(s.Throughput.P15), f(s.Throughput.P50), f(s.Throughput.P85),
+			f(s.Profit.Mean), f(s.Profit.P15), f(s.Profit.P50), f(s.Profit.P85),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
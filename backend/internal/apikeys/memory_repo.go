@@ -0,0 +1,99 @@
+package apikeys
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// memoryRepo implements the Repository interface on a memstore.Store.
+type memoryRepo struct {
+	store *memstore.Store
+}
+
+func (r *memoryRepo) CreateKey(ctx context.Context, k models.APIKey, hash string) (uuid.UUID, time.Time, error) {
+	row := memstore.APIKey{
+		ID:        uuid.New(),
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		KeyHash:   hash,
+		Scopes:    slices.Clone(k.Scopes),
+		CreatedAt: time.Now(),
+		ExpiresAt: k.ExpiresAt,
+	}
+	err := r.store.Update(func(t *memstore.Tables) error {
+		t.APIKeys = append(t.APIKeys, row)
+		return nil
+	})
+	return row.ID, row.CreatedAt, err
+}
+
+func (r *memoryRepo) GetKeyByID(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	return r.getKey(func(k memstore.APIKey) bool { return k.ID == id })
+}
+
+func (r *memoryRepo) GetKeyByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	return r.getKey(func(k memstore.APIKey) bool { return k.KeyHash == hash })
+}
+
+func (r *memoryRepo) getKey(match func(memstore.APIKey) bool) (*models.APIKey, error) {
+	var key *models.APIKey
+	err := r.store.View(func(t *memstore.Tables) error {
+		i := slices.IndexFunc(t.APIKeys, match)
+		if i < 0 {
+			return ErrNotFound
+		}
+		k := toModel(t.APIKeys[i])
+		key = &k
+		return nil
+	})
+	return key, err
+}
+
+func (r *memoryRepo) ListKeys(ctx context.Context) ([]models.APIKey, error) {
+	keys := make([]models.APIKey, 0)
+	err := r.store.View(func(t *memstore.Tables) error {
+		for _, k := range t.APIKeys {
+			keys = append(keys, toModel(k))
+		}
+		return nil
+	})
+	return keys, err
+}
+
+func (r *memoryRepo) RevokeKey(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.store.Update(func(t *memstore.Tables) error {
+		i := slices.IndexFunc(t.APIKeys, func(k memstore.APIKey) bool { return k.ID == id })
+		if i < 0 || t.APIKeys[i].RevokedAt != nil {
+			return ErrNotFound
+		}
+		t.APIKeys[i].RevokedAt = &at
+		return nil
+	})
+}
+
+func (r *memoryRepo) TouchKey(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.store.Update(func(t *memstore.Tables) error {
+		if i := slices.IndexFunc(t.APIKeys, func(k memstore.APIKey) bool { return k.ID == id }); i >= 0 {
+			t.APIKeys[i].LastUsedAt = &at
+		}
+		return nil
+	})
+}
+
+func toModel(k memstore.APIKey) models.APIKey {
+	return models.APIKey{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     slices.Clone(k.Scopes),
+		CreatedAt:  k.CreatedAt,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
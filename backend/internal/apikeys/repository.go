@@ -0,0 +1,39 @@
+package apikeys
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// ErrNotFound is returned for keys that do not exist or are already revoked.
+var ErrNotFound = errors.New("api key not found")
+
+// Repository stores API keys by the hash of the key.
+type Repository interface {
+	// CreateKey stores k under hash and returns its ID and creation time.
+	CreateKey(ctx context.Context, k models.APIKey, hash string) (uuid.UUID, time.Time, error)
+	GetKeyByID(ctx context.Context, id uuid.UUID) (*models.APIKey, error)
+	GetKeyByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	// ListKeys returns all keys, revoked ones included, by creation time.
+	ListKeys(ctx context.Context) ([]models.APIKey, error)
+	RevokeKey(ctx context.Context, id uuid.UUID, at time.Time) error
+	// TouchKey records that a key was used at the given time.
+	TouchKey(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// NewSQLRepo constructs an apikeys.Repository on a SQL database.
+func NewSQLRepo(db *sql.DB) Repository {
+	return &sqlRepo{db: db}
+}
+
+// NewMemoryRepo constructs an apikeys.Repository on an in-memory store.
+// Share one store between the repositories of all packages.
+func NewMemoryRepo(store *memstore.Store) Repository {
+	return &memoryRepo{store: store}
+}
@@ -0,0 +1,153 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/auth"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// touchEvery is how old a key's last use may get before it is written again;
+// it spares the database a write on every request.
+const touchEvery = time.Minute
+
+// prefixLen is how much of a key is kept in the clear to tell keys apart.
+const prefixLen = len(auth.KeyPrefix) + 4
+
+type ServiceInterface interface {
+	CreateKey(ctx context.Context, name string, scopes []auth.Scope, expiresAt *time.Time) (models.NewAPIKey, error)
+	ListKeys(ctx context.Context) ([]models.APIKey, error)
+	RevokeKey(ctx context.Context, id uuid.UUID) error
+	RotateKey(ctx context.Context, id uuid.UUID) (models.NewAPIKey, error)
+}
+
+type Service struct {
+	repo Repository
+	now  func() time.Time
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// CreateKey makes a key with the given scopes. The key is in the result
+// only; it cannot be read back later.
+func (s *Service) CreateKey(ctx context.Context, name string, scopes []auth.Scope, expiresAt *time.Time) (models.NewAPIKey, error) {
+	key, err := auth.NewKey()
+	if err != nil {
+		return models.NewAPIKey{}, err
+	}
+	k := models.APIKey{Name: name, Prefix: key[:prefixLen], ExpiresAt: expiresAt}
+	for _, sc := range scopes {
+		k.Scopes = append(k.Scopes, string(sc))
+	}
+	k.ID, k.CreatedAt, err = s.repo.CreateKey(ctx, k, auth.HashKey(key))
+	if err != nil {
+		return models.NewAPIKey{}, err
+	}
+	return models.NewAPIKey{APIKey: k, Key: key}, nil
+}
+
+func (s *Service) ListKeys(ctx context.Context) ([]models.APIKey, error) {
+	return s.repo.ListKeys(ctx)
+}
+
+func (s *Service) RevokeKey(ctx context.Context, id uuid.UUID) error {
+	return s.repo.RevokeKey(ctx, id, s.now())
+}
+
+// RotateKey replaces a key that is not revoked with a new one of the same
+// name, scopes and expiry, and revokes the old one.
+func (s *Service) RotateKey(ctx context.Context, id uuid.UUID) (models.NewAPIKey, error) {
+	old, err := s.repo.GetKeyByID(ctx, id)
+	if err != nil {
+		return models.NewAPIKey{}, err
+	}
+	if old.RevokedAt != nil {
+		return models.NewAPIKey{}, ErrNotFound
+	}
+	scopes, err := parseScopes(old.Scopes)
+	if err != nil {
+		return models.NewAPIKey{}, fmt.Errorf("api key %s: %w", id, err)
+	}
+
+	k, err := s.CreateKey(ctx, old.Name, scopes, old.ExpiresAt)
+	if err != nil {
+		return models.NewAPIKey{}, err
+	}
+	if err := s.repo.RevokeKey(ctx, id, s.now()); err != nil {
+		return models.NewAPIKey{}, err
+	}
+	return k, nil
+}
+
+// Authenticate returns the caller a key stands for. It implements
+// auth.KeyLookup.
+func (s *Service) Authenticate(ctx context.Context, key string) (auth.Caller, error) {
+	k, err := s.repo.GetKeyByHash(ctx, auth.HashKey(key))
+	if errors.Is(err, ErrNotFound) {
+		return auth.Caller{}, auth.ErrInvalidToken
+	}
+	if err != nil {
+		return auth.Caller{}, err
+	}
+	now := s.now()
+	if k.RevokedAt != nil || (k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)) {
+		return auth.Caller{}, auth.ErrInvalidToken
+	}
+	scopes, err := parseScopes(k.Scopes)
+	if err != nil {
+		return auth.Caller{}, fmt.Errorf("api key %s: %w", k.ID, err)
+	}
+
+	if k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) >= touchEvery {
+		// a missed last use is no reason to turn the caller away
+		if err := s.repo.TouchKey(ctx, k.ID, now); err != nil {
+			log.Printf("Authenticate: failed to record use of api key %s: %v", k.ID, err)
+		}
+	}
+
+	scope := auth.Broadest(scopes)
+	return auth.Caller{Scope: scope, Admin: scope == auth.ScopeAdmin}, nil
+}
+
+// Bootstrap makes an admin key named "bootstrap" if no usable admin key
+// exists, so that a new server, or one whose admin keys are all revoked, can
+// be managed at all. It returns the key, or "" if none was needed.
+func (s *Service) Bootstrap(ctx context.Context) (string, error) {
+	keys, err := s.repo.ListKeys(ctx)
+	if err != nil {
+		return "", err
+	}
+	now := s.now()
+	for _, k := range keys {
+		scopes, err := parseScopes(k.Scopes)
+		usable := err == nil && k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+		if usable && auth.Broadest(scopes) == auth.ScopeAdmin {
+			return "", nil
+		}
+	}
+
+	k, err := s.CreateKey(ctx, "bootstrap", []auth.Scope{auth.ScopeAdmin}, nil)
+	if err != nil {
+		return "", err
+	}
+	return k.Key, nil
+}
+
+func parseScopes(names []string) ([]auth.Scope, error) {
+	scopes := make([]auth.Scope, 0, len(names))
+	for _, n := range names {
+		sc, err := auth.ParseScope(n)
+		if err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, sc)
+	}
+	return scopes, nil
+}
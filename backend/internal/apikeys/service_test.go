@@ -0,0 +1,118 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/auth"
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
+)
+
+// newTestService returns a service on an empty memory store, with a clock
+// the test moves.
+func newTestService() (*Service, *time.Time) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := NewService(NewMemoryRepo(memstore.New()))
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	s, now := newTestService()
+
+	expires := now.Add(time.Hour)
+	k, err := s.CreateKey(ctx, "LMS", []auth.Scope{auth.ScopeRead, auth.ScopePlay}, &expires)
+	if err != nil {
+		t.Fatalf("CreateKey: %v", err)
+	}
+	if !strings.HasPrefix(k.Key, k.Prefix) || len(k.Prefix) >= len(k.Key) {
+		t.Errorf("prefix %q of key %q", k.Prefix, k.Key)
+	}
+
+	c, err := s.Authenticate(ctx, k.Key)
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if c != (auth.Caller{Scope: auth.ScopePlay}) {
+		t.Errorf("caller = %+v; want the play scope", c)
+	}
+	stored, _ := s.repo.GetKeyByID(ctx, k.ID)
+	if stored.LastUsedAt == nil || !stored.LastUsedAt.Equal(*now) {
+		t.Errorf("last used = %v; want %v", stored.LastUsedAt, *now)
+	}
+
+	// uses within a minute are not written
+	first := *now
+	*now = now.Add(30 * time.Second)
+	s.Authenticate(ctx, k.Key)
+	if stored, _ := s.repo.GetKeyByID(ctx, k.ID); !stored.LastUsedAt.Equal(first) {
+		t.Errorf("last used = %v; want it left at %v", stored.LastUsedAt, first)
+	}
+
+	if _, err := s.Authenticate(ctx, k.Key+"x"); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("Authenticate of an unknown key: err = %v; want %v", err, auth.ErrInvalidToken)
+	}
+	*now = expires
+	if _, err := s.Authenticate(ctx, k.Key); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("Authenticate of an expired key: err = %v; want %v", err, auth.ErrInvalidToken)
+	}
+}
+
+func TestService_RevokeAndRotate(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService()
+
+	old, err := s.CreateKey(ctx, "Dashboard", []auth.Scope{auth.ScopeRead}, nil)
+	if err != nil {
+		t.Fatalf("CreateKey: %v", err)
+	}
+	rotated, err := s.RotateKey(ctx, old.ID)
+	if err != nil {
+		t.Fatalf("RotateKey: %v", err)
+	}
+	if rotated.Key == old.Key || rotated.Name != "Dashboard" || len(rotated.Scopes) != 1 || rotated.Scopes[0] != "read" {
+		t.Errorf("rotated key = %+v; want a new key with the old name and scopes", rotated)
+	}
+	if _, err := s.Authenticate(ctx, old.Key); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("Authenticate of the rotated key: err = %v; want %v", err, auth.ErrInvalidToken)
+	}
+	if _, err := s.RotateKey(ctx, old.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("RotateKey of a revoked key: err = %v; want %v", err, ErrNotFound)
+	}
+
+	if err := s.RevokeKey(ctx, rotated.ID); err != nil {
+		t.Fatalf("RevokeKey: %v", err)
+	}
+	if _, err := s.Authenticate(ctx, rotated.Key); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("Authenticate of a revoked key: err = %v; want %v", err, auth.ErrInvalidToken)
+	}
+}
+
+func TestService_Bootstrap(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService()
+
+	key, err := s.Bootstrap(ctx)
+	if err != nil || key == "" {
+		t.Fatalf("Bootstrap = %q, %v; want a key", key, err)
+	}
+	if c, err := s.Authenticate(ctx, key); err != nil || !c.Admin {
+		t.Errorf("bootstrap key authenticates as %+v, %v; want an admin", c, err)
+	}
+	if again, err := s.Bootstrap(ctx); err != nil || again != "" {
+		t.Errorf("second Bootstrap = %q, %v; want no new key", again, err)
+	}
+
+	// once every admin key is revoked, a new one is made
+	keys, _ := s.ListKeys(ctx)
+	if err := s.RevokeKey(ctx, keys[0].ID); err != nil {
+		t.Fatalf("RevokeKey: %v", err)
+	}
+	if again, err := s.Bootstrap(ctx); err != nil || again == "" {
+		t.Errorf("Bootstrap without an admin key = %q, %v; want a key", again, err)
+	}
+}
@@ -0,0 +1,110 @@
+package apikeys
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type sqlRepo struct {
+	db *sql.DB
+}
+
+const keyColumns = `id, name, prefix, scopes, created_at, expires_at, last_used_at, revoked_at`
+
+func (r *sqlRepo) CreateKey(ctx context.Context, k models.APIKey, hash string) (uuid.UUID, time.Time, error) {
+	var (
+		id        uuid.UUID
+		createdAt time.Time
+	)
+	if err := r.db.QueryRowContext(ctx,
+		`INSERT INTO api_keys (name, prefix, key_hash, scopes, expires_at)
+		      VALUES ($1, $2, $3, $4, $5)
+		   RETURNING id, created_at`,
+		k.Name, k.Prefix, hash, strings.Join(k.Scopes, ","), k.ExpiresAt,
+	).Scan(&id, &createdAt); err != nil {
+		return uuid.Nil, time.Time{}, fmt.Errorf("insert api key: %w", err)
+	}
+	return id, createdAt, nil
+}
+
+func (r *sqlRepo) GetKeyByID(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	return r.getKey(ctx, `SELECT `+keyColumns+` FROM api_keys WHERE id = $1`, id)
+}
+
+func (r *sqlRepo) GetKeyByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	return r.getKey(ctx, `SELECT `+keyColumns+` FROM api_keys WHERE key_hash = $1`, hash)
+}
+
+func (r *sqlRepo) getKey(ctx context.Context, query string, arg any) (*models.APIKey, error) {
+	k, err := scanKey(r.db.QueryRowContext(ctx, query, arg))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query api key: %w", err)
+	}
+	return k, nil
+}
+
+func (r *sqlRepo) ListKeys(ctx context.Context) ([]models.APIKey, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+keyColumns+` FROM api_keys ORDER BY created_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("query api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := make([]models.APIKey, 0)
+	for rows.Next() {
+		k, err := scanKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan api key: %w", err)
+		}
+		keys = append(keys, *k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate api keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (r *sqlRepo) RevokeKey(ctx context.Context, id uuid.UUID, at time.Time) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE api_keys SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`, at, id)
+	if err != nil {
+		return fmt.Errorf("revoke api key: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("revoke api key (rows affected): %w", err)
+	} else if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *sqlRepo) TouchKey(ctx context.Context, id uuid.UUID, at time.Time) error {
+	if _, err := r.db.ExecContext(ctx,
+		`UPDATE api_keys SET last_used_at = $1 WHERE id = $2`, at, id); err != nil {
+		return fmt.Errorf("touch api key: %w", err)
+	}
+	return nil
+}
+
+// scanKey reads a row selected with keyColumns.
+func scanKey(row interface{ Scan(...any) error }) (*models.APIKey, error) {
+	var (
+		k      models.APIKey
+		scopes string
+	)
+	if err := row.Scan(&k.ID, &k.Name, &k.Prefix, &scopes, &k.CreatedAt,
+		&k.ExpiresAt, &k.LastUsedAt, &k.RevokedAt); err != nil {
+		return nil, err
+	}
+	k.Scopes = strings.Split(scopes, ",")
+	return &k, nil
+}
@@ -0,0 +1,141 @@
+// Package auth issues the tokens players get when they join a game, makes
+// and hashes API keys, and tells handlers who made a request.
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenTTL is how long a player token is valid after it is issued.
+const TokenTTL = 7 * 24 * time.Hour
+
+// ErrInvalidToken is returned for tokens that are malformed, not signed by
+// this server or expired.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Caller is who made a request: a player of one game, or an integration
+// using an API key, whose scope holds for every game.
+type Caller struct {
+	PlayerID    uuid.UUID
+	GameID      uuid.UUID
+	Facilitator bool  // the player facilitates their game
+	Scope       Scope // granted by an API key
+	Admin       bool  // an API key with the admin scope
+}
+
+// Has reports whether the caller's API key grants scope s.
+func (c Caller) Has(s Scope) bool {
+	return c.Admin || c.Scope.Includes(s)
+}
+
+// CanAccess reports whether the caller may look at the given game.
+func (c Caller) CanAccess(gameID uuid.UUID) bool {
+	return c.Has(ScopeRead) || c.plays(gameID)
+}
+
+// CanPlay reports whether the caller may make moves in the given game.
+func (c Caller) CanPlay(gameID uuid.UUID) bool {
+	return c.Has(ScopePlay) || c.plays(gameID)
+}
+
+// CanFacilitate reports whether the caller may run the given game: advance
+// its days, remove its players and the like.
+func (c Caller) CanFacilitate(gameID uuid.UUID) bool {
+	return c.Has(ScopeFacilitate) || (c.Facilitator && c.plays(gameID))
+}
+
+// plays reports whether the caller is a player of the given game.
+func (c Caller) plays(gameID uuid.UUID) bool {
+	return c.GameID != uuid.Nil && c.GameID == gameID
+}
+
+// FacilitatorLookup returns the player facilitating a game, uuid.Nil if
+// nobody is.
+type FacilitatorLookup func(ctx context.Context, gameID uuid.UUID) (uuid.UUID, error)
+
+// PlayerLookup reports whether a player still plays in a game.
+type PlayerLookup func(ctx context.Context, gameID, playerID uuid.UUID) (bool, error)
+
+type callerKey struct{}
+
+// WithCaller returns a copy of ctx carrying the caller.
+func WithCaller(ctx context.Context, c Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, c)
+}
+
+// CallerFrom returns the caller stored in ctx by WithCaller.
+func CallerFrom(ctx context.Context) (Caller, bool) {
+	c, ok := ctx.Value(callerKey{}).(Caller)
+	return c, ok
+}
+
+// Signer issues and verifies player tokens with HMAC-SHA256.
+type Signer struct {
+	secret []byte
+	now    func() time.Time
+}
+
+// NewSigner returns a signer using secret. Tokens issued with one secret are
+// rejected by signers with another.
+func NewSigner(secret []byte) *Signer {
+	return &Signer{secret: secret, now: time.Now}
+}
+
+// payload: player ID, game ID, expiry as Unix seconds
+const payloadLen = 16 + 16 + 8
+
+// Issue returns a token for a player of a game.
+func (s *Signer) Issue(playerID, gameID uuid.UUID) string {
+	payload := make([]byte, 0, payloadLen)
+	payload = append(payload, playerID[:]...)
+	payload = append(payload, gameID[:]...)
+	payload = binary.BigEndian.AppendUint64(payload, uint64(s.now().Add(TokenTTL).Unix()))
+
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(s.sign(payload))
+}
+
+// Verify checks a token and returns the player it was issued to.
+func (s *Signer) Verify(token string) (Caller, error) {
+	enc := base64.RawURLEncoding
+	payloadStr, sigStr, ok := strings.Cut(token, ".")
+	if !ok {
+		return Caller{}, ErrInvalidToken
+	}
+	payload, err := enc.DecodeString(payloadStr)
+	if err != nil || len(payload) != payloadLen {
+		return Caller{}, ErrInvalidToken
+	}
+	sig, err := enc.DecodeString(sigStr)
+	if err != nil || !hmac.Equal(sig, s.sign(payload)) {
+		return Caller{}, ErrInvalidToken
+	}
+
+	expires := time.Unix(int64(binary.BigEndian.Uint64(payload[32:])), 0)
+	if !s.now().Before(expires) {
+		return Caller{}, ErrInvalidToken
+	}
+
+	var c Caller
+	copy(c.PlayerID[:], payload[:16])
+	copy(c.GameID[:], payload[16:32])
+	if c.GameID == uuid.Nil {
+		return Caller{}, ErrInvalidToken
+	}
+	return c, nil
+}
+
+func (s *Signer) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
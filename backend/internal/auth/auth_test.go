@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSigner_IssueVerify(t *testing.T) {
+	s := NewSigner([]byte("secret"))
+	playerID, gameID := uuid.New(), uuid.New()
+
+	token := s.Issue(playerID, gameID)
+	c, err := s.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if c.PlayerID != playerID || c.GameID != gameID || c.Admin {
+		t.Errorf("caller = %+v; want player %s of game %s", c, playerID, gameID)
+	}
+
+	// flip a character of the payload
+	payload, sig, _ := strings.Cut(token, ".")
+	flipped := []byte(payload)
+	if flipped[0] == 'A' {
+		flipped[0] = 'B'
+	} else {
+		flipped[0] = 'A'
+	}
+
+	tests := map[string]string{
+		"empty":            "",
+		"no signature":     payload,
+		"tampered payload": string(flipped) + "." + sig,
+		"garbage":          "not.a-token",
+		"other secret":     NewSigner([]byte("other")).Issue(playerID, gameID),
+	}
+	for name, token := range tests {
+		if _, err := s.Verify(token); err != ErrInvalidToken {
+			t.Errorf("%s: err = %v; want %v", name, err, ErrInvalidToken)
+		}
+	}
+}
+
+func TestSigner_Expiry(t *testing.T) {
+	s := NewSigner([]byte("secret"))
+	start := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return start }
+	token := s.Issue(uuid.New(), uuid.New())
+
+	s.now = func() time.Time { return start.Add(TokenTTL - time.Second) }
+	if _, err := s.Verify(token); err != nil {
+		t.Errorf("Verify just before expiry: %v", err)
+	}
+	s.now = func() time.Time { return start.Add(TokenTTL) }
+	if _, err := s.Verify(token); err != ErrInvalidToken {
+		t.Errorf("Verify at expiry: err = %v; want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestCaller_CanAccess(t *testing.T) {
+	game := uuid.New()
+	if !(Caller{GameID: game}).CanAccess(game) {
+		t.Error("a player cannot access their own game")
+	}
+	if (Caller{GameID: game}).CanAccess(uuid.New()) {
+		t.Error("a player can access another game")
+	}
+	if (Caller{}).CanAccess(uuid.Nil) {
+		t.Error("an empty caller can access the nil game")
+	}
+	if !(Caller{Admin: true}).CanAccess(uuid.New()) {
+		t.Error("an admin cannot access a game")
+	}
+
+	ctx := WithCaller(context.Background(), Caller{GameID: game})
+	if c, ok := CallerFrom(ctx); !ok || c.GameID != game {
+		t.Errorf("CallerFrom = %+v, %v; want the caller stored", c, ok)
+	}
+	if _, ok := CallerFrom(context.Background()); ok {
+		t.Error("CallerFrom found a caller in an empty context")
+	}
+}
+
+func TestCaller_Scopes(t *testing.T) {
+	game := uuid.New()
+	tests := []struct {
+		name                     string
+		caller                   Caller
+		access, play, facilitate bool
+	}{
+		{"read key", Caller{Scope: ScopeRead}, true, false, false},
+		{"play key", Caller{Scope: ScopePlay}, true, true, false},
+		{"facilitate key", Caller{Scope: ScopeFacilitate}, true, true, true},
+		{"admin key", Caller{Scope: ScopeAdmin, Admin: true}, true, true, true},
+		{"player", Caller{GameID: game}, true, true, false},
+		{"facilitator", Caller{GameID: game, Facilitator: true}, true, true, true},
+		{"player of another game", Caller{GameID: uuid.New(), Facilitator: true}, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.caller.CanAccess(game); got != tt.access {
+				t.Errorf("CanAccess = %v; want %v", got, tt.access)
+			}
+			if got := tt.caller.CanPlay(game); got != tt.play {
+				t.Errorf("CanPlay = %v; want %v", got, tt.play)
+			}
+			if got := tt.caller.CanFacilitate(game); got != tt.facilitate {
+				t.Errorf("CanFacilitate = %v; want %v", got, tt.facilitate)
+			}
+		})
+	}
+
+	if got := Broadest([]Scope{ScopePlay, ScopeRead, ScopeFacilitate}); got != ScopeFacilitate {
+		t.Errorf("Broadest = %q; want %q", got, ScopeFacilitate)
+	}
+	if _, err := ParseScope("write"); err == nil {
+		t.Error("ParseScope accepted an unknown scope")
+	}
+}
+
+func TestNewKey(t *testing.T) {
+	a, err := NewKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := NewKey()
+	if a == b || !IsKey(a) {
+		t.Errorf("keys %q and %q; want two different keys starting with %q", a, b, KeyPrefix)
+	}
+	if HashKey(a) != HashKey(a) || HashKey(a) == HashKey(b) {
+		t.Error("HashKey is not a function of the key")
+	}
+}
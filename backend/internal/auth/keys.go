@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Scope is what an API key may do, in every game. Each scope includes the
+// ones before it.
+type Scope string
+
+const (
+	ScopeRead       Scope = "read"       // look at games
+	ScopePlay       Scope = "play"       // also make moves
+	ScopeFacilitate Scope = "facilitate" // also create, import and run games
+	ScopeAdmin      Scope = "admin"      // also manage API keys
+)
+
+// Scopes lists the scopes from the narrowest to the broadest.
+var Scopes = []Scope{ScopeRead, ScopePlay, ScopeFacilitate, ScopeAdmin}
+
+// ParseScope returns the scope named s.
+func ParseScope(s string) (Scope, error) {
+	if !slices.Contains(Scopes, Scope(s)) {
+		return "", fmt.Errorf("unknown scope %q", s)
+	}
+	return Scope(s), nil
+}
+
+// Includes reports whether s grants everything other does. The empty scope
+// includes nothing.
+func (s Scope) Includes(other Scope) bool {
+	i, j := slices.Index(Scopes, s), slices.Index(Scopes, other)
+	return i >= 0 && j >= 0 && i >= j
+}
+
+// Broadest returns the scope that includes all of scopes, or "" for none.
+func Broadest(scopes []Scope) Scope {
+	var b Scope
+	for _, s := range scopes {
+		if b == "" || s.Includes(b) {
+			b = s
+		}
+	}
+	return b
+}
+
+// KeyPrefix starts every API key, telling them apart from player tokens.
+const KeyPrefix = "ksk_"
+
+// NewKey returns a random API key.
+func NewKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate key: %w", err)
+	}
+	return KeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// IsKey reports whether token looks like an API key rather than a player
+// token.
+func IsKey(token string) bool {
+	return strings.HasPrefix(token, KeyPrefix)
+}
+
+// HashKey returns the hash an API key is stored and looked up by. Keys are
+// random and long, so a plain SHA-256 is enough.
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// KeyLookup returns the caller an API key stands for, or ErrInvalidToken if
+// the key is unknown, revoked or expired.
+type KeyLookup func(ctx context.Context, key string) (Caller, error)
@@ -0,0 +1,104 @@
+package cards
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/engine"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// GetAging builds the aging work-in-progress report of a game: every card
+// selected but not deployed, with its age, its column and how its age
+// compares to the lead times of the cards of its class of service deployed
+// so far.
+func (s *Service) GetAging(ctx context.Context, gameID uuid.UUID) (models.Aging, error) {
+	layout, err := s.repo.GetFlowLayout(ctx, gameID)
+	if err != nil {
+		return models.Aging{}, err
+	}
+	titles := make(map[uuid.UUID]string, len(layout.Columns))
+	for _, col := range layout.Columns {
+		titles[col.ID] = col.Title
+	}
+
+	aging := models.Aging{Day: layout.Day, LeadTimes: []models.ClassLeadTime{}, Cards: []models.AgingCard{}}
+	leadTimes := make(map[string][]int)
+	var all []int // of every card, whatever its class
+	err = s.repo.StreamCardFlows(ctx, gameID, func(f CardFlow) error {
+		c := f.Card
+		switch {
+		case c.SelectedDay == 0:
+		case c.DeployedDay > 0:
+			lt := c.DeployedDay - c.SelectedDay
+			leadTimes[c.ClassOfService] = append(leadTimes[c.ClassOfService], lt)
+			all = append(all, lt)
+		default:
+			card := models.AgingCard{
+				CardID:         c.ID,
+				Title:          c.Title,
+				ClassOfService: c.ClassOfService,
+				Column:         titles[c.ColumnID],
+				Age:            cardAge(c.SelectedDay, 0, layout.Day),
+				Blocked:        len(f.Blocks) > 0 && f.Blocks[len(f.Blocks)-1].Unblock == nil,
+			}
+			card.DaysInColumn = card.Age
+			if len(f.Moves) > 0 {
+				card.DaysInColumn = layout.Day - f.Moves[len(f.Moves)-1].Day
+			}
+			aging.Cards = append(aging.Cards, card)
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Aging{}, err
+	}
+
+	stats := make(map[string]models.ClassLeadTime, len(leadTimes))
+	for class, days := range leadTimes {
+		stats[class] = classLeadTime(class, days)
+	}
+	for _, class := range slices.Sorted(maps.Keys(stats)) {
+		aging.LeadTimes = append(aging.LeadTimes, stats[class])
+	}
+	if len(all) > 0 {
+		total := classLeadTime("", all)
+		aging.Total = &total
+	}
+
+	for i := range aging.Cards {
+		c := &aging.Cards[i]
+		lt, ok := stats[c.ClassOfService]
+		if !ok && aging.Total != nil {
+			lt, ok = *aging.Total, true
+		}
+		switch {
+		case !ok:
+			c.Status = models.AgingUnknown
+		case c.Age > lt.P85:
+			c.Status = models.AgingBreached
+		case c.Age > lt.P50:
+			c.Status = models.AgingAtRisk
+		default:
+			c.Status = models.AgingOnTrack
+		}
+	}
+	slices.SortFunc(aging.Cards, func(a, b models.AgingCard) int {
+		return cmp.Or(cmp.Compare(b.Age, a.Age), cmp.Compare(a.Title, b.Title))
+	})
+	return aging, nil
+}
+
+// classLeadTime sums up the lead times of the deployed cards of a class.
+func classLeadTime(class string, days []int) models.ClassLeadTime {
+	slices.Sort(days)
+	return models.ClassLeadTime{
+		ClassOfService: class,
+		Deployed:       len(days),
+		P50:            engine.Percentile(days, 50),
+		P85:            engine.Percentile(days, 85),
+	}
+}
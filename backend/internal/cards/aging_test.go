@@ -0,0 +1,95 @@
+package cards_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/cards"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestService_GetAging(t *testing.T) {
+	dev := uuid.New()
+	done := uuid.New()
+	layout := cards.FlowLayout{
+		Day:     10,
+		Columns: []cards.FlowColumn{{ID: dev, Title: "Development"}, {ID: done, Title: "Deployed"}},
+	}
+	deployed := func(cos string, selected, deployed int) cards.CardFlow {
+		return cards.CardFlow{Card: models.Card{ID: uuid.New(), ColumnID: done, ClassOfService: cos, SelectedDay: selected, DeployedDay: deployed}}
+	}
+	inProgress := func(title, cos string, selected int) cards.CardFlow {
+		return cards.CardFlow{Card: models.Card{ID: uuid.New(), Title: title, ColumnID: dev, ClassOfService: cos, SelectedDay: selected}}
+	}
+
+	a := inProgress("A", "S", 9)
+	a.Moves = []cards.CardMove{{Day: 9, From: "Options", To: "Development"}}
+	b := inProgress("B", "S", 4)
+	b.Blocks = []cards.CardBlock{{Blocker: models.Blocker{Reason: "On hold", Day: 8}}}
+	c := inProgress("C", "S", 1)
+	c.Blocks = []cards.CardBlock{{Blocker: models.Blocker{Reason: "On hold", Day: 2}, Unblock: &models.Unblock{Days: 1}}}
+	d := inProgress("D", "F", 8)
+	e := inProgress("E", "E", 5)
+	repo := &stubRepo{layout: layout, flows: []cards.CardFlow{
+		deployed("S", 1, 3), deployed("S", 2, 6), deployed("S", 1, 7), deployed("S", 1, 9), deployed("E", 2, 5), deployed("", 3, 5),
+		a, b, c, d, e,
+		{Card: models.Card{Title: "backlog", ColumnID: dev}},
+	}}
+
+	aging, err := cards.NewService(repo).GetAging(context.Background(), uuid.New())
+	if err != nil {
+		t.Fatalf("GetAging: %v", err)
+	}
+
+	wantLeadTimes := []models.ClassLeadTime{
+		{Deployed: 1, P50: 2, P85: 2}, // without a class
+		{ClassOfService: "E", Deployed: 1, P50: 3, P85: 3},
+		{ClassOfService: "S", Deployed: 4, P50: 4, P85: 8},
+	}
+	if !reflect.DeepEqual(aging.LeadTimes, wantLeadTimes) {
+		t.Errorf("lead times = %+v; want %+v", aging.LeadTimes, wantLeadTimes)
+	}
+	wantTotal := models.ClassLeadTime{Deployed: 6, P50: 3, P85: 8}
+	if aging.Total == nil || *aging.Total != wantTotal {
+		t.Errorf("total = %+v; want %+v", aging.Total, wantTotal)
+	}
+	card := func(f cards.CardFlow, age, inColumn int, blocked bool, status string) models.AgingCard {
+		return models.AgingCard{
+			CardID: f.Card.ID, Title: f.Card.Title, ClassOfService: f.Card.ClassOfService, Column: "Development",
+			Age: age, DaysInColumn: inColumn, Blocked: blocked, Status: status,
+		}
+	}
+	wantCards := []models.AgingCard{
+		card(c, 9, 9, false, models.AgingBreached),
+		card(b, 6, 6, true, models.AgingAtRisk),
+		card(e, 5, 5, false, models.AgingBreached), // against the expedite card deployed
+		card(d, 2, 2, false, models.AgingOnTrack),  // no fixed date card deployed: against all
+		card(a, 1, 1, false, models.AgingOnTrack),
+	}
+	if aging.Day != 10 || !reflect.DeepEqual(aging.Cards, wantCards) {
+		t.Errorf("day %d, cards = %+v; want day 10, %+v", aging.Day, aging.Cards, wantCards)
+	}
+}
+
+func TestService_GetAging_NothingDeployed(t *testing.T) {
+	dev := uuid.New()
+	repo := &stubRepo{
+		layout: cards.FlowLayout{Day: 3, Columns: []cards.FlowColumn{{ID: dev, Title: "Development"}}},
+		flows:  []cards.CardFlow{{Card: models.Card{Title: "S1", ColumnID: dev, SelectedDay: 1}}},
+	}
+	aging, err := cards.NewService(repo).GetAging(context.Background(), uuid.New())
+	if err != nil {
+		t.Fatalf("GetAging: %v", err)
+	}
+	if len(aging.LeadTimes) != 0 || aging.Total != nil || len(aging.Cards) != 1 || aging.Cards[0].Status != models.AgingUnknown {
+		t.Errorf("aging = %+v; want S1 unknown and no lead times", aging)
+	}
+
+	repo = &stubRepo{layoutErr: cards.ErrNotFound}
+	if _, err := cards.NewService(repo).GetAging(context.Background(), uuid.New()); !errors.Is(err, cards.ErrNotFound) {
+		t.Fatalf("err = %v; want %v", err, cards.ErrNotFound)
+	}
+}
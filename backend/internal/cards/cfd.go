@@ -0,0 +1,69 @@
+package cards
+
+import (
+	"context"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// columnOn works out the column the card was in at the end of day from its
+// move events: the column the first move after that day left from, or the
+// card's current column if it has not moved since.
+func (f CardFlow) columnOn(day int, resolve func(title string) uuid.UUID) uuid.UUID {
+	for _, m := range f.Moves {
+		if m.Day > day {
+			return resolve(m.From)
+		}
+	}
+	return f.Card.ColumnID
+}
+
+// GetCFD builds the cumulative flow diagram of a game: the number of cards
+// in every leaf column at the end of each day so far, annotated with the
+// stretches cards were blocked.
+func (s *Service) GetCFD(ctx context.Context, gameID uuid.UUID) (models.CFD, error) {
+	layout, err := s.repo.GetFlowLayout(ctx, gameID)
+	if err != nil {
+		return models.CFD{}, err
+	}
+
+	cfd := models.CFD{
+		Columns:     make([]string, len(layout.Columns)),
+		Days:        make([]models.CFDDay, layout.Day),
+		Annotations: []models.CFDAnnotation{},
+	}
+	pos := make(map[uuid.UUID]int, len(layout.Columns))
+	for i, col := range layout.Columns {
+		cfd.Columns[i] = col.Title
+		pos[col.ID] = i
+	}
+	for i := range cfd.Days {
+		cfd.Days[i] = models.CFDDay{Day: i + 1, Cards: make([]int, len(layout.Columns))}
+	}
+
+	resolve := columnResolver(layout)
+	err = s.repo.StreamCardFlows(ctx, gameID, func(f CardFlow) error {
+		for _, d := range cfd.Days {
+			if i, ok := pos[f.columnOn(d.Day, resolve)]; ok {
+				d.Cards[i]++
+			}
+		}
+		for _, b := range f.Blocks {
+			cfd.Annotations = append(cfd.Annotations, models.CFDAnnotation{
+				CardID:    f.Card.ID,
+				Title:     f.Card.Title,
+				Reason:    b.Blocker.Reason,
+				BlockedBy: b.Blocker.BlockedBy,
+				Day:       b.Blocker.Day,
+				Days:      b.Days(layout),
+				Unblocked: b.Unblock != nil,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return models.CFD{}, err
+	}
+	return cfd, nil
+}
@@ -0,0 +1,86 @@
+package cards
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// GetFlowEfficiency works out how many days every card that has started
+// spent in active and in queue columns, from its move events, and adds them
+// up by class of service and for all cards. Days in done columns do not
+// count.
+func (s *Service) GetFlowEfficiency(ctx context.Context, gameID uuid.UUID) (models.FlowEfficiency, error) {
+	layout, err := s.repo.GetFlowLayout(ctx, gameID)
+	if err != nil {
+		return models.FlowEfficiency{}, err
+	}
+	types := make(map[uuid.UUID]string, len(layout.Columns))
+	for _, col := range layout.Columns {
+		types[col.ID] = col.Type
+	}
+
+	fe := models.FlowEfficiency{Day: layout.Day, Classes: []models.ClassFlowEfficiency{}, Cards: []models.CardFlowEfficiency{}}
+	classes := make(map[string]*models.ClassFlowEfficiency)
+	all := &fe.Total
+	err = s.repo.StreamCardFlows(ctx, gameID, func(f CardFlow) error {
+		card := models.CardFlowEfficiency{
+			CardID:         f.Card.ID,
+			Title:          f.Card.Title,
+			ClassOfService: f.Card.ClassOfService,
+			Deployed:       f.Card.DeployedDay > 0,
+		}
+		for id, days := range f.ColumnDays(layout) {
+			switch types[id] {
+			case "active":
+				card.ActiveDays += days
+			case "queue":
+				card.QueueDays += days
+			}
+		}
+		if card.ActiveDays+card.QueueDays == 0 {
+			return nil // not started, or only today
+		}
+		card.Efficiency = efficiency(card.ActiveDays, card.QueueDays)
+		fe.Cards = append(fe.Cards, card)
+
+		c, ok := classes[card.ClassOfService]
+		if !ok {
+			c = &models.ClassFlowEfficiency{ClassOfService: card.ClassOfService}
+			classes[card.ClassOfService] = c
+		}
+		for _, sum := range []*models.ClassFlowEfficiency{c, all} {
+			sum.Cards++
+			sum.ActiveDays += card.ActiveDays
+			sum.QueueDays += card.QueueDays
+		}
+		return nil
+	})
+	if err != nil {
+		return models.FlowEfficiency{}, err
+	}
+
+	for _, class := range slices.Sorted(maps.Keys(classes)) {
+		c := classes[class]
+		c.Efficiency = efficiency(c.ActiveDays, c.QueueDays)
+		fe.Classes = append(fe.Classes, *c)
+	}
+	all.Efficiency = efficiency(all.ActiveDays, all.QueueDays)
+	slices.SortFunc(fe.Cards, func(a, b models.CardFlowEfficiency) int {
+		return cmp.Or(cmp.Compare(a.Efficiency, b.Efficiency), cmp.Compare(a.Title, b.Title))
+	})
+	return fe, nil
+}
+
+// efficiency is the share of active days, rounded to two decimals.
+func efficiency(active, queue int) float64 {
+	if active+queue == 0 {
+		return 0
+	}
+	return math.Round(float64(active)/float64(active+queue)*100) / 100
+}
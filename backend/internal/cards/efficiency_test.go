@@ -0,0 +1,91 @@
+package cards_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/cards"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestService_GetFlowEfficiency(t *testing.T) {
+	options, analysis, ready, deployed := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	s1, s2, e1, n1 := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	repo := &stubRepo{
+		layout: cards.FlowLayout{
+			Day: 10,
+			Columns: []cards.FlowColumn{
+				{ID: options, Title: "Options", Type: "queue"},
+				{ID: analysis, Title: "Analysis", Type: "active"},
+				{ID: ready, Title: "Ready", Type: "queue"},
+				{ID: deployed, Title: "Deployed", Type: "done"},
+			},
+		},
+		flows: []cards.CardFlow{
+			{
+				Card: models.Card{ID: s1, Title: "S1", ClassOfService: "S", ColumnID: deployed, SelectedDay: 1, DeployedDay: 6},
+				Moves: []cards.CardMove{
+					{Day: 1, From: "Options", To: "Analysis"},
+					{Day: 3, From: "Analysis", To: "Ready"},
+					{Day: 6, From: "Ready", To: "Deployed"},
+				},
+			},
+			{
+				Card:  models.Card{ID: s2, Title: "S2", ClassOfService: "S", ColumnID: analysis, SelectedDay: 8},
+				Moves: []cards.CardMove{{Day: 8, From: "Options", To: "Analysis"}},
+			},
+			{
+				Card: models.Card{ID: e1, Title: "E1", ClassOfService: "E", ColumnID: analysis, SelectedDay: 4},
+				Moves: []cards.CardMove{
+					{Day: 4, From: "Options", To: "Ready"},
+					{Day: 9, From: "Ready", To: "Analysis"},
+				},
+			},
+			{
+				Card:  models.Card{ID: n1, Title: "N1", ColumnID: analysis, SelectedDay: 7},
+				Moves: []cards.CardMove{{Day: 7, From: "Options", To: "Analysis"}},
+			},
+			// neither a card still in the backlog nor one started today counts
+			{Card: models.Card{Title: "S3", ClassOfService: "S", ColumnID: options}},
+			{
+				Card:  models.Card{Title: "S4", ClassOfService: "S", ColumnID: analysis, SelectedDay: 10},
+				Moves: []cards.CardMove{{Day: 10, From: "Options", To: "Analysis"}},
+			},
+		},
+	}
+
+	fe, err := cards.NewService(repo).GetFlowEfficiency(context.Background(), uuid.New())
+	if err != nil {
+		t.Fatalf("GetFlowEfficiency: %v", err)
+	}
+
+	wantCards := []models.CardFlowEfficiency{
+		{CardID: e1, Title: "E1", ClassOfService: "E", ActiveDays: 1, QueueDays: 5, Efficiency: 0.17},
+		{CardID: s1, Title: "S1", ClassOfService: "S", Deployed: true, ActiveDays: 2, QueueDays: 3, Efficiency: 0.4},
+		{CardID: n1, Title: "N1", ActiveDays: 3, Efficiency: 1},
+		{CardID: s2, Title: "S2", ClassOfService: "S", ActiveDays: 2, Efficiency: 1},
+	}
+	if !reflect.DeepEqual(fe.Cards, wantCards) {
+		t.Errorf("cards = %+v; want %+v", fe.Cards, wantCards)
+	}
+	wantClasses := []models.ClassFlowEfficiency{
+		{Cards: 1, ActiveDays: 3, Efficiency: 1}, // without a class
+		{ClassOfService: "E", Cards: 1, ActiveDays: 1, QueueDays: 5, Efficiency: 0.17},
+		{ClassOfService: "S", Cards: 2, ActiveDays: 4, QueueDays: 3, Efficiency: 0.57},
+	}
+	if fe.Day != 10 || !reflect.DeepEqual(fe.Classes, wantClasses) {
+		t.Errorf("day %d, classes = %+v; want day 10, %+v", fe.Day, fe.Classes, wantClasses)
+	}
+	wantTotal := models.ClassFlowEfficiency{Cards: 4, ActiveDays: 8, QueueDays: 8, Efficiency: 0.5}
+	if fe.Total != wantTotal {
+		t.Errorf("total = %+v; want %+v", fe.Total, wantTotal)
+	}
+
+	repo = &stubRepo{layoutErr: cards.ErrNotFound}
+	if _, err := cards.NewService(repo).GetFlowEfficiency(context.Background(), uuid.New()); !errors.Is(err, cards.ErrNotFound) {
+		t.Fatalf("err = %v; want %v", err, cards.ErrNotFound)
+	}
+}
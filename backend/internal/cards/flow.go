@@ -0,0 +1,276 @@
+package cards
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// FlowColumn is a column cards can sit in, titled "Parent - Sub" for
+// subcolumns.
+type FlowColumn struct {
+	ID    uuid.UUID
+	Title string
+	Type  string // "queue", "active" or "done"
+}
+
+// FlowLayout holds the game-wide dimensions of a card flow export.
+type FlowLayout struct {
+	Day         int          // current game day
+	EffortTypes []string     // in board order
+	Columns     []FlowColumn // leaf columns in board order
+	// Renamed maps the titles of leaf columns before a rename to the ones
+	// they had after it, so moves logged under an old title still resolve.
+	Renamed map[string]string
+}
+
+// addRenames records the renames of a "column_changed" event payload.
+func (l *FlowLayout) addRenames(payload []byte) error {
+	var change models.ColumnChange
+	if err := json.Unmarshal(payload, &change); err != nil {
+		return fmt.Errorf("decode column change: %w", err)
+	}
+	for from, to := range change.Renamed {
+		if l.Renamed == nil {
+			l.Renamed = make(map[string]string)
+		}
+		l.Renamed[from] = to
+	}
+	return nil
+}
+
+// CardMove is one "move" event of a card.
+type CardMove struct {
+	Day  int
+	From string
+	To   string
+}
+
+// CardBlock is a stretch of days a card was blocked, from its "block" event
+// to its "unblock" event.
+type CardBlock struct {
+	Blocker models.Blocker
+	Unblock *models.Unblock // nil while the card is still blocked
+}
+
+// CardFlow is a card with its efforts, its move history and the times it
+// was blocked.
+type CardFlow struct {
+	Card   models.Card
+	Moves  []CardMove
+	Blocks []CardBlock
+}
+
+// addEvent adds a "move", "block" or "unblock" event of the card to its
+// flow. Events must be added in the order they happened.
+func (f *CardFlow) addEvent(eventType string, payload []byte, day int) error {
+	switch eventType {
+	case "move":
+		m, err := decodeMove(payload, day)
+		if err != nil {
+			return err
+		}
+		f.Moves = append(f.Moves, m)
+	case "block":
+		var b models.Blocker
+		if err := json.Unmarshal(payload, &b); err != nil {
+			return fmt.Errorf("decode block event: %w", err)
+		}
+		f.Blocks = append(f.Blocks, CardBlock{Blocker: b})
+	case "unblock":
+		var u models.Unblock
+		if err := json.Unmarshal(payload, &u); err != nil {
+			return fmt.Errorf("decode unblock event: %w", err)
+		}
+		if n := len(f.Blocks); n > 0 && f.Blocks[n-1].Unblock == nil {
+			f.Blocks[n-1].Unblock = &u
+		}
+	}
+	return nil
+}
+
+// Days is the number of days the card was blocked, counting up to the
+// current game day while it still is.
+func (b CardBlock) Days(layout FlowLayout) int {
+	if b.Unblock != nil {
+		return b.Unblock.Days
+	}
+	return max(layout.Day-b.Blocker.Day, 0)
+}
+
+// BlockedDays is the number of days the card spent blocked.
+func (f CardFlow) BlockedDays(layout FlowLayout) int {
+	days := 0
+	for _, b := range f.Blocks {
+		days += b.Days(layout)
+	}
+	return days
+}
+
+// LeadTime is deployed day minus selected day, or 0 while the card is not
+// deployed yet.
+func (f CardFlow) LeadTime() int {
+	if f.Card.SelectedDay <= 0 || f.Card.DeployedDay <= 0 {
+		return 0
+	}
+	return f.Card.DeployedDay - f.Card.SelectedDay
+}
+
+// ColumnDays works out how many days the card spent in each column, keyed by
+// column ID. Time starts counting on the selected day and stops on the
+// deployed day, or on the current game day for cards still in flight.
+func (f CardFlow) ColumnDays(layout FlowLayout) map[uuid.UUID]int {
+	days := make(map[uuid.UUID]int)
+	if f.Card.SelectedDay <= 0 && len(f.Moves) == 0 {
+		return days // never left the backlog
+	}
+
+	end := layout.Day
+	if f.Card.DeployedDay > 0 {
+		end = f.Card.DeployedDay
+	}
+
+	resolve := columnResolver(layout)
+	current := f.Card.ColumnID
+	enter := f.Card.SelectedDay
+	if len(f.Moves) > 0 {
+		first := f.Moves[0]
+		current = resolve(first.From)
+		if enter <= 0 || enter > first.Day {
+			enter = first.Day
+		}
+	}
+
+	for _, m := range f.Moves {
+		if current != uuid.Nil && m.Day > enter {
+			days[current] += m.Day - enter
+		}
+		current = resolve(m.To)
+		enter = m.Day
+	}
+	if current != uuid.Nil && end > enter {
+		days[current] += end - enter
+	}
+
+	return days
+}
+
+// columnResolver maps the column titles used in move events to column IDs.
+// Full "Parent - Sub" titles always match; bare titles only when unambiguous.
+// Titles a column had before it was renamed follow the renames to its
+// current one.
+func columnResolver(layout FlowLayout) func(title string) uuid.UUID {
+	cols := layout.Columns
+	full := make(map[string]uuid.UUID, len(cols))
+	for _, c := range cols {
+		full[c.Title] = c.ID
+	}
+	return func(title string) uuid.UUID {
+		for range len(layout.Renamed) + 1 {
+			if id, ok := full[title]; ok {
+				return id
+			}
+			next, ok := layout.Renamed[title]
+			if !ok {
+				break
+			}
+			title = next
+		}
+		var match uuid.UUID
+		for _, c := range cols {
+			if strings.HasSuffix(c.Title, " - "+title) {
+				if match != uuid.Nil {
+					return uuid.Nil
+				}
+				match = c.ID
+			}
+		}
+		return match
+	}
+}
+
+// WriteFlowCSV streams one CSV row per card of a game to w: title, class of
+// service, value, selected and deployed day, lead time, days blocked,
+// estimate and actual for every effort type, and the days spent in every
+// column. Rows are written as they are read; nothing is written if the game
+// does not exist.
+func (s *Service) WriteFlowCSV(ctx context.Context, gameID uuid.UUID, w io.Writer) error {
+	layout, err := s.repo.GetFlowLayout(ctx, gameID)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	header := []string{
+		"title", "class_of_service", "value_estimate",
+		"selected_day", "deployed_day", "lead_time", "blocked_days",
+	}
+	for _, et := range layout.EffortTypes {
+		header = append(header, et+" estimate", et+" actual")
+	}
+	for _, col := range layout.Columns {
+		header = append(header, "days in "+col.Title)
+	}
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	err = s.repo.StreamCardFlows(ctx, gameID, func(f CardFlow) error {
+		row := []string{
+			f.Card.Title,
+			f.Card.ClassOfService,
+			f.Card.ValueEstimate,
+			optionalInt(f.Card.SelectedDay),
+			optionalInt(f.Card.DeployedDay),
+			"",
+			strconv.Itoa(f.BlockedDays(layout)),
+		}
+		if f.Card.SelectedDay > 0 && f.Card.DeployedDay > 0 {
+			row[5] = strconv.Itoa(f.LeadTime())
+		}
+
+		efforts := make(map[string]models.Effort, len(f.Card.Efforts))
+		for _, e := range f.Card.Efforts {
+			efforts[e.EffortType] = e
+		}
+		for _, et := range layout.EffortTypes {
+			e, ok := efforts[et]
+			if !ok {
+				row = append(row, "", "")
+				continue
+			}
+			row = append(row, strconv.Itoa(e.Estimate), strconv.Itoa(e.Actual))
+		}
+
+		days := f.ColumnDays(layout)
+		for _, col := range layout.Columns {
+			row = append(row, strconv.Itoa(days[col.ID]))
+		}
+
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("write card %q: %w", f.Card.Title, err)
+		}
+		cw.Flush()
+		return cw.Error()
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func optionalInt(v int) string {
+	if v <= 0 {
+		return ""
+	}
+	return strconv.Itoa(v)
+}
@@ -0,0 +1,321 @@
+package cards_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Germanicus1/kanban-sim/backend/internal/cards"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type stubRepo struct {
+	layout    cards.FlowLayout
+	flows     []cards.CardFlow
+	layoutErr error
+	search    cards.Search  // last search run
+	next      *cards.Cursor // cursor SearchCards returns
+}
+
+func (s *stubRepo) GetCardsByGameID(ctx context.Context, gameID uuid.UUID) ([]models.Card, error) {
+	return nil, nil
+}
+
+func (s *stubRepo) SearchCards(ctx context.Context, gameID uuid.UUID, search cards.Search) ([]models.Card, *cards.Cursor, error) {
+	s.search = search
+	return nil, s.next, s.layoutErr
+}
+
+func (s *stubRepo) GetFlowLayout(ctx context.Context, gameID uuid.UUID) (cards.FlowLayout, error) {
+	return s.layout, s.layoutErr
+}
+
+func (s *stubRepo) StreamCardFlows(ctx context.Context, gameID uuid.UUID, fn func(cards.CardFlow) error) error {
+	for _, f := range s.flows {
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestCardFlow_ColumnDays(t *testing.T) {
+	ready := uuid.New()
+	dev := uuid.New()
+	done := uuid.New()
+	layout := cards.FlowLayout{
+		Day: 10,
+		Columns: []cards.FlowColumn{
+			{ID: ready, Title: "Ready"},
+			{ID: dev, Title: "Development - In Progress"},
+			{ID: done, Title: "Deployed"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		flow cards.CardFlow
+		want map[uuid.UUID]int
+	}{
+		{
+			name: "still in backlog",
+			flow: cards.CardFlow{Card: models.Card{ColumnID: ready}},
+			want: map[uuid.UUID]int{},
+		},
+		{
+			name: "in flight without moves",
+			flow: cards.CardFlow{Card: models.Card{ColumnID: ready, SelectedDay: 7}},
+			want: map[uuid.UUID]int{ready: 3},
+		},
+		{
+			name: "deployed",
+			flow: cards.CardFlow{
+				Card: models.Card{ColumnID: done, SelectedDay: 2, DeployedDay: 8},
+				Moves: []cards.CardMove{
+					{Day: 3, From: "Ready", To: "In Progress"},
+					{Day: 8, From: "Development - In Progress", To: "Deployed"},
+				},
+			},
+			want: map[uuid.UUID]int{ready: 1, dev: 5},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.flow.ColumnDays(layout)
+			if len(got) != len(tc.want) {
+				t.Fatalf("ColumnDays = %v; want %v", got, tc.want)
+			}
+			for id, d := range tc.want {
+				if got[id] != d {
+					t.Errorf("ColumnDays = %v; want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCardFlow_ColumnDays_Renamed(t *testing.T) {
+	ready := uuid.New()
+	dev := uuid.New()
+	done := uuid.New()
+	layout := cards.FlowLayout{
+		Day: 10,
+		Columns: []cards.FlowColumn{
+			{ID: ready, Title: "Ready"},
+			{ID: dev, Title: "Build - Doing"},
+			{ID: done, Title: "Deployed"},
+		},
+		// Development became Build, then its In Progress became Doing
+		Renamed: map[string]string{
+			"Development - In Progress": "Build - In Progress",
+			"Build - In Progress":       "Build - Doing",
+		},
+	}
+	f := cards.CardFlow{
+		Card: models.Card{ColumnID: done, SelectedDay: 2, DeployedDay: 8},
+		Moves: []cards.CardMove{
+			{Day: 3, From: "Ready", To: "Development - In Progress"},
+			{Day: 8, From: "Build - In Progress", To: "Deployed"},
+		},
+	}
+	got := f.ColumnDays(layout)
+	if got[ready] != 1 || got[dev] != 5 || len(got) != 2 {
+		t.Errorf("ColumnDays = %v; want 1 day in Ready and 5 in Build - Doing", got)
+	}
+}
+
+func TestCardFlow_BlockedDays(t *testing.T) {
+	layout := cards.FlowLayout{Day: 10}
+	f := cards.CardFlow{Blocks: []cards.CardBlock{
+		{Blocker: models.Blocker{Reason: "Waiting for the customer", Day: 2}, Unblock: &models.Unblock{Days: 3, Worked: true}},
+		{Blocker: models.Blocker{Reason: "On hold", Day: 7}},
+	}}
+	if got := f.BlockedDays(layout); got != 6 {
+		t.Errorf("BlockedDays = %d; want 3 days worked off and 3 days still blocked", got)
+	}
+}
+
+func TestService_GetCFD(t *testing.T) {
+	options := uuid.New()
+	dev := uuid.New()
+	done := uuid.New()
+	s1 := uuid.New()
+	repo := &stubRepo{
+		layout: cards.FlowLayout{
+			Day: 4,
+			Columns: []cards.FlowColumn{
+				{ID: options, Title: "Options"},
+				{ID: dev, Title: "Development"},
+				{ID: done, Title: "Deployed"},
+			},
+		},
+		flows: []cards.CardFlow{
+			{
+				Card: models.Card{ID: s1, Title: "S1", ColumnID: done, SelectedDay: 1, DeployedDay: 3},
+				Moves: []cards.CardMove{
+					{Day: 1, From: "Options", To: "Development"},
+					{Day: 3, From: "Development", To: "Deployed"},
+				},
+				Blocks: []cards.CardBlock{
+					{Blocker: models.Blocker{Reason: "Waiting for the customer", BlockedBy: "Alice", Day: 1}, Unblock: &models.Unblock{Days: 1}},
+				},
+			},
+			{
+				Card:   models.Card{Title: "S2", ColumnID: options},
+				Blocks: []cards.CardBlock{{Blocker: models.Blocker{Reason: "On hold", Day: 2}}},
+			},
+		},
+	}
+
+	cfd, err := cards.NewService(repo).GetCFD(context.Background(), uuid.New())
+	if err != nil {
+		t.Fatalf("GetCFD: %v", err)
+	}
+
+	if want := []string{"Options", "Development", "Deployed"}; !reflect.DeepEqual(cfd.Columns, want) {
+		t.Errorf("columns = %v; want %v", cfd.Columns, want)
+	}
+	wantDays := []models.CFDDay{
+		{Day: 1, Cards: []int{1, 1, 0}},
+		{Day: 2, Cards: []int{1, 1, 0}},
+		{Day: 3, Cards: []int{1, 0, 1}},
+		{Day: 4, Cards: []int{1, 0, 1}},
+	}
+	if !reflect.DeepEqual(cfd.Days, wantDays) {
+		t.Errorf("days = %+v; want %+v", cfd.Days, wantDays)
+	}
+	wantAnnotations := []models.CFDAnnotation{
+		{CardID: s1, Title: "S1", Reason: "Waiting for the customer", BlockedBy: "Alice", Day: 1, Days: 1, Unblocked: true},
+		{Title: "S2", Reason: "On hold", Day: 2, Days: 2},
+	}
+	if !reflect.DeepEqual(cfd.Annotations, wantAnnotations) {
+		t.Errorf("annotations = %+v; want %+v", cfd.Annotations, wantAnnotations)
+	}
+}
+
+func TestService_GetCFD_NotFound(t *testing.T) {
+	repo := &stubRepo{layoutErr: cards.ErrNotFound}
+	if _, err := cards.NewService(repo).GetCFD(context.Background(), uuid.New()); !errors.Is(err, cards.ErrNotFound) {
+		t.Fatalf("err = %v; want %v", err, cards.ErrNotFound)
+	}
+}
+
+func TestService_WriteFlowCSV(t *testing.T) {
+	ready := uuid.New()
+	dev := uuid.New()
+	repo := &stubRepo{
+		layout: cards.FlowLayout{
+			Day:         6,
+			EffortTypes: []string{"Analysis", "Development"},
+			Columns:     []cards.FlowColumn{{ID: ready, Title: "Ready"}, {ID: dev, Title: "Development"}},
+		},
+		flows: []cards.CardFlow{
+			{
+				Card: models.Card{
+					Title: "S1", ClassOfService: "standard", ValueEstimate: "high",
+					ColumnID: dev, SelectedDay: 1, DeployedDay: 5,
+					Efforts: []models.Effort{{EffortType: "Development", Estimate: 4, Actual: 5}},
+				},
+				Moves: []cards.CardMove{{Day: 2, From: "Ready", To: "Development"}},
+				Blocks: []cards.CardBlock{
+					{Blocker: models.Blocker{Reason: "On hold", Day: 2}, Unblock: &models.Unblock{Days: 1}},
+				},
+			},
+			{Card: models.Card{Title: "S2", ColumnID: ready}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := cards.NewService(repo).WriteFlowCSV(context.Background(), uuid.New(), &buf); err != nil {
+		t.Fatalf("WriteFlowCSV: %v", err)
+	}
+
+	want := "title,class_of_service,value_estimate,selected_day,deployed_day,lead_time,blocked_days," +
+		"Analysis estimate,Analysis actual,Development estimate,Development actual," +
+		"days in Ready,days in Development\n" +
+		"S1,standard,high,1,5,4,1,,,4,5,1,3\n" +
+		"S2,,,,,,0,,,,,0,0\n"
+	if buf.String() != want {
+		t.Errorf("csv =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestService_WriteFlowCSV_NotFound(t *testing.T) {
+	repo := &stubRepo{layoutErr: cards.ErrNotFound}
+
+	var buf bytes.Buffer
+	err := cards.NewService(repo).WriteFlowCSV(context.Background(), uuid.New(), &buf)
+	if !errors.Is(err, cards.ErrNotFound) {
+		t.Fatalf("err = %v; want %v", err, cards.ErrNotFound)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("wrote %q; want nothing", buf.String())
+	}
+}
+
+func TestSQLRepo_StreamCardFlows(t *testing.T) {
+	gameID := uuid.New()
+	colID := uuid.New()
+	// the merge relies on the queries being ordered by card ID
+	first := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	second := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT id, column_id, title, class_of_service, value_estimate, selected_day, deployed_day, order_index FROM cards`).
+		WithArgs(gameID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "column_id", "title", "class_of_service", "value_estimate", "selected_day", "deployed_day", "order_index"}).
+			AddRow(first, colID, "S1", "standard", "high", 1, 4, 0).
+			AddRow(second, colID, "S2", nil, "low", nil, nil, 1))
+	mock.ExpectQuery(`SELECT e.card_id, et.title, e.estimate, e.remaining, e.actual FROM efforts`).
+		WithArgs(gameID).
+		WillReturnRows(sqlmock.NewRows([]string{"card_id", "title", "estimate", "remaining", "actual"}).
+			AddRow(first, "Analysis", 3, 0, 3).
+			AddRow(second, "Analysis", 2, 2, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT card_id, event_type, payload, day FROM game_events`)).
+		WithArgs(gameID).
+		WillReturnRows(sqlmock.NewRows([]string{"card_id", "event_type", "payload", "day"}).
+			AddRow(first, "move", []byte(`{"from":"Ready","to":"Done","day":3}`), 3).
+			AddRow(second, "block", []byte(`{"reason":"On hold","blockedBy":"Alice","day":2}`), 2))
+
+	var got []cards.CardFlow
+	err = cards.NewSQLRepo(db).StreamCardFlows(context.Background(), gameID, func(f cards.CardFlow) error {
+		got = append(got, f)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamCardFlows: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("got %d flows; want 2", len(got))
+	}
+	if len(got[0].Card.Efforts) != 1 || got[0].Card.Efforts[0].Actual != 3 {
+		t.Errorf("first card efforts = %+v", got[0].Card.Efforts)
+	}
+	if len(got[0].Moves) != 1 || got[0].Moves[0] != (cards.CardMove{Day: 3, From: "Ready", To: "Done"}) {
+		t.Errorf("first card moves = %+v", got[0].Moves)
+	}
+	if len(got[1].Card.Efforts) != 1 || got[1].Card.Efforts[0].Remaining != 2 {
+		t.Errorf("second card efforts = %+v", got[1].Card.Efforts)
+	}
+	if len(got[1].Moves) != 0 || got[1].Card.SelectedDay != 0 {
+		t.Errorf("second card = %+v", got[1])
+	}
+	if len(got[1].Blocks) != 1 || got[1].Blocks[0].Blocker.BlockedBy != "Alice" || got[1].Blocks[0].Unblock != nil {
+		t.Errorf("second card blocks = %+v; want one open block by Alice", got[1].Blocks)
+	}
+}
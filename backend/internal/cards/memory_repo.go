@@ -0,0 +1,246 @@
+package cards
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// memoryRepo implements the Repository interface on a memstore.Store.
+type memoryRepo struct {
+	store *memstore.Store
+}
+
+func cardModel(c memstore.Card) models.Card {
+	return models.Card{
+		ID: c.ID, GameID: c.GameID, ColumnID: c.ColumnID, Title: c.Title,
+		ClassOfService: c.ClassOfService, ValueEstimate: c.ValueEstimate,
+		SelectedDay: c.SelectedDay, DeployedDay: c.DeployedDay, OrderIndex: c.OrderIndex,
+	}
+}
+
+func (r *memoryRepo) GetCardsByGameID(ctx context.Context, gameID uuid.UUID) ([]models.Card, error) {
+	var cards []models.Card
+	err := r.store.View(func(t *memstore.Tables) error {
+		if t.LiveGame(gameID) < 0 {
+			return nil
+		}
+		for _, c := range t.Cards {
+			if c.GameID == gameID {
+				cards = append(cards, cardModel(c))
+			}
+		}
+		return nil
+	})
+	return cards, err
+}
+
+func (r *memoryRepo) GetFlowLayout(ctx context.Context, gameID uuid.UUID) (FlowLayout, error) {
+	var layout FlowLayout
+	err := r.store.View(func(t *memstore.Tables) error {
+		i := t.LiveGame(gameID)
+		if i < 0 {
+			return ErrNotFound
+		}
+		layout.Day = t.Games[i].Day
+
+		var effortTypes []memstore.EffortType
+		for _, et := range t.EffortTypes {
+			if et.GameID == gameID {
+				effortTypes = append(effortTypes, et)
+			}
+		}
+		sort.SliceStable(effortTypes, func(i, j int) bool {
+			return effortTypes[i].OrderIndex < effortTypes[j].OrderIndex
+		})
+		for _, et := range effortTypes {
+			layout.EffortTypes = append(layout.EffortTypes, et.Title)
+		}
+
+		// only leaf columns hold cards; subcolumns follow their parent's position
+		parents := make(map[uuid.UUID]memstore.Column)
+		hasSub := make(map[uuid.UUID]bool)
+		for _, c := range t.Columns {
+			if c.GameID != gameID {
+				continue
+			}
+			parents[c.ID] = c
+			if c.ParentID != nil {
+				hasSub[*c.ParentID] = true
+			}
+		}
+		type leaf struct {
+			col      FlowColumn
+			pos, sub int
+		}
+		var leaves []leaf
+		for _, c := range t.Columns {
+			if c.GameID != gameID || hasSub[c.ID] {
+				continue
+			}
+			l := leaf{col: FlowColumn{ID: c.ID, Title: c.Title, Type: c.Type}, pos: c.OrderIndex, sub: c.OrderIndex}
+			if c.ParentID != nil {
+				p := parents[*c.ParentID]
+				l.col.Title = p.Title + " - " + c.Title
+				l.pos = p.OrderIndex
+			}
+			leaves = append(leaves, l)
+		}
+		sort.SliceStable(leaves, func(i, j int) bool {
+			if leaves[i].pos != leaves[j].pos {
+				return leaves[i].pos < leaves[j].pos
+			}
+			return leaves[i].sub < leaves[j].sub
+		})
+		for _, l := range leaves {
+			layout.Columns = append(layout.Columns, l.col)
+		}
+
+		for _, e := range t.Events {
+			if e.GameID == gameID && e.EventType == "column_changed" {
+				if err := layout.addRenames(e.Payload); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	return layout, err
+}
+
+// SearchCards sorts every card of the game that passes the filters by its
+// cursor, the same order the SQL repository uses.
+func (r *memoryRepo) SearchCards(ctx context.Context, gameID uuid.UUID, s Search) ([]models.Card, *Cursor, error) {
+	var found []searchRow
+	err := r.store.View(func(t *memstore.Tables) error {
+		i := t.LiveGame(gameID)
+		if i < 0 {
+			return ErrNotFound
+		}
+		day := t.Games[i].Day
+
+		columns := make(map[uuid.UUID]memstore.Column)
+		for _, c := range t.Columns {
+			if c.GameID == gameID {
+				columns[c.ID] = c
+			}
+		}
+		for _, c := range t.Cards {
+			if c.GameID != gameID {
+				continue
+			}
+			row := searchRow{card: cardModel(c), sub: -1}
+			col := columns[c.ColumnID]
+			row.card.ColumnTitle, row.column = col.Title, col.OrderIndex
+			if col.ParentID != nil {
+				p := columns[*col.ParentID]
+				row.card.ColumnTitle = p.Title + " - " + col.Title
+				row.column, row.sub = p.OrderIndex, col.OrderIndex
+			}
+			row.card.Age = cardAge(c.SelectedDay, c.DeployedDay, day)
+			if c.BlockedReason != nil {
+				row.card.Blocker = &models.Blocker{Reason: *c.BlockedReason, BlockedBy: c.BlockedBy, Day: c.BlockedDay, Effort: c.UnblockEffort}
+			}
+
+			switch {
+			case s.ColumnID != nil && col.ID != *s.ColumnID && (col.ParentID == nil || *col.ParentID != *s.ColumnID),
+				s.ClassOfService != "" && c.ClassOfService != s.ClassOfService,
+				s.ValueEstimate != "" && c.ValueEstimate != s.ValueEstimate,
+				s.Blocked != nil && *s.Blocked != (c.BlockedReason != nil),
+				s.MinAge > 0 && (c.SelectedDay == 0 || row.card.Age < s.MinAge),
+				s.After != nil && row.cursor(s.Sort).compare(*s.After) <= 0:
+				continue
+			}
+			found = append(found, row)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	slices.SortFunc(found, func(a, b searchRow) int { return a.cursor(s.Sort).compare(b.cursor(s.Sort)) })
+	list, next := searchPage(found, s)
+	return list, next, nil
+}
+
+// StreamCardFlows copies the flows out under the read lock and calls fn
+// after releasing it, so fn may be slow without blocking writers.
+func (r *memoryRepo) StreamCardFlows(ctx context.Context, gameID uuid.UUID, fn func(CardFlow) error) error {
+	var flows []CardFlow
+	err := r.store.View(func(t *memstore.Tables) error {
+		effortTypes := make(map[uuid.UUID]memstore.EffortType)
+		for _, et := range t.EffortTypes {
+			if et.GameID == gameID {
+				effortTypes[et.ID] = et
+			}
+		}
+
+		index := make(map[uuid.UUID]int)
+		for _, c := range t.Cards {
+			if c.GameID == gameID {
+				index[c.ID] = len(flows)
+				flows = append(flows, CardFlow{Card: cardModel(c)})
+			}
+		}
+
+		var efforts []memstore.Effort
+		for _, e := range t.Efforts {
+			if _, ok := index[e.CardID]; ok {
+				efforts = append(efforts, e)
+			}
+		}
+		sort.SliceStable(efforts, func(i, j int) bool {
+			return effortTypes[efforts[i].EffortTypeID].OrderIndex < effortTypes[efforts[j].EffortTypeID].OrderIndex
+		})
+		for _, e := range efforts {
+			f := &flows[index[e.CardID]]
+			f.Card.Efforts = append(f.Card.Efforts, models.Effort{
+				EffortType: effortTypes[e.EffortTypeID].Title,
+				Estimate:   e.Estimate,
+				Remaining:  e.Remaining,
+				Actual:     e.Actual,
+			})
+		}
+
+		var events []memstore.Event
+		for _, ev := range t.Events {
+			if ev.GameID == gameID && (ev.EventType == "move" || ev.EventType == "block" || ev.EventType == "unblock") {
+				events = append(events, ev)
+			}
+		}
+		sort.SliceStable(events, func(i, j int) bool {
+			if events[i].Day != events[j].Day {
+				return events[i].Day < events[j].Day
+			}
+			return events[i].CreatedAt.Before(events[j].CreatedAt)
+		})
+		for _, ev := range events {
+			i, ok := index[ev.CardID]
+			if !ok {
+				continue
+			}
+			if err := flows[i].addEvent(ev.EventType, ev.Payload, ev.Day); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(flows, func(i, j int) bool {
+		return flows[i].Card.ID.String() < flows[j].Card.ID.String()
+	})
+	for _, f := range flows {
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,16 +3,36 @@ package cards
 import (
 	"context"
 	"database/sql"
+	"errors"
 
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
 	"github.com/Germanicus1/kanban-sim/backend/internal/models"
 	"github.com/google/uuid"
 )
 
+var ErrNotFound = errors.New("not found")
+
 type Repository interface {
 	GetCardsByGameID(ctx context.Context, gameID uuid.UUID) ([]models.Card, error)
+	// GetFlowLayout returns the effort types and leaf columns of a game, or
+	// ErrNotFound if the game does not exist.
+	GetFlowLayout(ctx context.Context, gameID uuid.UUID) (FlowLayout, error)
+	// StreamCardFlows calls fn once per card of the game, without loading
+	// all cards into memory first.
+	StreamCardFlows(ctx context.Context, gameID uuid.UUID, fn func(CardFlow) error) error
+	// SearchCards returns the cards of a game that pass a search, a page of
+	// them after its cursor, and the cursor of the next page or nil on the
+	// last one. It returns ErrNotFound if the game does not exist.
+	SearchCards(ctx context.Context, gameID uuid.UUID, s Search) ([]models.Card, *Cursor, error)
 }
 
 // sqlRepo implements the Repository interface using a SQL database.
 func NewSQLRepo(db *sql.DB) Repository {
 	return &sqlRepo{db: db}
 }
+
+// NewMemoryRepo constructs a cards.Repository on an in-memory store. Share
+// one store between the repositories of all packages.
+func NewMemoryRepo(store *memstore.Store) Repository {
+	return &memoryRepo{store: store}
+}
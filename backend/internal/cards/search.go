@@ -0,0 +1,179 @@
+package cards
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidQuery is returned for a card search with an unknown sort or
+	// a filter or page size out of range.
+	ErrInvalidQuery = errors.New("invalid card query")
+	// ErrInvalidCursor is returned for a cursor that is not the nextCursor
+	// of a search sorted the same way.
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// Sorts of a card search.
+const (
+	SortPosition = "position" // board order: by column, then top first
+	SortAge      = "age"      // oldest first
+	SortTitle    = "title"
+)
+
+// Page sizes of a card search.
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// Search is a checked card query, as repositories run it.
+type Search struct {
+	models.CardQuery
+	After *Cursor // nil for the first page
+}
+
+// Cursor is the sort key of the last card of a page; the next page starts
+// after it. Only the fields of its sort are set.
+type Cursor struct {
+	Sort  string    `json:"s"`
+	Key   []int     `json:"k,omitempty"` // position: column, sub-column, card; age: the age negated
+	Title string    `json:"t,omitempty"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// keyLen is the length of the Key of a cursor by sort.
+var keyLen = map[string]int{SortPosition: 3, SortAge: 1, SortTitle: 0}
+
+// searchRow is a card found by a search, with the position of its column:
+// the top-level column, and the sub-column or -1.
+type searchRow struct {
+	card        models.Card
+	column, sub int
+}
+
+// cursor is the sort key of a row.
+func (r searchRow) cursor(sort string) Cursor {
+	c := Cursor{Sort: sort, ID: r.card.ID}
+	switch sort {
+	case SortPosition:
+		c.Key = []int{r.column, r.sub, r.card.OrderIndex}
+	case SortAge:
+		c.Key = []int{-r.card.Age}
+	case SortTitle:
+		c.Title = r.card.Title
+	}
+	return c
+}
+
+// compare orders two cursors of the same sort.
+func (c Cursor) compare(o Cursor) int {
+	return cmp.Or(
+		slices.Compare(c.Key, o.Key),
+		strings.Compare(c.Title, o.Title),
+		strings.Compare(c.ID.String(), o.ID.String()),
+	)
+}
+
+// searchPage cuts the rows found by a search, in order and after its
+// cursor, to a page, and returns the cursor of the next page if more rows
+// follow.
+func searchPage(rows []searchRow, s Search) ([]models.Card, *Cursor) {
+	var next *Cursor
+	if len(rows) > s.Limit {
+		rows = rows[:s.Limit]
+		c := rows[len(rows)-1].cursor(s.Sort)
+		next = &c
+	}
+	list := make([]models.Card, len(rows))
+	for i, r := range rows {
+		list[i] = r.card
+	}
+	return list, next
+}
+
+// encodeCursor turns a cursor into the nextCursor of a page.
+func encodeCursor(c Cursor) (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("marshal cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodeCursor reads a nextCursor back, checking it was made for sort.
+func decodeCursor(s, sort string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.Sort != sort || len(c.Key) != keyLen[sort] {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// cardAge is the number of days a card has been selected: up to the day it
+// was deployed, or to the current day. Cards not selected have no age.
+func cardAge(selectedDay, deployedDay, day int) int {
+	switch {
+	case selectedDay == 0:
+		return 0
+	case deployedDay > 0:
+		return deployedDay - selectedDay
+	default:
+		return day - selectedDay
+	}
+}
+
+// SearchCards returns a page of the cards of a game that pass the query, in
+// the order it asks for. The nextCursor of the page fetches the one after
+// it; cards moved in between may be skipped or seen twice.
+func (s *Service) SearchCards(ctx context.Context, gameID uuid.UUID, q models.CardQuery) (models.CardPage, error) {
+	if q.Sort == "" {
+		q.Sort = SortPosition
+	}
+	if _, ok := keyLen[q.Sort]; !ok {
+		return models.CardPage{}, fmt.Errorf("%w: sort %q", ErrInvalidQuery, q.Sort)
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultPageSize
+	}
+	if q.Limit < 0 || q.Limit > MaxPageSize || q.MinAge < 0 {
+		return models.CardPage{}, fmt.Errorf("%w: limit %d, minimum age %d", ErrInvalidQuery, q.Limit, q.MinAge)
+	}
+
+	search := Search{CardQuery: q}
+	if q.Cursor != "" {
+		after, err := decodeCursor(q.Cursor, q.Sort)
+		if err != nil {
+			return models.CardPage{}, err
+		}
+		search.After = after
+	}
+
+	list, next, err := s.repo.SearchCards(ctx, gameID, search)
+	if err != nil {
+		return models.CardPage{}, err
+	}
+	page := models.CardPage{Cards: list}
+	if page.Cards == nil {
+		page.Cards = []models.Card{}
+	}
+	if next != nil {
+		if page.NextCursor, err = encodeCursor(*next); err != nil {
+			return models.CardPage{}, err
+		}
+	}
+	return page, nil
+}
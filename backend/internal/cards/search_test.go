@@ -0,0 +1,75 @@
+package cards_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/cards"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestService_SearchCards(t *testing.T) {
+	ctx := context.Background()
+	gameID := uuid.New()
+
+	t.Run("defaults", func(t *testing.T) {
+		repo := &stubRepo{}
+		page, err := cards.NewService(repo).SearchCards(ctx, gameID, models.CardQuery{})
+		if err != nil {
+			t.Fatalf("SearchCards: %v", err)
+		}
+		if repo.search.Sort != cards.SortPosition || repo.search.Limit != cards.DefaultPageSize || repo.search.After != nil {
+			t.Errorf("search = %+v; want the first page by position, %d cards", repo.search, cards.DefaultPageSize)
+		}
+		if page.Cards == nil || page.NextCursor != "" {
+			t.Errorf("page = %+v; want no cards and no next page", page)
+		}
+	})
+
+	t.Run("cursor round trip", func(t *testing.T) {
+		last := cards.Cursor{Sort: cards.SortAge, Key: []int{-4}, ID: uuid.New()}
+		repo := &stubRepo{next: &last}
+		svc := cards.NewService(repo)
+		page, err := svc.SearchCards(ctx, gameID, models.CardQuery{Sort: cards.SortAge, Limit: 10})
+		if err != nil {
+			t.Fatalf("SearchCards: %v", err)
+		}
+		if page.NextCursor == "" {
+			t.Fatal("nextCursor is empty; want the cursor of the next page")
+		}
+
+		repo.next = nil
+		if _, err := svc.SearchCards(ctx, gameID, models.CardQuery{Sort: cards.SortAge, Limit: 10, Cursor: page.NextCursor}); err != nil {
+			t.Fatalf("SearchCards of the next page: %v", err)
+		}
+		if a := repo.search.After; a == nil || a.ID != last.ID || len(a.Key) != 1 || a.Key[0] != -4 {
+			t.Errorf("after = %+v; want %+v", a, last)
+		}
+
+		// a cursor only fits the sort it was made for
+		if _, err := svc.SearchCards(ctx, gameID, models.CardQuery{Sort: cards.SortTitle, Cursor: page.NextCursor}); !errors.Is(err, cards.ErrInvalidCursor) {
+			t.Errorf("cursor of another sort: err = %v; want %v", err, cards.ErrInvalidCursor)
+		}
+	})
+
+	rejected := []struct {
+		name string
+		q    models.CardQuery
+		want error
+	}{
+		{"unknown sort", models.CardQuery{Sort: "value"}, cards.ErrInvalidQuery},
+		{"page too large", models.CardQuery{Limit: cards.MaxPageSize + 1}, cards.ErrInvalidQuery},
+		{"negative page size", models.CardQuery{Limit: -1}, cards.ErrInvalidQuery},
+		{"negative age", models.CardQuery{MinAge: -1}, cards.ErrInvalidQuery},
+		{"garbled cursor", models.CardQuery{Cursor: "not a cursor"}, cards.ErrInvalidCursor},
+	}
+	for _, tc := range rejected {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := cards.NewService(&stubRepo{}).SearchCards(ctx, gameID, tc.q); !errors.Is(err, tc.want) {
+				t.Errorf("err = %v; want %v", err, tc.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package cards
 
 import (
 	"context"
+	"io"
 
 	"github.com/Germanicus1/kanban-sim/backend/internal/models"
 	"github.com/google/uuid"
@@ -9,6 +10,11 @@ import (
 
 type CardsServiceInterface interface {
 	GetCardsByGameID(ctx context.Context, gameID uuid.UUID) ([]models.Card, error)
+	WriteFlowCSV(ctx context.Context, gameID uuid.UUID, w io.Writer) error
+	GetCFD(ctx context.Context, gameID uuid.UUID) (models.CFD, error)
+	SearchCards(ctx context.Context, gameID uuid.UUID, q models.CardQuery) (models.CardPage, error)
+	GetAging(ctx context.Context, gameID uuid.UUID) (models.Aging, error)
+	GetFlowEfficiency(ctx context.Context, gameID uuid.UUID) (models.FlowEfficiency, error)
 }
 
 type Service struct {
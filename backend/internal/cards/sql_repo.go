@@ -13,7 +13,7 @@ type sqlRepo struct {
 }
 
 func (r *sqlRepo) GetCardsByGameID(ctx context.Context, gameID uuid.UUID) ([]models.Card, error) {
-	query := `SELECT id, game_id, column_id, title, class_of_service, value_estimate, selected_day, deployed_day, order_index FROM cards WHERE game_id = $1`
+	query := `SELECT c.id, c.game_id, c.column_id, c.title, c.class_of_service, c.value_estimate, c.selected_day, c.deployed_day, c.order_index FROM cards c JOIN games g ON g.id = c.game_id WHERE c.game_id = $1 AND g.deleted_at IS NULL`
 
 	rows, err := r.db.QueryContext(ctx, query, gameID)
 	if err != nil {
@@ -0,0 +1,281 @@
+package cards
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func (r *sqlRepo) GetFlowLayout(ctx context.Context, gameID uuid.UUID) (FlowLayout, error) {
+	var layout FlowLayout
+
+	switch err := r.db.QueryRowContext(ctx,
+		`SELECT day FROM games WHERE id = $1 AND deleted_at IS NULL`, gameID,
+	).Scan(&layout.Day); err {
+	case nil:
+	case sql.ErrNoRows:
+		return layout, ErrNotFound
+	default:
+		return layout, fmt.Errorf("query game: %w", err)
+	}
+
+	etRows, err := r.db.QueryContext(ctx,
+		`SELECT title FROM effort_types WHERE game_id = $1 ORDER BY order_index`,
+		gameID,
+	)
+	if err != nil {
+		return layout, fmt.Errorf("query effort_types: %w", err)
+	}
+	defer etRows.Close()
+	for etRows.Next() {
+		var title string
+		if err := etRows.Scan(&title); err != nil {
+			return layout, fmt.Errorf("scan effort_type: %w", err)
+		}
+		layout.EffortTypes = append(layout.EffortTypes, title)
+	}
+	if err := etRows.Err(); err != nil {
+		return layout, fmt.Errorf("iterate effort_types: %w", err)
+	}
+
+	// only leaf columns hold cards; subcolumns follow their parent's position
+	colRows, err := r.db.QueryContext(ctx,
+		`SELECT c.id, c.title, c.col_type, p.title
+		   FROM columns c
+		   LEFT JOIN columns p ON p.id = c.parent_id
+		  WHERE c.game_id = $1
+		    AND NOT EXISTS (SELECT 1 FROM columns sub WHERE sub.parent_id = c.id)
+		  ORDER BY COALESCE(p.order_index, c.order_index), c.order_index`,
+		gameID,
+	)
+	if err != nil {
+		return layout, fmt.Errorf("query columns: %w", err)
+	}
+	defer colRows.Close()
+	for colRows.Next() {
+		var (
+			col         FlowColumn
+			parentTitle sql.NullString
+		)
+		if err := colRows.Scan(&col.ID, &col.Title, &col.Type, &parentTitle); err != nil {
+			return layout, fmt.Errorf("scan column: %w", err)
+		}
+		if parentTitle.Valid {
+			col.Title = parentTitle.String + " - " + col.Title
+		}
+		layout.Columns = append(layout.Columns, col)
+	}
+	if err := colRows.Err(); err != nil {
+		return layout, fmt.Errorf("iterate columns: %w", err)
+	}
+
+	// titles columns had before they were renamed, oldest rename first
+	evRows, err := r.db.QueryContext(ctx,
+		`SELECT payload
+		   FROM game_events
+		  WHERE game_id = $1 AND event_type = 'column_changed'
+		  ORDER BY created_at`,
+		gameID,
+	)
+	if err != nil {
+		return layout, fmt.Errorf("query column events: %w", err)
+	}
+	defer evRows.Close()
+	for evRows.Next() {
+		var payload []byte
+		if err := evRows.Scan(&payload); err != nil {
+			return layout, fmt.Errorf("scan column event: %w", err)
+		}
+		if err := layout.addRenames(payload); err != nil {
+			return layout, err
+		}
+	}
+	if err := evRows.Err(); err != nil {
+		return layout, fmt.Errorf("iterate column events: %w", err)
+	}
+
+	return layout, nil
+}
+
+// StreamCardFlows runs three queries ordered by card ID (cards, efforts and
+// move and block events) and merges them row by row, so only one card is held in
+// memory at a time.
+func (r *sqlRepo) StreamCardFlows(ctx context.Context, gameID uuid.UUID, fn func(CardFlow) error) error {
+	cardRows, err := r.db.QueryContext(ctx,
+		`SELECT id, column_id, title, class_of_service, value_estimate,
+		        selected_day, deployed_day, order_index
+		   FROM cards
+		  WHERE game_id = $1
+		  ORDER BY id`,
+		gameID,
+	)
+	if err != nil {
+		return fmt.Errorf("query cards: %w", err)
+	}
+	defer cardRows.Close()
+
+	effRows, err := r.db.QueryContext(ctx,
+		`SELECT e.card_id, et.title, e.estimate, e.remaining, e.actual
+		   FROM efforts e
+		   JOIN cards c ON c.id = e.card_id
+		   JOIN effort_types et ON et.id = e.effort_type_id
+		  WHERE c.game_id = $1
+		  ORDER BY e.card_id, et.order_index`,
+		gameID,
+	)
+	if err != nil {
+		return fmt.Errorf("query efforts: %w", err)
+	}
+	defer effRows.Close()
+	efforts := &peekRows{rows: effRows}
+
+	eventRows, err := r.db.QueryContext(ctx,
+		`SELECT card_id, event_type, payload, day
+		   FROM game_events
+		  WHERE game_id = $1 AND event_type IN ('move', 'block', 'unblock')
+		  ORDER BY card_id, day, created_at`,
+		gameID,
+	)
+	if err != nil {
+		return fmt.Errorf("query card events: %w", err)
+	}
+	defer eventRows.Close()
+	events := &peekRows{rows: eventRows}
+
+	for cardRows.Next() {
+		var (
+			f              CardFlow
+			classOfService sql.NullString
+			selectedDay    sql.NullInt64
+			deployedDay    sql.NullInt64
+		)
+		if err := cardRows.Scan(
+			&f.Card.ID, &f.Card.ColumnID, &f.Card.Title, &classOfService,
+			&f.Card.ValueEstimate, &selectedDay, &deployedDay, &f.Card.OrderIndex,
+		); err != nil {
+			return fmt.Errorf("scan card: %w", err)
+		}
+		f.Card.GameID = gameID
+		f.Card.ClassOfService = classOfService.String
+		f.Card.SelectedDay = int(selectedDay.Int64)
+		f.Card.DeployedDay = int(deployedDay.Int64)
+
+		err := efforts.each(f.Card.ID, func(rows *sql.Rows) error {
+			var (
+				cardID uuid.UUID
+				e      models.Effort
+			)
+			if err := rows.Scan(&cardID, &e.EffortType, &e.Estimate, &e.Remaining, &e.Actual); err != nil {
+				return fmt.Errorf("scan effort: %w", err)
+			}
+			f.Card.Efforts = append(f.Card.Efforts, e)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		err = events.each(f.Card.ID, func(rows *sql.Rows) error {
+			var (
+				cardID    uuid.UUID
+				eventType string
+				payload   []byte
+				day       int
+			)
+			if err := rows.Scan(&cardID, &eventType, &payload, &day); err != nil {
+				return fmt.Errorf("scan card event: %w", err)
+			}
+			return f.addEvent(eventType, payload, day)
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	if err := cardRows.Err(); err != nil {
+		return fmt.Errorf("iterate cards: %w", err)
+	}
+	if err := efforts.rows.Err(); err != nil {
+		return fmt.Errorf("iterate efforts: %w", err)
+	}
+	if err := events.rows.Err(); err != nil {
+		return fmt.Errorf("iterate card events: %w", err)
+	}
+	return nil
+}
+
+// peekRows walks a result set whose first column is a card ID, ordered by
+// that ID, handing out the rows of one card at a time.
+type peekRows struct {
+	rows    *sql.Rows
+	started bool
+	done    bool
+	cardID  uuid.UUID
+	dest    []any
+}
+
+// advance moves to the next row and reads its card ID.
+func (p *peekRows) advance() error {
+	p.started = true
+	if !p.rows.Next() {
+		p.done = true
+		return nil
+	}
+	if p.dest == nil {
+		cols, err := p.rows.Columns()
+		if err != nil {
+			return err
+		}
+		p.dest = make([]any, len(cols))
+		p.dest[0] = &p.cardID
+		for i := 1; i < len(p.dest); i++ {
+			p.dest[i] = new(any)
+		}
+	}
+	return p.rows.Scan(p.dest...)
+}
+
+// each calls scan for every row belonging to cardID, skipping rows of cards
+// that sort before it.
+func (p *peekRows) each(cardID uuid.UUID, scan func(*sql.Rows) error) error {
+	if !p.started {
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+	for !p.done {
+		switch c := bytes.Compare(p.cardID[:], cardID[:]); {
+		case c < 0:
+		case c == 0:
+			if err := scan(p.rows); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeMove reads the {"from","to"} payload of a move event.
+func decodeMove(payload []byte, day int) (CardMove, error) {
+	var p struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return CardMove{}, fmt.Errorf("decode move event: %w", err)
+	}
+	return CardMove{Day: day, From: p.From, To: p.To}, nil
+}
@@ -0,0 +1,132 @@
+package cards
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// Sort keys of a card search in SQL, as in searchRow.cursor. The age is
+// worked out against the game's day, always parameter $2.
+const (
+	searchColumnPos = `COALESCE(p.order_index, col.order_index)`
+	searchSubPos    = `CASE WHEN p.id IS NULL THEN -1 ELSE col.order_index END`
+	searchAge       = `CASE WHEN COALESCE(c.selected_day, 0) = 0 THEN 0
+	                        WHEN COALESCE(c.deployed_day, 0) > 0 THEN c.deployed_day - c.selected_day
+	                        ELSE $2 - c.selected_day END`
+)
+
+// searchOrder is the ORDER BY of each sort, and the row its cursor compares
+// against.
+var searchOrder = map[string]string{
+	SortPosition: searchColumnPos + `, ` + searchSubPos + `, c.order_index, c.id`,
+	SortAge:      `-(` + searchAge + `), c.id`,
+	SortTitle:    `c.title, c.id`,
+}
+
+// SearchCards filters and sorts in SQL and reads one card past the page to
+// know whether another page follows. The cursor is compared as a row value,
+// which follows the same order as ORDER BY.
+func (r *sqlRepo) SearchCards(ctx context.Context, gameID uuid.UUID, s Search) ([]models.Card, *Cursor, error) {
+	var day int
+	switch err := r.db.QueryRowContext(ctx, `SELECT day FROM games WHERE id = $1 AND deleted_at IS NULL`, gameID).Scan(&day); {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil, ErrNotFound
+	case err != nil:
+		return nil, nil, fmt.Errorf("query game: %w", err)
+	}
+
+	args := []any{gameID, day}
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	var where []string
+	if s.ColumnID != nil {
+		n := arg(*s.ColumnID)
+		where = append(where, `(c.column_id = `+n+` OR col.parent_id = `+n+`)`)
+	}
+	if s.ClassOfService != "" {
+		where = append(where, `c.class_of_service = `+arg(s.ClassOfService))
+	}
+	if s.ValueEstimate != "" {
+		where = append(where, `c.value_estimate = `+arg(s.ValueEstimate))
+	}
+	if s.Blocked != nil {
+		if *s.Blocked {
+			where = append(where, `c.blocked_reason IS NOT NULL`)
+		} else {
+			where = append(where, `c.blocked_reason IS NULL`)
+		}
+	}
+	if s.MinAge > 0 {
+		where = append(where, `COALESCE(c.selected_day, 0) > 0 AND (`+searchAge+`) >= `+arg(s.MinAge))
+	}
+	if a := s.After; a != nil {
+		var key []string
+		for _, k := range a.Key {
+			key = append(key, arg(k))
+		}
+		if s.Sort == SortTitle {
+			key = append(key, arg(a.Title))
+		}
+		key = append(key, arg(a.ID))
+		where = append(where, `(`+searchOrder[s.Sort]+`) > (`+strings.Join(key, ", ")+`)`)
+	}
+
+	query := `SELECT c.id, c.game_id, c.column_id,
+	                 CASE WHEN p.id IS NULL THEN col.title ELSE p.title || ' - ' || col.title END,
+	                 c.title, COALESCE(c.class_of_service, ''), c.value_estimate,
+	                 COALESCE(c.selected_day, 0), COALESCE(c.deployed_day, 0), c.order_index,
+	                 ` + searchAge + `,
+	                 c.blocked_reason, c.blocked_by, c.blocked_day, c.unblock_effort,
+	                 ` + searchColumnPos + `, ` + searchSubPos + `
+	            FROM cards c
+	            JOIN columns col ON col.id = c.column_id
+	       LEFT JOIN columns p ON p.id = col.parent_id
+	           WHERE c.game_id = $1`
+	for _, w := range where {
+		query += "\n\t\t\t   AND " + w
+	}
+	query += "\n\t\t\t ORDER BY " + searchOrder[s.Sort] + "\n\t\t\t LIMIT " + arg(s.Limit+1)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("query cards: %w", err)
+	}
+	defer rows.Close()
+
+	var found []searchRow
+	for rows.Next() {
+		var (
+			row           searchRow
+			blockedReason sql.NullString
+			blocker       models.Blocker
+		)
+		c := &row.card
+		if err := rows.Scan(
+			&c.ID, &c.GameID, &c.ColumnID, &c.ColumnTitle,
+			&c.Title, &c.ClassOfService, &c.ValueEstimate,
+			&c.SelectedDay, &c.DeployedDay, &c.OrderIndex, &c.Age,
+			&blockedReason, &blocker.BlockedBy, &blocker.Day, &blocker.Effort,
+			&row.column, &row.sub,
+		); err != nil {
+			return nil, nil, fmt.Errorf("scan card: %w", err)
+		}
+		if blockedReason.Valid {
+			blocker.Reason = blockedReason.String
+			c.Blocker = &blocker
+		}
+		found = append(found, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("iterate cards: %w", err)
+	}
+	list, next := searchPage(found, s)
+	return list, next, nil
+}
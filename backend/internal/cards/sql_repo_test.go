@@ -14,7 +14,7 @@ import (
 )
 
 func TestGetCardsByGameID(t *testing.T) {
-	const query = `SELECT id, game_id, column_id, title, class_of_service, value_estimate, selected_day, deployed_day, order_index FROM cards WHERE game_id = $1`
+	const query = `SELECT c.id, c.game_id, c.column_id, c.title, c.class_of_service, c.value_estimate, c.selected_day, c.deployed_day, c.order_index FROM cards c JOIN games g ON g.id = c.game_id WHERE c.game_id = $1 AND g.deleted_at IS NULL`
 	gameID := uuid.New()
 	cardID := uuid.New()
 	colID := uuid.New()
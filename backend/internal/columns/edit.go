@@ -0,0 +1,282 @@
+package columns
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrNotFound is returned for a game that does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrColumnNotFound is returned for a column that is not on the board.
+	ErrColumnNotFound = errors.New("column not found")
+	// ErrGameEnded is returned for changes to the board of a game that has
+	// ended.
+	ErrGameEnded = errors.New("game ended")
+	// ErrInvalidColumn is returned for a column update the board cannot take.
+	ErrInvalidColumn = errors.New("invalid column")
+	// ErrColumnHasCards is returned for removing a column that holds cards,
+	// or splitting one into sub-columns.
+	ErrColumnHasCards = errors.New("column holds cards")
+)
+
+// event is a game event logged for a column update.
+type event struct {
+	Type    string // "wip_changed" or "column_changed"
+	Payload any
+}
+
+// edit is what a column update does to the columns of a game.
+type edit struct {
+	Column  models.Column   // the column as updated, with its sub-columns
+	Removed []uuid.UUID     // sub-columns to delete
+	Added   []models.Column // sub-columns to insert
+	Kept    []models.Column // existing sub-columns, with their new order_index
+	Events  []event
+}
+
+// planEdit works out an update of the column with the given ID, from the
+// flat list of a game's columns and the IDs of the columns holding cards. It
+// checks that the board can still be played: titles stay unique among
+// siblings, no column holding cards goes away or is split, "done" columns get
+// no sub-columns, and a column keeps (or keeps lacking) an active stage, so
+// that the effort types still line up with the board.
+func planEdit(cols []models.Column, holding map[uuid.UUID]bool, id uuid.UUID, u models.ColumnUpdate) (edit, error) {
+	i := slices.IndexFunc(cols, func(c models.Column) bool { return c.ID == id })
+	if i < 0 {
+		return edit{}, fmt.Errorf("column %s: %w", id, ErrColumnNotFound)
+	}
+	col := cols[i]
+	var parent *models.Column
+	if col.ParentID != nil {
+		if j := slices.IndexFunc(cols, func(c models.Column) bool { return c.ID == *col.ParentID }); j >= 0 {
+			parent = &cols[j]
+		}
+		if u.WIPLimit != nil || u.SubColumns != nil {
+			return edit{}, fmt.Errorf("%w: sub-column %q has no WIP limit or sub-columns of its own", ErrInvalidColumn, col.Title)
+		}
+	}
+	subs := children(cols, col.ID)
+
+	e := edit{Column: col, Kept: subs}
+	change := models.ColumnChange{ColumnID: col.ID, Column: col.Title}
+	changed := false
+
+	// 1) title
+	if u.Title != nil {
+		title := strings.TrimSpace(*u.Title)
+		if title == "" {
+			return edit{}, fmt.Errorf("%w: empty title", ErrInvalidColumn)
+		}
+		if title != col.Title {
+			for _, c := range cols {
+				if c.ID != col.ID && sameParent(c.ParentID, col.ParentID) && c.Title == title {
+					return edit{}, fmt.Errorf("%w: title %q is taken", ErrInvalidColumn, title)
+				}
+			}
+			change.Title, changed = title, true
+			change.Renamed = renames(col, parent, subs, title)
+			e.Column.Title = title
+		}
+	}
+
+	// 2) WIP limit
+	if u.WIPLimit != nil {
+		if *u.WIPLimit < 0 {
+			return edit{}, fmt.Errorf("%w: WIP limit %d", ErrInvalidColumn, *u.WIPLimit)
+		}
+		if *u.WIPLimit != col.WIPLimit {
+			e.Events = append(e.Events, event{Type: "wip_changed", Payload: models.WIPChange{
+				ColumnID: col.ID, Column: col.Title, From: col.WIPLimit, To: *u.WIPLimit,
+			}})
+			e.Column.WIPLimit = *u.WIPLimit
+		}
+	}
+
+	// 3) sub-columns
+	if u.SubColumns != nil {
+		var err error
+		if e.Kept, e.Added, e.Removed, err = planSubColumns(holding, col, subs, *u.SubColumns); err != nil {
+			return edit{}, err
+		}
+		after := append(append([]models.Column(nil), e.Kept...), e.Added...)
+		sort.Slice(after, func(i, j int) bool { return after[i].OrderIndex < after[j].OrderIndex })
+		if hasActive(col, subs) != hasActive(col, after) {
+			return edit{}, fmt.Errorf("%w: column %q must keep its active stage as it is", ErrInvalidColumn, col.Title)
+		}
+		if isFirst(cols, col) && len(after) > 0 && subType(col, after[0]) != "queue" {
+			return edit{}, fmt.Errorf("%w: the board must start with a queue", ErrInvalidColumn)
+		}
+		if len(e.Added) > 0 || len(e.Removed) > 0 || !sameOrder(subs, after) {
+			for _, c := range e.Added {
+				change.Added = append(change.Added, c.Title)
+			}
+			for _, c := range subs {
+				if slices.Contains(e.Removed, c.ID) {
+					change.Removed = append(change.Removed, c.Title)
+				}
+			}
+			change.SubColumns = make([]string, 0, len(after))
+			for _, c := range after {
+				change.SubColumns = append(change.SubColumns, c.Title)
+			}
+			changed = true
+		}
+	}
+	if changed {
+		e.Events = append(e.Events, event{Type: "column_changed", Payload: change})
+	}
+
+	if col.ParentID == nil {
+		e.Column.SubColumns = append(append([]models.Column(nil), e.Kept...), e.Added...)
+		sort.Slice(e.Column.SubColumns, func(i, j int) bool {
+			return e.Column.SubColumns[i].OrderIndex < e.Column.SubColumns[j].OrderIndex
+		})
+	}
+	return e, nil
+}
+
+// planSubColumns works out the sub-columns a column should have from the
+// wanted ones, in order: existing ones kept, new ones added and the rest
+// removed. Their order_index runs from 0 in the wanted order.
+func planSubColumns(holding map[uuid.UUID]bool, col models.Column, subs []models.Column, want []models.SubColumnSpec) (kept, added []models.Column, removed []uuid.UUID, err error) {
+	if col.Type == "done" && len(want) > 0 {
+		return nil, nil, nil, fmt.Errorf("%w: done column %q cannot have sub-columns", ErrInvalidColumn, col.Title)
+	}
+	if len(subs) == 0 && len(want) > 0 && holding[col.ID] {
+		return nil, nil, nil, fmt.Errorf("%w: %q", ErrColumnHasCards, col.Title)
+	}
+
+	titles := make(map[string]bool, len(want))
+	seen := make(map[uuid.UUID]bool, len(want))
+	for i, w := range want {
+		var c models.Column
+		if w.ID != nil {
+			j := slices.IndexFunc(subs, func(s models.Column) bool { return s.ID == *w.ID })
+			if j < 0 || seen[*w.ID] {
+				return nil, nil, nil, fmt.Errorf("%w: %s is not a sub-column of %q", ErrInvalidColumn, *w.ID, col.Title)
+			}
+			seen[*w.ID] = true
+			c = subs[j]
+			c.OrderIndex = i
+			kept = append(kept, c)
+		} else {
+			typ := w.Type
+			if typ == "" {
+				typ = col.Type
+			}
+			if typ != "active" && typ != "queue" {
+				return nil, nil, nil, fmt.Errorf("%w: sub-column type %q", ErrInvalidColumn, w.Type)
+			}
+			parentID := col.ID
+			c = models.Column{
+				ID:         uuid.New(),
+				ParentID:   &parentID,
+				Title:      strings.TrimSpace(w.Title),
+				OrderIndex: i,
+				Type:       typ,
+			}
+			if c.Title == "" {
+				return nil, nil, nil, fmt.Errorf("%w: new sub-column without a title", ErrInvalidColumn)
+			}
+			added = append(added, c)
+		}
+		if titles[c.Title] {
+			return nil, nil, nil, fmt.Errorf("%w: title %q is taken", ErrInvalidColumn, c.Title)
+		}
+		titles[c.Title] = true
+	}
+
+	for _, s := range subs {
+		if seen[s.ID] {
+			continue
+		}
+		if holding[s.ID] {
+			return nil, nil, nil, fmt.Errorf("%w: %q", ErrColumnHasCards, col.Title+" - "+s.Title)
+		}
+		removed = append(removed, s.ID)
+	}
+	return kept, added, removed, nil
+}
+
+// renames maps the titles cards moved between under the old title of a
+// column to the ones they have under the new title: "Parent - Sub" for
+// sub-columns, the bare title for columns without any.
+func renames(col models.Column, parent *models.Column, subs []models.Column, title string) map[string]string {
+	m := make(map[string]string)
+	switch {
+	case parent != nil:
+		m[parent.Title+" - "+col.Title] = parent.Title + " - " + title
+	case len(subs) == 0:
+		m[col.Title] = title
+	default:
+		for _, s := range subs {
+			m[col.Title+" - "+s.Title] = title + " - " + s.Title
+		}
+	}
+	return m
+}
+
+// children returns the sub-columns of a column, in order.
+func children(cols []models.Column, id uuid.UUID) []models.Column {
+	var subs []models.Column
+	for _, c := range cols {
+		if c.ParentID != nil && *c.ParentID == id {
+			subs = append(subs, c)
+		}
+	}
+	sort.SliceStable(subs, func(i, j int) bool { return subs[i].OrderIndex < subs[j].OrderIndex })
+	return subs
+}
+
+// hasActive reports whether a column with the given sub-columns makes an
+// active stage on the board, as the engine builds it.
+func hasActive(col models.Column, subs []models.Column) bool {
+	if len(subs) == 0 {
+		return col.Type == "active"
+	}
+	for _, s := range subs {
+		if subType(col, s) == "active" {
+			return true
+		}
+	}
+	return false
+}
+
+// subType is the type of a sub-column, its parent's if it has none.
+func subType(col, sub models.Column) string {
+	if sub.Type == "" {
+		return col.Type
+	}
+	return sub.Type
+}
+
+// isFirst reports whether col is the leftmost top-level column.
+func isFirst(cols []models.Column, col models.Column) bool {
+	if col.ParentID != nil {
+		return false
+	}
+	for _, c := range cols {
+		if c.ParentID == nil && c.OrderIndex < col.OrderIndex {
+			return false
+		}
+	}
+	return true
+}
+
+func sameParent(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameOrder(a, b []models.Column) bool {
+	return slices.EqualFunc(a, b, func(x, y models.Column) bool { return x.ID == y.ID })
+}
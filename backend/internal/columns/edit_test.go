@@ -0,0 +1,97 @@
+package columns
+
+import (
+	"errors"
+	"maps"
+	"testing"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestPlanEdit(t *testing.T) {
+	options, dev, doing, waiting, done := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	cols := []models.Column{
+		{ID: options, Title: "Options", Type: "queue", OrderIndex: 0},
+		{ID: dev, Title: "Development", Type: "active", WIPLimit: 4, OrderIndex: 1},
+		{ID: doing, ParentID: &dev, Title: "Doing", Type: "active", OrderIndex: 0},
+		{ID: waiting, ParentID: &dev, Title: "Waiting", Type: "queue", OrderIndex: 1},
+		{ID: done, Title: "Deployed", Type: "done", OrderIndex: 2},
+	}
+	str := func(v string) *string { return &v }
+	subs := func(s ...models.SubColumnSpec) *[]models.SubColumnSpec { return &s }
+
+	tests := []struct {
+		name    string
+		column  uuid.UUID
+		update  models.ColumnUpdate
+		wantErr error
+		renamed map[string]string
+	}{
+		{
+			name:    "rename a column with sub-columns",
+			column:  dev,
+			update:  models.ColumnUpdate{Title: str("Build")},
+			renamed: map[string]string{"Development - Doing": "Build - Doing", "Development - Waiting": "Build - Waiting"},
+		},
+		{
+			name:    "rename a sub-column",
+			column:  waiting,
+			update:  models.ColumnUpdate{Title: str("Ready")},
+			renamed: map[string]string{"Development - Waiting": "Development - Ready"},
+		},
+		{
+			name:    "sibling title taken",
+			column:  waiting,
+			update:  models.ColumnUpdate{Title: str("Doing")},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name:    "losing the active stage",
+			column:  dev,
+			update:  models.ColumnUpdate{SubColumns: subs(models.SubColumnSpec{ID: &waiting})},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name:    "gaining an active stage",
+			column:  options,
+			update:  models.ColumnUpdate{SubColumns: subs(models.SubColumnSpec{Title: "Ready"}, models.SubColumnSpec{Title: "Refine", Type: "active"})},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name:   "the board still starts with a queue",
+			column: options,
+			update: models.ColumnUpdate{SubColumns: subs(models.SubColumnSpec{Title: "Ideas"}, models.SubColumnSpec{Title: "Ready"})},
+		},
+		{
+			name:    "a sub-column listed twice",
+			column:  dev,
+			update:  models.ColumnUpdate{SubColumns: subs(models.SubColumnSpec{ID: &doing}, models.SubColumnSpec{ID: &doing})},
+			wantErr: ErrInvalidColumn,
+		},
+		{
+			name:    "unknown sub-column type",
+			column:  dev,
+			update:  models.ColumnUpdate{SubColumns: subs(models.SubColumnSpec{ID: &doing}, models.SubColumnSpec{Title: "Live", Type: "done"})},
+			wantErr: ErrInvalidColumn,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := planEdit(cols, map[uuid.UUID]bool{}, tc.column, tc.update)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("planEdit: err = %v; want %v", err, tc.wantErr)
+			}
+			if tc.renamed == nil {
+				return
+			}
+			if len(e.Events) != 1 {
+				t.Fatalf("events = %+v; want one column_changed", e.Events)
+			}
+			if got := e.Events[0].Payload.(models.ColumnChange).Renamed; !maps.Equal(got, tc.renamed) {
+				t.Errorf("renamed = %v; want %v", got, tc.renamed)
+			}
+		})
+	}
+}
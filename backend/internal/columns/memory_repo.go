@@ -0,0 +1,128 @@
+package columns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// memoryRepo implements the Repository interface on a memstore.Store.
+type memoryRepo struct {
+	store *memstore.Store
+}
+
+// GetColumnsByGameID orders like the SQL query: grouped by parent, top-level
+// columns (no parent) last, then by order_index.
+func (r memoryRepo) GetColumnsByGameID(ctx context.Context, gameID uuid.UUID) ([]models.Column, error) {
+	cols := make([]models.Column, 0)
+	err := r.store.View(func(t *memstore.Tables) error {
+		if t.LiveGame(gameID) < 0 {
+			return nil
+		}
+		for _, c := range t.Columns {
+			if c.GameID == gameID {
+				cols = append(cols, models.Column{
+					ID: c.ID, ParentID: c.ParentID, Title: c.Title,
+					WIPLimit: c.WIPLimit, Type: c.Type, OrderIndex: c.OrderIndex,
+				})
+			}
+		}
+		return nil
+	})
+	sort.SliceStable(cols, func(i, j int) bool {
+		a, b := cols[i].ParentID, cols[j].ParentID
+		switch {
+		case a == nil && b == nil:
+		case a == nil:
+			return false
+		case b == nil:
+			return true
+		case *a != *b:
+			return a.String() < b.String()
+		}
+		return cols[i].OrderIndex < cols[j].OrderIndex
+	})
+	return cols, err
+}
+
+// UpdateColumn plans the update against the store and writes it, like the
+// SQL TX.
+func (r memoryRepo) UpdateColumn(ctx context.Context, gameID, columnID uuid.UUID, u models.ColumnUpdate) (models.Column, error) {
+	var e edit
+	err := r.store.Update(func(t *memstore.Tables) error {
+		g := t.LiveGame(gameID)
+		if g < 0 {
+			return fmt.Errorf("game %s: %w", gameID, ErrNotFound)
+		}
+		if t.Games[g].Status == string(models.GameEnded) {
+			return ErrGameEnded
+		}
+
+		var cols []models.Column
+		for _, c := range t.Columns {
+			if c.GameID == gameID {
+				cols = append(cols, models.Column{
+					ID: c.ID, ParentID: c.ParentID, Title: c.Title,
+					WIPLimit: c.WIPLimit, Type: c.Type, OrderIndex: c.OrderIndex,
+				})
+			}
+		}
+		holding := make(map[uuid.UUID]bool)
+		for _, c := range t.Cards {
+			if c.GameID == gameID {
+				holding[c.ColumnID] = true
+			}
+		}
+		var err error
+		if e, err = planEdit(cols, holding, columnID, u); err != nil {
+			return err
+		}
+
+		order := make(map[uuid.UUID]int, len(e.Kept))
+		for _, c := range e.Kept {
+			order[c.ID] = c.OrderIndex
+		}
+		kept := t.Columns[:0]
+		for _, c := range t.Columns {
+			switch {
+			case slices.Contains(e.Removed, c.ID):
+				continue
+			case c.ID == e.Column.ID:
+				c.Title, c.WIPLimit = e.Column.Title, e.Column.WIPLimit
+			}
+			if i, ok := order[c.ID]; ok {
+				c.OrderIndex = i
+			}
+			kept = append(kept, c)
+		}
+		t.Columns = kept
+		for _, c := range e.Added {
+			t.Columns = append(t.Columns, memstore.Column{
+				ID: c.ID, GameID: gameID, ParentID: c.ParentID, Title: c.Title,
+				Type: c.Type, OrderIndex: c.OrderIndex,
+			})
+		}
+
+		for _, ev := range e.Events {
+			payload, err := json.Marshal(ev.Payload)
+			if err != nil {
+				return fmt.Errorf("marshal %s: %w", ev.Type, err)
+			}
+			t.Events = append(t.Events, memstore.Event{
+				ID: uuid.New(), GameID: gameID, EventType: ev.Type,
+				Payload: payload, Day: t.Games[g].Day, CreatedAt: memstore.Now(),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Column{}, err
+	}
+	return e.Column, nil
+}
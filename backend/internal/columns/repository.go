@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
 	"github.com/Germanicus1/kanban-sim/backend/internal/models"
 	"github.com/google/uuid"
 )
@@ -11,8 +12,19 @@ import (
 type Repository interface {
 	// GetColumnsByGameID retrieves all columns for a given game ID.
 	GetColumnsByGameID(ctx context.Context, gameID uuid.UUID) ([]models.Column, error)
+
+	// UpdateColumn applies an update to a column of a game that has not
+	// ended and logs it on the game's current day, in one TX. It returns the
+	// column as updated, with its sub-columns.
+	UpdateColumn(ctx context.Context, gameID, columnID uuid.UUID, u models.ColumnUpdate) (models.Column, error)
 }
 
 func NewSQLRepo(db *sql.DB) Repository {
 	return &sqlRepo{db: db}
 }
+
+// NewMemoryRepo constructs a columns.Repository on an in-memory store. Share
+// one store between the repositories of all packages.
+func NewMemoryRepo(store *memstore.Store) Repository {
+	return &memoryRepo{store: store}
+}
@@ -2,6 +2,7 @@ package columns
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/Germanicus1/kanban-sim/backend/internal/models"
 	"github.com/google/uuid"
@@ -9,6 +10,7 @@ import (
 
 type ColumnServiceInterface interface {
 	GetColumnsByGameID(ctx context.Context, gameID uuid.UUID) ([]models.Column, error)
+	UpdateColumn(ctx context.Context, gameID, columnID uuid.UUID, u models.ColumnUpdate) (models.Column, error)
 }
 
 type Service struct {
@@ -22,3 +24,13 @@ func NewService(repo Repository) *Service {
 func (s *Service) GetColumnsByGameID(ctx context.Context, gameID uuid.UUID) ([]models.Column, error) {
 	return s.repo.GetColumnsByGameID(ctx, gameID)
 }
+
+// UpdateColumn changes the title or WIP limit of a column, or the
+// sub-columns of a top-level one, while the game is played. Every change is
+// logged as a "wip_changed" or "column_changed" event on the current day.
+func (s *Service) UpdateColumn(ctx context.Context, gameID, columnID uuid.UUID, u models.ColumnUpdate) (models.Column, error) {
+	if u.Title == nil && u.WIPLimit == nil && u.SubColumns == nil {
+		return models.Column{}, fmt.Errorf("%w: nothing to change", ErrInvalidColumn)
+	}
+	return s.repo.UpdateColumn(ctx, gameID, columnID, u)
+}
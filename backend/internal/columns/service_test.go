@@ -2,6 +2,7 @@ package columns_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/Germanicus1/kanban-sim/backend/internal/columns"
@@ -14,6 +15,7 @@ type mockRepo struct {
 	wantErr    error
 	gotColumn  models.Column
 	wantColumn models.Column
+	gotUpdate  *models.ColumnUpdate
 }
 
 func (m *mockRepo) GetColumnsByGameID(ctx context.Context, gameID uuid.UUID) ([]models.Column, error) {
@@ -23,6 +25,14 @@ func (m *mockRepo) GetColumnsByGameID(ctx context.Context, gameID uuid.UUID) ([]
 	return []models.Column{m.wantColumn}, nil
 }
 
+func (m *mockRepo) UpdateColumn(ctx context.Context, gameID, columnID uuid.UUID, u models.ColumnUpdate) (models.Column, error) {
+	m.gotUpdate = &u
+	if m.wantErr != nil {
+		return models.Column{}, m.wantErr
+	}
+	return m.wantColumn, nil
+}
+
 func TestService_GetColumnsByGameID(t *testing.T) {
 	wantID := uuid.New()
 	wantColumn := models.Column{ID: wantID, Title: "Test Column", OrderIndex: 0, WIPLimit: 0, Type: "active"}
@@ -37,3 +47,26 @@ func TestService_GetColumnsByGameID(t *testing.T) {
 		t.Errorf("GetColumnsByGameID got %v, want %v", gotColumns, []models.Column{wantColumn})
 	}
 }
+
+func TestService_UpdateColumn(t *testing.T) {
+	title := "Build"
+	wantColumn := models.Column{ID: uuid.New(), Title: title, Type: "active"}
+	mr := &mockRepo{wantColumn: wantColumn}
+	svc := columns.NewService(mr)
+
+	got, err := svc.UpdateColumn(context.Background(), uuid.New(), wantColumn.ID, models.ColumnUpdate{Title: &title})
+	if err != nil {
+		t.Fatalf("UpdateColumn returned error: %v", err)
+	}
+	if got.ID != wantColumn.ID || got.Title != title {
+		t.Errorf("UpdateColumn got %v, want %v", got, wantColumn)
+	}
+
+	mr.gotUpdate = nil
+	if _, err := svc.UpdateColumn(context.Background(), uuid.New(), wantColumn.ID, models.ColumnUpdate{}); !errors.Is(err, columns.ErrInvalidColumn) {
+		t.Errorf("UpdateColumn without changes: err = %v; want %v", err, columns.ErrInvalidColumn)
+	}
+	if mr.gotUpdate != nil {
+		t.Error("UpdateColumn without changes reached the repository")
+	}
+}
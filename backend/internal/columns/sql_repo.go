@@ -12,10 +12,21 @@ type sqlRepo struct {
 	db *sql.DB
 }
 
+// queryer is satisfied by both *sql.DB and *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
 func (r sqlRepo) GetColumnsByGameID(ctx context.Context, gameID uuid.UUID) ([]models.Column, error) {
-	query := `SELECT id, title, wip_limit, col_type, parent_id, order_index FROM columns WHERE game_id = $1 ORDER BY parent_id, order_index`
+	return queryColumns(ctx, r.db, gameID)
+}
+
+// queryColumns reads the columns of a game, grouped by parent, top-level
+// columns last.
+func queryColumns(ctx context.Context, q queryer, gameID uuid.UUID) ([]models.Column, error) {
+	query := `SELECT c.id, c.title, c.wip_limit, c.col_type, c.parent_id, c.order_index FROM columns c JOIN games g ON g.id = c.game_id WHERE c.game_id = $1 AND g.deleted_at IS NULL ORDER BY c.parent_id IS NULL, c.parent_id, c.order_index`
 
-	rows, err := r.db.QueryContext(ctx,
+	rows, err := q.QueryContext(ctx,
 		query,
 		gameID, // pass UUID directly, not a string
 	)
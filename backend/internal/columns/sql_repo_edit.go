@@ -0,0 +1,158 @@
+package columns
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// UpdateColumn plans the update against the columns as they are inside the
+// TX, then writes it. Sub-columns that are kept move to temporary negative
+// order indexes first, so reordering them never trips the unique index on
+// (game_id, parent_id, order_index).
+func (r sqlRepo) UpdateColumn(ctx context.Context, gameID, columnID uuid.UUID, u models.ColumnUpdate) (models.Column, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Column{}, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	e, day, err := planInTx(ctx, tx, gameID, columnID, u)
+	if err != nil {
+		tx.Rollback()
+		return models.Column{}, err
+	}
+	if err := writeEdit(ctx, tx, gameID, e); err != nil {
+		tx.Rollback()
+		return models.Column{}, err
+	}
+
+	for _, ev := range e.Events {
+		payload, err := json.Marshal(ev.Payload)
+		if err != nil {
+			tx.Rollback()
+			return models.Column{}, fmt.Errorf("marshal %s: %w", ev.Type, err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO game_events (game_id, event_type, payload, day, created_at)
+			     VALUES ($1, $2, $3, $4, $5)`,
+			gameID, ev.Type, payload, day, time.Now().UTC(),
+		); err != nil {
+			tx.Rollback()
+			return models.Column{}, fmt.Errorf("insert %s event: %w", ev.Type, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Column{}, fmt.Errorf("commit tx: %w", err)
+	}
+	return e.Column, nil
+}
+
+// planInTx reads the game, its columns and the columns holding cards, and
+// plans the update. It returns the plan and the game's current day.
+func planInTx(ctx context.Context, tx *sql.Tx, gameID, columnID uuid.UUID, u models.ColumnUpdate) (edit, int, error) {
+	var (
+		day    int
+		status string
+	)
+	switch err := tx.QueryRowContext(ctx,
+		`SELECT day, status FROM games WHERE id = $1 AND deleted_at IS NULL`, gameID,
+	).Scan(&day, &status); {
+	case errors.Is(err, sql.ErrNoRows):
+		return edit{}, 0, fmt.Errorf("game %s: %w", gameID, ErrNotFound)
+	case err != nil:
+		return edit{}, 0, fmt.Errorf("query game: %w", err)
+	}
+	if status == string(models.GameEnded) {
+		return edit{}, 0, ErrGameEnded
+	}
+
+	cols, err := queryColumns(ctx, tx, gameID)
+	if err != nil {
+		return edit{}, 0, fmt.Errorf("query columns: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx,
+		`SELECT DISTINCT column_id FROM cards WHERE game_id = $1`, gameID,
+	)
+	if err != nil {
+		return edit{}, 0, fmt.Errorf("query cards: %w", err)
+	}
+	defer rows.Close()
+	holding := make(map[uuid.UUID]bool)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return edit{}, 0, fmt.Errorf("scan card column: %w", err)
+		}
+		holding[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return edit{}, 0, fmt.Errorf("iterate cards: %w", err)
+	}
+
+	e, err := planEdit(cols, holding, columnID, u)
+	return e, day, err
+}
+
+// writeEdit writes a planned update to the columns table.
+func writeEdit(ctx context.Context, tx *sql.Tx, gameID uuid.UUID, e edit) error {
+	// 1) the column itself
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE columns SET title = $1, wip_limit = $2 WHERE id = $3 AND game_id = $4`,
+		e.Column.Title, e.Column.WIPLimit, e.Column.ID, gameID,
+	); err != nil {
+		return fmt.Errorf("update column: %w", err)
+	}
+
+	// 2) sub-columns gone
+	for _, id := range e.Removed {
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM columns WHERE id = $1 AND game_id = $2`, id, gameID,
+		); err != nil {
+			return fmt.Errorf("delete sub-column %s: %w", id, err)
+		}
+	}
+
+	// 3) sub-columns kept, out of the way
+	for i, c := range e.Kept {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE columns SET order_index = $1 WHERE id = $2`, -1-i, c.ID,
+		); err != nil {
+			return fmt.Errorf("move sub-column %s: %w", c.ID, err)
+		}
+	}
+
+	// 4) new sub-columns
+	for _, c := range e.Added {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO columns (id, game_id, title, parent_id, order_index, wip_limit, col_type)
+			     VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			c.ID, gameID, c.Title, c.ParentID, c.OrderIndex, 0, c.Type,
+		); err != nil {
+			return fmt.Errorf("insert sub-column %q: %w", c.Title, err)
+		}
+	}
+
+	// 5) sub-columns kept, in their new places
+	for _, c := range e.Kept {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE columns SET order_index = $1 WHERE id = $2`, c.OrderIndex, c.ID,
+		); err != nil {
+			return fmt.Errorf("order sub-column %s: %w", c.ID, err)
+		}
+	}
+	return nil
+}
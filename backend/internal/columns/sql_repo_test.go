@@ -13,7 +13,7 @@ import (
 )
 
 func TestSQLRepo_GetColumnsByGameID(t *testing.T) {
-	const query = `SELECT id, title, wip_limit, col_type, parent_id, order_index FROM columns WHERE game_id = $1 ORDER BY parent_id, order_index`
+	const query = `SELECT c.id, c.title, c.wip_limit, c.col_type, c.parent_id, c.order_index FROM columns c JOIN games g ON g.id = c.game_id WHERE c.game_id = $1 AND g.deleted_at IS NULL ORDER BY c.parent_id IS NULL, c.parent_id, c.order_index`
 	gameID := uuid.New()
 	colID := uuid.New()
 
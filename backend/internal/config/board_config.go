@@ -3,7 +3,9 @@ package config
 import (
 	"embed"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 
 	"github.com/Germanicus1/kanban-sim/backend/internal/models"
 )
@@ -18,9 +20,28 @@ func LoadBoardConfig() (*models.Board, error) {
 	if err != nil {
 		return nil, fmt.Errorf("embed read failed: %w", err)
 	}
+	return ParseBoardConfig(b)
+}
+
+// LoadBoardConfigFile loads a scenario from a JSON file on disk. The file
+// has the same shape as the embedded board_config.json.
+func LoadBoardConfigFile(path string) (*models.Board, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read scenario: %w", err)
+	}
+	return ParseBoardConfig(b)
+}
+
+// ParseBoardConfig decodes a board configuration from JSON and checks its
+// quality settings.
+func ParseBoardConfig(b []byte) (*models.Board, error) {
 	var cfg models.Board
 	if err := json.Unmarshal(b, &cfg); err != nil {
 		return nil, fmt.Errorf("invalid JSON: %w", err)
 	}
+	if !cfg.Quality.Valid() {
+		return nil, errors.New("invalid quality: chances and shares must be between 0 and 1")
+	}
 	return &cfg, nil
 }
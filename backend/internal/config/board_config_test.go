@@ -1,6 +1,8 @@
 package config_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Germanicus1/kanban-sim/backend/internal/config"
@@ -36,4 +38,53 @@ func TestLoadBoardConfig(t *testing.T) {
 	if len(cfg.EffortTypes) == 0 {
 		t.Errorf("expected at least one EffortType, got 0")
 	}
+
+	// and how often work goes wrong
+	if cfg.Quality.TestFailure == 0 || cfg.Quality.EscapedDefect == 0 {
+		t.Errorf("quality = %+v; want test failures and escaped defects", cfg.Quality)
+	}
+}
+
+// TestParseBoardConfig_Quality verifies that quality chances outside 0..1
+// are rejected.
+func TestParseBoardConfig_Quality(t *testing.T) {
+	if _, err := config.ParseBoardConfig([]byte(`{"quality": {"testFailure": 1.2}}`)); err == nil {
+		t.Error("expected an error for a test failure chance above 1")
+	}
+	cfg, err := config.ParseBoardConfig([]byte(`{"quality": {"testFailure": 0.3, "rework": 1}}`))
+	if err != nil {
+		t.Fatalf("ParseBoardConfig returned error: %v", err)
+	}
+	if cfg.Quality.TestFailure != 0.3 || cfg.Quality.Rework != 1 {
+		t.Errorf("quality = %+v; want a test failure chance of 0.3 and full rework", cfg.Quality)
+	}
+}
+
+// TestLoadBoardConfigFile verifies that a scenario on disk is read with the
+// same rules as the embedded one.
+func TestLoadBoardConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	scenario := `{
+		"effortTypes": [{ "title": "Build" }],
+		"columns": [{ "title": "Todo", "type": "queue" }, { "title": "Doing", "type": "active", "wipLimit": 2 }],
+		"cards": [{ "title": "C1", "columnTitle": "Todo", "efforts": [{ "effortType": "Build", "estimate": 5 }] }]
+	}`
+	if err := os.WriteFile(path, []byte(scenario), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.LoadBoardConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadBoardConfigFile returned error: %v", err)
+	}
+	if len(cfg.Columns) != 2 || cfg.Columns[1].WIPLimit != 2 {
+		t.Errorf("columns = %+v; want Todo and Doing with WIP limit 2", cfg.Columns)
+	}
+	if len(cfg.Cards) != 1 || cfg.Cards[0].Efforts[0].Estimate != 5 {
+		t.Errorf("cards = %+v; want C1 with an estimate of 5", cfg.Cards)
+	}
+
+	if _, err := config.LoadBoardConfigFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
 }
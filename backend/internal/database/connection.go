@@ -4,24 +4,51 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/pressly/goose/v3"
+	"modernc.org/sqlite"
 )
 
+// Supported values of DB_DRIVER.
+const (
+	Postgres = "postgres"
+	SQLite   = "sqlite"
+)
+
+// DefaultSQLitePath is the database file used when SQLITE_PATH is not set.
+const DefaultSQLitePath = "kanban-sim.db"
+
 var DB *sql.DB
 
-// InitDB initializes and returns a new DB connection
+// InitDB initializes and returns a new DB connection. DB_DRIVER picks the
+// database: Postgres (the default), configured by the POSTGRES_* variables,
+// or SQLite, stored in the file SQLITE_PATH.
 func InitDB() (*sql.DB, error) {
-	connStr := fmt.Sprintf(
-		"postgres://%s:%s@localhost:%s/%s?sslmode=disable",
-		os.Getenv("POSTGRES_USER"),
-		os.Getenv("POSTGRES_PASSWORD"),
-		os.Getenv("DB_PORT"),
-		os.Getenv("POSTGRES_DB"),
+	var (
+		db  *sql.DB
+		err error
 	)
-
-	db, err := sql.Open("pgx", connStr)
+	switch driver := os.Getenv("DB_DRIVER"); driver {
+	case "", Postgres:
+		connStr := fmt.Sprintf(
+			"postgres://%s:%s@localhost:%s/%s?sslmode=disable",
+			os.Getenv("POSTGRES_USER"),
+			os.Getenv("POSTGRES_PASSWORD"),
+			os.Getenv("DB_PORT"),
+			os.Getenv("POSTGRES_DB"),
+		)
+		db, err = sql.Open("pgx", connStr)
+	case SQLite:
+		path := os.Getenv("SQLITE_PATH")
+		if path == "" {
+			path = DefaultSQLitePath
+		}
+		db, err = OpenSQLite(path)
+	default:
+		return nil, fmt.Errorf("unknown DB_DRIVER %q, want %s or %s", driver, Postgres, SQLite)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("unable to open database: %w", err)
 	}
@@ -30,8 +57,26 @@ func InitDB() (*sql.DB, error) {
 	return db, nil
 }
 
+// OpenSQLite opens the SQLite database in the file at path, creating it if
+// needed. Foreign keys are enforced, so deletes cascade as on Postgres.
+func OpenSQLite(path string) (*sql.DB, error) {
+	dsn := "file:" + path +
+		"?_pragma=foreign_keys(1)" +
+		"&_pragma=busy_timeout(5000)" +
+		"&_pragma=journal_mode(WAL)" +
+		"&_time_format=sqlite"
+	return sql.Open("sqlite", dsn)
+}
+
+// Migrate applies the migrations in dir. SQLite databases use the set in
+// the "sqlite" subdirectory of dir instead.
 func Migrate(db *sql.DB, dir string) error {
-	err := goose.SetDialect("postgres")
+	dialect := "postgres"
+	if _, ok := db.Driver().(*sqlite.Driver); ok {
+		dialect, dir = "sqlite3", filepath.Join(dir, "sqlite")
+	}
+
+	err := goose.SetDialect(dialect)
 	if err != nil {
 		return fmt.Errorf("migrate: %w", err)
 	}
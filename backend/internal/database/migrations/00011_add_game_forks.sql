@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+-- 1) Link forked games to the game they were branched from
+ALTER TABLE games
+  ADD COLUMN parent_game_id UUID REFERENCES games(id) ON DELETE SET NULL,
+  ADD COLUMN forked_at_day INT;
+
+-- 2) Remember on which game day an event happened
+ALTER TABLE game_events
+  ADD COLUMN day INT NOT NULL DEFAULT 0;
+
+UPDATE game_events
+   SET day = (payload->>'day')::INT
+ WHERE payload ? 'day';
+
+-- 3) Board state at the end of each finished day
+CREATE TABLE game_snapshots (
+  game_id UUID NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+  day INT NOT NULL,
+  board JSONB NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (game_id, day)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS game_snapshots;
+
+ALTER TABLE game_events
+  DROP COLUMN day;
+
+ALTER TABLE games
+  DROP COLUMN forked_at_day,
+  DROP COLUMN parent_game_id;
+-- +goose StatementEnd
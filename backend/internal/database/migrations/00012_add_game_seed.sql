@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Seed of the game's dice; every roll is derived from it
+ALTER TABLE games
+  ADD COLUMN seed BIGINT NOT NULL DEFAULT 0;
+
+UPDATE games
+   SET seed = floor(random() * 9007199254740990)::BIGINT + 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE games
+  DROP COLUMN seed;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Player running the game; the role is vacant once they leave
+ALTER TABLE games
+  ADD COLUMN facilitator_id UUID REFERENCES players(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE games
+  DROP COLUMN facilitator_id;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Lifecycle of a game; new games wait in the lobby until started
+ALTER TABLE games
+  ADD COLUMN status TEXT NOT NULL DEFAULT 'lobby'
+  CHECK (status IN ('lobby', 'running', 'paused', 'ended'));
+
+-- games from before the lifecycle were being played
+UPDATE games SET status = 'running';
+
+-- lifecycle events belong to the game, not to a card
+ALTER TABLE game_events
+  ALTER COLUMN card_id DROP NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM game_events WHERE card_id IS NULL;
+ALTER TABLE game_events
+  ALTER COLUMN card_id SET NOT NULL;
+
+ALTER TABLE games
+  DROP COLUMN status;
+-- +goose StatementEnd
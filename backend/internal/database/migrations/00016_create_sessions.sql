@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A training session: games played side by side from the same scenario and seed
+CREATE TABLE sessions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  name TEXT NOT NULL,
+  seed BIGINT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- the game each team of a session plays
+CREATE TABLE session_games (
+  session_id UUID NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+  game_id UUID NOT NULL UNIQUE REFERENCES games(id) ON DELETE CASCADE,
+  team TEXT NOT NULL,
+  order_index INT NOT NULL,
+  PRIMARY KEY (session_id, game_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE session_games;
+DROP TABLE sessions;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The cards of a column are in order_index order, top first, numbered from 0
+-- without gaps. Cards of older games all had order_index 0 or one running
+-- number across the game; number them per column, keeping that order.
+UPDATE cards
+   SET order_index = ranked.n
+  FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY column_id ORDER BY order_index, id) - 1 AS n
+          FROM cards) AS ranked
+ WHERE cards.id = ranked.id;
+CREATE INDEX cards_column_order_idx ON cards (column_id, order_index);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS cards_column_order_idx;
+-- +goose StatementEnd
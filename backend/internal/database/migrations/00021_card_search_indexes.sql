@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Card searches filter the cards of one game and page through them in the
+-- order asked for; cards_column_order_idx already covers a single column.
+CREATE INDEX cards_game_title_idx ON cards (game_id, title, id);
+CREATE INDEX cards_game_selected_idx ON cards (game_id, selected_day);
+CREATE INDEX cards_game_cos_idx ON cards (game_id, class_of_service, value_estimate);
+CREATE INDEX columns_parent_idx ON columns (parent_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS columns_parent_idx;
+DROP INDEX IF EXISTS cards_game_cos_idx;
+DROP INDEX IF EXISTS cards_game_selected_idx;
+DROP INDEX IF EXISTS cards_game_title_idx;
+-- +goose StatementEnd
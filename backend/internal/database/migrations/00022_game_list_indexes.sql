@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The game list pages through games by creation time or day, optionally of
+-- one status or scenario.
+CREATE INDEX games_created_idx ON games (created_at, id);
+CREATE INDEX games_day_idx ON games (day, created_at, id);
+CREATE INDEX games_status_created_idx ON games (status, created_at);
+CREATE INDEX games_scenario_created_idx ON games (scenario, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS games_scenario_created_idx;
+DROP INDEX IF EXISTS games_status_created_idx;
+DROP INDEX IF EXISTS games_day_idx;
+DROP INDEX IF EXISTS games_created_idx;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- When the retention policy retired a game; retired games are hidden and
+-- deleted for good after a grace period. A game is inactive since its last
+-- event.
+ALTER TABLE games
+  ADD COLUMN deleted_at TIMESTAMP;
+CREATE INDEX games_deleted_idx ON games (deleted_at);
+CREATE INDEX game_events_game_created_idx ON game_events (game_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS game_events_game_created_idx;
+DROP INDEX IF EXISTS games_deleted_idx;
+ALTER TABLE games
+  DROP COLUMN deleted_at;
+-- +goose StatementEnd
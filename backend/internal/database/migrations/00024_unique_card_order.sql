@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- No two cards of a column share an order index. The check is deferred to
+-- the end of the transaction, as a reorder shifts cards one at a time.
+-- SQLite cannot defer it and relies on placements being serialized.
+UPDATE cards
+   SET order_index = ranked.n
+  FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY column_id ORDER BY order_index, id) - 1 AS n
+          FROM cards) AS ranked
+ WHERE cards.id = ranked.id
+   AND cards.order_index <> ranked.n;
+ALTER TABLE cards
+  ADD CONSTRAINT cards_column_order_key UNIQUE (column_id, order_index) DEFERRABLE INITIALLY DEFERRED;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE cards
+  DROP CONSTRAINT IF EXISTS cards_column_order_key;
+-- +goose StatementEnd
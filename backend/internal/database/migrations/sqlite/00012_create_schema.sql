@@ -0,0 +1,131 @@
+-- The SQLite schema, equal to the Postgres one after its migration 00012.
+-- Later migrations use the same numbers in both sets.
+--
+-- SQLite has no UUID type, gen_random_uuid(), enums or JSONB: UUIDs are
+-- lowercase TEXT with a random (v4) default, column types are checked TEXT
+-- and JSON is stored as is.
+
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE games (
+    id TEXT PRIMARY KEY DEFAULT (lower(
+        hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' ||
+        substr(hex(randomblob(2)), 2) || '-' ||
+        substr('89ab', 1 + abs(random()) % 4, 1) ||
+        substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    day INT NOT NULL,
+    parent_game_id TEXT REFERENCES games(id) ON DELETE SET NULL,
+    forked_at_day INT,
+    seed BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE columns (
+    id TEXT PRIMARY KEY DEFAULT (lower(
+        hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' ||
+        substr(hex(randomblob(2)), 2) || '-' ||
+        substr('89ab', 1 + abs(random()) % 4, 1) ||
+        substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    game_id TEXT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    wip_limit INT DEFAULT 0,
+    col_type TEXT NOT NULL DEFAULT 'queue' CHECK (col_type IN ('queue', 'active', 'done')),
+    parent_id TEXT REFERENCES columns(id) ON DELETE CASCADE,
+    order_index INT NOT NULL
+);
+
+CREATE UNIQUE INDEX columns_game_order_idx
+  ON columns (game_id, order_index)
+  WHERE parent_id IS NULL;
+
+CREATE UNIQUE INDEX columns_sub_order_idx
+  ON columns (game_id, parent_id, order_index)
+  WHERE parent_id IS NOT NULL;
+
+CREATE TABLE cards (
+    id TEXT PRIMARY KEY DEFAULT (lower(
+        hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' ||
+        substr(hex(randomblob(2)), 2) || '-' ||
+        substr('89ab', 1 + abs(random()) % 4, 1) ||
+        substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    game_id TEXT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    column_id TEXT NOT NULL REFERENCES columns(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    class_of_service TEXT,
+    selected_day INT,
+    deployed_day INT,
+    order_index INT NOT NULL DEFAULT 0,
+    value_estimate TEXT NOT NULL DEFAULT 'high'
+);
+
+CREATE TABLE effort_types (
+    id TEXT PRIMARY KEY DEFAULT (lower(
+        hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' ||
+        substr(hex(randomblob(2)), 2) || '-' ||
+        substr('89ab', 1 + abs(random()) % 4, 1) ||
+        substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    game_id TEXT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    order_index INT NOT NULL,
+    UNIQUE(game_id, order_index)
+);
+
+CREATE TABLE efforts (
+    id TEXT PRIMARY KEY DEFAULT (lower(
+        hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' ||
+        substr(hex(randomblob(2)), 2) || '-' ||
+        substr('89ab', 1 + abs(random()) % 4, 1) ||
+        substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    card_id TEXT NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    effort_type_id TEXT NOT NULL REFERENCES effort_types(id) ON DELETE CASCADE,
+    estimate INT NOT NULL DEFAULT 0,
+    remaining INT NOT NULL DEFAULT 0,
+    actual INT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE players (
+    id TEXT PRIMARY KEY DEFAULT (lower(
+        hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' ||
+        substr(hex(randomblob(2)), 2) || '-' ||
+        substr('89ab', 1 + abs(random()) % 4, 1) ||
+        substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    game_id TEXT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    name TEXT NOT NULL
+);
+
+CREATE TABLE game_events (
+    id TEXT PRIMARY KEY DEFAULT (lower(
+        hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' ||
+        substr(hex(randomblob(2)), 2) || '-' ||
+        substr('89ab', 1 + abs(random()) % 4, 1) ||
+        substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    game_id TEXT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    card_id TEXT NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    event_type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    day INT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE game_snapshots (
+    game_id TEXT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    day INT NOT NULL,
+    board TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    PRIMARY KEY (game_id, day)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS game_snapshots;
+DROP TABLE IF EXISTS game_events;
+DROP TABLE IF EXISTS players;
+DROP TABLE IF EXISTS efforts;
+DROP TABLE IF EXISTS effort_types;
+DROP TABLE IF EXISTS cards;
+DROP INDEX IF EXISTS columns_sub_order_idx;
+DROP INDEX IF EXISTS columns_game_order_idx;
+DROP TABLE IF EXISTS columns;
+DROP TABLE IF EXISTS games;
+-- +goose StatementEnd
@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Lifecycle of a game; new games wait in the lobby until started
+ALTER TABLE games
+  ADD COLUMN status TEXT NOT NULL DEFAULT 'lobby'
+  CHECK (status IN ('lobby', 'running', 'paused', 'ended'));
+
+-- games from before the lifecycle were being played
+UPDATE games SET status = 'running';
+
+-- lifecycle events belong to the game, not to a card. SQLite cannot drop
+-- NOT NULL, so the table is rebuilt.
+CREATE TABLE game_events_new (
+    id TEXT PRIMARY KEY DEFAULT (lower(
+        hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' ||
+        substr(hex(randomblob(2)), 2) || '-' ||
+        substr('89ab', 1 + abs(random()) % 4, 1) ||
+        substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    game_id TEXT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    card_id TEXT REFERENCES cards(id) ON DELETE CASCADE,
+    event_type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    day INT NOT NULL DEFAULT 0
+);
+INSERT INTO game_events_new (id, game_id, card_id, event_type, payload, created_at, day)
+     SELECT id, game_id, card_id, event_type, payload, created_at, day FROM game_events;
+DROP TABLE game_events;
+ALTER TABLE game_events_new RENAME TO game_events;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE TABLE game_events_new (
+    id TEXT PRIMARY KEY DEFAULT (lower(
+        hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' ||
+        substr(hex(randomblob(2)), 2) || '-' ||
+        substr('89ab', 1 + abs(random()) % 4, 1) ||
+        substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    game_id TEXT NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    card_id TEXT NOT NULL REFERENCES cards(id) ON DELETE CASCADE,
+    event_type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now')),
+    day INT NOT NULL DEFAULT 0
+);
+INSERT INTO game_events_new (id, game_id, card_id, event_type, payload, created_at, day)
+     SELECT id, game_id, card_id, event_type, payload, created_at, day
+       FROM game_events WHERE card_id IS NOT NULL;
+DROP TABLE game_events;
+ALTER TABLE game_events_new RENAME TO game_events;
+
+ALTER TABLE games
+  DROP COLUMN status;
+-- +goose StatementEnd
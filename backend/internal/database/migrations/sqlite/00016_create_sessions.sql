@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A training session: games played side by side from the same scenario and seed
+CREATE TABLE sessions (
+    id TEXT PRIMARY KEY DEFAULT (lower(
+        hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' ||
+        substr(hex(randomblob(2)), 2) || '-' ||
+        substr('89ab', 1 + abs(random()) % 4, 1) ||
+        substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))),
+    name TEXT NOT NULL,
+    seed BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now'))
+);
+
+-- the game each team of a session plays
+CREATE TABLE session_games (
+    session_id TEXT NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    game_id TEXT NOT NULL UNIQUE REFERENCES games(id) ON DELETE CASCADE,
+    team TEXT NOT NULL,
+    order_index INT NOT NULL,
+    PRIMARY KEY (session_id, game_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE session_games;
+DROP TABLE sessions;
+-- +goose StatementEnd
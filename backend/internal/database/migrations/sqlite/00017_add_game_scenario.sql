@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Scenario a game was created from, to compare games of the same one; games
+-- from before scenarios were recorded all used the default board
+ALTER TABLE games
+  ADD COLUMN scenario TEXT NOT NULL DEFAULT 'default';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE games
+  DROP COLUMN scenario;
+-- +goose StatementEnd
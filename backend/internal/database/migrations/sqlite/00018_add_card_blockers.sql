@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- What holds up a blocked card: a card is blocked while it has a reason.
+-- unblock_effort is the unblocking work still needed; 0 means a player has
+-- to lift the blocker.
+ALTER TABLE cards
+  ADD COLUMN blocked_reason TEXT;
+ALTER TABLE cards
+  ADD COLUMN blocked_by TEXT NOT NULL DEFAULT '';
+ALTER TABLE cards
+  ADD COLUMN blocked_day INT NOT NULL DEFAULT 0;
+ALTER TABLE cards
+  ADD COLUMN unblock_effort INT NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE cards
+  DROP COLUMN unblock_effort;
+ALTER TABLE cards
+  DROP COLUMN blocked_day;
+ALTER TABLE cards
+  DROP COLUMN blocked_by;
+ALTER TABLE cards
+  DROP COLUMN blocked_reason;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- How often work goes wrong in a game: the chance a card fails its testing
+-- and the share of development it needs again, the chance a deployed card
+-- spawns a defect and the share of its estimates the defect needs. Games
+-- from before had no test failures or defects.
+ALTER TABLE games
+  ADD COLUMN test_failure REAL NOT NULL DEFAULT 0;
+ALTER TABLE games
+  ADD COLUMN rework REAL NOT NULL DEFAULT 0;
+ALTER TABLE games
+  ADD COLUMN escaped_defect REAL NOT NULL DEFAULT 0;
+ALTER TABLE games
+  ADD COLUMN defect_effort REAL NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE games
+  DROP COLUMN defect_effort;
+ALTER TABLE games
+  DROP COLUMN escaped_defect;
+ALTER TABLE games
+  DROP COLUMN rework;
+ALTER TABLE games
+  DROP COLUMN test_failure;
+-- +goose StatementEnd
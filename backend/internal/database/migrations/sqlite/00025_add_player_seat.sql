@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The seat of a player is the order they joined their game in, from 0. A
+-- player's dice are keyed on it, so the copies of a game roll alike. Players
+-- of older games are seated in no particular order.
+ALTER TABLE players
+  ADD COLUMN seat INT NOT NULL DEFAULT 0;
+UPDATE players
+   SET seat = ranked.n
+  FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY game_id ORDER BY id) - 1 AS n
+          FROM players) AS ranked
+ WHERE players.id = ranked.id;
+CREATE UNIQUE INDEX players_game_seat_key ON players (game_id, seat);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS players_game_seat_key;
+ALTER TABLE players
+  DROP COLUMN seat;
+-- +goose StatementEnd
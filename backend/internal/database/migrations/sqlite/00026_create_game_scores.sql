@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- What each ended game scored, so the leaderboard reads the scores rather
+-- than rebuilding every board. Games ended before are scored the first time
+-- the leaderboard ranks them.
+CREATE TABLE game_scores (
+    game_id TEXT PRIMARY KEY REFERENCES games(id) ON DELETE CASCADE,
+    days INT NOT NULL,
+    deployed INT NOT NULL,
+    profit INT NOT NULL,
+    lead_time INT NOT NULL,
+    throughput REAL NOT NULL,
+    fixed_date_hit_rate REAL NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS game_scores;
+-- +goose StatementEnd
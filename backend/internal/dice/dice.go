@@ -0,0 +1,83 @@
+// Package dice provides the reproducible randomness of a game. Every roll is
+// derived from the game's seed and the (day, player, card) it is made for, so
+// the same decisions always produce the same outcomes, whatever order they
+// are taken in.
+//
+// Players and cards are told apart by keys rather than by their IDs, which
+// every copy of a game gets anew: a fork, an import or another team's game
+// of a session rolls the same dice as the game it was copied from.
+package dice
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	mrand "math/rand/v2"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// cardNamespace and seatNamespace derive the keys of cards and players.
+var (
+	cardNamespace = uuid.MustParse("5b0f6c3e-6a1f-4d7a-9d89-3f3c1b8e2a10")
+	seatNamespace = uuid.MustParse("7a3e9c15-4b2d-4f6a-8e07-5c1d9b2f4a68")
+)
+
+// CardKey returns the key of a card's dice, derived from its title.
+func CardKey(title string) uuid.UUID {
+	return uuid.NewSHA1(cardNamespace, []byte(title))
+}
+
+// SeatKey returns the key of the dice of the player in the given seat of a
+// game: 0 for the first player to join, 1 for the next and so on.
+func SeatKey(seat int) uuid.UUID {
+	return uuid.NewSHA1(seatNamespace, []byte(strconv.Itoa(seat)))
+}
+
+// Sides is the number of faces of a die.
+const Sides = 6
+
+// maxSeed keeps generated seeds exact as JSON numbers (2^53).
+const maxSeed = 1 << 53
+
+// Roller derives random streams from one game seed.
+type Roller struct {
+	seed int64
+}
+
+// New returns a Roller for seed.
+func New(seed int64) Roller {
+	return Roller{seed: seed}
+}
+
+// Stream returns the random stream of one player working on one card on one
+// day, given by their keys. Calling it twice with the same arguments yields
+// identical streams.
+func (r Roller) Stream(day int, player, card uuid.UUID) *mrand.Rand {
+	var buf [8 + 8 + 16 + 16]byte
+	binary.BigEndian.PutUint64(buf[0:], uint64(r.seed))
+	binary.BigEndian.PutUint64(buf[8:], uint64(day))
+	copy(buf[16:], player[:])
+	copy(buf[32:], card[:])
+	sum := sha256.Sum256(buf[:])
+
+	return mrand.New(mrand.NewPCG(
+		binary.BigEndian.Uint64(sum[0:]),
+		binary.BigEndian.Uint64(sum[8:]),
+	))
+}
+
+// Roll is the first throw of a die in Stream(day, player, card), 1 to Sides.
+func (r Roller) Roll(day int, player, card uuid.UUID) int {
+	return r.Stream(day, player, card).IntN(Sides) + 1
+}
+
+// NewSeed picks a random, non-zero seed for a new game.
+func NewSeed() int64 {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic("dice: reading random seed: " + err.Error())
+	}
+	return int64(binary.BigEndian.Uint64(b[:])%(maxSeed-1)) + 1
+}
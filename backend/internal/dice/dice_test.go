@@ -0,0 +1,57 @@
+package dice
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestRoller_Deterministic(t *testing.T) {
+	player := uuid.MustParse("00000000-0000-0000-0000-0000000000aa")
+	card := uuid.MustParse("00000000-0000-0000-0000-0000000000bb")
+
+	a := New(42)
+	b := New(42)
+	for day := 1; day <= 20; day++ {
+		got := a.Roll(day, player, card)
+		if got < 1 || got > Sides {
+			t.Fatalf("day %d: roll %d out of range", day, got)
+		}
+		if again := b.Roll(day, player, card); again != got {
+			t.Fatalf("day %d: rolls differ: %d and %d", day, got, again)
+		}
+	}
+
+	sa, sb := a.Stream(3, player, card), b.Stream(3, player, card)
+	for i := 0; i < 10; i++ {
+		if x, y := sa.Uint64(), sb.Uint64(); x != y {
+			t.Fatalf("draw %d: streams differ", i)
+		}
+	}
+}
+
+func TestRoller_DependsOnInputs(t *testing.T) {
+	player := uuid.MustParse("00000000-0000-0000-0000-0000000000aa")
+	card := uuid.MustParse("00000000-0000-0000-0000-0000000000bb")
+	base := New(42).Stream(3, player, card).Uint64()
+
+	variants := map[string]uint64{
+		"seed":   New(43).Stream(3, player, card).Uint64(),
+		"day":    New(42).Stream(4, player, card).Uint64(),
+		"player": New(42).Stream(3, card, card).Uint64(),
+		"card":   New(42).Stream(3, player, player).Uint64(),
+	}
+	for name, v := range variants {
+		if v == base {
+			t.Errorf("changing the %s did not change the stream", name)
+		}
+	}
+}
+
+func TestNewSeed(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if s := NewSeed(); s <= 0 || s >= maxSeed {
+			t.Fatalf("NewSeed() = %d; want 0 < seed < 2^53", s)
+		}
+	}
+}
@@ -0,0 +1,416 @@
+// Package engine plays games entirely in memory: it knows the rules of moving
+// cards and working on them, while a Strategy takes the team's decisions.
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/dice"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnknownCard   = errors.New("unknown card")
+	ErrUnknownWorker = errors.New("unknown worker")
+	ErrInvalidMove   = errors.New("invalid move")
+	ErrNoWork        = errors.New("no work left on card")
+	ErrBlocked       = errors.New("card is blocked")
+)
+
+// workerNamespace makes worker IDs a pure function of the names, so dice
+// derived from them are the same in every run. defectNamespace derives the
+// ID of a defect from the card it escaped from.
+var (
+	workerNamespace = uuid.MustParse("0c8e7f52-2d4b-4c1e-8f5a-7d6e9b3a4c21")
+	defectNamespace = uuid.MustParse("9e4a2c71-3b5d-4f08-a6c2-1d7e8f9b0a35")
+)
+
+// Stage is one column a card can sit in: a top-level column without
+// subcolumns, or a subcolumn titled "Parent - Sub".
+type Stage struct {
+	Title      string
+	ColumnID   uuid.UUID // column of a loaded game, zero for scenarios
+	Group      string    // top-level column the stage belongs to
+	Type       string    // "queue", "active" or "done"
+	WIPLimit   int       // limit of the whole group, 0 for none
+	EffortType string    // work done while a card is in this stage, if any
+}
+
+// Effort is the work of one type a card needs.
+type Effort struct {
+	Type      string
+	Estimate  int
+	Remaining int
+	Actual    int
+}
+
+// ClassFixedDate is the class of service of cards that must be deployed by
+// a given day.
+const ClassFixedDate = "F"
+
+// ClassExpedite is the class of service of urgent cards, such as defects
+// found after deployment. Strategies start them before any other card.
+const ClassExpedite = "E"
+
+// Card is a card in play.
+type Card struct {
+	ID             uuid.UUID
+	Title          string
+	ClassOfService string
+	ValueEstimate  string
+	Stage          int // index into Board.Stages
+	SelectedDay    int
+	DeployedDay    int
+	Efforts        []Effort
+	Blocker        *models.Blocker // nil unless the card is blocked
+}
+
+// key is what the card's dice are keyed on: its title, which copies of the
+// game keep, rather than its ID.
+func (c *Card) key() uuid.UUID {
+	return dice.CardKey(c.Title)
+}
+
+// Effort returns the card's effort of the given type, or nil.
+func (c *Card) Effort(effortType string) *Effort {
+	for i := range c.Efforts {
+		if c.Efforts[i].Type == effortType {
+			return &c.Efforts[i]
+		}
+	}
+	return nil
+}
+
+// Worker is one member of the team.
+type Worker struct {
+	ID        uuid.UUID
+	Name      string
+	Specialty string // effort type the worker is best at
+}
+
+// NewWorker returns a worker whose ID is derived from its name.
+func NewWorker(name, specialty string) Worker {
+	return Worker{
+		ID:        uuid.NewSHA1(workerNamespace, []byte(name)),
+		Name:      name,
+		Specialty: specialty,
+	}
+}
+
+// Board is the complete state of a game on one day.
+type Board struct {
+	Day    int
+	Stages []Stage
+	Cards  []*Card
+	Team   []Worker
+}
+
+// NewBoard lays out a scenario. Active columns are matched to the effort
+// types in order; cards start with the work of every column they have
+// already passed completed.
+func NewBoard(cfg models.Board, team []Worker) (*Board, error) {
+	stages, err := buildStages(cfg.Columns, cfg.EffortTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Board{Day: 1, Stages: stages, Team: team}
+
+	stageIdx := make(map[string]int, len(stages))
+	for i, s := range stages {
+		stageIdx[s.Title] = i
+	}
+	workIdx := workStages(stages)
+
+	for _, cc := range cfg.Cards {
+		idx, ok := stageIdx[cc.ColumnTitle]
+		if !ok {
+			return nil, fmt.Errorf("card %q is in unknown column %q", cc.Title, cc.ColumnTitle)
+		}
+		c := &Card{
+			ID:             dice.CardKey(cc.Title),
+			Title:          cc.Title,
+			ClassOfService: cc.ClassOfService,
+			ValueEstimate:  cc.ValueEstimate,
+			Stage:          idx,
+			SelectedDay:    cc.SelectedDay,
+			DeployedDay:    cc.DeployedDay,
+			Blocker:        copyBlocker(cc.Blocker),
+		}
+		for _, e := range cc.Efforts {
+			eff := Effort{Type: e.EffortType, Estimate: e.Estimate, Remaining: e.Estimate}
+			if at, ok := workIdx[e.EffortType]; ok && at < idx {
+				eff.Remaining, eff.Actual = 0, e.Estimate
+			}
+			c.Efforts = append(c.Efforts, eff)
+		}
+		b.Cards = append(b.Cards, c)
+	}
+
+	return b, nil
+}
+
+// LoadBoard lays out a game in progress, such as a live game read from the
+// database, on the given day. Cards keep their IDs and the work recorded on
+// them, except that the work of every column a card has already passed
+// counts as done.
+func LoadBoard(state models.Board, day int, team []Worker) (*Board, error) {
+	stages, err := buildStages(state.Columns, state.EffortTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Board{Day: day, Stages: stages, Team: team}
+
+	stageIdx := make(map[uuid.UUID]int, len(stages))
+	for i, s := range stages {
+		stageIdx[s.ColumnID] = i
+	}
+	workIdx := workStages(stages)
+
+	for _, cc := range state.Cards {
+		idx, ok := stageIdx[cc.ColumnID]
+		if !ok || cc.ColumnID == uuid.Nil {
+			return nil, fmt.Errorf("card %q is in unknown column %s", cc.Title, cc.ColumnID)
+		}
+		c := &Card{
+			ID:             cc.ID,
+			Title:          cc.Title,
+			ClassOfService: cc.ClassOfService,
+			ValueEstimate:  cc.ValueEstimate,
+			Stage:          idx,
+			SelectedDay:    cc.SelectedDay,
+			DeployedDay:    cc.DeployedDay,
+			Blocker:        copyBlocker(cc.Blocker),
+		}
+		for _, e := range cc.Efforts {
+			eff := Effort{Type: e.EffortType, Estimate: e.Estimate, Remaining: e.Remaining, Actual: e.Actual}
+			if at, ok := workIdx[e.EffortType]; ok && at < idx {
+				eff.Actual += eff.Remaining
+				eff.Remaining = 0
+			}
+			c.Efforts = append(c.Efforts, eff)
+		}
+		b.Cards = append(b.Cards, c)
+	}
+
+	return b, nil
+}
+
+// workStages maps each effort type to the first stage where it is done.
+func workStages(stages []Stage) map[string]int {
+	idx := make(map[string]int)
+	for i, s := range stages {
+		if _, ok := idx[s.EffortType]; s.EffortType != "" && !ok {
+			idx[s.EffortType] = i
+		}
+	}
+	return idx
+}
+
+// buildStages flattens the column tree into stages, left to right.
+func buildStages(cols []models.Column, effortTypes []models.EffortType) ([]Stage, error) {
+	top := append([]models.Column(nil), cols...)
+	sort.SliceStable(top, func(i, j int) bool { return top[i].OrderIndex < top[j].OrderIndex })
+
+	var (
+		stages []Stage
+		next   int // next effort type to hand out
+	)
+	for _, col := range top {
+		subs := append([]models.Column(nil), col.SubColumns...)
+		sort.SliceStable(subs, func(i, j int) bool { return subs[i].OrderIndex < subs[j].OrderIndex })
+
+		first := len(stages)
+		if len(subs) == 0 {
+			stages = append(stages, Stage{
+				Title:    col.Title,
+				ColumnID: col.ID,
+				Group:    col.Title,
+				Type:     col.Type,
+				WIPLimit: col.WIPLimit,
+			})
+		}
+		for _, sub := range subs {
+			typ := sub.Type
+			if typ == "" {
+				typ = col.Type
+			}
+			stages = append(stages, Stage{
+				Title:    col.Title + " - " + sub.Title,
+				ColumnID: sub.ID,
+				Group:    col.Title,
+				Type:     typ,
+				WIPLimit: col.WIPLimit,
+			})
+		}
+
+		active := false
+		for i := first; i < len(stages); i++ {
+			if stages[i].Type == "active" {
+				active = true
+			}
+		}
+		if !active {
+			continue
+		}
+		if next >= len(effortTypes) {
+			return nil, fmt.Errorf("active column %q has no effort type", col.Title)
+		}
+		for i := first; i < len(stages); i++ {
+			if stages[i].Type == "active" {
+				stages[i].EffortType = effortTypes[next].Title
+			}
+		}
+		next++
+	}
+
+	if len(stages) < 2 || stages[0].Type != "queue" || stages[len(stages)-1].Type != "done" {
+		return nil, errors.New("board must start with a queue and end with a done column")
+	}
+	return stages, nil
+}
+
+// Clone returns a deep copy, for strategies to plan on.
+func (b *Board) Clone() Board {
+	c := Board{
+		Day:    b.Day,
+		Stages: append([]Stage(nil), b.Stages...),
+		Cards:  make([]*Card, len(b.Cards)),
+		Team:   append([]Worker(nil), b.Team...),
+	}
+	for i, card := range b.Cards {
+		cp := *card
+		cp.Efforts = append([]Effort(nil), card.Efforts...)
+		cp.Blocker = copyBlocker(card.Blocker)
+		c.Cards[i] = &cp
+	}
+	return c
+}
+
+// copyBlocker returns a copy of b, so that boards never share one.
+func copyBlocker(b *models.Blocker) *models.Blocker {
+	if b == nil {
+		return nil
+	}
+	cp := *b
+	return &cp
+}
+
+// DoneStage is the index of the last stage, where deployed cards end up.
+func (b *Board) DoneStage() int {
+	return len(b.Stages) - 1
+}
+
+// Card looks a card up by ID.
+func (b *Board) Card(id uuid.UUID) *Card {
+	for _, c := range b.Cards {
+		if c.ID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+// Worker looks a worker up by ID.
+func (b *Board) Worker(id uuid.UUID) (Worker, bool) {
+	for _, w := range b.Team {
+		if w.ID == id {
+			return w, true
+		}
+	}
+	return Worker{}, false
+}
+
+// CardsIn returns the cards in a stage, in board order.
+func (b *Board) CardsIn(stage int) []*Card {
+	var out []*Card
+	for _, c := range b.Cards {
+		if c.Stage == stage {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// GroupWIP counts the cards in all stages of a top-level column.
+func (b *Board) GroupWIP(group string) int {
+	n := 0
+	for _, c := range b.Cards {
+		if b.Stages[c.Stage].Group == group {
+			n++
+		}
+	}
+	return n
+}
+
+// WIP counts the cards that have been selected but are not deployed yet.
+func (b *Board) WIP() int {
+	n := 0
+	for _, c := range b.Cards {
+		if c.Stage > 0 && c.Stage < b.DoneStage() {
+			n++
+		}
+	}
+	return n
+}
+
+// RemainingWork is what is left to do on a card in its current stage, or 0
+// when the stage has no work. For a blocked card it is the unblocking work
+// left instead.
+func (b *Board) RemainingWork(c *Card) int {
+	if c.Blocker != nil {
+		return c.Blocker.Effort
+	}
+	et := b.Stages[c.Stage].EffortType
+	if et == "" {
+		return 0
+	}
+	if e := c.Effort(et); e != nil {
+		return e.Remaining
+	}
+	return 0
+}
+
+// CanMove reports whether the rules allow a card one column to the right:
+// it is not deployed yet, not blocked, and the work of its current column is
+// finished. WIP limits are a policy, not a rule; see HasRoom.
+func (b *Board) CanMove(c *Card) bool {
+	return c.Stage < b.DoneStage() && c.Blocker == nil && b.RemainingWork(c) == 0
+}
+
+// HasRoom reports whether a card can move one column to the right without
+// breaking the WIP limit of the column it enters.
+func (b *Board) HasRoom(c *Card) bool {
+	if c.Stage >= b.DoneStage() {
+		return false
+	}
+	from, to := b.Stages[c.Stage], b.Stages[c.Stage+1]
+	if to.WIPLimit == 0 || to.Group == from.Group {
+		return true
+	}
+	return b.GroupWIP(to.Group) < to.WIPLimit
+}
+
+// Move takes a card one column to the right. Leaving the first column selects
+// the card; entering the last deploys it.
+func (b *Board) Move(id uuid.UUID) error {
+	c := b.Card(id)
+	if c == nil {
+		return fmt.Errorf("%w: %s", ErrUnknownCard, id)
+	}
+	if !b.CanMove(c) {
+		return fmt.Errorf("%w: card %q cannot leave %q", ErrInvalidMove, c.Title, b.Stages[c.Stage].Title)
+	}
+	if c.Stage == 0 {
+		c.SelectedDay = b.Day
+	}
+	c.Stage++
+	if c.Stage == b.DoneStage() {
+		c.DeployedDay = b.Day
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/dice"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// DefaultValues is the revenue a deployed card earns per day, by value
+// estimate.
+var DefaultValues = map[string]int{
+	"low":       100,
+	"medium":    200,
+	"high":      300,
+	"very high": 500,
+}
+
+// DefaultDailyWage is what one worker costs per day.
+const DefaultDailyWage = 50
+
+// DefaultTeam is the team of the standard scenario.
+const DefaultTeam = "Analysis=2,Development=3,Testing=2"
+
+// Options tune a game.
+type Options struct {
+	Seed      int64
+	Team      []Worker
+	Values    map[string]int // daily revenue of a deployed card; DefaultValues if nil
+	DailyWage int            // DefaultDailyWage if 0
+	Quality   models.Quality // test failures and escaped defects; New uses the scenario's if zero
+}
+
+// Move takes a card one column to the right.
+type Move struct {
+	CardID uuid.UUID
+}
+
+// Assignment puts a worker on a card for the day.
+type Assignment struct {
+	WorkerID uuid.UUID
+	CardID   uuid.UUID
+}
+
+// DayStats is what happened on one day.
+type DayStats struct {
+	Day      int `json:"day"`
+	Moves    int `json:"moves"`
+	Deployed int `json:"deployed"`
+	Working  int `json:"working"` // workers who did any work
+	WIP      int `json:"wip"`     // at the end of the day
+	Revenue  int `json:"revenue"`
+	Cost     int `json:"cost"`
+	Failed   int `json:"failed"`  // cards that failed their testing
+	Defects  int `json:"defects"` // defects found in cards deployed
+
+	Failures []Failure `json:"-"`
+	Escapes  []Escape  `json:"-"`
+}
+
+// Game is one game played in memory.
+type Game struct {
+	Board  *Board
+	opts   Options
+	roller dice.Roller
+	days   []DayStats
+}
+
+// New sets up a game from a scenario.
+func New(cfg models.Board, opts Options) (*Game, error) {
+	if len(opts.Team) == 0 {
+		return nil, errors.New("a game needs a team")
+	}
+	board, err := NewBoard(cfg, opts.Team)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Quality == (models.Quality{}) {
+		opts.Quality = cfg.Quality
+	}
+	return Resume(board, opts)
+}
+
+// Resume continues a game from a board, such as one made by LoadBoard. The
+// team is the board's; opts.Team is not used.
+func Resume(b *Board, opts Options) (*Game, error) {
+	if len(b.Team) == 0 {
+		return nil, errors.New("a game needs a team")
+	}
+	if opts.Values == nil {
+		opts.Values = DefaultValues
+	}
+	if opts.DailyWage == 0 {
+		opts.DailyWage = DefaultDailyWage
+	}
+	opts.Team = b.Team
+	return &Game{Board: b, opts: opts, roller: dice.New(opts.Seed)}, nil
+}
+
+// Step plays the current day: the strategy's moves are made, then every
+// assigned worker rolls a die and works on their card. Specialists put in
+// the full roll, anyone else half of it; points beyond what the card still
+// needs are lost. Work on a card blocked when the work starts goes into
+// unblocking it instead, at the full roll whoever does it; a card whose
+// blocker is cleared can be worked on and moved again the next day. A
+// blocker that needs no unblocking work takes no work at all. Once the work
+// is done, a card that finished its testing today may fail it and go back
+// to development, and a card deployed today may turn out to have a defect,
+// which is spawned into the first column; see Options.Quality. A strategy
+// asking for something the rules forbid is an error.
+func (g *Game) Step(s Strategy) (DayStats, error) {
+	b := g.Board
+	stats := DayStats{Day: b.Day}
+	dev, test := testTypes(b.Stages)
+	var tested, deployed []*Card
+
+	planning := b.Clone()
+	plan := s.Plan(&planning)
+
+	// 1) moves, in the order the strategy made them
+	for _, m := range plan.Moves {
+		if err := b.Move(m.CardID); err != nil {
+			return stats, fmt.Errorf("day %d: %s: %w", b.Day, s.Name(), err)
+		}
+		stats.Moves++
+		if c := b.Card(m.CardID); c.Stage == b.DoneStage() {
+			stats.Deployed++
+			deployed = append(deployed, c)
+		}
+	}
+
+	// 2) work
+	busy := make(map[uuid.UUID]bool)
+	worked := make(map[uuid.UUID]bool)  // cards worked on today
+	blocked := make(map[uuid.UUID]bool) // cards blocked when work starts
+	for _, c := range b.Cards {
+		if c.Blocker != nil {
+			blocked[c.ID] = true
+		}
+	}
+	for _, a := range plan.Assignments {
+		w, ok := b.Worker(a.WorkerID)
+		if !ok {
+			return stats, fmt.Errorf("day %d: %s: %w: %s", b.Day, s.Name(), ErrUnknownWorker, a.WorkerID)
+		}
+		if busy[w.ID] {
+			return stats, fmt.Errorf("day %d: %s: worker %q assigned twice", b.Day, s.Name(), w.Name)
+		}
+		c := b.Card(a.CardID)
+		if c == nil {
+			return stats, fmt.Errorf("day %d: %s: %w: %s", b.Day, s.Name(), ErrUnknownCard, a.CardID)
+		}
+		if blocked[c.ID] {
+			if c.Blocker != nil && c.Blocker.Effort == 0 {
+				return stats, fmt.Errorf("day %d: %s: %w: %q", b.Day, s.Name(), ErrBlocked, c.Title)
+			}
+			busy[w.ID] = true
+			worked[c.ID] = true
+			if c.Blocker == nil {
+				continue // cleared by someone else today
+			}
+			c.Blocker.Effort -= min(g.roller.Roll(b.Day, w.ID, c.key()), c.Blocker.Effort)
+			if c.Blocker.Effort == 0 {
+				c.Blocker = nil
+			}
+			continue
+		}
+		effortType := b.Stages[c.Stage].EffortType
+		e := c.Effort(effortType)
+		if e == nil || e.Remaining == 0 && !worked[c.ID] {
+			return stats, fmt.Errorf("day %d: %s: %w: %q", b.Day, s.Name(), ErrNoWork, c.Title)
+		}
+		busy[w.ID] = true
+		worked[c.ID] = true
+
+		points := g.roller.Roll(b.Day, w.ID, c.key())
+		if w.Specialty != effortType {
+			points /= 2
+		}
+		points = min(points, e.Remaining)
+		e.Remaining -= points
+		e.Actual += points
+		if effortType == test && points > 0 && e.Remaining == 0 {
+			tested = append(tested, c)
+		}
+	}
+	stats.Working = len(busy)
+
+	// 3) quality
+	for _, c := range tested {
+		if f, ok := g.failTest(c, dev, test); ok {
+			stats.Failures = append(stats.Failures, f)
+		}
+	}
+	for _, c := range deployed {
+		if e, ok := g.escape(c); ok {
+			stats.Escapes = append(stats.Escapes, e)
+		}
+	}
+	stats.Failed, stats.Defects = len(stats.Failures), len(stats.Escapes)
+
+	// 4) books
+	for _, c := range b.Cards {
+		if c.Stage == b.DoneStage() {
+			stats.Revenue += g.opts.Values[c.ValueEstimate]
+		}
+	}
+	stats.Cost = len(b.Team) * g.opts.DailyWage
+	stats.WIP = b.WIP()
+
+	g.days = append(g.days, stats)
+	b.Day++
+	return stats, nil
+}
+
+// Run plays the given number of days and sums them up.
+func (g *Game) Run(s Strategy, days int) (Result, error) {
+	for i := 0; i < days; i++ {
+		if _, err := g.Step(s); err != nil {
+			return Result{}, err
+		}
+	}
+	return g.Result(s.Name()), nil
+}
+
+// Days returns what happened on every day played so far.
+func (g *Game) Days() []DayStats {
+	return append([]DayStats(nil), g.days...)
+}
+
+// ParseTeam reads a team spec like "Analysis=2,Development=3". Workers are
+// named after their specialty and numbered, e.g. "Development 2".
+func ParseTeam(spec string) ([]Worker, error) {
+	var team []Worker
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		specialty, countStr, ok := strings.Cut(part, "=")
+		count, err := strconv.Atoi(strings.TrimSpace(countStr))
+		if !ok || err != nil || count < 0 {
+			return nil, fmt.Errorf("invalid team member %q, want <effort type>=<count>", part)
+		}
+		specialty = strings.TrimSpace(specialty)
+		for i := 1; i <= count; i++ {
+			team = append(team, NewWorker(fmt.Sprintf("%s %d", specialty, i), specialty))
+		}
+	}
+	if len(team) == 0 {
+		return nil, errors.New("team is empty")
+	}
+	return team, nil
+}
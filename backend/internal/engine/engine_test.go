@@ -0,0 +1,446 @@
+package engine_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/config"
+	"github.com/Germanicus1/kanban-sim/backend/internal/engine"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+)
+
+func newGame(t *testing.T, seed int64) *engine.Game {
+	t.Helper()
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, err := engine.ParseTeam(engine.DefaultTeam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := engine.New(*cfg, engine.Options{Seed: seed, Team: team})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestNewBoard_Stages(t *testing.T) {
+	b := newGame(t, 1).Board
+
+	var titles, work []string
+	for _, s := range b.Stages {
+		titles = append(titles, s.Title)
+		work = append(work, s.EffortType)
+	}
+	wantTitles := []string{
+		"Options", "Selected",
+		"Analysis - In Progress", "Analysis - Done",
+		"Development - In Progress", "Development - Done",
+		"Test", "Ready to Deploy", "Deployed",
+	}
+	wantWork := []string{"", "", "Analysis", "", "Development", "", "Testing", "", ""}
+	if !reflect.DeepEqual(titles, wantTitles) {
+		t.Errorf("stages = %v; want %v", titles, wantTitles)
+	}
+	if !reflect.DeepEqual(work, wantWork) {
+		t.Errorf("effort types = %v; want %v", work, wantWork)
+	}
+
+	// S3 sits in "Development - Done": analysis and development are finished
+	for _, c := range b.Cards {
+		if c.Title != "S3" {
+			continue
+		}
+		if c.Effort("Analysis").Remaining != 0 || c.Effort("Development").Remaining != 0 {
+			t.Errorf("S3 efforts = %+v; want analysis and development done", c.Efforts)
+		}
+		if c.Effort("Testing").Remaining != c.Effort("Testing").Estimate {
+			t.Errorf("S3 efforts = %+v; want testing untouched", c.Efforts)
+		}
+	}
+}
+
+func TestBoard_Move(t *testing.T) {
+	b := newGame(t, 1).Board
+
+	var inProgress, backlog *engine.Card
+	for _, c := range b.Cards {
+		switch {
+		case c.Stage == 0 && backlog == nil:
+			backlog = c
+		case b.Stages[c.Stage].Title == "Analysis - In Progress":
+			inProgress = c
+		}
+	}
+
+	if err := b.Move(inProgress.ID); !errors.Is(err, engine.ErrInvalidMove) {
+		t.Errorf("moving a card with work left: err = %v; want %v", err, engine.ErrInvalidMove)
+	}
+
+	if err := b.Move(backlog.ID); err != nil {
+		t.Fatalf("selecting a card: %v", err)
+	}
+	if backlog.Stage != 1 || backlog.SelectedDay != b.Day {
+		t.Errorf("selected card = %+v; want stage 1 on day %d", backlog, b.Day)
+	}
+}
+
+// fixedPlan is a strategy that plans the same day every day.
+type fixedPlan engine.Plan
+
+func (fixedPlan) Name() string                       { return "fixed" }
+func (p fixedPlan) Plan(b *engine.Board) engine.Plan { return engine.Plan(p) }
+
+func TestGame_Step_Blocked(t *testing.T) {
+	g := newGame(t, 1)
+	b := g.Board
+	var card *engine.Card
+	for _, c := range b.Cards {
+		if b.Stages[c.Stage].Title == "Analysis - In Progress" {
+			card = c
+			break
+		}
+	}
+	analysis := *card.Effort("Analysis")
+	card.Blocker = &models.Blocker{Reason: "Waiting for the customer", Day: 1, Effort: 3}
+	if b.RemainingWork(card) != 3 || b.CanMove(card) {
+		t.Fatalf("blocked card: remaining %d, can move %v; want 3 and false", b.RemainingWork(card), b.CanMove(card))
+	}
+
+	// the analyst's work goes into unblocking until the blocker is cleared
+	plan := fixedPlan{Assignments: []engine.Assignment{{WorkerID: b.Team[0].ID, CardID: card.ID}}}
+	for day := 1; card.Blocker != nil; day++ {
+		if day > 3 {
+			t.Fatalf("blocker = %+v after 3 days; want it cleared", card.Blocker)
+		}
+		stats, err := g.Step(plan)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.Working != 1 {
+			t.Errorf("day %d: %d working; want the analyst unblocking", day, stats.Working)
+		}
+		if *card.Effort("Analysis") != analysis {
+			t.Fatalf("day %d: analysis = %+v; want untouched %+v", day, *card.Effort("Analysis"), analysis)
+		}
+	}
+	if _, err := g.Step(plan); err != nil {
+		t.Fatal(err)
+	}
+	if e := card.Effort("Analysis"); e.Actual == analysis.Actual {
+		t.Errorf("analysis = %+v; want work done the day after unblocking", *e)
+	}
+
+	// a blocker without unblocking work takes no work at all
+	card.Blocker = &models.Blocker{Reason: "On hold", Day: b.Day}
+	if _, err := g.Step(plan); !errors.Is(err, engine.ErrBlocked) {
+		t.Errorf("working on a card on hold: err = %v; want %v", err, engine.ErrBlocked)
+	}
+	s, err := engine.StrategyByName("strict-wip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stage := card.Stage
+	for day := 0; day < 10; day++ {
+		if _, err := g.Step(s); err != nil {
+			t.Fatalf("strict-wip with a card on hold: %v", err)
+		}
+	}
+	if card.Stage != stage || card.Blocker == nil {
+		t.Errorf("card on hold = %+v; want it left in stage %d", card, stage)
+	}
+}
+
+func TestGame_Step_Quality(t *testing.T) {
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Quality = models.Quality{TestFailure: 1, Rework: 0.5, EscapedDefect: 1, DefectEffort: 0.5}
+	team, _ := engine.ParseTeam(engine.DefaultTeam)
+	g, err := engine.New(*cfg, engine.Options{Seed: 1, Team: team})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := g.Board
+
+	// one card about to finish its testing, another ready to deploy
+	tested, deployed := b.Cards[0], b.Cards[1]
+	for _, c := range []*engine.Card{tested, deployed} {
+		for i := range c.Efforts {
+			c.Efforts[i].Remaining, c.Efforts[i].Actual = 0, c.Efforts[i].Estimate
+		}
+	}
+	tested.Stage = b.DoneStage() - 2
+	tested.Effort("Testing").Remaining = 1
+	deployed.Stage = b.DoneStage() - 1
+	tester := b.Team[len(b.Team)-1]
+
+	stats, err := g.Step(fixedPlan{
+		Moves:       []engine.Move{{CardID: deployed.ID}},
+		Assignments: []engine.Assignment{{WorkerID: tester.ID, CardID: tested.ID}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the tested card fails and goes back to development
+	if stats.Failed != 1 || stats.Failures[0].CardID != tested.ID {
+		t.Fatalf("failures = %+v; want %s", stats.Failures, tested.Title)
+	}
+	if got := b.Stages[tested.Stage].Title; got != "Development - In Progress" {
+		t.Errorf("failed card is in %q; want it back in development", got)
+	}
+	dev, test := tested.Effort("Development"), tested.Effort("Testing")
+	if want := (dev.Estimate + 1) / 2; dev.Remaining != want || stats.Failures[0].Rework != want {
+		t.Errorf("development = %+v, rework %d; want %d to redo", *dev, stats.Failures[0].Rework, want)
+	}
+	if want := (test.Estimate + 1) / 2; test.Remaining != want {
+		t.Errorf("testing = %+v; want %d to redo", *test, want)
+	}
+
+	// the deployed card has a defect, spawned into the backlog as expedite
+	if stats.Defects != 1 || stats.Escapes[0].CardID != deployed.ID {
+		t.Fatalf("escapes = %+v; want %s", stats.Escapes, deployed.Title)
+	}
+	defect := b.Card(stats.Escapes[0].DefectID)
+	if defect == nil || defect.Stage != 0 || defect.ClassOfService != engine.ClassExpedite {
+		t.Fatalf("defect = %+v; want an expedite card in the first column", defect)
+	}
+	for _, e := range defect.Efforts {
+		if e.Remaining != e.Estimate || e.Estimate != max((deployed.Effort(e.Type).Estimate+1)/2, 1) {
+			t.Errorf("defect effort = %+v; want half of %s's, rounded up", e, deployed.Title)
+		}
+	}
+
+	// strategies start it before anything else
+	s, err := engine.StrategyByName("highest-value-first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	planning := b.Clone()
+	for _, m := range s.Plan(&planning).Moves {
+		if c := b.Card(m.CardID); c.Stage == 0 {
+			if c.ID != defect.ID {
+				t.Errorf("started %q first; want the defect", c.Title)
+			}
+			return
+		}
+	}
+	t.Error("no card started; want the defect")
+}
+
+func TestGame_Run_Reproducible(t *testing.T) {
+	for _, name := range engine.Strategies() {
+		t.Run(name, func(t *testing.T) {
+			s, err := engine.StrategyByName(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			first, err := newGame(t, 7).Run(s, 15)
+			if err != nil {
+				t.Fatal(err)
+			}
+			second, err := newGame(t, 7).Run(s, 15)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if first != second {
+				t.Errorf("same seed, different results:\n%+v\n%+v", first, second)
+			}
+			if first.Days != 15 || first.Deployed == 0 {
+				t.Errorf("result = %+v; want 15 days with deployments", first)
+			}
+		})
+	}
+}
+
+func TestBoard_Progress_MatchesResult(t *testing.T) {
+	s, err := engine.StrategyByName("strict-wip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := newGame(t, 7)
+	r, err := g.Run(s, 15)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixed, fixedDone := 0, 0
+	for _, c := range g.Board.Cards {
+		if c.ClassOfService == engine.ClassFixedDate {
+			fixed++
+			if c.Stage == g.Board.DoneStage() {
+				fixedDone++
+			}
+		}
+	}
+	if fixed == 0 {
+		t.Fatal("the default board has no fixed-date cards")
+	}
+
+	got := g.Board.Progress(nil, 0)
+	want := engine.Progress{
+		Days:       r.Days,
+		WIP:        g.Board.WIP(),
+		Deployed:   r.Deployed,
+		Throughput: r.Throughput,
+		LeadTime:   r.LeadTime,
+		Financials: r.Financials,
+
+		FixedDateHitRate: math.Round(float64(fixedDone)/float64(fixed)*100) / 100,
+	}
+	if got != want {
+		t.Errorf("Progress() = %+v; want %+v", got, want)
+	}
+}
+
+func TestStrictWIP_RespectsLimits(t *testing.T) {
+	// rework sends failed cards back whatever the limits, so play without it
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Quality = models.Quality{}
+	team, _ := engine.ParseTeam(engine.DefaultTeam)
+	g, err := engine.New(*cfg, engine.Options{Seed: 3, Team: team})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := engine.StrategyByName("strict-wip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the scenario starts within its limits, so it must stay there
+	for day := 0; day < 20; day++ {
+		if _, err := g.Step(s); err != nil {
+			t.Fatal(err)
+		}
+		seen := make(map[string]bool)
+		for _, st := range g.Board.Stages {
+			if st.WIPLimit == 0 || seen[st.Group] {
+				continue
+			}
+			seen[st.Group] = true
+			if wip := g.Board.GroupWIP(st.Group); wip > st.WIPLimit {
+				t.Fatalf("day %d: %q holds %d cards; limit %d", g.Board.Day-1, st.Group, wip, st.WIPLimit)
+			}
+		}
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	team, _ := engine.ParseTeam("Build=1")
+
+	tests := []struct {
+		name string
+		cfg  models.Board
+		team []engine.Worker
+	}{
+		{name: "no team", cfg: models.Board{}},
+		{name: "no done column", team: team, cfg: models.Board{
+			Columns: []models.Column{{Title: "Todo", Type: "queue"}},
+		}},
+		{name: "card in unknown column", team: team, cfg: models.Board{
+			Columns: []models.Column{{Title: "Todo", Type: "queue"}, {Title: "Done", Type: "done", OrderIndex: 1}},
+			Cards:   []models.Card{{Title: "C1", ColumnTitle: "Nowhere"}},
+		}},
+		{name: "active column without effort type", team: team, cfg: models.Board{
+			Columns: []models.Column{
+				{Title: "Todo", Type: "queue"},
+				{Title: "Build", Type: "active", OrderIndex: 1},
+				{Title: "Done", Type: "done", OrderIndex: 2},
+			},
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := engine.New(tc.cfg, engine.Options{Team: tc.team}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestParseTeam(t *testing.T) {
+	team, err := engine.ParseTeam("Analysis=1, Testing=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, w := range team {
+		names = append(names, w.Name+"/"+w.Specialty)
+	}
+	want := []string{"Analysis 1/Analysis", "Testing 1/Testing", "Testing 2/Testing"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("team = %v; want %v", names, want)
+	}
+
+	for _, spec := range []string{"", "Analysis", "Analysis=x"} {
+		if _, err := engine.ParseTeam(spec); err == nil {
+			t.Errorf("ParseTeam(%q): expected an error", spec)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	for p, want := range map[float64]int{50: 5, 85: 9, 100: 10, 0: 1} {
+		if got := engine.Percentile(values, p); got != want {
+			t.Errorf("Percentile(%v) = %d; want %d", p, got, want)
+		}
+	}
+}
+
+func TestHighestValueFirst_StartsMostValuable(t *testing.T) {
+	g := newGame(t, 5)
+	s, err := engine.StrategyByName("highest-value-first")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	best := 0
+	for _, c := range g.Board.CardsIn(0) {
+		best = max(best, engine.DefaultValues[c.ValueEstimate])
+	}
+
+	planning := g.Board.Clone()
+	plan := s.Plan(&planning)
+	for _, m := range plan.Moves {
+		c := g.Board.Card(m.CardID)
+		if c.Stage != 0 {
+			continue
+		}
+		if v := engine.DefaultValues[c.ValueEstimate]; v != best {
+			t.Errorf("first card started is worth %d a day; want %d", v, best)
+		}
+		return
+	}
+	t.Fatal("no card started on the first day")
+}
+
+func TestFinishBeforeStart_LimitsWIP(t *testing.T) {
+	g := newGame(t, 9)
+	s, err := engine.StrategyByName("finish-before-start")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for day := 0; day < 20; day++ {
+		planning := g.Board.Clone()
+		s.Plan(&planning)
+		// new work is only started while fewer cards than specialties are in progress
+		if wip := planning.WIP(); wip > 3 && wip > g.Board.WIP() {
+			t.Fatalf("day %d: plan raises WIP from %d to %d", g.Board.Day, g.Board.WIP(), wip)
+		}
+		if _, err := g.Step(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
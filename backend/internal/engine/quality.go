@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// tester and customer roll the dice of test failures and escaped defects,
+// apart from those of any worker.
+var (
+	tester   = uuid.MustParse("4f6d8b2a-7c1e-4a3f-b590-2e8d6c4a1b73")
+	customer = uuid.MustParse("d2a7e5c9-1f8b-4e6a-93c4-6b0f2d8e5a17")
+)
+
+// Failure is a card that failed its testing and went back to be reworked.
+type Failure struct {
+	CardID uuid.UUID
+	From   int // stage the card failed in
+	To     int // stage it went back to
+	Rework int // development effort restored
+}
+
+// Escape is a defect found in a card deployed today.
+type Escape struct {
+	CardID   uuid.UUID // the deployed card
+	DefectID uuid.UUID // the defect, spawned into the first stage
+}
+
+// testTypes returns the effort types of development and testing: the last
+// two done on the board, in order. Either is empty when there are fewer.
+func testTypes(stages []Stage) (dev, test string) {
+	for _, s := range stages {
+		if s.EffortType != "" && s.EffortType != test {
+			dev, test = test, s.EffortType
+		}
+	}
+	return dev, test
+}
+
+// chance reports whether something that happens with probability p happens
+// to a card today, by the die of the one who rolls for it.
+func (g *Game) chance(p float64, roller uuid.UUID, c *Card) bool {
+	return p > 0 && g.roller.Stream(g.Board.Day, roller, c.key()).Float64() < p
+}
+
+// share is the given share of an estimate, rounded up.
+func share(p float64, estimate int) int {
+	return int(math.Ceil(p * float64(estimate)))
+}
+
+// failTest may fail a card that has just finished its testing. A failed
+// card goes back to the first stage of development, where the rework share
+// of its development estimate needs doing again, and as much of its testing
+// after that.
+func (g *Game) failTest(c *Card, dev, test string) (Failure, bool) {
+	q := g.opts.Quality
+	to, ok := workStages(g.Board.Stages)[dev]
+	if !ok || !g.chance(q.TestFailure, tester, c) {
+		return Failure{}, false
+	}
+
+	f := Failure{CardID: c.ID, From: c.Stage, To: to}
+	if e := c.Effort(dev); e != nil {
+		f.Rework = share(q.Rework, e.Estimate)
+		e.Remaining += f.Rework
+	}
+	if e := c.Effort(test); e != nil {
+		e.Remaining += share(q.Rework, e.Estimate)
+	}
+	c.Stage = to
+	return f, true
+}
+
+// escape may find a defect in a card deployed today. The defect is spawned
+// into the first stage as an expedite card that needs the defect share of
+// each of the card's estimates, at least a point of each, and earns nothing
+// once deployed. Its ID is derived from the card's, so a card has one
+// defect at most.
+func (g *Game) escape(c *Card) (Escape, bool) {
+	q := g.opts.Quality
+	id := uuid.NewSHA1(defectNamespace, c.ID[:])
+	if g.Board.Card(id) != nil || !g.chance(q.EscapedDefect, customer, c) {
+		return Escape{}, false
+	}
+
+	d := &Card{ID: id, Title: c.Title + " defect", ClassOfService: ClassExpedite}
+	for _, e := range c.Efforts {
+		est := max(share(q.DefectEffort, e.Estimate), 1)
+		d.Efforts = append(d.Efforts, Effort{Type: e.Type, Estimate: est, Remaining: est})
+	}
+	g.Board.Cards = append(g.Board.Cards, d)
+	return Escape{CardID: c.ID, DefectID: id}, true
+}
@@ -0,0 +1,185 @@
+package engine
+
+import (
+	"cmp"
+	"math"
+	"sort"
+)
+
+// Result sums up a game.
+type Result struct {
+	Strategy    string        `json:"strategy"`
+	Seed        int64         `json:"seed"`
+	Days        int           `json:"days"`
+	Deployed    int           `json:"deployed"`
+	Failed      int           `json:"failed"`      // test failures
+	Defects     int           `json:"defects"`     // defects found after deployment
+	Throughput  float64       `json:"throughput"`  // cards deployed per day
+	Utilisation float64       `json:"utilisation"` // share of worker-days spent working
+	LeadTime    LeadTimeStats `json:"leadTime"`
+	WIP         WIPStats      `json:"wip"`
+	Financials  Financials    `json:"financials"`
+}
+
+// LeadTimeStats describes the days from selection to deployment of the cards
+// deployed during the game.
+type LeadTimeStats struct {
+	Mean float64 `json:"mean"`
+	P50  int     `json:"p50"`
+	P85  int     `json:"p85"`
+	Max  int     `json:"max"`
+}
+
+// WIPStats describes the cards in progress at the end of each day.
+type WIPStats struct {
+	Mean float64 `json:"mean"`
+	Max  int     `json:"max"`
+}
+
+// Financials are the game's books.
+type Financials struct {
+	Revenue int `json:"revenue"`
+	Cost    int `json:"cost"`
+	Profit  int `json:"profit"`
+}
+
+// Result sums up the days played so far.
+func (g *Game) Result(strategy string) Result {
+	r := Result{Strategy: strategy, Seed: g.opts.Seed, Days: len(g.days)}
+	if r.Days == 0 {
+		return r
+	}
+
+	working := 0
+	for _, d := range g.days {
+		r.Deployed += d.Deployed
+		r.Failed += d.Failed
+		r.Defects += d.Defects
+		working += d.Working
+		r.WIP.Mean += float64(d.WIP)
+		r.WIP.Max = max(r.WIP.Max, d.WIP)
+		r.Financials.Revenue += d.Revenue
+		r.Financials.Cost += d.Cost
+	}
+	r.Financials.Profit = r.Financials.Revenue - r.Financials.Cost
+	r.Throughput = round2(float64(r.Deployed) / float64(r.Days))
+	r.WIP.Mean = round2(r.WIP.Mean / float64(r.Days))
+	if team := len(g.Board.Team); team > 0 {
+		r.Utilisation = round2(float64(working) / float64(team*r.Days))
+	}
+
+	r.LeadTime = leadTimeStats(g.leadTimes())
+
+	return r
+}
+
+// leadTimes lists the lead times of the cards deployed during the days
+// played so far.
+func (g *Game) leadTimes() []int {
+	if len(g.days) == 0 {
+		return nil
+	}
+	first := g.days[0].Day
+	var out []int
+	for _, c := range g.Board.Cards {
+		if c.DeployedDay >= first && c.SelectedDay > 0 {
+			out = append(out, c.DeployedDay-c.SelectedDay)
+		}
+	}
+	return out
+}
+
+// Progress is where a game stands on its board's day, worked out from the
+// board alone.
+type Progress struct {
+	Days       int           `json:"days"` // days played, those before the board's day
+	WIP        int           `json:"wip"`
+	Deployed   int           `json:"deployed"`
+	Throughput float64       `json:"throughput"`
+	LeadTime   LeadTimeStats `json:"leadTime"`
+	Financials Financials    `json:"financials"`
+	// FixedDateHitRate is the share of fixed-date cards deployed. Cards
+	// carry no due day, so the day the board is on counts as theirs.
+	FixedDateHitRate float64 `json:"fixedDateHitRate"`
+}
+
+// Progress sums up a game whose days were not played in memory, such as a
+// live game laid out by LoadBoard. As in Step, a deployed card earns its
+// value on every day from the one it was deployed on, and the whole team is
+// paid for every day played. Values and dailyWage default like in Options.
+func (b *Board) Progress(values map[string]int, dailyWage int) Progress {
+	if values == nil {
+		values = DefaultValues
+	}
+	if dailyWage == 0 {
+		dailyWage = DefaultDailyWage
+	}
+	s := Progress{Days: max(b.Day-1, 0), WIP: b.WIP()}
+	if s.Days == 0 {
+		return s
+	}
+
+	var (
+		leadTimes        []int
+		fixed, fixedDone int
+	)
+	for _, c := range b.Cards {
+		if c.ClassOfService == ClassFixedDate {
+			fixed++
+			if c.Stage == b.DoneStage() {
+				fixedDone++
+			}
+		}
+		if c.Stage != b.DoneStage() {
+			continue
+		}
+		s.Financials.Revenue += values[c.ValueEstimate] * (b.Day - max(c.DeployedDay, 1))
+		if c.DeployedDay < 1 {
+			continue
+		}
+		s.Deployed++
+		if c.SelectedDay > 0 {
+			leadTimes = append(leadTimes, c.DeployedDay-c.SelectedDay)
+		}
+	}
+	s.Financials.Cost = len(b.Team) * dailyWage * s.Days
+	s.Financials.Profit = s.Financials.Revenue - s.Financials.Cost
+	s.Throughput = round2(float64(s.Deployed) / float64(s.Days))
+	s.LeadTime = leadTimeStats(leadTimes)
+	if fixed > 0 {
+		s.FixedDateHitRate = round2(float64(fixedDone) / float64(fixed))
+	}
+	return s
+}
+
+func leadTimeStats(days []int) LeadTimeStats {
+	if len(days) == 0 {
+		return LeadTimeStats{}
+	}
+	sort.Ints(days)
+	sum := 0
+	for _, d := range days {
+		sum += d
+	}
+	return LeadTimeStats{
+		Mean: round2(float64(sum) / float64(len(days))),
+		P50:  Percentile(days, 50),
+		P85:  Percentile(days, 85),
+		Max:  days[len(days)-1],
+	}
+}
+
+// Percentile returns the nearest-rank percentile p of sorted values.
+func Percentile[T cmp.Ordered](sorted []T, p float64) T {
+	if len(sorted) == 0 {
+		var zero T
+		return zero
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	rank = min(max(rank, 1), len(sorted))
+	return sorted[rank-1]
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
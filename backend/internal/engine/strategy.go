@@ -0,0 +1,250 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Plan is what a strategy decides for one day.
+type Plan struct {
+	// Moves are made first, in order. Moving a card out of the first column
+	// pulls it into the system.
+	Moves []Move
+	// Assignments put workers on cards once the moves are made. Workers left
+	// out stay idle.
+	Assignments []Assignment
+}
+
+// Strategy takes the team's decisions. Plan is handed a copy of the board,
+// team included, so it may make its moves on it while planning and assign
+// workers on the resulting board.
+type Strategy interface {
+	Name() string
+	Plan(b *Board) Plan
+}
+
+var strategies = map[string]func() Strategy{
+	"strict-wip":           func() Strategy { return strictWIP{} },
+	"maximise-utilisation": func() Strategy { return maximiseUtilisation{} },
+	"highest-value-first":  func() Strategy { return highestValueFirst{} },
+	"finish-before-start":  func() Strategy { return finishBeforeStart{} },
+}
+
+// Strategies lists the names of the built-in strategies.
+func Strategies() []string {
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// StrategyByName returns a built-in strategy.
+func StrategyByName(name string) (Strategy, error) {
+	mk, ok := strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy %q, want one of %v", name, Strategies())
+	}
+	return mk(), nil
+}
+
+// expected points of a die roll, for planning
+const (
+	expectedSpecialist = 4
+	expectedOther      = 2
+)
+
+// strictWIP never breaks a WIP limit. It moves cards right to left so that
+// finishing frees room for starting, and has everyone swarm on the card
+// closest to done, helping outside their specialty when they run out of it.
+type strictWIP struct{}
+
+func (strictWIP) Name() string { return "strict-wip" }
+
+func (strictWIP) Plan(b *Board) Plan {
+	moves := pullRightToLeft(b, b.CardsIn(0), b.HasRoom)
+	return Plan{Moves: moves, Assignments: assignLeastLeft(b, nil)}
+}
+
+// maximiseUtilisation ignores WIP limits and starts new work whenever that
+// gives somebody their own card to work on, spreading the team over as many
+// cards as it can.
+type maximiseUtilisation struct{}
+
+func (maximiseUtilisation) Name() string { return "maximise-utilisation" }
+
+func (maximiseUtilisation) Plan(b *Board) Plan {
+	moves := pullRightToLeft(b, b.CardsIn(0), func(c *Card) bool {
+		return c.Stage > 0 || len(b.CardsIn(1)) < len(b.Team)
+	})
+
+	workers := make(map[uuid.UUID]int) // workers on each card
+	var out []Assignment
+	for _, w := range b.Team {
+		var best *Card
+		own := false
+		for _, c := range workable(b) {
+			isOwn := b.Stages[c.Stage].EffortType == w.Specialty
+			switch {
+			case best == nil, isOwn && !own, isOwn == own && workers[c.ID] < workers[best.ID]:
+				best, own = c, isOwn
+			}
+		}
+		if best == nil {
+			continue
+		}
+		workers[best.ID]++
+		out = append(out, Assignment{WorkerID: w.ID, CardID: best.ID})
+	}
+	return Plan{Moves: moves, Assignments: out}
+}
+
+// highestValueFirst respects WIP limits, starts the most valuable cards of
+// the backlog first and puts workers on the most valuable card they can
+// work on.
+type highestValueFirst struct{}
+
+func (highestValueFirst) Name() string { return "highest-value-first" }
+
+func (highestValueFirst) Plan(b *Board) Plan {
+	backlog := b.CardsIn(0)
+	sort.SliceStable(backlog, func(i, j int) bool { return value(backlog[i]) > value(backlog[j]) })
+	moves := pullRightToLeft(b, backlog, b.HasRoom)
+
+	return Plan{Moves: moves, Assignments: assignLeastLeft(b, func(a, c *Card) bool {
+		return value(a) > value(c)
+	})}
+}
+
+// finishBeforeStart respects WIP limits and keeps no more cards in progress
+// than the team has specialties. The whole team swarms on the card closest
+// to done before touching the next one.
+type finishBeforeStart struct{}
+
+func (finishBeforeStart) Name() string { return "finish-before-start" }
+
+func (finishBeforeStart) Plan(b *Board) Plan {
+	specialties := make(map[string]bool)
+	for _, w := range b.Team {
+		specialties[w.Specialty] = true
+	}
+	moves := pullRightToLeft(b, b.CardsIn(0), func(c *Card) bool {
+		if c.Stage == 0 && b.WIP() >= len(specialties) {
+			return false
+		}
+		return b.HasRoom(c)
+	})
+
+	planned := make(map[uuid.UUID]int) // points expected to be put in today
+	var out []Assignment
+	for _, w := range b.Team {
+		var best *Card
+		for _, c := range workable(b) {
+			if b.RemainingWork(c)-planned[c.ID] <= 0 {
+				continue
+			}
+			if best == nil || c.Stage > best.Stage {
+				best = c
+			}
+		}
+		if best == nil {
+			continue
+		}
+		planned[best.ID] += expectedPoints(b, w, best)
+		out = append(out, Assignment{WorkerID: w.ID, CardID: best.ID})
+	}
+	return Plan{Moves: moves, Assignments: out}
+}
+
+// assignLeastLeft puts every worker on a card with work left, preferring
+// their own specialty, then cards first by better (if given), then the card
+// with the least work left once the workers already on it are counted.
+func assignLeastLeft(b *Board, better func(a, c *Card) bool) []Assignment {
+	planned := make(map[uuid.UUID]int) // points expected to be put in today
+	left := func(c *Card) int { return b.RemainingWork(c) - planned[c.ID] }
+
+	var out []Assignment
+	for _, w := range b.Team {
+		var best *Card
+		own := false
+		for _, c := range workable(b) {
+			isOwn := b.Stages[c.Stage].EffortType == w.Specialty
+			switch {
+			case left(c) <= 0:
+				continue
+			case best == nil, isOwn && !own:
+				best, own = c, isOwn
+			case isOwn != own:
+				continue
+			case better != nil && better(c, best):
+				best = c
+			case better != nil && better(best, c):
+				continue
+			case left(c) < left(best):
+				best = c
+			}
+		}
+		if best == nil {
+			continue
+		}
+		planned[best.ID] += expectedPoints(b, w, best)
+		out = append(out, Assignment{WorkerID: w.ID, CardID: best.ID})
+	}
+	return out
+}
+
+// expectedPoints is what a worker is expected to put into a card today.
+func expectedPoints(b *Board, w Worker, c *Card) int {
+	if b.Stages[c.Stage].EffortType == w.Specialty {
+		return expectedSpecialist
+	}
+	return expectedOther
+}
+
+// value is the daily revenue a card earns once deployed.
+func value(c *Card) int {
+	return DefaultValues[c.ValueEstimate]
+}
+
+// pullRightToLeft moves every card as far right as the rules and allow let
+// it, starting with the column closest to done. Cards still in the backlog
+// are started last: expedite cards first, then the rest in the order given.
+func pullRightToLeft(b *Board, backlog []*Card, allow func(*Card) bool) []Move {
+	backlog = append([]*Card(nil), backlog...)
+	sort.SliceStable(backlog, func(i, j int) bool {
+		return backlog[i].ClassOfService == ClassExpedite && backlog[j].ClassOfService != ClassExpedite
+	})
+
+	var moves []Move
+	advance := func(c *Card) {
+		for b.CanMove(c) && allow(c) {
+			if err := b.Move(c.ID); err != nil {
+				break
+			}
+			moves = append(moves, Move{CardID: c.ID})
+		}
+	}
+	for stage := b.DoneStage() - 1; stage > 0; stage-- {
+		for _, c := range b.CardsIn(stage) {
+			advance(c)
+		}
+	}
+	for _, c := range backlog {
+		advance(c)
+	}
+	return moves
+}
+
+// workable lists the cards with work left in their current column.
+func workable(b *Board) []*Card {
+	var out []*Card
+	for _, c := range b.Cards {
+		if b.RemainingWork(c) > 0 {
+			out = append(out, c)
+		}
+	}
+	return out
+}
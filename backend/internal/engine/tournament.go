@@ -0,0 +1,242 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+)
+
+// ScenarioLimits names the WIP limit setting that keeps the scenario's own
+// limits.
+const ScenarioLimits = "scenario"
+
+// WIPSetting overrides the WIP limits of some top-level columns. Columns it
+// leaves out keep the scenario's limit; 0 removes a limit.
+type WIPSetting struct {
+	Name   string
+	Limits map[string]int // by column title
+}
+
+// ParseWIPSetting reads a setting like "Development=4,Test=3". The spec
+// itself becomes the setting's name; ScenarioLimits overrides nothing.
+func ParseWIPSetting(spec string) (WIPSetting, error) {
+	spec = strings.TrimSpace(spec)
+	ws := WIPSetting{Name: spec, Limits: make(map[string]int)}
+	if spec == ScenarioLimits {
+		return ws, nil
+	}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		column, limitStr, ok := strings.Cut(part, "=")
+		limit, err := strconv.Atoi(strings.TrimSpace(limitStr))
+		if !ok || err != nil || limit < 0 {
+			return WIPSetting{}, fmt.Errorf("invalid WIP limit %q, want <column>=<limit>", part)
+		}
+		ws.Limits[strings.TrimSpace(column)] = limit
+	}
+	if len(ws.Limits) == 0 {
+		return WIPSetting{}, errors.New("WIP limit setting is empty")
+	}
+	return ws, nil
+}
+
+// Apply returns a copy of the scenario with the setting's limits.
+func (ws WIPSetting) Apply(scenario models.Board) (models.Board, error) {
+	out := scenario
+	out.Columns = append([]models.Column(nil), scenario.Columns...)
+
+	seen := make(map[string]bool)
+	for i, col := range out.Columns {
+		if limit, ok := ws.Limits[col.Title]; ok {
+			out.Columns[i].WIPLimit = limit
+			seen[col.Title] = true
+		}
+	}
+	for title := range ws.Limits {
+		if !seen[title] {
+			return models.Board{}, fmt.Errorf("WIP limit for unknown column %q", title)
+		}
+	}
+	return out, nil
+}
+
+// Tournament plays every strategy with every WIP limit setting and seed
+// against one scenario.
+type Tournament struct {
+	Scenario   models.Board
+	Team       []Worker
+	Days       int
+	Strategies []string
+	WIPLimits  []WIPSetting
+	Seeds      []int64
+	Parallel   int // games played at once; runtime.NumCPU() if 0
+}
+
+// Standing sums up the games a strategy played with one WIP limit setting.
+type Standing struct {
+	Strategy   string        `json:"strategy"`
+	WIPLimits  string        `json:"wipLimits"`
+	Runs       int           `json:"runs"`
+	LeadTime   LeadTimeStats `json:"leadTime"`   // over the cards deployed in all runs
+	Throughput Spread        `json:"throughput"` // cards deployed per day
+	Profit     Spread        `json:"profit"`
+}
+
+// Spread describes a figure over the runs of an experiment.
+type Spread struct {
+	Mean float64 `json:"mean"`
+	P15  float64 `json:"p15"`
+	P50  float64 `json:"p50"`
+	P85  float64 `json:"p85"`
+}
+
+// run is one game of a tournament.
+type run struct {
+	cell      int // index of the strategy and WIP limit setting
+	seed      int64
+	result    Result
+	leadTimes []int
+	err       error
+}
+
+// Run plays all games, spread over Parallel goroutines, and returns a
+// standing per strategy and WIP limit setting, the most profitable first.
+// The outcome does not depend on the number of goroutines.
+func (t Tournament) Run() ([]Standing, error) {
+	if t.Days <= 0 {
+		return nil, errors.New("days must be positive")
+	}
+	if len(t.Strategies) == 0 || len(t.WIPLimits) == 0 || len(t.Seeds) == 0 {
+		return nil, errors.New("tournament needs strategies, WIP limit settings and seeds")
+	}
+	scenarios := make([]models.Board, len(t.WIPLimits))
+	for i, ws := range t.WIPLimits {
+		sc, err := ws.Apply(t.Scenario)
+		if err != nil {
+			return nil, err
+		}
+		scenarios[i] = sc
+	}
+	for _, name := range t.Strategies {
+		if _, err := StrategyByName(name); err != nil {
+			return nil, err
+		}
+	}
+
+	// every game gets its own slot, so the order of the results is fixed
+	runs := make([]run, 0, len(t.Strategies)*len(t.WIPLimits)*len(t.Seeds))
+	for si := range t.Strategies {
+		for wi := range t.WIPLimits {
+			for _, seed := range t.Seeds {
+				runs = append(runs, run{cell: si*len(t.WIPLimits) + wi, seed: seed})
+			}
+		}
+	}
+
+	parallel := t.Parallel
+	if parallel <= 0 {
+		parallel = runtime.NumCPU()
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(parallel, len(runs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				r := &runs[i]
+				strategy := t.Strategies[r.cell/len(t.WIPLimits)]
+				r.result, r.leadTimes, r.err = t.play(scenarios[r.cell%len(t.WIPLimits)], strategy, r.seed)
+			}
+		}()
+	}
+	for i := range runs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	standings := make([]Standing, len(t.Strategies)*len(t.WIPLimits))
+	cells := make([][]run, len(standings))
+	for _, r := range runs {
+		if r.err != nil {
+			return nil, fmt.Errorf("%s with WIP limits %s, seed %d: %w",
+				t.Strategies[r.cell/len(t.WIPLimits)], t.WIPLimits[r.cell%len(t.WIPLimits)].Name, r.seed, r.err)
+		}
+		cells[r.cell] = append(cells[r.cell], r)
+	}
+	for i, cell := range cells {
+		standings[i] = standing(t.Strategies[i/len(t.WIPLimits)], t.WIPLimits[i%len(t.WIPLimits)].Name, cell)
+	}
+	slices.SortStableFunc(standings, func(a, b Standing) int {
+		switch {
+		case a.Profit.Mean > b.Profit.Mean:
+			return -1
+		case a.Profit.Mean < b.Profit.Mean:
+			return 1
+		}
+		return 0
+	})
+	return standings, nil
+}
+
+// play plays one game of the tournament. Games share nothing, so any number
+// of them can be played at once.
+func (t Tournament) play(scenario models.Board, strategy string, seed int64) (Result, []int, error) {
+	s, err := StrategyByName(strategy)
+	if err != nil {
+		return Result{}, nil, err
+	}
+	g, err := New(scenario, Options{Seed: seed, Team: t.Team})
+	if err != nil {
+		return Result{}, nil, err
+	}
+	result, err := g.Run(s, t.Days)
+	if err != nil {
+		return Result{}, nil, err
+	}
+	return result, g.leadTimes(), nil
+}
+
+func standing(strategy, wip string, runs []run) Standing {
+	st := Standing{Strategy: strategy, WIPLimits: wip, Runs: len(runs)}
+
+	var leadTimes []int
+	throughput := make([]float64, 0, len(runs))
+	profit := make([]float64, 0, len(runs))
+	for _, r := range runs {
+		leadTimes = append(leadTimes, r.leadTimes...)
+		throughput = append(throughput, r.result.Throughput)
+		profit = append(profit, float64(r.result.Financials.Profit))
+	}
+	st.LeadTime = leadTimeStats(leadTimes)
+	st.Throughput = spread(throughput)
+	st.Profit = spread(profit)
+	return st
+}
+
+func spread(values []float64) Spread {
+	if len(values) == 0 {
+		return Spread{}
+	}
+	slices.Sort(values)
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return Spread{
+		Mean: round2(sum / float64(len(values))),
+		P15:  Percentile(values, 15),
+		P50:  Percentile(values, 50),
+		P85:  Percentile(values, 85),
+	}
+}
@@ -0,0 +1,112 @@
+package engine_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/config"
+	"github.com/Germanicus1/kanban-sim/backend/internal/engine"
+)
+
+func TestParseWIPSetting(t *testing.T) {
+	ws, err := engine.ParseWIPSetting(" Development=4, Testing=0 ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"Development": 4, "Testing": 0}
+	if ws.Name != "Development=4, Testing=0" || !reflect.DeepEqual(ws.Limits, want) {
+		t.Errorf("ParseWIPSetting = %+v; want limits %v", ws, want)
+	}
+
+	if ws, err := engine.ParseWIPSetting(engine.ScenarioLimits); err != nil || len(ws.Limits) != 0 {
+		t.Errorf("ParseWIPSetting(%q) = %+v, %v; want no overrides", engine.ScenarioLimits, ws, err)
+	}
+	for _, spec := range []string{"", "Development", "Development=-1", "Development=many"} {
+		if _, err := engine.ParseWIPSetting(spec); err == nil {
+			t.Errorf("ParseWIPSetting(%q) succeeded; want an error", spec)
+		}
+	}
+}
+
+func TestWIPSetting_Apply(t *testing.T) {
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ws, _ := engine.ParseWIPSetting("Development=9")
+	got, err := ws.Apply(*cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, col := range got.Columns {
+		want := cfg.Columns[i].WIPLimit
+		if col.Title == "Development" {
+			want = 9
+		}
+		if col.WIPLimit != want {
+			t.Errorf("%s: WIP limit %d; want %d", col.Title, col.WIPLimit, want)
+		}
+	}
+	for _, col := range cfg.Columns {
+		if col.Title == "Development" && col.WIPLimit == 9 {
+			t.Error("Apply changed the scenario it was given")
+		}
+	}
+
+	ws, _ = engine.ParseWIPSetting("Design=2")
+	if _, err := ws.Apply(*cfg); err == nil {
+		t.Error("Apply with an unknown column succeeded; want an error")
+	}
+}
+
+func TestTournament_Run(t *testing.T) {
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, err := engine.ParseTeam(engine.DefaultTeam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tight, _ := engine.ParseWIPSetting("Development=2")
+
+	tournament := func(parallel int) []engine.Standing {
+		standings, err := engine.Tournament{
+			Scenario:   *cfg,
+			Team:       team,
+			Days:       10,
+			Strategies: []string{"strict-wip", "maximise-utilisation"},
+			WIPLimits:  []engine.WIPSetting{{Name: engine.ScenarioLimits}, tight},
+			Seeds:      []int64{1, 2, 3, 4},
+			Parallel:   parallel,
+		}.Run()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return standings
+	}
+
+	serial := tournament(1)
+	if !reflect.DeepEqual(serial, tournament(8)) {
+		t.Error("standings depend on the number of goroutines")
+	}
+	if len(serial) != 4 {
+		t.Fatalf("standings = %d; want one per strategy and WIP limit setting", len(serial))
+	}
+	for i, s := range serial {
+		if s.Runs != 4 || s.LeadTime.Max == 0 || s.Throughput.Mean == 0 {
+			t.Errorf("standing %+v; want 4 runs with deployments", s)
+		}
+		if i > 0 && s.Profit.Mean > serial[i-1].Profit.Mean {
+			t.Errorf("standing %d is more profitable than standing %d", i, i-1)
+		}
+	}
+
+	if _, err := (engine.Tournament{
+		Scenario: *cfg, Team: team, Days: 10,
+		Strategies: []string{"coin-flip"}, WIPLimits: []engine.WIPSetting{tight}, Seeds: []int64{1},
+	}).Run(); err == nil {
+		t.Error("Run with an unknown strategy succeeded; want an error")
+	}
+}
@@ -0,0 +1,87 @@
+package games
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCardNotFound   = errors.New("card not found")
+	ErrCardBlocked    = errors.New("card already blocked")
+	ErrCardNotBlocked = errors.New("card not blocked")
+	ErrCardDeployed   = errors.New("card already deployed")
+	ErrInvalidEffort  = errors.New("invalid unblocking effort")
+)
+
+// BlockCard blocks a card of a running game on the game's current day. Until
+// it is unblocked, no work is done on the card and it cannot move. With an
+// effort, the work put into the card goes into unblocking it and the
+// blocker is cleared once that much is done; without one, a player has to
+// lift it with UnblockCard. The blocker is put down to the player, if the
+// caller is one.
+func (s *Service) BlockCard(ctx context.Context, gameID, cardID, playerID uuid.UUID, reason string, effort int) (models.Blocker, error) {
+	if effort < 0 {
+		return models.Blocker{}, fmt.Errorf("%w: %d", ErrInvalidEffort, effort)
+	}
+	game, err := s.repo.GetGameByID(ctx, gameID)
+	if err != nil {
+		return models.Blocker{}, err
+	}
+	if err := checkRunning(game); err != nil {
+		return models.Blocker{}, err
+	}
+	card, err := s.card(ctx, gameID, cardID)
+	if err != nil {
+		return models.Blocker{}, err
+	}
+	switch {
+	case card.Blocker != nil:
+		return models.Blocker{}, ErrCardBlocked
+	case card.DeployedDay > 0:
+		return models.Blocker{}, ErrCardDeployed
+	}
+	return s.repo.BlockCard(ctx, gameID, cardID, playerID, models.Blocker{
+		Reason: reason,
+		Day:    game.Day,
+		Effort: effort,
+	})
+}
+
+// UnblockCard lifts the blocker of a card of a running game, whatever
+// unblocking work it still needed. The card can be worked on again the same
+// day.
+func (s *Service) UnblockCard(ctx context.Context, gameID, cardID uuid.UUID) error {
+	game, err := s.repo.GetGameByID(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	if err := checkRunning(game); err != nil {
+		return err
+	}
+	card, err := s.card(ctx, gameID, cardID)
+	if err != nil {
+		return err
+	}
+	if card.Blocker == nil {
+		return ErrCardNotBlocked
+	}
+	return s.repo.UnblockCard(ctx, gameID, cardID, game.Day, models.Unblock{Days: game.Day - card.Blocker.Day})
+}
+
+// card looks a card up on the board of a game.
+func (s *Service) card(ctx context.Context, gameID, cardID uuid.UUID) (models.Card, error) {
+	board, err := s.repo.GetBoard(ctx, gameID)
+	if err != nil {
+		return models.Card{}, err
+	}
+	for _, c := range board.Cards {
+		if c.ID == cardID {
+			return c, nil
+		}
+	}
+	return models.Card{}, fmt.Errorf("%w: %s", ErrCardNotFound, cardID)
+}
@@ -0,0 +1,161 @@
+package games
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/engine"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidBoard is returned when a game's board cannot be laid out for a
+// bot, e.g. because it does not end in a done column.
+var ErrInvalidBoard = errors.New("board cannot be played by a bot")
+
+// PlayBotDay lets a strategy play the game's current day with the given
+// team: it makes its moves, its workers roll the game's dice, and the game
+// then moves on to the next day. The outcome is stored like any other day.
+func (s *Service) PlayBotDay(ctx context.Context, id uuid.UUID, strategy engine.Strategy, team []engine.Worker) (models.BotDay, error) {
+	game, err := s.repo.GetGameByID(ctx, id)
+	if err != nil {
+		return models.BotDay{}, err
+	}
+	if err := checkRunning(game); err != nil {
+		return models.BotDay{}, err
+	}
+	state, err := s.repo.GetBoard(ctx, id)
+	if err != nil {
+		return models.BotDay{}, err
+	}
+
+	board, err := engine.LoadBoard(state, game.Day, team)
+	if err != nil {
+		return models.BotDay{}, fmt.Errorf("%w: %v", ErrInvalidBoard, err)
+	}
+	before := board.Clone()
+	g, err := engine.Resume(board, engine.Options{Seed: game.Seed, Quality: game.Quality})
+	if err != nil {
+		return models.BotDay{}, err
+	}
+	stats, err := g.Step(strategy)
+	if err != nil {
+		return models.BotDay{}, err
+	}
+
+	if err := s.repo.ApplyDay(ctx, id, game.Day, cardChanges(&before, board, stats)); err != nil {
+		return models.BotDay{}, err
+	}
+	if err := s.UpdateGame(ctx, id, game.Day+1); err != nil {
+		return models.BotDay{}, err
+	}
+
+	return models.BotDay{
+		Strategy: strategy.Name(),
+		Day:      stats.Day,
+		Moves:    stats.Moves,
+		Deployed: stats.Deployed,
+		Working:  stats.Working,
+		WIP:      stats.WIP,
+		Failed:   stats.Failed,
+		Defects:  stats.Defects,
+	}, nil
+}
+
+// cardChanges lists the cards that differ between two states of a board, a
+// day apart, with what the day's stats tell about them. after must hold the
+// cards of before in the same order, as Clone guarantees, followed by any
+// spawned during the day.
+func cardChanges(before, after *engine.Board, stats engine.DayStats) []models.CardChange {
+	failures := make(map[uuid.UUID]engine.Failure, len(stats.Failures))
+	for _, f := range stats.Failures {
+		failures[f.CardID] = f
+	}
+	defects := make(map[uuid.UUID]uuid.UUID, len(stats.Escapes))
+	for _, e := range stats.Escapes {
+		defects[e.CardID] = e.DefectID
+	}
+
+	var changes []models.CardChange
+	for i, c := range after.Cards {
+		if i >= len(before.Cards) {
+			changes = append(changes, models.CardChange{
+				CardID:         c.ID,
+				ColumnID:       after.Stages[c.Stage].ColumnID,
+				Efforts:        effortModels(c),
+				Spawned:        true,
+				Title:          c.Title,
+				ClassOfService: c.ClassOfService,
+				ValueEstimate:  c.ValueEstimate,
+			})
+			continue
+		}
+		old := before.Cards[i]
+
+		changed := c.Stage != old.Stage || c.SelectedDay != old.SelectedDay || c.DeployedDay != old.DeployedDay ||
+			!sameBlocker(c.Blocker, old.Blocker)
+		for j, e := range c.Efforts {
+			if e != old.Efforts[j] {
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		ch := models.CardChange{
+			CardID:      c.ID,
+			ColumnID:    after.Stages[c.Stage].ColumnID,
+			SelectedDay: c.SelectedDay,
+			DeployedDay: c.DeployedDay,
+			Efforts:     effortModels(c),
+			Blocker:     c.Blocker,
+		}
+		if old.Blocker != nil && c.Blocker == nil {
+			// the day's work counts as blocked; the card is free from the next
+			ch.Unblock = &models.Unblock{Days: after.Day - old.Blocker.Day, Worked: true}
+		}
+		// moves made in the morning, then back to development on a failure
+		f, failed := failures[c.ID]
+		reached := c.Stage
+		if failed {
+			reached = f.From
+		}
+		for st := old.Stage; st < reached; st++ {
+			ch.Moves = append(ch.Moves, models.ColumnMove{
+				From: after.Stages[st].Title,
+				To:   after.Stages[st+1].Title,
+			})
+		}
+		if failed {
+			back := models.ColumnMove{From: after.Stages[f.From].Title, To: after.Stages[f.To].Title}
+			ch.Moves = append(ch.Moves, back)
+			ch.TestFailure = &models.TestFailure{From: back.From, To: back.To, Rework: f.Rework}
+		}
+		if id, ok := defects[c.ID]; ok {
+			ch.Defect = &models.Defect{CardID: id, Title: after.Card(id).Title}
+		}
+		changes = append(changes, ch)
+	}
+	return changes
+}
+
+// effortModels lists the efforts of a card, by effort type title.
+func effortModels(c *engine.Card) []models.Effort {
+	efforts := make([]models.Effort, 0, len(c.Efforts))
+	for _, e := range c.Efforts {
+		efforts = append(efforts, models.Effort{
+			EffortType: e.Type, Estimate: e.Estimate, Remaining: e.Remaining, Actual: e.Actual,
+		})
+	}
+	return efforts
+}
+
+// sameBlocker reports whether two cards are held up alike.
+func sameBlocker(a, b *models.Blocker) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
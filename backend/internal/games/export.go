@@ -0,0 +1,179 @@
+package games
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidExport = errors.New("invalid game export")
+
+// buildExport assembles a GameExport from the flat rows of one game.
+func buildExport(
+	game models.Game,
+	effortTypes []models.EffortType,
+	state boardSnapshot,
+	players []models.ExportPlayer,
+	events []models.GameEvent,
+) models.GameExport {
+	doc := models.GameExport{
+		Version:     models.GameExportVersion,
+		ExportedAt:  time.Now().UTC(),
+		Game:        game,
+		Seed:        game.Seed,
+		EffortTypes: effortTypes,
+		Columns:     make([]models.Column, 0),
+		Cards:       make([]models.Card, 0, len(state.Cards)),
+		Players:     players,
+		Events:      events,
+	}
+	if doc.EffortTypes == nil {
+		doc.EffortTypes = make([]models.EffortType, 0)
+	}
+	if doc.Players == nil {
+		doc.Players = make([]models.ExportPlayer, 0)
+	}
+	if doc.Events == nil {
+		doc.Events = make([]models.GameEvent, 0)
+	}
+
+	// 1) column tree, and the title each card uses to reference its column
+	byID := make(map[uuid.UUID]snapshotColumn, len(state.Columns))
+	for _, c := range state.Columns {
+		byID[c.ID] = c
+	}
+	columnTitles := make(map[uuid.UUID]string, len(state.Columns))
+	topIdx := make(map[uuid.UUID]int)
+	for _, c := range sortedColumns(state.Columns) {
+		col := models.Column{
+			ID:         c.ID,
+			ParentID:   c.ParentID,
+			Title:      c.Title,
+			OrderIndex: c.OrderIndex,
+			WIPLimit:   c.WIPLimit,
+			Type:       c.Type,
+		}
+		if c.ParentID == nil {
+			topIdx[c.ID] = len(doc.Columns)
+			doc.Columns = append(doc.Columns, col)
+			columnTitles[c.ID] = c.Title
+			continue
+		}
+		if i, ok := topIdx[*c.ParentID]; ok {
+			doc.Columns[i].SubColumns = append(doc.Columns[i].SubColumns, col)
+		}
+		columnTitles[c.ID] = byID[*c.ParentID].Title + " - " + c.Title
+	}
+
+	// 2) cards with their efforts
+	effortTitles := make(map[uuid.UUID]string, len(effortTypes))
+	for _, et := range effortTypes {
+		effortTitles[et.ID] = et.Title
+	}
+	for _, c := range state.Cards {
+		card := models.Card{
+			ID:             c.ID,
+			GameID:         game.ID,
+			ColumnID:       c.ColumnID,
+			ColumnTitle:    columnTitles[c.ColumnID],
+			Title:          c.Title,
+			ClassOfService: c.ClassOfService,
+			ValueEstimate:  c.ValueEstimate,
+			SelectedDay:    c.SelectedDay,
+			DeployedDay:    c.DeployedDay,
+			OrderIndex:     c.OrderIndex,
+			Efforts:        make([]models.Effort, 0, len(c.Efforts)),
+			Blocker:        c.Blocker,
+		}
+		for _, e := range c.Efforts {
+			card.Efforts = append(card.Efforts, models.Effort{
+				EffortType: effortTitles[e.EffortTypeID],
+				Estimate:   e.Estimate,
+				Remaining:  e.Remaining,
+				Actual:     e.Actual,
+			})
+		}
+		doc.Cards = append(doc.Cards, card)
+	}
+
+	return doc
+}
+
+// sortedColumns orders columns parents first, each level by order_index.
+func sortedColumns(cols []snapshotColumn) []snapshotColumn {
+	out := append([]snapshotColumn(nil), cols...)
+	sort.SliceStable(out, func(i, j int) bool {
+		if (out[i].ParentID == nil) != (out[j].ParentID == nil) {
+			return out[i].ParentID == nil
+		}
+		return out[i].OrderIndex < out[j].OrderIndex
+	})
+	return out
+}
+
+// validateExport checks that an archive can be imported: the version is
+// supported and every title and card ID it references is defined in it.
+func validateExport(doc models.GameExport) error {
+	if doc.Version != models.GameExportVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidExport, doc.Version)
+	}
+	if !doc.Game.Quality.Valid() {
+		return fmt.Errorf("%w: quality chances and shares must be between 0 and 1", ErrInvalidExport)
+	}
+
+	effortTypes := make(map[string]bool, len(doc.EffortTypes))
+	for _, et := range doc.EffortTypes {
+		if et.Title == "" || effortTypes[et.Title] {
+			return fmt.Errorf("%w: effort type %q is empty or duplicated", ErrInvalidExport, et.Title)
+		}
+		effortTypes[et.Title] = true
+	}
+
+	columns := make(map[string]bool)
+	for _, col := range doc.Columns {
+		if col.Title == "" || columns[col.Title] {
+			return fmt.Errorf("%w: column %q is empty or duplicated", ErrInvalidExport, col.Title)
+		}
+		columns[col.Title] = true
+		for _, sub := range col.SubColumns {
+			key := col.Title + " - " + sub.Title
+			if sub.Title == "" || columns[key] {
+				return fmt.Errorf("%w: column %q is empty or duplicated", ErrInvalidExport, key)
+			}
+			columns[key] = true
+		}
+	}
+
+	cards := make(map[uuid.UUID]bool, len(doc.Cards))
+	for _, c := range doc.Cards {
+		if !columns[c.ColumnTitle] {
+			return fmt.Errorf("%w: card %q is in unknown column %q", ErrInvalidExport, c.Title, c.ColumnTitle)
+		}
+		for _, e := range c.Efforts {
+			if !effortTypes[e.EffortType] {
+				return fmt.Errorf("%w: card %q has unknown effort type %q", ErrInvalidExport, c.Title, e.EffortType)
+			}
+		}
+		if c.ID != uuid.Nil {
+			cards[c.ID] = true
+		}
+	}
+
+	for _, p := range doc.Players {
+		if p.Name == "" {
+			return fmt.Errorf("%w: player without a name", ErrInvalidExport)
+		}
+	}
+
+	for _, ev := range doc.Events {
+		if ev.CardID != nil && !cards[*ev.CardID] {
+			return fmt.Errorf("%w: event %q references unknown card %s", ErrInvalidExport, ev.EventType, *ev.CardID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package games
+
+import (
+	"testing"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildExport_RoundTripsThroughValidation(t *testing.T) {
+	gameID := uuid.New()
+	etID := uuid.New()
+	devID := uuid.New()
+	doneID := uuid.New()
+	cardID := uuid.New()
+
+	doc := buildExport(
+		models.Game{ID: gameID, Day: 5},
+		[]models.EffortType{{ID: etID, Title: "Development"}},
+		boardSnapshot{
+			Columns: []snapshotColumn{
+				// subcolumn listed first to check parents are placed first
+				{ID: doneID, ParentID: &devID, Title: "Done", Type: "queue", OrderIndex: 1},
+				{ID: devID, Title: "Development", Type: "active", OrderIndex: 3, WIPLimit: 3},
+			},
+			Cards: []snapshotCard{{
+				ID: cardID, ColumnID: doneID, Title: "S7", ValueEstimate: "low",
+				Efforts: []snapshotEffort{{EffortTypeID: etID, Estimate: 8, Remaining: 0, Actual: 9}},
+			}},
+		},
+		nil,
+		[]models.GameEvent{{CardID: &cardID, EventType: "move", Day: 4}},
+	)
+
+	require.Equal(t, models.GameExportVersion, doc.Version)
+	require.Len(t, doc.Columns, 1)
+	require.Len(t, doc.Columns[0].SubColumns, 1)
+	require.Equal(t, 3, doc.Columns[0].WIPLimit)
+	require.Len(t, doc.Cards, 1)
+	require.Equal(t, "Development - Done", doc.Cards[0].ColumnTitle)
+	require.Equal(t, []models.Effort{{EffortType: "Development", Estimate: 8, Remaining: 0, Actual: 9}}, doc.Cards[0].Efforts)
+	require.NotNil(t, doc.Players)
+
+	require.NoError(t, validateExport(doc))
+}
@@ -10,6 +10,20 @@ import (
 
 var ErrNotFound = errors.New("not found")
 
+// ErrPlayerNotInGame is returned when a player named for a game does not
+// belong to it.
+var ErrPlayerNotInGame = errors.New("player not in game")
+
+// ErrInvalidTransition is returned when a game cannot take a lifecycle step
+// from the status it is in.
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// ErrGameNotRunning is returned for moves in a game that is not running.
+var ErrGameNotRunning = errors.New("game not running")
+
+// ErrGameEnded is returned for changes to a game that has ended.
+var ErrGameEnded = errors.New("game ended")
+
 // GameRepository defines data-access methods for games
 type GameRepository interface {
 	Create(ctx context.Context, g *models.Game) error
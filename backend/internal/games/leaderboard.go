@@ -0,0 +1,159 @@
+package games
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/engine"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+)
+
+// ErrInvalidScore is returned for scores the leaderboard cannot rank by.
+var ErrInvalidScore = errors.New("invalid score")
+
+// Score is what the leaderboard ranks games by. Compare returns a negative
+// number when a ranks before b.
+type Score struct {
+	Name    string
+	Compare func(a, b models.LeaderboardEntry) int
+}
+
+// The scores: the higher the better, except for lead time. Games without a
+// deployed card have no lead time and come last on it.
+var (
+	ScoreProfit     = Score{"profit", func(a, b models.LeaderboardEntry) int { return cmp.Compare(b.Profit, a.Profit) }}
+	ScoreThroughput = Score{"throughput", func(a, b models.LeaderboardEntry) int { return cmp.Compare(b.Throughput, a.Throughput) }}
+	ScoreFixedDate  = Score{"fixed_date", func(a, b models.LeaderboardEntry) int {
+		return cmp.Compare(b.FixedDateHitRate, a.FixedDateHitRate)
+	}}
+	ScoreLeadTime = Score{"lead_time", func(a, b models.LeaderboardEntry) int {
+		switch {
+		case a.Deployed == 0 || b.Deployed == 0:
+			return cmp.Compare(b.Deployed, a.Deployed)
+		default:
+			return cmp.Compare(a.LeadTime, b.LeadTime)
+		}
+	}}
+)
+
+// Scores lists the scores the leaderboard can rank by.
+var Scores = []Score{ScoreProfit, ScoreLeadTime, ScoreThroughput, ScoreFixedDate}
+
+// ParseScore returns the score of the given name.
+func ParseScore(name string) (Score, error) {
+	for _, s := range Scores {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+	return Score{}, fmt.Errorf("%w: %q", ErrInvalidScore, name)
+}
+
+// GameScore is every score of an ended game. Its board no longer changes, so
+// the score is worked out once, when the game ends, and kept.
+type GameScore struct {
+	Days             int
+	Deployed         int
+	Profit           int
+	LeadTime         int // median, in days
+	Throughput       float64
+	FixedDateHitRate float64
+}
+
+// ScoredGame is a game with its kept score; nil if none was kept.
+type ScoredGame struct {
+	models.Game
+	Score *GameScore
+}
+
+// Leaderboard ranks the ended games that pass the filter by a score, oldest
+// first among equals. The games and their kept scores are read in one go;
+// only games that ended without a kept score, before scores were kept, are
+// scored now, and keep the score from then on.
+func (s *Service) Leaderboard(ctx context.Context, score Score, f models.LeaderboardFilter) ([]models.LeaderboardEntry, error) {
+	list, err := s.repo.ScoredGames(ctx, models.GameQuery{
+		Status: models.GameEnded, Scenario: f.Scenario, From: f.From, To: f.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	type entry struct {
+		models.LeaderboardEntry
+		created time.Time
+	}
+	entries := make([]entry, 0, len(list))
+	for _, g := range list {
+		at, err := time.Parse(time.RFC3339Nano, g.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("game %s: created at: %w", g.ID, err)
+		}
+		if g.Score == nil {
+			sc, err := s.keepScore(ctx, g.Game)
+			if err != nil {
+				return nil, err
+			}
+			g.Score = &sc
+		}
+		entries = append(entries, entry{created: at, LeaderboardEntry: models.LeaderboardEntry{
+			GameID:           g.ID,
+			Scenario:         g.Scenario,
+			CreatedAt:        g.CreatedAt,
+			Days:             g.Score.Days,
+			Deployed:         g.Score.Deployed,
+			Profit:           g.Score.Profit,
+			LeadTime:         g.Score.LeadTime,
+			Throughput:       g.Score.Throughput,
+			FixedDateHitRate: g.Score.FixedDateHitRate,
+		}})
+	}
+
+	slices.SortFunc(entries, func(a, b entry) int {
+		if c := score.Compare(a.LeaderboardEntry, b.LeaderboardEntry); c != 0 {
+			return c
+		}
+		return a.created.Compare(b.created)
+	})
+
+	ranked := make([]models.LeaderboardEntry, len(entries))
+	for i, e := range entries {
+		ranked[i] = e.LeaderboardEntry
+		ranked[i].Rank = i + 1
+		if i > 0 && score.Compare(ranked[i-1], ranked[i]) == 0 {
+			ranked[i].Rank = ranked[i-1].Rank
+		}
+	}
+	return ranked, nil
+}
+
+// keepScore works out the score of an ended game from its board and keeps
+// it. Profit is worked out with the standard team and card values, so that
+// games are measured alike whoever played them.
+func (s *Service) keepScore(ctx context.Context, g models.Game) (GameScore, error) {
+	team, err := engine.ParseTeam(engine.DefaultTeam)
+	if err != nil {
+		return GameScore{}, err
+	}
+	state, err := s.repo.GetBoard(ctx, g.ID)
+	if err != nil {
+		return GameScore{}, fmt.Errorf("game %s: %w", g.ID, err)
+	}
+	board, err := engine.LoadBoard(state, g.Day, team)
+	if err != nil {
+		return GameScore{}, fmt.Errorf("game %s: %w: %v", g.ID, ErrInvalidBoard, err)
+	}
+	p := board.Progress(nil, 0)
+	sc := GameScore{
+		Days:             p.Days,
+		Deployed:         p.Deployed,
+		Profit:           p.Financials.Profit,
+		LeadTime:         p.LeadTime.P50,
+		Throughput:       p.Throughput,
+		FixedDateHitRate: p.FixedDateHitRate,
+	}
+	return sc, s.repo.SaveScore(ctx, g.ID, sc)
+}
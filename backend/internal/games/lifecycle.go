@@ -0,0 +1,82 @@
+package games
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// Transition is a step of the game lifecycle, taken with
+// POST /games/{id}/<Name>.
+type Transition struct {
+	Name  string
+	From  []models.GameStatus
+	To    models.GameStatus
+	Event string // type of the event logged for the step
+}
+
+// The lifecycle: a game waits in the lobby until it is started, may be
+// paused and resumed while running, and can be ended from any status.
+var (
+	Start  = Transition{"start", []models.GameStatus{models.GameLobby}, models.GameRunning, "game_started"}
+	Pause  = Transition{"pause", []models.GameStatus{models.GameRunning}, models.GamePaused, "game_paused"}
+	Resume = Transition{"resume", []models.GameStatus{models.GamePaused}, models.GameRunning, "game_resumed"}
+	End    = Transition{"end", []models.GameStatus{models.GameLobby, models.GameRunning, models.GamePaused}, models.GameEnded, "game_ended"}
+)
+
+// ChangeStatus takes a game through a lifecycle step and returns the game
+// in its new status.
+func (s *Service) ChangeStatus(ctx context.Context, id uuid.UUID, t Transition) (models.Game, error) {
+	game, err := s.repo.GetGameByID(ctx, id)
+	if err != nil {
+		return models.Game{}, err
+	}
+	if !slices.Contains(t.From, game.Status) {
+		return models.Game{}, fmt.Errorf("%w: cannot %s a game that is %s", ErrInvalidTransition, t.Name, game.Status)
+	}
+	if err := s.repo.SetStatus(ctx, id, game.Status, t.To, t.Event); err != nil {
+		return models.Game{}, err
+	}
+	if t.To == models.GameEnded {
+		// the board is final now, so its score is kept; the game is read
+		// again, as a day may have been played since. Should this fail, the
+		// leaderboard scores the game when it first ranks it.
+		ended, err := s.repo.GetGameByID(ctx, id)
+		if err == nil {
+			_, err = s.keepScore(ctx, ended)
+		}
+		if err != nil {
+			log.Printf("ChangeStatus: failed to keep the score of game %s: %v", id, err)
+		}
+	}
+	game.Status = t.To
+	return game, nil
+}
+
+// ResetGame restarts a game that has not ended from the board it started
+// with, back in the lobby on day 1. Its players and facilitator stay.
+func (s *Service) ResetGame(ctx context.Context, id uuid.UUID) (models.Game, error) {
+	game, err := s.repo.GetGameByID(ctx, id)
+	if err != nil {
+		return models.Game{}, err
+	}
+	if game.Status == models.GameEnded {
+		return models.Game{}, ErrGameEnded
+	}
+	if err := s.repo.ResetGame(ctx, id); err != nil {
+		return models.Game{}, err
+	}
+	return s.repo.GetGameByID(ctx, id)
+}
+
+// checkRunning returns ErrGameNotRunning unless the game may be played.
+func checkRunning(g models.Game) error {
+	if g.Status != models.GameRunning {
+		return fmt.Errorf("%w: game is %s", ErrGameNotRunning, g.Status)
+	}
+	return nil
+}
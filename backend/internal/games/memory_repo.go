@@ -0,0 +1,1182 @@
+package games
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/Germanicus1/kanban-sim/backend/internal/response"
+	"github.com/google/uuid"
+)
+
+// memoryRepo implements Repository on a memstore.Store, with the same
+// results and errors as sqlRepo.
+type memoryRepo struct {
+	store *memstore.Store
+}
+
+func gameModel(g memstore.Game) models.Game {
+	return models.Game{
+		ID:            g.ID,
+		CreatedAt:     g.CreatedAt.Format(time.RFC3339Nano),
+		Day:           g.Day,
+		ParentGameID:  g.ParentGameID,
+		ForkedAtDay:   g.ForkedAtDay,
+		Seed:          g.Seed,
+		FacilitatorID: g.FacilitatorID,
+		Status:        models.GameStatus(g.Status),
+		Scenario:      g.Scenario,
+		Quality: models.Quality{
+			TestFailure: g.TestFailure, Rework: g.Rework,
+			EscapedDefect: g.EscapedDefect, DefectEffort: g.DefectEffort,
+		},
+	}
+}
+
+// setQuality is qualityValues for a game row of the in-memory tables.
+func setQuality(g *memstore.Game, q models.Quality) {
+	g.TestFailure, g.Rework, g.EscapedDefect, g.DefectEffort = q.TestFailure, q.Rework, q.EscapedDefect, q.DefectEffort
+}
+
+// eventCard returns the card of an event row, nil for events about the game.
+func eventCard(ev memstore.Event) *uuid.UUID {
+	if ev.CardID == uuid.Nil {
+		return nil
+	}
+	id := ev.CardID
+	return &id
+}
+
+func (r *memoryRepo) CreateGame(ctx context.Context, cfg models.BoardConfig) (uuid.UUID, error) {
+	gameID := uuid.New()
+	err := r.store.Update(func(t *memstore.Tables) error {
+		g := memstore.Game{
+			ID:        gameID,
+			CreatedAt: memstore.Now(),
+			Day:       1,
+			Status:    string(models.GameLobby),
+			Seed:      cfg.Seed,
+			Scenario:  cfg.Scenario,
+		}
+		setQuality(&g, cfg.Quality)
+		t.Games = append(t.Games, g)
+		if _, err := seedMemoryBoard(t, gameID, freshBoard(cfg)); err != nil {
+			return err
+		}
+
+		// the facilitator joins with the game
+		if cfg.Facilitator != "" {
+			playerID := uuid.New()
+			t.Players = append(t.Players, memstore.Player{ID: playerID, GameID: gameID, Name: cfg.Facilitator})
+			t.Games[len(t.Games)-1].FacilitatorID = &playerID
+		}
+		return saveMemorySnapshot(t, gameID, InitialDay)
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return gameID, nil
+}
+
+// seedMemoryBoard is seedBoard for the in-memory tables.
+func seedMemoryBoard(t *memstore.Tables, gameID uuid.UUID, cfg models.BoardConfig) ([]uuid.UUID, error) {
+	// 1) effort types
+	effortTypeIDs := make(map[string]uuid.UUID, len(cfg.EffortTypes))
+	for idx, et := range cfg.EffortTypes {
+		id := uuid.New()
+		t.EffortTypes = append(t.EffortTypes, memstore.EffortType{
+			ID: id, GameID: gameID, Title: et.Title, OrderIndex: idx,
+		})
+		effortTypeIDs[et.Title] = id
+	}
+
+	// 2) columns & subcolumns
+	columnIDs := make(map[string]uuid.UUID, len(cfg.Columns)*2)
+	for _, col := range cfg.Columns {
+		mainID := uuid.New()
+		t.Columns = append(t.Columns, memstore.Column{
+			ID: mainID, GameID: gameID, Title: col.Title,
+			WIPLimit: col.WIPLimit, Type: columnType(col.Type), OrderIndex: col.OrderIndex,
+		})
+		columnIDs[col.Title] = mainID
+
+		for _, sub := range col.SubColumns {
+			subID := uuid.New()
+			parentID := mainID
+			t.Columns = append(t.Columns, memstore.Column{
+				ID: subID, GameID: gameID, ParentID: &parentID, Title: sub.Title,
+				WIPLimit: sub.WIPLimit, Type: columnType(sub.Type), OrderIndex: sub.OrderIndex,
+			})
+			columnIDs[col.Title+" - "+sub.Title] = subID
+		}
+	}
+
+	// 3) cards & their efforts
+	cardIDs := make([]uuid.UUID, 0, len(cfg.Cards))
+	order := columnOrder(cfg.Cards)
+	for i, c := range cfg.Cards {
+		colID, ok := columnIDs[c.ColumnTitle]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", c.ColumnTitle)
+		}
+		cardID := uuid.New()
+		card := memstore.Card{
+			ID: cardID, GameID: gameID, ColumnID: colID,
+			Title: c.Title, ClassOfService: c.ClassOfService, ValueEstimate: c.ValueEstimate,
+			SelectedDay: c.SelectedDay, DeployedDay: c.DeployedDay, OrderIndex: order[i],
+		}
+		setBlocker(&card, c.Blocker)
+		t.Cards = append(t.Cards, card)
+		cardIDs = append(cardIDs, cardID)
+
+		for _, e := range c.Efforts {
+			etID, ok := effortTypeIDs[e.EffortType]
+			if !ok {
+				return nil, fmt.Errorf("unknown effort type %q", e.EffortType)
+			}
+			t.Efforts = append(t.Efforts, memstore.Effort{
+				ID: uuid.New(), CardID: cardID, EffortTypeID: etID,
+				Estimate: e.Estimate, Remaining: e.Remaining, Actual: e.Actual,
+			})
+		}
+	}
+
+	return cardIDs, nil
+}
+
+// columnType applies the column default of the schema.
+func columnType(t string) string {
+	if t == "" {
+		return "queue"
+	}
+	return t
+}
+
+func (r *memoryRepo) GetBoard(ctx context.Context, gameID uuid.UUID) (models.Board, error) {
+	board := models.Board{GameID: gameID}
+	err := r.store.View(func(t *memstore.Tables) error {
+		// 1) column tree
+		var cols []memstore.Column
+		for _, c := range t.Columns {
+			if c.GameID == gameID {
+				cols = append(cols, c)
+			}
+		}
+		sort.SliceStable(cols, func(i, j int) bool { return cols[i].OrderIndex < cols[j].OrderIndex })
+		for _, c := range cols {
+			if c.ParentID != nil {
+				continue
+			}
+			col := columnModel(c)
+			for _, sub := range cols {
+				if sub.ParentID != nil && *sub.ParentID == c.ID {
+					col.SubColumns = append(col.SubColumns, columnModel(sub))
+				}
+			}
+			board.Columns = append(board.Columns, col)
+		}
+
+		// 2) effort types
+		effortTypes := gameEffortTypes(t, gameID)
+		for _, et := range effortTypes {
+			board.EffortTypes = append(board.EffortTypes, models.EffortType{
+				ID: et.ID, Title: et.Title, OrderIndex: et.OrderIndex,
+			})
+		}
+
+		// 3) cards and their efforts
+		var cards []memstore.Card
+		for _, c := range t.Cards {
+			if c.GameID == gameID {
+				cards = append(cards, c)
+			}
+		}
+		sort.SliceStable(cards, func(i, j int) bool {
+			if cards[i].SelectedDay != cards[j].SelectedDay {
+				return cards[i].SelectedDay < cards[j].SelectedDay
+			}
+			if cards[i].OrderIndex != cards[j].OrderIndex {
+				return cards[i].OrderIndex < cards[j].OrderIndex
+			}
+			return cards[i].Title < cards[j].Title
+		})
+		for _, c := range cards {
+			card := models.Card{
+				ID: c.ID, GameID: c.GameID, ColumnID: c.ColumnID, Title: c.Title,
+				ClassOfService: c.ClassOfService, ValueEstimate: c.ValueEstimate,
+				SelectedDay: c.SelectedDay, DeployedDay: c.DeployedDay, OrderIndex: c.OrderIndex,
+				Blocker: memoryBlocker(c),
+			}
+			for _, et := range effortTypes {
+				for _, e := range t.Efforts {
+					if e.CardID == c.ID && e.EffortTypeID == et.ID {
+						card.Efforts = append(card.Efforts, models.Effort{
+							EffortType: et.Title, Estimate: e.Estimate, Remaining: e.Remaining, Actual: e.Actual,
+						})
+					}
+				}
+			}
+			board.Cards = append(board.Cards, card)
+		}
+		return nil
+	})
+	return board, err
+}
+
+func columnModel(c memstore.Column) models.Column {
+	return models.Column{
+		ID: c.ID, ParentID: c.ParentID, Title: c.Title,
+		OrderIndex: c.OrderIndex, WIPLimit: c.WIPLimit, Type: c.Type,
+	}
+}
+
+// gameEffortTypes returns the effort types of a game by order_index.
+func gameEffortTypes(t *memstore.Tables, gameID uuid.UUID) []memstore.EffortType {
+	var out []memstore.EffortType
+	for _, et := range t.EffortTypes {
+		if et.GameID == gameID {
+			out = append(out, et)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].OrderIndex < out[j].OrderIndex })
+	return out
+}
+
+func (r *memoryRepo) GetGameByID(ctx context.Context, id uuid.UUID) (models.Game, error) {
+	var g models.Game
+	err := r.store.View(func(t *memstore.Tables) error {
+		i := t.LiveGame(id)
+		if i < 0 {
+			return ErrNotFound
+		}
+		g = gameModel(t.Games[i])
+		return nil
+	})
+	return g, err
+}
+
+func (r *memoryRepo) DeleteGame(ctx context.Context, id uuid.UUID) error {
+	return r.store.Update(func(t *memstore.Tables) error {
+		if !t.DeleteGame(id) {
+			return response.ErrNotFound
+		}
+		return nil
+	})
+}
+
+func (r *memoryRepo) UpdateGame(ctx context.Context, id uuid.UUID, day int) error {
+	return r.store.Update(func(t *memstore.Tables) error {
+		i := t.LiveGame(id)
+		if i < 0 {
+			return ErrNotFound
+		}
+		t.Games[i].Day = day
+		return nil
+	})
+}
+
+func (r *memoryRepo) ListGames(ctx context.Context) ([]models.Game, error) {
+	var games []models.Game
+	err := r.store.View(func(t *memstore.Tables) error {
+		rows := slices.DeleteFunc(append([]memstore.Game(nil), t.Games...), func(g memstore.Game) bool { return !g.DeletedAt.IsZero() })
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].CreatedAt.After(rows[j].CreatedAt) })
+		for _, g := range rows {
+			games = append(games, gameModel(g))
+		}
+		return nil
+	})
+	return games, err
+}
+
+// SearchGames is SearchGames for the in-memory tables, comparing IDs as
+// strings like the databases do.
+func (r *memoryRepo) SearchGames(ctx context.Context, s GameSearch) ([]models.Game, int, bool, error) {
+	var (
+		games []models.Game
+		total int
+		more  bool
+	)
+	err := r.store.View(func(t *memstore.Tables) error {
+		compare := func(a, b memstore.Game) int {
+			c := 0
+			if s.Sort == SortDay {
+				c = cmp.Compare(a.Day, b.Day)
+			}
+			c = cmp.Or(c, a.CreatedAt.Compare(b.CreatedAt), strings.Compare(a.ID.String(), b.ID.String()))
+			if !s.Ascending {
+				c = -c
+			}
+			return c
+		}
+
+		var rows []memstore.Game
+		for _, g := range t.Games {
+			if memoryPasses(t, g, s.GameQuery) {
+				rows = append(rows, g)
+			}
+		}
+		total = len(rows)
+		slices.SortFunc(rows, compare)
+
+		if s.After != nil {
+			after := memstore.Game{ID: s.After.ID, Day: s.After.Day, CreatedAt: s.After.CreatedAt}
+			rows = slices.DeleteFunc(rows, func(g memstore.Game) bool { return compare(g, after) <= 0 })
+		}
+		if more = len(rows) > s.Limit; more {
+			rows = rows[:s.Limit]
+		}
+		for _, g := range rows {
+			games = append(games, gameModel(g))
+		}
+		return nil
+	})
+	return games, total, more, err
+}
+
+// memoryPasses is gameFilter for the in-memory tables.
+func memoryPasses(t *memstore.Tables, g memstore.Game, q models.GameQuery) bool {
+	inSession := func() bool {
+		for _, sg := range t.SessionGames {
+			if sg.GameID == g.ID && sg.SessionID == *q.SessionID {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case !g.DeletedAt.IsZero(),
+		q.Status != "" && models.GameStatus(g.Status) != q.Status,
+		q.Scenario != "" && g.Scenario != q.Scenario,
+		q.SessionID != nil && !inSession(),
+		!q.From.IsZero() && g.CreatedAt.Before(q.From),
+		!q.To.IsZero() && !g.CreatedAt.Before(q.To):
+		return false
+	}
+	return true
+}
+
+func (r *memoryRepo) ScoredGames(ctx context.Context, q models.GameQuery) ([]ScoredGame, error) {
+	var games []ScoredGame
+	err := r.store.View(func(t *memstore.Tables) error {
+		scores := make(map[uuid.UUID]memstore.Score, len(t.Scores))
+		for _, s := range t.Scores {
+			scores[s.GameID] = s
+		}
+		for _, g := range t.Games {
+			if !memoryPasses(t, g, q) {
+				continue
+			}
+			game := ScoredGame{Game: gameModel(g)}
+			if s, ok := scores[g.ID]; ok {
+				game.Score = &GameScore{
+					Days: s.Days, Deployed: s.Deployed, Profit: s.Profit, LeadTime: s.LeadTime,
+					Throughput: s.Throughput, FixedDateHitRate: s.FixedDateHitRate,
+				}
+			}
+			games = append(games, game)
+		}
+		return nil
+	})
+	return games, err
+}
+
+func (r *memoryRepo) SaveScore(ctx context.Context, gameID uuid.UUID, s GameScore) error {
+	return r.store.Update(func(t *memstore.Tables) error {
+		if t.Game(gameID) < 0 {
+			return fmt.Errorf("insert score: game %s does not exist", gameID)
+		}
+		score := memstore.Score{
+			GameID: gameID, Days: s.Days, Deployed: s.Deployed, Profit: s.Profit, LeadTime: s.LeadTime,
+			Throughput: s.Throughput, FixedDateHitRate: s.FixedDateHitRate,
+		}
+		for i, kept := range t.Scores {
+			if kept.GameID == gameID {
+				t.Scores[i] = score
+				return nil
+			}
+		}
+		t.Scores = append(t.Scores, score)
+		return nil
+	})
+}
+
+// memoryInactive reports whether a game, not soft-deleted, had no activity
+// at or after a time.
+func memoryInactive(t *memstore.Tables, g memstore.Game, before time.Time) bool {
+	if !g.DeletedAt.IsZero() || !g.CreatedAt.Before(before) {
+		return false
+	}
+	for _, e := range t.Events {
+		if e.GameID == g.ID && !e.CreatedAt.Before(before) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *memoryRepo) InactiveGames(ctx context.Context, before time.Time) ([]models.Game, error) {
+	var rows []memstore.Game
+	err := r.store.View(func(t *memstore.Tables) error {
+		for _, g := range t.Games {
+			if memoryInactive(t, g, before) {
+				rows = append(rows, g)
+			}
+		}
+		return nil
+	})
+	slices.SortFunc(rows, func(a, b memstore.Game) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	games := make([]models.Game, len(rows))
+	for i, g := range rows {
+		games[i] = gameModel(g)
+	}
+	return games, err
+}
+
+func (r *memoryRepo) SoftDeleteGame(ctx context.Context, id uuid.UUID, inactiveBefore, at time.Time) (bool, error) {
+	var done bool
+	err := r.store.Update(func(t *memstore.Tables) error {
+		if i := t.Game(id); i >= 0 && memoryInactive(t, t.Games[i], inactiveBefore) {
+			t.Games[i].DeletedAt = at
+			done = true
+		}
+		return nil
+	})
+	return done, err
+}
+
+func (r *memoryRepo) SoftDeletedGames(ctx context.Context, before time.Time) ([]models.Game, error) {
+	var rows []memstore.Game
+	err := r.store.View(func(t *memstore.Tables) error {
+		for _, g := range t.Games {
+			if !g.DeletedAt.IsZero() && g.DeletedAt.Before(before) {
+				rows = append(rows, g)
+			}
+		}
+		return nil
+	})
+	slices.SortFunc(rows, func(a, b memstore.Game) int { return a.DeletedAt.Compare(b.DeletedAt) })
+	games := make([]models.Game, len(rows))
+	for i, g := range rows {
+		games[i] = gameModel(g)
+	}
+	return games, err
+}
+
+// memoryBoardState is loadBoardState for the in-memory tables.
+func memoryBoardState(t *memstore.Tables, gameID uuid.UUID) boardSnapshot {
+	var state boardSnapshot
+
+	for _, c := range t.Columns {
+		if c.GameID == gameID {
+			state.Columns = append(state.Columns, snapshotColumn{
+				ID: c.ID, ParentID: c.ParentID, Title: c.Title,
+				WIPLimit: c.WIPLimit, Type: c.Type, OrderIndex: c.OrderIndex,
+			})
+		}
+	}
+	sort.SliceStable(state.Columns, func(i, j int) bool {
+		return state.Columns[i].OrderIndex < state.Columns[j].OrderIndex
+	})
+
+	for _, c := range t.Cards {
+		if c.GameID == gameID {
+			state.Cards = append(state.Cards, snapshotCard{
+				ID: c.ID, ColumnID: c.ColumnID, Title: c.Title,
+				ClassOfService: c.ClassOfService, ValueEstimate: c.ValueEstimate,
+				SelectedDay: c.SelectedDay, DeployedDay: c.DeployedDay, OrderIndex: c.OrderIndex,
+				Blocker: memoryBlocker(c),
+			})
+		}
+	}
+	sort.SliceStable(state.Cards, func(i, j int) bool {
+		return state.Cards[i].OrderIndex < state.Cards[j].OrderIndex
+	})
+
+	effortTypes := gameEffortTypes(t, gameID)
+	for i := range state.Cards {
+		for _, et := range effortTypes {
+			for _, e := range t.Efforts {
+				if e.CardID == state.Cards[i].ID && e.EffortTypeID == et.ID {
+					state.Cards[i].Efforts = append(state.Cards[i].Efforts, snapshotEffort{
+						EffortTypeID: et.ID, Estimate: e.Estimate, Remaining: e.Remaining, Actual: e.Actual,
+					})
+				}
+			}
+		}
+	}
+
+	return state
+}
+
+func (r *memoryRepo) SaveSnapshot(ctx context.Context, gameID uuid.UUID, day int) error {
+	return r.store.Update(func(t *memstore.Tables) error {
+		if t.LiveGame(gameID) < 0 {
+			return fmt.Errorf("insert snapshot: game %s does not exist", gameID)
+		}
+		return saveMemorySnapshot(t, gameID, day)
+	})
+}
+
+// saveMemorySnapshot is saveSnapshot for the in-memory tables.
+func saveMemorySnapshot(t *memstore.Tables, gameID uuid.UUID, day int) error {
+	board, err := json.Marshal(memoryBoardState(t, gameID))
+	if err != nil {
+		return fmt.Errorf("marshal snapshot: %w", err)
+	}
+	snap := memstore.Snapshot{GameID: gameID, Day: day, Board: board, CreatedAt: memstore.Now()}
+	for i, s := range t.Snapshots {
+		if s.GameID == gameID && s.Day == day {
+			t.Snapshots[i] = snap
+			return nil
+		}
+	}
+	t.Snapshots = append(t.Snapshots, snap)
+	return nil
+}
+
+func (r *memoryRepo) ResetGame(ctx context.Context, id uuid.UUID) error {
+	return r.store.Update(func(t *memstore.Tables) error {
+		// 1) the game must exist and have kept its initial board
+		i := t.LiveGame(id)
+		if i < 0 {
+			return ErrNotFound
+		}
+		var raw []byte
+		for _, s := range t.Snapshots {
+			if s.GameID == id && s.Day == InitialDay {
+				raw = s.Board
+			}
+		}
+		if raw == nil {
+			return ErrSnapshotNotFound
+		}
+		var state boardSnapshot
+		if err := json.Unmarshal(raw, &state); err != nil {
+			return fmt.Errorf("decode snapshot: %w", err)
+		}
+
+		// 2) what was played
+		t.ClearBoard(id)
+		snapshots := t.Snapshots[:0]
+		for _, s := range t.Snapshots {
+			if s.GameID != id || s.Day == InitialDay {
+				snapshots = append(snapshots, s)
+			}
+		}
+		t.Snapshots = snapshots
+
+		// 3) the board as it started, with its original IDs
+		for _, col := range state.Columns {
+			t.Columns = append(t.Columns, memstore.Column{
+				ID: col.ID, GameID: id, ParentID: col.ParentID, Title: col.Title,
+				WIPLimit: col.WIPLimit, Type: col.Type, OrderIndex: col.OrderIndex,
+			})
+		}
+		for _, c := range state.Cards {
+			card := memstore.Card{
+				ID: c.ID, GameID: id, ColumnID: c.ColumnID, Title: c.Title,
+				ClassOfService: c.ClassOfService, ValueEstimate: c.ValueEstimate,
+				SelectedDay: c.SelectedDay, DeployedDay: c.DeployedDay, OrderIndex: c.OrderIndex,
+			}
+			setBlocker(&card, c.Blocker)
+			t.Cards = append(t.Cards, card)
+			for _, e := range c.Efforts {
+				t.Efforts = append(t.Efforts, memstore.Effort{
+					ID: uuid.New(), CardID: c.ID, EffortTypeID: e.EffortTypeID,
+					Estimate: e.Estimate, Remaining: e.Remaining, Actual: e.Actual,
+				})
+			}
+		}
+
+		// 4) back to day 1, in the lobby
+		t.Games[i].Day = 1
+		t.Games[i].Status = string(models.GameLobby)
+		return nil
+	})
+}
+
+func (r *memoryRepo) ForkGame(ctx context.Context, sourceID uuid.UUID, day int) (uuid.UUID, error) {
+	gameID := uuid.New()
+	err := r.store.Update(func(t *memstore.Tables) error {
+		// 1) the source must exist and the day must already be over
+		i := t.LiveGame(sourceID)
+		if i < 0 {
+			return ErrNotFound
+		}
+		source := t.Games[i]
+
+		var (
+			state  boardSnapshot
+			newDay int
+		)
+		switch {
+		case day == 0:
+			day, newDay = source.Day, source.Day
+			state = memoryBoardState(t, sourceID)
+		case day < 1 || day >= source.Day:
+			return ErrInvalidDay
+		default:
+			newDay = day + 1
+			var raw []byte
+			for _, s := range t.Snapshots {
+				if s.GameID == sourceID && s.Day == day {
+					raw = s.Board
+				}
+			}
+			if raw == nil {
+				return ErrSnapshotNotFound
+			}
+			if err := json.Unmarshal(raw, &state); err != nil {
+				return fmt.Errorf("decode snapshot: %w", err)
+			}
+		}
+
+		// 2) the new game, linked to its parent
+		parentID, forkedAt := sourceID, day
+		t.Games = append(t.Games, memstore.Game{
+			ID: gameID, CreatedAt: memstore.Now(), Day: newDay,
+			ParentGameID: &parentID, ForkedAtDay: &forkedAt, Seed: source.Seed,
+			Status: string(models.GameLobby), Scenario: source.Scenario,
+			TestFailure: source.TestFailure, Rework: source.Rework,
+			EscapedDefect: source.EscapedDefect, DefectEffort: source.DefectEffort,
+		})
+
+		// 3) effort types
+		effortTypeIDs := make(map[uuid.UUID]uuid.UUID)
+		for _, et := range gameEffortTypes(t, sourceID) {
+			id := uuid.New()
+			t.EffortTypes = append(t.EffortTypes, memstore.EffortType{
+				ID: id, GameID: gameID, Title: et.Title, OrderIndex: et.OrderIndex,
+			})
+			effortTypeIDs[et.ID] = id
+		}
+
+		// 4) columns, parents before their subcolumns
+		columnIDs := make(map[uuid.UUID]uuid.UUID, len(state.Columns))
+		for _, topLevel := range []bool{true, false} {
+			for _, col := range state.Columns {
+				if (col.ParentID == nil) != topLevel {
+					continue
+				}
+				var parentID *uuid.UUID
+				if col.ParentID != nil {
+					id, ok := columnIDs[*col.ParentID]
+					if !ok {
+						return fmt.Errorf("unknown parent of column %q", col.Title)
+					}
+					parentID = &id
+				}
+				id := uuid.New()
+				t.Columns = append(t.Columns, memstore.Column{
+					ID: id, GameID: gameID, ParentID: parentID, Title: col.Title,
+					WIPLimit: col.WIPLimit, Type: col.Type, OrderIndex: col.OrderIndex,
+				})
+				columnIDs[col.ID] = id
+			}
+		}
+
+		// 5) cards and their efforts
+		cardIDs := make(map[uuid.UUID]uuid.UUID, len(state.Cards))
+		for _, c := range state.Cards {
+			colID, ok := columnIDs[c.ColumnID]
+			if !ok {
+				return fmt.Errorf("unknown column of card %q", c.Title)
+			}
+			id := uuid.New()
+			card := memstore.Card{
+				ID: id, GameID: gameID, ColumnID: colID, Title: c.Title,
+				ClassOfService: c.ClassOfService, ValueEstimate: c.ValueEstimate,
+				SelectedDay: c.SelectedDay, DeployedDay: c.DeployedDay, OrderIndex: c.OrderIndex,
+			}
+			setBlocker(&card, c.Blocker)
+			t.Cards = append(t.Cards, card)
+			cardIDs[c.ID] = id
+
+			for _, e := range c.Efforts {
+				etID, ok := effortTypeIDs[e.EffortTypeID]
+				if !ok {
+					return fmt.Errorf("unknown effort type of card %q", c.Title)
+				}
+				t.Efforts = append(t.Efforts, memstore.Effort{
+					ID: uuid.New(), CardID: id, EffortTypeID: etID,
+					Estimate: e.Estimate, Remaining: e.Remaining, Actual: e.Actual,
+				})
+			}
+		}
+
+		// 6) players; the facilitator's copy facilitates the fork
+		fork := t.Game(gameID)
+		for _, p := range t.Players {
+			if p.GameID != sourceID {
+				continue
+			}
+			id := uuid.New()
+			t.Players = append(t.Players, memstore.Player{ID: id, GameID: gameID, Name: p.Name, Seat: p.Seat})
+			if source.FacilitatorID != nil && *source.FacilitatorID == p.ID {
+				t.Games[fork].FacilitatorID = &id
+			}
+		}
+
+		// 7) card events up to and including the fork day; the fork has a
+		// lifecycle of its own
+		for _, ev := range sortedEvents(t, sourceID) {
+			cardID, ok := cardIDs[ev.CardID]
+			if ev.Day > day || !ok {
+				continue
+			}
+			ev.ID, ev.GameID, ev.CardID = uuid.New(), gameID, cardID
+			t.Events = append(t.Events, ev)
+		}
+		return saveMemorySnapshot(t, gameID, InitialDay)
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return gameID, nil
+}
+
+// sortedEvents returns the events of a game by created_at.
+func sortedEvents(t *memstore.Tables, gameID uuid.UUID) []memstore.Event {
+	var out []memstore.Event
+	for _, ev := range t.Events {
+		if ev.GameID == gameID {
+			out = append(out, ev)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out
+}
+
+func (r *memoryRepo) ExportGame(ctx context.Context, id uuid.UUID) (models.GameExport, error) {
+	var doc models.GameExport
+	err := r.store.View(func(t *memstore.Tables) error {
+		i := t.LiveGame(id)
+		if i < 0 {
+			return ErrNotFound
+		}
+
+		var effortTypes []models.EffortType
+		for _, et := range gameEffortTypes(t, id) {
+			effortTypes = append(effortTypes, models.EffortType{ID: et.ID, Title: et.Title, OrderIndex: et.OrderIndex})
+		}
+
+		var seated []memstore.Player
+		for _, p := range t.Players {
+			if p.GameID == id {
+				seated = append(seated, p)
+			}
+		}
+		sort.SliceStable(seated, func(i, j int) bool { return seated[i].Seat < seated[j].Seat })
+		var players []models.ExportPlayer
+		for _, p := range seated {
+			players = append(players, models.ExportPlayer{ID: p.ID, Name: p.Name})
+		}
+
+		var events []models.GameEvent
+		for _, ev := range sortedEvents(t, id) {
+			events = append(events, models.GameEvent{
+				ID: ev.ID, GameID: ev.GameID, CardID: eventCard(ev), EventType: ev.EventType,
+				Payload: ev.Payload, Day: ev.Day, CreatedAt: ev.CreatedAt,
+			})
+		}
+
+		doc = buildExport(gameModel(t.Games[i]), effortTypes, memoryBoardState(t, id), players, events)
+		return nil
+	})
+	return doc, err
+}
+
+func (r *memoryRepo) ImportGame(ctx context.Context, doc models.GameExport) (uuid.UUID, error) {
+	gameID := uuid.New()
+	err := r.store.Update(func(t *memstore.Tables) error {
+		// 1) the game itself, on the day it was exported
+		day := doc.Game.Day
+		if day < 1 {
+			day = 1
+		}
+		g := memstore.Game{
+			ID: gameID, CreatedAt: memstore.Now(), Day: day, Seed: doc.Seed,
+			Status: string(models.GameLobby), Scenario: doc.Game.Scenario,
+		}
+		setQuality(&g, doc.Game.Quality)
+		t.Games = append(t.Games, g)
+
+		// 2) effort types, columns, cards and efforts
+		cardIDs, err := seedMemoryBoard(t, gameID, models.BoardConfig{
+			EffortTypes: doc.EffortTypes,
+			Columns:     doc.Columns,
+			Cards:       doc.Cards,
+		})
+		if err != nil {
+			return err
+		}
+
+		// 3) players; the facilitator's copy facilitates the new game
+		game := t.Game(gameID)
+		for seat, p := range doc.Players {
+			id := uuid.New()
+			t.Players = append(t.Players, memstore.Player{ID: id, GameID: gameID, Name: p.Name, Seat: seat})
+			if doc.Game.FacilitatorID != nil && *doc.Game.FacilitatorID == p.ID {
+				t.Games[game].FacilitatorID = &id
+			}
+		}
+
+		// 4) card events, remapped onto the new cards; the new game has a
+		// lifecycle of its own
+		newCardIDs := make(map[uuid.UUID]uuid.UUID, len(cardIDs))
+		for i, c := range doc.Cards {
+			newCardIDs[c.ID] = cardIDs[i]
+		}
+		for _, ev := range doc.Events {
+			if ev.CardID == nil {
+				continue
+			}
+			cardID, ok := newCardIDs[*ev.CardID]
+			if !ok {
+				return fmt.Errorf("%w: event references unknown card %s", ErrInvalidExport, ev.CardID)
+			}
+			payload := []byte(ev.Payload)
+			if len(payload) == 0 {
+				payload = []byte("{}")
+			}
+			t.Events = append(t.Events, memstore.Event{
+				ID: uuid.New(), GameID: gameID, CardID: cardID, EventType: ev.EventType,
+				Payload: payload, Day: ev.Day, CreatedAt: ev.CreatedAt,
+			})
+		}
+		return saveMemorySnapshot(t, gameID, InitialDay)
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return gameID, nil
+}
+
+func (r *memoryRepo) ApplyDay(ctx context.Context, gameID uuid.UUID, day int, changes []models.CardChange) error {
+	return r.store.Update(func(t *memstore.Tables) error {
+		if t.LiveGame(gameID) < 0 {
+			return fmt.Errorf("game %s: %w", gameID, ErrNotFound)
+		}
+		effortTypes := make(map[string]uuid.UUID)
+		for _, et := range t.EffortTypes {
+			if et.GameID == gameID {
+				effortTypes[et.Title] = et.ID
+			}
+		}
+
+		// events of the same day keep the order of the moves
+		at := memstore.Now()
+		for _, ch := range changes {
+			if ch.Spawned {
+				t.Cards = append(t.Cards, memstore.Card{
+					ID: ch.CardID, GameID: gameID, ColumnID: ch.ColumnID,
+					Title: ch.Title, ClassOfService: ch.ClassOfService, ValueEstimate: ch.ValueEstimate,
+					SelectedDay: ch.SelectedDay, DeployedDay: ch.DeployedDay, OrderIndex: bottom(t, gameID, ch.ColumnID),
+				})
+				for _, e := range ch.Efforts {
+					t.Efforts = append(t.Efforts, memstore.Effort{
+						ID: uuid.New(), CardID: ch.CardID, EffortTypeID: effortTypes[e.EffortType],
+						Estimate: e.Estimate, Remaining: e.Remaining, Actual: e.Actual,
+					})
+				}
+				continue
+			}
+
+			// 1) where the card is now; a card new to a column goes to the bottom
+			i := t.Card(ch.CardID)
+			if i < 0 || t.Cards[i].GameID != gameID {
+				return fmt.Errorf("update card %s: %w", ch.CardID, ErrNotFound)
+			}
+			if t.Cards[i].ColumnID != ch.ColumnID {
+				t.Cards[i].OrderIndex = bottom(t, gameID, ch.ColumnID)
+			}
+			t.Cards[i].ColumnID = ch.ColumnID
+			t.Cards[i].SelectedDay = ch.SelectedDay
+			t.Cards[i].DeployedDay = ch.DeployedDay
+			setBlocker(&t.Cards[i], ch.Blocker)
+
+			// 2) the work done on it
+			for _, e := range ch.Efforts {
+				for j, row := range t.Efforts {
+					if row.CardID == ch.CardID && row.EffortTypeID == effortTypes[e.EffortType] {
+						t.Efforts[j].Remaining = e.Remaining
+						t.Efforts[j].Actual = e.Actual
+					}
+				}
+			}
+
+			// 3) a move event per column crossed
+			for _, m := range ch.Moves {
+				payload, err := json.Marshal(m)
+				if err != nil {
+					return fmt.Errorf("marshal move: %w", err)
+				}
+				at = at.Add(time.Microsecond)
+				t.Events = append(t.Events, memstore.Event{
+					ID: uuid.New(), GameID: gameID, CardID: ch.CardID, EventType: "move",
+					Payload: payload, Day: day, CreatedAt: at,
+				})
+			}
+
+			// 4) an unblock event once its blocker is worked off
+			if ch.Unblock != nil {
+				payload, err := json.Marshal(ch.Unblock)
+				if err != nil {
+					return fmt.Errorf("marshal unblock: %w", err)
+				}
+				at = at.Add(time.Microsecond)
+				t.Events = append(t.Events, memstore.Event{
+					ID: uuid.New(), GameID: gameID, CardID: ch.CardID, EventType: "unblock",
+					Payload: payload, Day: day, CreatedAt: at,
+				})
+			}
+
+			// 5) what went wrong with its quality
+			if ch.TestFailure != nil {
+				payload, err := json.Marshal(ch.TestFailure)
+				if err != nil {
+					return fmt.Errorf("marshal test_failed: %w", err)
+				}
+				at = at.Add(time.Microsecond)
+				t.Events = append(t.Events, memstore.Event{
+					ID: uuid.New(), GameID: gameID, CardID: ch.CardID, EventType: "test_failed",
+					Payload: payload, Day: day, CreatedAt: at,
+				})
+			}
+			if ch.Defect != nil {
+				payload, err := json.Marshal(ch.Defect)
+				if err != nil {
+					return fmt.Errorf("marshal defect_escaped: %w", err)
+				}
+				at = at.Add(time.Microsecond)
+				t.Events = append(t.Events, memstore.Event{
+					ID: uuid.New(), GameID: gameID, CardID: ch.CardID, EventType: "defect_escaped",
+					Payload: payload, Day: day, CreatedAt: at,
+				})
+			}
+		}
+
+		// close the gaps cards left in the columns they moved out of
+		renumberMemoryCards(t, gameID)
+		return nil
+	})
+}
+
+// memoryBlocker is scanBlocker for a card row of the in-memory tables.
+func memoryBlocker(c memstore.Card) *models.Blocker {
+	if c.BlockedReason == nil {
+		return nil
+	}
+	return &models.Blocker{Reason: *c.BlockedReason, BlockedBy: c.BlockedBy, Day: c.BlockedDay, Effort: c.UnblockEffort}
+}
+
+// setBlocker is blockerValues for a card row of the in-memory tables.
+func setBlocker(c *memstore.Card, b *models.Blocker) {
+	if b == nil {
+		c.BlockedReason, c.BlockedBy, c.BlockedDay, c.UnblockEffort = nil, "", 0, 0
+		return
+	}
+	reason := b.Reason
+	c.BlockedReason, c.BlockedBy, c.BlockedDay, c.UnblockEffort = &reason, b.BlockedBy, b.Day, b.Effort
+}
+
+func (r *memoryRepo) BlockCard(ctx context.Context, gameID, cardID, playerID uuid.UUID, b models.Blocker) (models.Blocker, error) {
+	err := r.store.Update(func(t *memstore.Tables) error {
+		b.BlockedBy = ""
+		if pi := t.Player(playerID); pi >= 0 && t.Players[pi].GameID == gameID {
+			b.BlockedBy = t.Players[pi].Name
+		}
+		i := t.Card(cardID)
+		if i < 0 || t.Cards[i].GameID != gameID {
+			return fmt.Errorf("update card %s: %w", cardID, ErrNotFound)
+		}
+		payload, err := json.Marshal(b)
+		if err != nil {
+			return fmt.Errorf("marshal blocker: %w", err)
+		}
+		setBlocker(&t.Cards[i], &b)
+		t.Events = append(t.Events, memstore.Event{
+			ID: uuid.New(), GameID: gameID, CardID: cardID, EventType: "block",
+			Payload: payload, Day: b.Day, CreatedAt: memstore.Now(),
+		})
+		return nil
+	})
+	if err != nil {
+		return models.Blocker{}, err
+	}
+	return b, nil
+}
+
+func (r *memoryRepo) UnblockCard(ctx context.Context, gameID, cardID uuid.UUID, day int, u models.Unblock) error {
+	payload, err := json.Marshal(u)
+	if err != nil {
+		return fmt.Errorf("marshal unblock: %w", err)
+	}
+	return r.store.Update(func(t *memstore.Tables) error {
+		i := t.Card(cardID)
+		if i < 0 || t.Cards[i].GameID != gameID {
+			return fmt.Errorf("update card %s: %w", cardID, ErrNotFound)
+		}
+		setBlocker(&t.Cards[i], nil)
+		t.Events = append(t.Events, memstore.Event{
+			ID: uuid.New(), GameID: gameID, CardID: cardID, EventType: "unblock",
+			Payload: payload, Day: day, CreatedAt: memstore.Now(),
+		})
+		return nil
+	})
+}
+
+func (r *memoryRepo) PlayerSeat(ctx context.Context, gameID, playerID uuid.UUID) (int, bool, error) {
+	var (
+		seat int
+		ok   bool
+	)
+	err := r.store.View(func(t *memstore.Tables) error {
+		pi := t.Player(playerID)
+		if ok = pi >= 0 && t.Players[pi].GameID == gameID; ok {
+			seat = t.Players[pi].Seat
+		}
+		return nil
+	})
+	return seat, ok, err
+}
+
+func (r *memoryRepo) SetFacilitator(ctx context.Context, gameID, playerID uuid.UUID) error {
+	return r.store.Update(func(t *memstore.Tables) error {
+		gi := t.LiveGame(gameID)
+		if gi < 0 {
+			return ErrNotFound
+		}
+		if pi := t.Player(playerID); pi < 0 || t.Players[pi].GameID != gameID {
+			return ErrPlayerNotInGame
+		}
+		t.Games[gi].FacilitatorID = &playerID
+		return nil
+	})
+}
+
+func (r *memoryRepo) SetStatus(ctx context.Context, id uuid.UUID, from, to models.GameStatus, eventType string) error {
+	payload, err := json.Marshal(models.StatusChange{From: from, To: to})
+	if err != nil {
+		return fmt.Errorf("marshal status change: %w", err)
+	}
+	return r.store.Update(func(t *memstore.Tables) error {
+		i := t.LiveGame(id)
+		if i < 0 {
+			return ErrNotFound
+		}
+		if t.Games[i].Status != string(from) {
+			return ErrInvalidTransition
+		}
+		t.Games[i].Status = string(to)
+		t.Events = append(t.Events, memstore.Event{
+			ID: uuid.New(), GameID: id, EventType: eventType,
+			Payload: payload, Day: t.Games[i].Day, CreatedAt: memstore.Now(),
+		})
+		return nil
+	})
+}
+
+// bottom is the order index of a card put at the bottom of a column.
+func bottom(t *memstore.Tables, gameID, columnID uuid.UUID) int {
+	order := -1
+	for _, c := range t.Cards {
+		if c.GameID == gameID && c.ColumnID == columnID {
+			order = max(order, c.OrderIndex)
+		}
+	}
+	return order + 1
+}
+
+// renumberMemoryCards numbers the cards of every column of a game from 0
+// again, keeping their order, like renumberCards.
+func renumberMemoryCards(t *memstore.Tables, gameID uuid.UUID) {
+	var idx []int
+	for i, c := range t.Cards {
+		if c.GameID == gameID {
+			idx = append(idx, i)
+		}
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		x, y := t.Cards[idx[a]], t.Cards[idx[b]]
+		if x.OrderIndex != y.OrderIndex {
+			return x.OrderIndex < y.OrderIndex
+		}
+		return x.ID.String() < y.ID.String()
+	})
+	next := make(map[uuid.UUID]int)
+	for _, i := range idx {
+		col := t.Cards[i].ColumnID
+		t.Cards[i].OrderIndex = next[col]
+		next[col]++
+	}
+}
+
+// PositionCard puts the card among the other cards of the column and closes
+// the gap it left in its old one, like the SQL TX.
+func (r *memoryRepo) PositionCard(ctx context.Context, gameID uuid.UUID, p Placement) (models.CardOrder, error) {
+	var order []models.OrderedCard
+	err := r.store.Update(func(t *memstore.Tables) error {
+		if t.LiveGame(gameID) < 0 {
+			return fmt.Errorf("game %s: %w", gameID, ErrNotFound)
+		}
+		i := t.Card(p.CardID)
+		if i < 0 || t.Cards[i].GameID != gameID {
+			return fmt.Errorf("card %s: %w", p.CardID, ErrNotFound)
+		}
+		if t.Cards[i].ColumnID != p.From {
+			return fmt.Errorf("%w: %s", ErrCardMoved, p.CardID)
+		}
+		moved := t.Cards[i].ColumnID != p.ColumnID
+		if moved {
+			t.Cards[i].ColumnID = p.ColumnID
+			t.Cards[i].SelectedDay = p.SelectedDay
+		}
+
+		var others []models.OrderedCard
+		at := make(map[uuid.UUID]int)
+		for j, c := range t.Cards {
+			if c.GameID == gameID && c.ColumnID == p.ColumnID && c.ID != p.CardID {
+				others = append(others, models.OrderedCard{ID: c.ID, Title: c.Title, OrderIndex: c.OrderIndex})
+				at[c.ID] = j
+			}
+		}
+		sort.SliceStable(others, func(a, b int) bool {
+			if others[a].OrderIndex != others[b].OrderIndex {
+				return others[a].OrderIndex < others[b].OrderIndex
+			}
+			return others[a].ID.String() < others[b].ID.String()
+		})
+		at[p.CardID] = i
+		order = place(others, models.OrderedCard{ID: p.CardID, Title: t.Cards[i].Title}, p.Index)
+		for _, c := range order {
+			t.Cards[at[c.ID]].OrderIndex = c.OrderIndex
+		}
+		if moved {
+			renumberMemoryCards(t, gameID)
+		}
+
+		if p.Move != nil {
+			payload, err := json.Marshal(p.Move)
+			if err != nil {
+				return fmt.Errorf("marshal move: %w", err)
+			}
+			t.Events = append(t.Events, memstore.Event{
+				ID: uuid.New(), GameID: gameID, CardID: p.CardID, EventType: "move",
+				Payload: payload, Day: p.Day, CreatedAt: memstore.Now(),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return models.CardOrder{}, err
+	}
+	return models.CardOrder{ColumnID: p.ColumnID, Cards: order}, nil
+}
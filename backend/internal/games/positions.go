@@ -0,0 +1,159 @@
+package games
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidPosition is returned for a place a card cannot be put in.
+	ErrInvalidPosition = errors.New("invalid card position")
+	// ErrCardMoved is returned when a card changed column while it was being
+	// positioned.
+	ErrCardMoved = errors.New("card moved meanwhile")
+)
+
+// Placement is where PositionCard puts a card, and what changes with it when
+// the card lands in another column.
+type Placement struct {
+	CardID      uuid.UUID
+	ColumnID    uuid.UUID
+	From        uuid.UUID          // column the card is in; ErrCardMoved if no longer
+	Index       int                // place in the column, past the end for the bottom
+	Day         int                // day the move is logged on
+	SelectedDay int                // the card's selected day afterwards
+	Move        *models.ColumnMove // set when the card changes column
+}
+
+// PositionCard puts a card of a running game at a place in a column, top
+// first, and returns the new order of that column. The other cards of the
+// column shift to make room, so the order stays numbered from 0 without
+// gaps. A card put into another column is moved there like in play: the move
+// is logged on the current day, and a card leaving the backlog is selected.
+// Blocked cards can be reordered but not moved; deployed cards neither. A
+// card moved by someone else meanwhile is left alone.
+func (s *Service) PositionCard(ctx context.Context, gameID, cardID uuid.UUID, pos models.CardPosition) (models.CardOrder, error) {
+	if pos.Index < 0 {
+		return models.CardOrder{}, fmt.Errorf("%w: index %d", ErrInvalidPosition, pos.Index)
+	}
+	game, err := s.repo.GetGameByID(ctx, gameID)
+	if err != nil {
+		return models.CardOrder{}, err
+	}
+	if err := checkRunning(game); err != nil {
+		return models.CardOrder{}, err
+	}
+	board, err := s.repo.GetBoard(ctx, gameID)
+	if err != nil {
+		return models.CardOrder{}, err
+	}
+	i := slices.IndexFunc(board.Cards, func(c models.Card) bool { return c.ID == cardID })
+	if i < 0 {
+		return models.CardOrder{}, fmt.Errorf("%w: %s", ErrCardNotFound, cardID)
+	}
+	card := board.Cards[i]
+	if card.DeployedDay > 0 {
+		return models.CardOrder{}, ErrCardDeployed
+	}
+
+	p := Placement{
+		CardID:      cardID,
+		ColumnID:    card.ColumnID,
+		From:        card.ColumnID,
+		Index:       pos.Index,
+		Day:         game.Day,
+		SelectedDay: card.SelectedDay,
+	}
+	if pos.ColumnID == nil || *pos.ColumnID == card.ColumnID {
+		return s.repo.PositionCard(ctx, gameID, p)
+	}
+
+	if card.Blocker != nil {
+		return models.CardOrder{}, ErrCardBlocked
+	}
+	leaves := leafColumns(board.Columns)
+	from := slices.IndexFunc(leaves, func(l leafColumn) bool { return l.ID == card.ColumnID })
+	to := slices.IndexFunc(leaves, func(l leafColumn) bool { return l.ID == *pos.ColumnID })
+	if to < 0 || leaves[to].Type == "done" {
+		return models.CardOrder{}, fmt.Errorf("%w: %s is not a column cards can be put in", ErrInvalidPosition, *pos.ColumnID)
+	}
+	p.ColumnID = *pos.ColumnID
+	p.Move = &models.ColumnMove{To: leaves[to].Title}
+	if from >= 0 {
+		p.Move.From = leaves[from].Title
+	}
+	if from == 0 && p.SelectedDay == 0 {
+		p.SelectedDay = game.Day
+	}
+	return s.repo.PositionCard(ctx, gameID, p)
+}
+
+// leafColumn is a column cards can sit in, titled like the stages of the
+// engine: "Parent - Sub" for sub-columns.
+type leafColumn struct {
+	ID    uuid.UUID
+	Title string
+	Type  string
+}
+
+// leafColumns lists the columns of a board cards can sit in, in board order.
+func leafColumns(cols []models.Column) []leafColumn {
+	top := append([]models.Column(nil), cols...)
+	sort.SliceStable(top, func(i, j int) bool { return top[i].OrderIndex < top[j].OrderIndex })
+
+	var out []leafColumn
+	for _, col := range top {
+		if len(col.SubColumns) == 0 {
+			out = append(out, leafColumn{ID: col.ID, Title: col.Title, Type: col.Type})
+			continue
+		}
+		subs := append([]models.Column(nil), col.SubColumns...)
+		sort.SliceStable(subs, func(i, j int) bool { return subs[i].OrderIndex < subs[j].OrderIndex })
+		for _, sub := range subs {
+			typ := sub.Type
+			if typ == "" {
+				typ = col.Type
+			}
+			out = append(out, leafColumn{ID: sub.ID, Title: col.Title + " - " + sub.Title, Type: typ})
+		}
+	}
+	return out
+}
+
+// columnOrder numbers the cards of a board config per column from 0: by
+// their order index and, for equal ones, in the order they are listed.
+func columnOrder(cards []models.Card) []int {
+	idx := make([]int, len(cards))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return cards[idx[a]].OrderIndex < cards[idx[b]].OrderIndex })
+
+	next := make(map[string]int)
+	order := make([]int, len(cards))
+	for _, i := range idx {
+		order[i] = next[cards[i].ColumnTitle]
+		next[cards[i].ColumnTitle]++
+	}
+	return order
+}
+
+// place puts a card into the other cards of a column at index, clamped to
+// the column, and numbers them all again from 0.
+func place(cards []models.OrderedCard, card models.OrderedCard, index int) []models.OrderedCard {
+	index = min(index, len(cards))
+	out := make([]models.OrderedCard, 0, len(cards)+1)
+	out = append(out, cards[:index]...)
+	out = append(out, card)
+	out = append(out, cards[index:]...)
+	for i := range out {
+		out[i].OrderIndex = i
+	}
+	return out
+}
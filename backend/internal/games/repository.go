@@ -3,7 +3,9 @@ package games
 import (
 	"context"
 	"database/sql"
+	"time"
 
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
 	"github.com/Germanicus1/kanban-sim/backend/internal/models"
 	"github.com/google/uuid"
 )
@@ -16,9 +18,68 @@ type Repository interface {
 	DeleteGame(ctx context.Context, id uuid.UUID) error
 	UpdateGame(ctx context.Context, id uuid.UUID, day int) error
 	ListGames(ctx context.Context) ([]models.Game, error)
+	// SearchGames returns a page of the games that pass a search, how many
+	// pass it on all pages, and whether more follow the page.
+	SearchGames(ctx context.Context, s GameSearch) (page []models.Game, total int, more bool, err error)
+	// ScoredGames lists the games that pass the filters of a query, whatever
+	// its sort and page, oldest first, with their kept scores.
+	ScoredGames(ctx context.Context, q models.GameQuery) ([]ScoredGame, error)
+	// SaveScore keeps the score of an ended game.
+	SaveScore(ctx context.Context, gameID uuid.UUID, s GameScore) error
+	// InactiveGames lists the games, not soft-deleted, that were created and
+	// had their last event before a time.
+	InactiveGames(ctx context.Context, before time.Time) ([]models.Game, error)
+	// SoftDeleteGame hides a game from reads until it is deleted for good,
+	// unless it was active since inactiveBefore; it reports whether it did.
+	SoftDeleteGame(ctx context.Context, id uuid.UUID, inactiveBefore, at time.Time) (bool, error)
+	// SoftDeletedGames lists the games soft-deleted before a time.
+	SoftDeletedGames(ctx context.Context, before time.Time) ([]models.Game, error)
+	// SaveSnapshot stores the current board as the state at the end of day;
+	// under InitialDay it keeps the board a game started with, which
+	// CreateGame, ForkGame and ImportGame store along with the new game.
+	SaveSnapshot(ctx context.Context, id uuid.UUID, day int) error
+	ForkGame(ctx context.Context, id uuid.UUID, day int) (uuid.UUID, error)
+	ExportGame(ctx context.Context, id uuid.UUID) (models.GameExport, error)
+	ImportGame(ctx context.Context, doc models.GameExport) (uuid.UUID, error)
+	// ApplyDay records what was played on a day: where the cards moved, the
+	// work done on them, a move event per column crossed and an unblock
+	// event per blocker worked off.
+	ApplyDay(ctx context.Context, id uuid.UUID, day int, changes []models.CardChange) error
+	// PlayerSeat returns the seat of a player of a game, and whether the
+	// player plays in it.
+	PlayerSeat(ctx context.Context, gameID, playerID uuid.UUID) (int, bool, error)
+	// BlockCard puts a blocker on a card of a game, put down to the player
+	// with the given ID by name, and logs a block event. It returns the
+	// blocker as stored.
+	BlockCard(ctx context.Context, gameID, cardID, playerID uuid.UUID, b models.Blocker) (models.Blocker, error)
+	// UnblockCard lifts the blocker of a card and logs an unblock event on
+	// the given day.
+	UnblockCard(ctx context.Context, gameID, cardID uuid.UUID, day int, u models.Unblock) error
+	// PositionCard puts a card of a game at a place in a column and numbers
+	// the cards of every column it touched again from 0, in one TX. It moves
+	// the card to the column if it is in another, and then logs the move on
+	// the placement's day. It returns the new order of the column.
+	PositionCard(ctx context.Context, gameID uuid.UUID, p Placement) (models.CardOrder, error)
+	// SetFacilitator hands the facilitator role of a game to one of its
+	// players.
+	SetFacilitator(ctx context.Context, gameID, playerID uuid.UUID) error
+	// SetStatus moves a game from status from to status to and logs the
+	// change as an event of the given type. It fails with
+	// ErrInvalidTransition if the game is not in status from.
+	SetStatus(ctx context.Context, id uuid.UUID, from, to models.GameStatus, eventType string) error
+	// ResetGame restores the board of a game from its InitialDay snapshot,
+	// clears what was played and puts it back on day 1 in the lobby. It
+	// fails with ErrSnapshotNotFound if the game has no initial board.
+	ResetGame(ctx context.Context, id uuid.UUID) error
 }
 
 // NewSQLRepo constructs a games.Repository backed by *sql.DB.
 func NewSQLRepo(db *sql.DB) Repository {
 	return &sqlRepo{db: db}
 }
+
+// NewMemoryRepo constructs a games.Repository on an in-memory store. Share
+// one store between the repositories of all packages.
+func NewMemoryRepo(store *memstore.Store) Repository {
+	return &memoryRepo{store: store}
+}
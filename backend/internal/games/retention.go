@@ -0,0 +1,98 @@
+package games
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/Germanicus1/kanban-sim/backend/internal/response"
+)
+
+// RetentionPolicy is when abandoned games are cleaned up. A game is
+// inactive since its last event, or since it was created if it has none.
+type RetentionPolicy struct {
+	InactiveFor time.Duration // soft-delete games inactive this long
+	Grace       time.Duration // delete them for good this long after
+	DryRun      bool          // only report what would be removed
+}
+
+// Cleanup is what one run of a retention policy removed, or would have
+// removed in a dry run.
+type Cleanup struct {
+	SoftDeleted []models.Game
+	Deleted     []models.Game
+}
+
+// CleanUp applies a retention policy as of now: games soft-deleted longer
+// than the grace period are deleted with everything they own, then the
+// inactive games are soft-deleted. A dry run changes nothing, so it only
+// lists games that were soft-deleted before.
+func (s *Service) CleanUp(ctx context.Context, p RetentionPolicy, now time.Time) (Cleanup, error) {
+	var c Cleanup
+
+	expired, err := s.repo.SoftDeletedGames(ctx, now.Add(-p.Grace))
+	if err != nil {
+		return c, fmt.Errorf("list soft-deleted games: %w", err)
+	}
+	for _, g := range expired {
+		if !p.DryRun {
+			// a game deleted by hand in the meantime is gone all the same
+			if err := s.repo.DeleteGame(ctx, g.ID); err != nil && !errors.Is(err, response.ErrNotFound) {
+				return c, fmt.Errorf("delete game %s: %w", g.ID, err)
+			}
+		}
+		c.Deleted = append(c.Deleted, g)
+	}
+
+	cutoff := now.Add(-p.InactiveFor)
+	inactive, err := s.repo.InactiveGames(ctx, cutoff)
+	if err != nil {
+		return c, fmt.Errorf("list inactive games: %w", err)
+	}
+	for _, g := range inactive {
+		if !p.DryRun {
+			done, err := s.repo.SoftDeleteGame(ctx, g.ID, cutoff, now)
+			if err != nil {
+				return c, fmt.Errorf("soft-delete game %s: %w", g.ID, err)
+			}
+			if !done { // played since it was listed
+				continue
+			}
+		}
+		c.SoftDeleted = append(c.SoftDeleted, g)
+	}
+	return c, nil
+}
+
+// RunRetention applies a retention policy right away and then every
+// interval, logging each game it removes, until ctx is done. Failed runs
+// are logged and retried on the next tick.
+func (s *Service) RunRetention(ctx context.Context, p RetentionPolicy, every time.Duration) {
+	verb := ""
+	if p.DryRun {
+		verb = "would be "
+	}
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		c, err := s.CleanUp(ctx, p, time.Now().UTC())
+		for _, g := range c.Deleted {
+			log.Printf("Retention: game %s %sdeleted (%s, day %d, created %s)", g.ID, verb, g.Status, g.Day, g.CreatedAt)
+		}
+		for _, g := range c.SoftDeleted {
+			log.Printf("Retention: game %s %ssoft-deleted (%s, day %d, created %s)", g.ID, verb, g.Status, g.Day, g.CreatedAt)
+		}
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Retention: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package games
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidQuery is returned for a game list with an unknown status or
+	// sort, or a page size out of range.
+	ErrInvalidQuery = errors.New("invalid game query")
+	// ErrInvalidCursor is returned for a cursor that is not the next_cursor
+	// of a list sorted the same way.
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// Sorts of the game list.
+const (
+	SortCreated = "created"
+	SortDay     = "day" // by day, then by creation time
+)
+
+// Page sizes of the game list.
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// GameSearch is a checked game query, as repositories run it.
+type GameSearch struct {
+	models.GameQuery
+	After *GameKey // last game of the page before; nil for the first page
+}
+
+// GameKey is what a game is sorted by in the game list, whatever the sort.
+type GameKey struct {
+	Day       int
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// KeyOf returns the sort key of a listed game.
+func KeyOf(g models.Game) (GameKey, error) {
+	created, err := time.Parse(time.RFC3339Nano, g.CreatedAt)
+	if err != nil {
+		return GameKey{}, fmt.Errorf("parse created_at of game %s: %w", g.ID, err)
+	}
+	return GameKey{Day: g.Day, CreatedAt: created, ID: g.ID}, nil
+}
+
+// gameCursor is what the next_cursor of a page stands for: the sort key of
+// the last game on it, and the order it was listed in. It holds the key
+// rather than the game, so it still works once that game is deleted.
+type gameCursor struct {
+	Sort      string    `json:"s"`
+	Ascending bool      `json:"a,omitempty"`
+	Day       int       `json:"d,omitempty"`
+	CreatedAt time.Time `json:"c"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// SearchGames returns a page of the games that pass the query, in the order
+// it asks for, with the number of games that pass it on all pages. The
+// next_cursor of the page fetches the one after it; games played in between
+// may be skipped or seen twice when sorting by day.
+func (s *Service) SearchGames(ctx context.Context, q models.GameQuery) (models.GamePage, error) {
+	if q.Sort == "" {
+		q.Sort = SortCreated
+	}
+	if q.Sort != SortCreated && q.Sort != SortDay {
+		return models.GamePage{}, fmt.Errorf("%w: sort %q", ErrInvalidQuery, q.Sort)
+	}
+	switch q.Status {
+	case "", models.GameLobby, models.GameRunning, models.GamePaused, models.GameEnded:
+	default:
+		return models.GamePage{}, fmt.Errorf("%w: status %q", ErrInvalidQuery, q.Status)
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultPageSize
+	}
+	if q.Limit < 0 || q.Limit > MaxPageSize {
+		return models.GamePage{}, fmt.Errorf("%w: limit %d", ErrInvalidQuery, q.Limit)
+	}
+
+	search := GameSearch{GameQuery: q}
+	if q.Cursor != "" {
+		var c gameCursor
+		b, err := base64.RawURLEncoding.DecodeString(q.Cursor)
+		if err != nil || json.Unmarshal(b, &c) != nil || c.Sort != q.Sort || c.Ascending != q.Ascending ||
+			c.CreatedAt.IsZero() || c.ID == uuid.Nil {
+			return models.GamePage{}, ErrInvalidCursor
+		}
+		search.After = &GameKey{Day: c.Day, CreatedAt: c.CreatedAt, ID: c.ID}
+	}
+
+	list, total, more, err := s.repo.SearchGames(ctx, search)
+	if err != nil {
+		return models.GamePage{}, err
+	}
+	page := models.GamePage{Games: list, Total: total}
+	if page.Games == nil {
+		page.Games = []models.Game{}
+	}
+	if more && len(list) > 0 {
+		key, err := KeyOf(list[len(list)-1])
+		if err != nil {
+			return models.GamePage{}, err
+		}
+		b, err := json.Marshal(gameCursor{Sort: q.Sort, Ascending: q.Ascending, Day: key.Day, CreatedAt: key.CreatedAt, ID: key.ID})
+		if err != nil {
+			return models.GamePage{}, fmt.Errorf("marshal cursor: %w", err)
+		}
+		page.NextCursor = base64.RawURLEncoding.EncodeToString(b)
+	}
+	return page, nil
+}
@@ -2,7 +2,10 @@ package games
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/Germanicus1/kanban-sim/backend/internal/dice"
+	"github.com/Germanicus1/kanban-sim/backend/internal/engine"
 	"github.com/Germanicus1/kanban-sim/backend/internal/models"
 	"github.com/google/uuid"
 )
@@ -15,7 +18,19 @@ type ServiceInterface interface {
 	GetGame(ctx context.Context, id uuid.UUID) (models.Game, error)
 	DeleteGame(ctx context.Context, id uuid.UUID) error
 	UpdateGame(ctx context.Context, id uuid.UUID, day int) error
-	ListGames(ctx context.Context) ([]models.Game, error)
+	SearchGames(ctx context.Context, q models.GameQuery) (models.GamePage, error)
+	ForkGame(ctx context.Context, id uuid.UUID, day int) (uuid.UUID, error)
+	ExportGame(ctx context.Context, id uuid.UUID) (models.GameExport, error)
+	ImportGame(ctx context.Context, doc models.GameExport) (uuid.UUID, error)
+	RollDice(ctx context.Context, id, playerID, cardID uuid.UUID) (models.DiceRoll, error)
+	PlayBotDay(ctx context.Context, id uuid.UUID, strategy engine.Strategy, team []engine.Worker) (models.BotDay, error)
+	SetFacilitator(ctx context.Context, id, playerID uuid.UUID) error
+	ChangeStatus(ctx context.Context, id uuid.UUID, t Transition) (models.Game, error)
+	ResetGame(ctx context.Context, id uuid.UUID) (models.Game, error)
+	Leaderboard(ctx context.Context, score Score, f models.LeaderboardFilter) ([]models.LeaderboardEntry, error)
+	BlockCard(ctx context.Context, gameID, cardID, playerID uuid.UUID, reason string, effort int) (models.Blocker, error)
+	UnblockCard(ctx context.Context, gameID, cardID uuid.UUID) error
+	PositionCard(ctx context.Context, gameID, cardID uuid.UUID, pos models.CardPosition) (models.CardOrder, error)
 }
 
 // Service holds the business-logic methods.
@@ -28,8 +43,20 @@ func NewService(repo Repository) *Service {
 	return &Service{repo: repo}
 }
 
+// DefaultScenario is the scenario of games created from the default board,
+// and of imported games that do not name theirs.
+const DefaultScenario = "default"
+
 // CreateGame calls into your repo to persist a new game and seed all data.
+// A game created without a dice seed gets a random one. The board it starts
+// with is kept, to reset the game to.
 func (s *Service) CreateGame(ctx context.Context, cfg models.BoardConfig) (uuid.UUID, error) {
+	if cfg.Seed == 0 {
+		cfg.Seed = dice.NewSeed()
+	}
+	if cfg.Scenario == "" {
+		cfg.Scenario = DefaultScenario
+	}
 	return s.repo.CreateGame(ctx, cfg)
 }
 
@@ -48,11 +75,116 @@ func (s *Service) DeleteGame(ctx context.Context, id uuid.UUID) error {
 	return s.repo.DeleteGame(ctx, id)
 }
 
-// UpdateGame forwards to the repository.
+// UpdateGame sets the day of a running game. When the day moves forward, the
+// board is first snapshotted as the state at the end of the day being left,
+// so the game can later be forked from it.
 func (s *Service) UpdateGame(ctx context.Context, id uuid.UUID, day int) error {
+	game, err := s.repo.GetGameByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := checkRunning(game); err != nil {
+		return err
+	}
+	if day > game.Day {
+		if err := s.repo.SaveSnapshot(ctx, id, game.Day); err != nil {
+			return err
+		}
+	}
 	return s.repo.UpdateGame(ctx, id, day)
 }
 
 func (s *Service) ListGames(ctx context.Context) ([]models.Game, error) {
 	return s.repo.ListGames(ctx)
 }
+
+// ForkGame branches a new game off the given one at the end of day. A day of
+// 0 forks the current state, which the fork is reset to.
+func (s *Service) ForkGame(ctx context.Context, id uuid.UUID, day int) (uuid.UUID, error) {
+	return s.repo.ForkGame(ctx, id, day)
+}
+
+// ExportGame returns a portable archive of the whole game.
+func (s *Service) ExportGame(ctx context.Context, id uuid.UUID) (models.GameExport, error) {
+	return s.repo.ExportGame(ctx, id)
+}
+
+// ImportGame validates an archive and recreates it as a new game, which is
+// reset to the imported board.
+func (s *Service) ImportGame(ctx context.Context, doc models.GameExport) (uuid.UUID, error) {
+	if err := validateExport(doc); err != nil {
+		return uuid.Nil, err
+	}
+	if doc.Seed == 0 {
+		doc.Seed = dice.NewSeed()
+	}
+	if doc.Game.Scenario == "" {
+		doc.Game.Scenario = DefaultScenario
+	}
+	return s.repo.ImportGame(ctx, doc)
+}
+
+// RollDice rolls the die of a player working on a card on the game's current
+// day. The outcome only depends on the game's seed, the day, the player's
+// seat and the card's title, so asking again gives the same answer, and so
+// does the same player in a copy of the game. Dice are only rolled in
+// running games, for players and cards of the game.
+func (s *Service) RollDice(ctx context.Context, id, playerID, cardID uuid.UUID) (models.DiceRoll, error) {
+	game, err := s.repo.GetGameByID(ctx, id)
+	if err != nil {
+		return models.DiceRoll{}, err
+	}
+	if err := checkRunning(game); err != nil {
+		return models.DiceRoll{}, err
+	}
+	seat, ok, err := s.repo.PlayerSeat(ctx, id, playerID)
+	switch {
+	case err != nil:
+		return models.DiceRoll{}, err
+	case !ok:
+		return models.DiceRoll{}, fmt.Errorf("%w: %s", ErrPlayerNotInGame, playerID)
+	}
+	card, err := s.card(ctx, id, cardID)
+	if err != nil {
+		return models.DiceRoll{}, err
+	}
+	return models.DiceRoll{
+		Day:      game.Day,
+		PlayerID: playerID,
+		CardID:   cardID,
+		Value:    dice.New(game.Seed).Roll(game.Day, dice.SeatKey(seat), dice.CardKey(card.Title)),
+	}, nil
+}
+
+// SetFacilitator hands the facilitator role of a game that has not ended to
+// one of its players.
+func (s *Service) SetFacilitator(ctx context.Context, id, playerID uuid.UUID) error {
+	game, err := s.repo.GetGameByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if game.Status == models.GameEnded {
+		return ErrGameEnded
+	}
+	return s.repo.SetFacilitator(ctx, id, playerID)
+}
+
+// Plays reports whether a player still plays in a game. It serves as the
+// auth.PlayerLookup of the server.
+func (s *Service) Plays(ctx context.Context, gameID, playerID uuid.UUID) (bool, error) {
+	_, ok, err := s.repo.PlayerSeat(ctx, gameID, playerID)
+	return ok, err
+}
+
+// FacilitatorOf returns the player facilitating a game, uuid.Nil if nobody
+// is. It serves as the auth.FacilitatorLookup of the server.
+func (s *Service) FacilitatorOf(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	game, err := s.repo.GetGameByID(ctx, id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if game.FacilitatorID == nil {
+		return uuid.Nil, nil
+	}
+	return *game.FacilitatorID, nil
+}
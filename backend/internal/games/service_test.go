@@ -2,10 +2,20 @@
 package games
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"errors"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/Germanicus1/kanban-sim/backend/internal/config"
+	"github.com/Germanicus1/kanban-sim/backend/internal/engine"
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
 	"github.com/Germanicus1/kanban-sim/backend/internal/models"
 	"github.com/Germanicus1/kanban-sim/backend/internal/response"
 	"github.com/google/uuid"
@@ -19,6 +29,11 @@ type mockRepo struct {
 	wantBoard     models.Board
 	wantDeleteErr error
 	gotDeleteID   uuid.UUID
+	wantGame      models.Game
+	snapshotDays  []int
+	gotForkDay    int
+	gotImport     *models.GameExport
+	wantPlayer    uuid.UUID
 }
 
 func (m *mockRepo) CreateGame(ctx context.Context, cfg models.BoardConfig) (uuid.UUID, error) {
@@ -32,7 +47,7 @@ func (m *mockRepo) GetBoard(ctx context.Context, id uuid.UUID) (models.Board, er
 }
 
 func (m *mockRepo) GetGameByID(ctx context.Context, id uuid.UUID) (models.Game, error) {
-	return models.Game{}, nil
+	return m.wantGame, nil
 }
 
 func (m *mockRepo) DeleteGame(ctx context.Context, id uuid.UUID) error {
@@ -49,6 +64,90 @@ func (m *mockRepo) ListGames(ctx context.Context) ([]models.Game, error) {
 	return []models.Game{}, nil
 }
 
+func (m *mockRepo) InactiveGames(ctx context.Context, before time.Time) ([]models.Game, error) {
+	return nil, m.wantErr
+}
+
+func (m *mockRepo) SoftDeleteGame(ctx context.Context, id uuid.UUID, inactiveBefore, at time.Time) (bool, error) {
+	return false, m.wantErr
+}
+
+func (m *mockRepo) SoftDeletedGames(ctx context.Context, before time.Time) ([]models.Game, error) {
+	return nil, m.wantErr
+}
+
+func (m *mockRepo) PlayerSeat(ctx context.Context, gameID, playerID uuid.UUID) (int, bool, error) {
+	return 0, playerID == m.wantPlayer, m.wantErr
+}
+
+func (m *mockRepo) SearchGames(ctx context.Context, s GameSearch) ([]models.Game, int, bool, error) {
+	return nil, 0, false, m.wantErr
+}
+
+func (m *mockRepo) ScoredGames(ctx context.Context, q models.GameQuery) ([]ScoredGame, error) {
+	return nil, m.wantErr
+}
+
+func (m *mockRepo) SaveScore(ctx context.Context, gameID uuid.UUID, s GameScore) error {
+	return nil
+}
+
+func (m *mockRepo) SaveSnapshot(ctx context.Context, id uuid.UUID, day int) error {
+	m.snapshotDays = append(m.snapshotDays, day)
+	return nil
+}
+
+func (m *mockRepo) ForkGame(ctx context.Context, id uuid.UUID, day int) (uuid.UUID, error) {
+	m.gotGame = id
+	m.gotForkDay = day
+	return m.wantID, m.wantErr
+}
+
+func (m *mockRepo) ExportGame(ctx context.Context, id uuid.UUID) (models.GameExport, error) {
+	m.gotGame = id
+	return models.GameExport{Version: models.GameExportVersion}, m.wantErr
+}
+
+func (m *mockRepo) ImportGame(ctx context.Context, doc models.GameExport) (uuid.UUID, error) {
+	m.gotImport = &doc
+	return m.wantID, m.wantErr
+}
+
+func (m *mockRepo) ApplyDay(ctx context.Context, id uuid.UUID, day int, changes []models.CardChange) error {
+	m.gotGame = id
+	return m.wantErr
+}
+
+func (m *mockRepo) SetFacilitator(ctx context.Context, id, playerID uuid.UUID) error {
+	m.gotGame = id
+	return m.wantErr
+}
+
+func (m *mockRepo) SetStatus(ctx context.Context, id uuid.UUID, from, to models.GameStatus, eventType string) error {
+	m.gotGame = id
+	return m.wantErr
+}
+
+func (m *mockRepo) ResetGame(ctx context.Context, id uuid.UUID) error {
+	m.gotGame = id
+	return m.wantErr
+}
+
+func (m *mockRepo) BlockCard(ctx context.Context, gameID, cardID, playerID uuid.UUID, b models.Blocker) (models.Blocker, error) {
+	m.gotGame = gameID
+	return b, m.wantErr
+}
+
+func (m *mockRepo) PositionCard(ctx context.Context, gameID uuid.UUID, p Placement) (models.CardOrder, error) {
+	m.gotGame = gameID
+	return models.CardOrder{ColumnID: p.ColumnID}, m.wantErr
+}
+
+func (m *mockRepo) UnblockCard(ctx context.Context, gameID, cardID uuid.UUID, day int, u models.Unblock) error {
+	m.gotGame = gameID
+	return m.wantErr
+}
+
 func TestService_GetBoard(t *testing.T) {
 	wantID := uuid.New()
 	wantBoard := models.Board{GameID: wantID}
@@ -95,7 +194,7 @@ func TestService_DeleteGame_NotFound(t *testing.T) {
 func TestService_UpdateGame_Success(t *testing.T) {
 	id := uuid.New()
 	day := 1
-	mr := &mockRepo{}
+	mr := &mockRepo{wantGame: models.Game{ID: id, Status: models.GameRunning}}
 	svc := NewService(mr)
 
 	err := svc.UpdateGame(context.Background(), id, day)
@@ -106,3 +205,866 @@ func TestService_UpdateGame_Success(t *testing.T) {
 		t.Errorf("repo.UpdateGame called with %v; want %v", mr.wantID, id)
 	}
 }
+
+func TestService_UpdateGame_SnapshotsFinishedDay(t *testing.T) {
+	id := uuid.New()
+	mr := &mockRepo{wantGame: models.Game{ID: id, Day: 4, Status: models.GameRunning}}
+	svc := NewService(mr)
+
+	if err := svc.UpdateGame(context.Background(), id, 5); err != nil {
+		t.Fatalf("UpdateGame returned error: %v", err)
+	}
+	if len(mr.snapshotDays) != 1 || mr.snapshotDays[0] != 4 {
+		t.Errorf("snapshots taken for days %v; want [4]", mr.snapshotDays)
+	}
+
+	mr.snapshotDays = nil
+	if err := svc.UpdateGame(context.Background(), id, 3); err != nil {
+		t.Fatalf("UpdateGame returned error: %v", err)
+	}
+	if len(mr.snapshotDays) != 0 {
+		t.Errorf("snapshots taken for days %v when moving back; want none", mr.snapshotDays)
+	}
+}
+
+func TestService_ForkGame(t *testing.T) {
+	sourceID := uuid.New()
+	forkID := uuid.New()
+	mr := &mockRepo{wantID: forkID}
+	svc := NewService(mr)
+
+	got, err := svc.ForkGame(context.Background(), sourceID, 9)
+	if err != nil {
+		t.Fatalf("ForkGame returned error: %v", err)
+	}
+	if got != forkID {
+		t.Errorf("ForkGame = %v; want %v", got, forkID)
+	}
+	if mr.gotGame != sourceID || mr.gotForkDay != 9 {
+		t.Errorf("repo.ForkGame called with (%v, %d); want (%v, 9)", mr.gotGame, mr.gotForkDay, sourceID)
+	}
+}
+
+func TestService_CreateGame_PicksSeed(t *testing.T) {
+	mr := &mockRepo{}
+	svc := NewService(mr)
+
+	if _, err := svc.CreateGame(context.Background(), models.BoardConfig{}); err != nil {
+		t.Fatalf("CreateGame returned error: %v", err)
+	}
+	if mr.gotCfg.Seed == 0 {
+		t.Error("game created without a seed")
+	}
+
+	if _, err := svc.CreateGame(context.Background(), models.BoardConfig{Seed: 77}); err != nil {
+		t.Fatalf("CreateGame returned error: %v", err)
+	}
+	if mr.gotCfg.Seed != 77 {
+		t.Errorf("seed = %d; want 77", mr.gotCfg.Seed)
+	}
+}
+
+func TestService_RollDice(t *testing.T) {
+	ctx := context.Background()
+	gameID := uuid.New()
+	playerID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	cardID := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	board := models.Board{GameID: gameID, Cards: []models.Card{{ID: cardID, GameID: gameID, Title: "S1"}}}
+
+	// same seed, seat and card: the outcome only depends on the day
+	for day, want := range map[int]int{1: 2, 2: 1, 3: 4, 4: 1} {
+		mr := &mockRepo{wantGame: models.Game{ID: gameID, Day: day, Seed: 20240607, Status: models.GameRunning}, wantBoard: board, wantPlayer: playerID}
+		svc := NewService(mr)
+
+		got, err := svc.RollDice(ctx, gameID, playerID, cardID)
+		if err != nil {
+			t.Fatalf("RollDice returned error: %v", err)
+		}
+		if got.Value != want || got.Day != day {
+			t.Errorf("day %d: RollDice = %+v; want value %d", day, got, want)
+		}
+	}
+
+	// only for players and cards of the game
+	mr := &mockRepo{wantGame: models.Game{ID: gameID, Day: 1, Seed: 20240607, Status: models.GameRunning}, wantBoard: board, wantPlayer: playerID}
+	svc := NewService(mr)
+	if _, err := svc.RollDice(ctx, gameID, uuid.New(), cardID); !errors.Is(err, ErrPlayerNotInGame) {
+		t.Errorf("RollDice for a player of another game: err = %v; want %v", err, ErrPlayerNotInGame)
+	}
+	if _, err := svc.RollDice(ctx, gameID, playerID, uuid.New()); !errors.Is(err, ErrCardNotFound) {
+		t.Errorf("RollDice for a card of another game: err = %v; want %v", err, ErrCardNotFound)
+	}
+}
+
+func TestService_ImportGame(t *testing.T) {
+	cardID := uuid.New()
+	valid := models.GameExport{
+		Version:     models.GameExportVersion,
+		Game:        models.Game{Day: 7},
+		EffortTypes: []models.EffortType{{Title: "Analysis"}},
+		Columns: []models.Column{
+			{Title: "Analysis", SubColumns: []models.Column{{Title: "Done"}}},
+		},
+		Cards: []models.Card{{
+			ID: cardID, Title: "S1", ColumnTitle: "Analysis - Done",
+			Efforts: []models.Effort{{EffortType: "Analysis", Estimate: 3}},
+		}},
+		Players: []models.ExportPlayer{{Name: "Ada"}},
+		Events:  []models.GameEvent{{CardID: &cardID, EventType: "move", Day: 2}},
+	}
+
+	tests := []struct {
+		name     string
+		mutate   func(doc *models.GameExport)
+		wantErr  bool
+		wantRepo bool
+	}{
+		{"valid", func(doc *models.GameExport) {}, false, true},
+		{"unknown version", func(doc *models.GameExport) { doc.Version = 99 }, true, false},
+		{"unknown column", func(doc *models.GameExport) {
+			doc.Cards = []models.Card{{ID: cardID, Title: "S1", ColumnTitle: "Nowhere"}}
+		}, true, false},
+		{"unknown effort type", func(doc *models.GameExport) {
+			doc.Cards = []models.Card{{
+				ID: cardID, Title: "S1", ColumnTitle: "Analysis",
+				Efforts: []models.Effort{{EffortType: "Design"}},
+			}}
+		}, true, false},
+		{"event for unknown card", func(doc *models.GameExport) {
+			unknown := uuid.New()
+			doc.Events = []models.GameEvent{{CardID: &unknown, EventType: "move"}}
+		}, true, false},
+		{"quality out of range", func(doc *models.GameExport) {
+			doc.Game.Quality = models.Quality{TestFailure: 1.5}
+		}, true, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := valid
+			tc.mutate(&doc)
+			mr := &mockRepo{wantID: uuid.New()}
+			svc := NewService(mr)
+
+			got, err := svc.ImportGame(context.Background(), doc)
+			if tc.wantErr {
+				if !errors.Is(err, ErrInvalidExport) {
+					t.Errorf("ImportGame error = %v; want ErrInvalidExport", err)
+				}
+			} else if err != nil {
+				t.Fatalf("ImportGame returned error: %v", err)
+			} else if got != mr.wantID {
+				t.Errorf("ImportGame = %v; want %v", got, mr.wantID)
+			}
+			if (mr.gotImport != nil) != tc.wantRepo {
+				t.Errorf("repo.ImportGame called = %v; want %v", mr.gotImport != nil, tc.wantRepo)
+			}
+		})
+	}
+}
+
+func TestService_PlayBotDay(t *testing.T) {
+	ctx := context.Background()
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, err := engine.ParseTeam(engine.DefaultTeam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	strategy, err := engine.StrategyByName("strict-wip")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// two games with the same seed play the same day
+	play := func() (models.BotDay, models.Board) {
+		svc := NewService(NewMemoryRepo(memstore.New()))
+		id, err := svc.CreateGame(ctx, models.BoardConfig{
+			Seed: 11, EffortTypes: cfg.EffortTypes, Columns: cfg.Columns, Cards: cfg.Cards,
+		})
+		if err != nil {
+			t.Fatalf("CreateGame returned error: %v", err)
+		}
+		if _, err := svc.ChangeStatus(ctx, id, Start); err != nil {
+			t.Fatalf("ChangeStatus returned error: %v", err)
+		}
+		day, err := svc.PlayBotDay(ctx, id, strategy, team)
+		if err != nil {
+			t.Fatalf("PlayBotDay returned error: %v", err)
+		}
+		g, _ := svc.GetGame(ctx, id)
+		if g.Day != 2 {
+			t.Errorf("day after PlayBotDay = %d; want 2", g.Day)
+		}
+		b, _ := svc.GetBoard(ctx, id)
+		return day, b
+	}
+	first, board := play()
+	second, _ := play()
+
+	if first != second {
+		t.Errorf("same seed, different days:\n%+v\n%+v", first, second)
+	}
+	if first.Strategy != "strict-wip" || first.Day != 1 || first.Working == 0 {
+		t.Errorf("PlayBotDay = %+v; want day 1 of strict-wip with workers busy", first)
+	}
+
+	worked := false
+	for _, c := range board.Cards {
+		for _, e := range c.Efforts {
+			if e.Actual > 0 {
+				worked = true
+			}
+		}
+	}
+	if !worked {
+		t.Error("no work was stored on any card")
+	}
+}
+
+func TestService_PlayBotDay_Quality(t *testing.T) {
+	ctx := context.Background()
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, _ := engine.ParseTeam(engine.DefaultTeam)
+	strategy, _ := engine.StrategyByName("strict-wip")
+
+	// every card fails its testing and every deployed card has a defect
+	svc := NewService(NewMemoryRepo(memstore.New()))
+	id, err := svc.CreateGame(ctx, models.BoardConfig{
+		Seed: 11, EffortTypes: cfg.EffortTypes, Columns: cfg.Columns, Cards: cfg.Cards,
+		Quality: models.Quality{TestFailure: 1, Rework: 0.5, EscapedDefect: 1, DefectEffort: 0.5},
+	})
+	if err != nil {
+		t.Fatalf("CreateGame returned error: %v", err)
+	}
+	if _, err := svc.ChangeStatus(ctx, id, Start); err != nil {
+		t.Fatalf("ChangeStatus returned error: %v", err)
+	}
+	var failed, defects int
+	for day := 1; day <= 10; day++ {
+		d, err := svc.PlayBotDay(ctx, id, strategy, team)
+		if err != nil {
+			t.Fatalf("day %d: PlayBotDay returned error: %v", day, err)
+		}
+		failed += d.Failed
+		defects += d.Defects
+	}
+	if failed == 0 || defects == 0 {
+		t.Fatalf("%d failures and %d defects in 10 days; want both", failed, defects)
+	}
+
+	b, _ := svc.GetBoard(ctx, id)
+	spawned := 0
+	for _, c := range b.Cards {
+		if c.ClassOfService == engine.ClassExpedite {
+			spawned++
+		}
+	}
+	if len(b.Cards) != len(cfg.Cards)+defects || spawned != defects {
+		t.Errorf("%d cards, %d expedite; want %d defects added to the %d cards", len(b.Cards), spawned, defects, len(cfg.Cards))
+	}
+
+	doc, err := svc.ExportGame(ctx, id)
+	if err != nil {
+		t.Fatalf("ExportGame returned error: %v", err)
+	}
+	events := make(map[string]int)
+	for _, ev := range doc.Events {
+		events[ev.EventType]++
+	}
+	if events["test_failed"] != failed || events["defect_escaped"] != defects {
+		t.Errorf("events = %v; want %d test_failed and %d defect_escaped", events, failed, defects)
+	}
+}
+
+func TestService_ChangeStatus(t *testing.T) {
+	ctx := context.Background()
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := NewService(NewMemoryRepo(memstore.New()))
+	id, err := svc.CreateGame(ctx, models.BoardConfig{
+		Seed: 3, EffortTypes: cfg.EffortTypes, Columns: cfg.Columns, Cards: cfg.Cards,
+	})
+	if err != nil {
+		t.Fatalf("CreateGame returned error: %v", err)
+	}
+
+	// nothing is played in the lobby
+	if err := svc.UpdateGame(ctx, id, 2); !errors.Is(err, ErrGameNotRunning) {
+		t.Errorf("UpdateGame in the lobby: err = %v; want %v", err, ErrGameNotRunning)
+	}
+
+	steps := []struct {
+		t       Transition
+		want    models.GameStatus
+		wantErr error
+	}{
+		{Resume, "", ErrInvalidTransition},
+		{Start, models.GameRunning, nil},
+		{Start, "", ErrInvalidTransition},
+		{Pause, models.GamePaused, nil},
+		{Resume, models.GameRunning, nil},
+		{End, models.GameEnded, nil},
+		{End, "", ErrInvalidTransition},
+	}
+	for _, step := range steps {
+		g, err := svc.ChangeStatus(ctx, id, step.t)
+		if !errors.Is(err, step.wantErr) {
+			t.Fatalf("%s: err = %v; want %v", step.t.Name, err, step.wantErr)
+		}
+		if err == nil && g.Status != step.want {
+			t.Errorf("%s: status = %s; want %s", step.t.Name, g.Status, step.want)
+		}
+	}
+
+	// an ended game is read-only
+	if _, err := svc.RollDice(ctx, id, uuid.New(), uuid.New()); !errors.Is(err, ErrGameNotRunning) {
+		t.Errorf("RollDice after the end: err = %v; want %v", err, ErrGameNotRunning)
+	}
+	if err := svc.SetFacilitator(ctx, id, uuid.New()); !errors.Is(err, ErrGameEnded) {
+		t.Errorf("SetFacilitator after the end: err = %v; want %v", err, ErrGameEnded)
+	}
+
+	doc, err := svc.ExportGame(ctx, id)
+	if err != nil {
+		t.Fatalf("ExportGame returned error: %v", err)
+	}
+	var logged []string
+	for _, ev := range doc.Events {
+		if ev.CardID == nil {
+			logged = append(logged, ev.EventType)
+		}
+	}
+	want := []string{"game_started", "game_paused", "game_resumed", "game_ended"}
+	if !slices.Equal(logged, want) {
+		t.Errorf("lifecycle events = %v; want %v", logged, want)
+	}
+}
+
+func TestService_ResetGame(t *testing.T) {
+	ctx := context.Background()
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, _ := engine.ParseTeam(engine.DefaultTeam)
+	strategy, _ := engine.StrategyByName("strict-wip")
+
+	svc := NewService(NewMemoryRepo(memstore.New()))
+	id, err := svc.CreateGame(ctx, models.BoardConfig{
+		Seed: 5, Facilitator: "Grace", EffortTypes: cfg.EffortTypes, Columns: cfg.Columns, Cards: cfg.Cards,
+	})
+	if err != nil {
+		t.Fatalf("CreateGame returned error: %v", err)
+	}
+	initial, _ := svc.GetBoard(ctx, id)
+	byID := func(b models.Board) models.Board {
+		// cards picked on the same day come in no particular order
+		slices.SortFunc(b.Cards, func(x, y models.Card) int { return strings.Compare(x.ID.String(), y.ID.String()) })
+		return b
+	}
+
+	// a practice round
+	if _, err := svc.ChangeStatus(ctx, id, Start); err != nil {
+		t.Fatalf("ChangeStatus returned error: %v", err)
+	}
+	for range 3 {
+		if _, err := svc.PlayBotDay(ctx, id, strategy, team); err != nil {
+			t.Fatalf("PlayBotDay returned error: %v", err)
+		}
+	}
+
+	g, err := svc.ResetGame(ctx, id)
+	if err != nil {
+		t.Fatalf("ResetGame returned error: %v", err)
+	}
+	if g.Day != 1 || g.Status != models.GameLobby || g.FacilitatorID == nil {
+		t.Errorf("game after reset = %+v; want day 1 in the lobby with its facilitator", g)
+	}
+	if b, _ := svc.GetBoard(ctx, id); !reflect.DeepEqual(byID(b), byID(initial)) {
+		t.Error("board after reset differs from the board the game was created with")
+	}
+
+	if _, err := svc.ChangeStatus(ctx, id, End); err != nil {
+		t.Fatalf("ChangeStatus returned error: %v", err)
+	}
+	if _, err := svc.ResetGame(ctx, id); !errors.Is(err, ErrGameEnded) {
+		t.Errorf("ResetGame of an ended game: err = %v; want %v", err, ErrGameEnded)
+	}
+}
+
+func TestService_Leaderboard(t *testing.T) {
+	ctx := context.Background()
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, err := engine.ParseTeam(engine.DefaultTeam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	strategy, err := engine.StrategyByName("strict-wip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := NewService(NewMemoryRepo(memstore.New()))
+
+	// play games of the given length; only ended ones are ranked
+	play := func(scenario string, days int, end bool) uuid.UUID {
+		id, err := svc.CreateGame(ctx, models.BoardConfig{
+			Seed: 11, Scenario: scenario, EffortTypes: cfg.EffortTypes, Columns: cfg.Columns, Cards: cfg.Cards,
+		})
+		if err != nil {
+			t.Fatalf("CreateGame: %v", err)
+		}
+		if _, err := svc.ChangeStatus(ctx, id, Start); err != nil {
+			t.Fatalf("ChangeStatus: %v", err)
+		}
+		for i := 0; i < days; i++ {
+			if _, err := svc.PlayBotDay(ctx, id, strategy, team); err != nil {
+				t.Fatalf("PlayBotDay: %v", err)
+			}
+		}
+		if end {
+			if _, err := svc.ChangeStatus(ctx, id, End); err != nil {
+				t.Fatalf("ChangeStatus: %v", err)
+			}
+		}
+		return id
+	}
+	short := play("", 3, true)
+	long := play("", 12, true)
+	other := play("advanced", 12, true)
+	play("", 12, false)
+
+	got, err := svc.Leaderboard(ctx, ScoreProfit, models.LeaderboardFilter{Scenario: DefaultScenario})
+	if err != nil {
+		t.Fatalf("Leaderboard: %v", err)
+	}
+	if len(got) != 2 || got[0].GameID != long || got[1].GameID != short || got[0].Rank != 1 || got[1].Rank != 2 {
+		t.Fatalf("Leaderboard by profit = %+v; want the long game before the short one", got)
+	}
+	if got[0].Days != 12 || got[0].Deployed == 0 || got[0].Profit <= got[1].Profit || got[0].Scenario != DefaultScenario {
+		t.Errorf("long game = %+v; want 12 days played with deployments", got[0])
+	}
+
+	all, err := svc.Leaderboard(ctx, ScoreThroughput, models.LeaderboardFilter{})
+	if err != nil {
+		t.Fatalf("Leaderboard: %v", err)
+	}
+	if len(all) != 3 || !slices.IsSortedFunc(all, func(a, b models.LeaderboardEntry) int { return cmp.Compare(b.Throughput, a.Throughput) }) {
+		t.Errorf("Leaderboard by throughput = %+v; want all three, highest first", all)
+	}
+
+	// equal scores share a rank, oldest game first
+	tie := Score{"tie", func(a, b models.LeaderboardEntry) int { return 0 }}
+	tied, err := svc.Leaderboard(ctx, tie, models.LeaderboardFilter{})
+	if err != nil {
+		t.Fatalf("Leaderboard: %v", err)
+	}
+	if len(tied) != 3 || tied[0].GameID != short || tied[1].GameID != long || tied[2].GameID != other ||
+		tied[0].Rank != 1 || tied[1].Rank != 1 || tied[2].Rank != 1 {
+		t.Errorf("Leaderboard with all scores equal = %+v; want one rank, oldest first", tied)
+	}
+
+	// no game is created tomorrow
+	tomorrow := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	if got, _ := svc.Leaderboard(ctx, ScoreProfit, models.LeaderboardFilter{From: tomorrow}); len(got) != 0 {
+		t.Errorf("Leaderboard from tomorrow = %+v; want none", got)
+	}
+	if got, _ := svc.Leaderboard(ctx, ScoreProfit, models.LeaderboardFilter{To: tomorrow}); len(got) != 3 {
+		t.Errorf("Leaderboard until today = %d games; want 3", len(got))
+	}
+
+	if _, err := ParseScore("fun"); !errors.Is(err, ErrInvalidScore) {
+		t.Errorf("ParseScore(fun): err = %v; want %v", err, ErrInvalidScore)
+	}
+}
+
+func TestService_BlockCard(t *testing.T) {
+	ctx := context.Background()
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, _ := engine.ParseTeam(engine.DefaultTeam)
+	strategy, _ := engine.StrategyByName("strict-wip")
+
+	svc := NewService(NewMemoryRepo(memstore.New()))
+	id, err := svc.CreateGame(ctx, models.BoardConfig{
+		Seed: 7, Facilitator: "Grace", EffortTypes: cfg.EffortTypes, Columns: cfg.Columns, Cards: cfg.Cards,
+	})
+	if err != nil {
+		t.Fatalf("CreateGame returned error: %v", err)
+	}
+	g, _ := svc.GetGame(ctx, id)
+	grace := *g.FacilitatorID
+
+	board, _ := svc.GetBoard(ctx, id)
+	var inFlight []uuid.UUID
+	for _, c := range board.Cards {
+		if c.SelectedDay > 0 && c.DeployedDay == 0 {
+			inFlight = append(inFlight, c.ID)
+		}
+	}
+	if len(inFlight) < 2 {
+		t.Fatalf("%d cards in flight; want at least 2", len(inFlight))
+	}
+
+	if _, err := svc.BlockCard(ctx, id, inFlight[0], grace, "On hold", 0); !errors.Is(err, ErrGameNotRunning) {
+		t.Errorf("BlockCard in the lobby: err = %v; want %v", err, ErrGameNotRunning)
+	}
+	if _, err := svc.ChangeStatus(ctx, id, Start); err != nil {
+		t.Fatalf("ChangeStatus returned error: %v", err)
+	}
+	if _, err := svc.BlockCard(ctx, id, uuid.New(), grace, "On hold", 0); !errors.Is(err, ErrCardNotFound) {
+		t.Errorf("BlockCard of an unknown card: err = %v; want %v", err, ErrCardNotFound)
+	}
+	if _, err := svc.BlockCard(ctx, id, inFlight[0], grace, "On hold", -1); !errors.Is(err, ErrInvalidEffort) {
+		t.Errorf("BlockCard with a negative effort: err = %v; want %v", err, ErrInvalidEffort)
+	}
+	if err := svc.UnblockCard(ctx, id, inFlight[0]); !errors.Is(err, ErrCardNotBlocked) {
+		t.Errorf("UnblockCard of a card not blocked: err = %v; want %v", err, ErrCardNotBlocked)
+	}
+
+	// every card in flight needs a point of unblocking work; the first one
+	// is lifted by hand instead
+	for _, cardID := range inFlight[1:] {
+		b, err := svc.BlockCard(ctx, id, cardID, grace, "Waiting for the customer", 1)
+		if err != nil {
+			t.Fatalf("BlockCard returned error: %v", err)
+		}
+		if b.BlockedBy != "Grace" || b.Day != 1 || b.Effort != 1 {
+			t.Errorf("blocker = %+v; want one point blocked by Grace on day 1", b)
+		}
+	}
+	if _, err := svc.BlockCard(ctx, id, inFlight[0], grace, "On hold", 0); err != nil {
+		t.Fatalf("BlockCard returned error: %v", err)
+	}
+	if _, err := svc.BlockCard(ctx, id, inFlight[0], grace, "On hold", 0); !errors.Is(err, ErrCardBlocked) {
+		t.Errorf("blocking a card twice: err = %v; want %v", err, ErrCardBlocked)
+	}
+
+	for range 3 {
+		if _, err := svc.PlayBotDay(ctx, id, strategy, team); err != nil {
+			t.Fatalf("PlayBotDay returned error: %v", err)
+		}
+	}
+	if err := svc.UnblockCard(ctx, id, inFlight[0]); err != nil {
+		t.Fatalf("UnblockCard returned error: %v", err)
+	}
+
+	board, _ = svc.GetBoard(ctx, id)
+	for _, c := range board.Cards {
+		if c.ID == inFlight[0] && c.Blocker != nil {
+			t.Errorf("card lifted by hand still blocked: %+v", c.Blocker)
+		}
+	}
+	doc, err := svc.ExportGame(ctx, id)
+	if err != nil {
+		t.Fatalf("ExportGame returned error: %v", err)
+	}
+	blocks, worked := 0, 0
+	for _, ev := range doc.Events {
+		switch ev.EventType {
+		case "block":
+			blocks++
+		case "unblock":
+			var u models.Unblock
+			if err := json.Unmarshal(ev.Payload, &u); err != nil {
+				t.Fatal(err)
+			}
+			if !u.Worked {
+				if *ev.CardID != inFlight[0] || u.Days != 3 || ev.Day != 4 {
+					t.Errorf("unblock by hand = %+v on day %d; want 3 days on day 4", u, ev.Day)
+				}
+				continue
+			}
+			worked++
+			if u.Days < 1 {
+				t.Errorf("worked off blocker = %+v; want at least a day blocked", u)
+			}
+		}
+	}
+	if blocks != len(inFlight) || worked == 0 {
+		t.Errorf("%d block events, %d worked off; want %d and some worked off", blocks, worked, len(inFlight))
+	}
+}
+
+func TestService_PositionCard(t *testing.T) {
+	ctx := context.Background()
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, _ := engine.ParseTeam(engine.DefaultTeam)
+	strategy, _ := engine.StrategyByName("strict-wip")
+
+	svc := NewService(NewMemoryRepo(memstore.New()))
+	id, err := svc.CreateGame(ctx, models.BoardConfig{
+		Seed: 7, Facilitator: "Grace", EffortTypes: cfg.EffortTypes, Columns: cfg.Columns, Cards: cfg.Cards,
+	})
+	if err != nil {
+		t.Fatalf("CreateGame returned error: %v", err)
+	}
+	board, _ := svc.GetBoard(ctx, id)
+	leaves := leafColumns(board.Columns)
+	backlog, selected, deployed := leaves[0].ID, leaves[1].ID, leaves[len(leaves)-1].ID
+	column := func(columnID uuid.UUID) []models.Card {
+		board, _ := svc.GetBoard(ctx, id)
+		var out []models.Card
+		for _, c := range board.Cards {
+			if c.ColumnID == columnID {
+				out = append(out, c)
+			}
+		}
+		sort.SliceStable(out, func(i, j int) bool { return out[i].OrderIndex < out[j].OrderIndex })
+		return out
+	}
+
+	// new games number the cards of every column from 0
+	options := column(backlog)
+	if len(options) < 3 {
+		t.Fatalf("%d cards in the backlog; want at least 3", len(options))
+	}
+	for i, c := range options {
+		if c.OrderIndex != i {
+			t.Fatalf("backlog order = %+v; want it numbered from 0", options)
+		}
+	}
+	last := options[len(options)-1]
+
+	if _, err := svc.PositionCard(ctx, id, last.ID, models.CardPosition{}); !errors.Is(err, ErrGameNotRunning) {
+		t.Errorf("PositionCard in the lobby: err = %v; want %v", err, ErrGameNotRunning)
+	}
+	if _, err := svc.ChangeStatus(ctx, id, Start); err != nil {
+		t.Fatalf("ChangeStatus returned error: %v", err)
+	}
+	if _, err := svc.PositionCard(ctx, id, last.ID, models.CardPosition{Index: -1}); !errors.Is(err, ErrInvalidPosition) {
+		t.Errorf("PositionCard at -1: err = %v; want %v", err, ErrInvalidPosition)
+	}
+	if _, err := svc.PositionCard(ctx, id, last.ID, models.CardPosition{ColumnID: &deployed}); !errors.Is(err, ErrInvalidPosition) {
+		t.Errorf("PositionCard into the done column: err = %v; want %v", err, ErrInvalidPosition)
+	}
+
+	// the last card of the backlog goes to the top; the rest shift down
+	order, err := svc.PositionCard(ctx, id, last.ID, models.CardPosition{Index: 0})
+	if err != nil {
+		t.Fatalf("PositionCard returned error: %v", err)
+	}
+	if len(order.Cards) != len(options) || order.Cards[0].ID != last.ID || order.Cards[1].ID != options[0].ID {
+		t.Fatalf("backlog order = %+v; want %s on top of the rest", order.Cards, last.Title)
+	}
+	for i, c := range column(backlog) {
+		if c.ID != order.Cards[i].ID || c.OrderIndex != i {
+			t.Fatalf("stored backlog order = %+v; want %+v", column(backlog), order.Cards)
+		}
+	}
+
+	// a card put into the next column is selected, and leaves no gap
+	second := options[0]
+	order, err = svc.PositionCard(ctx, id, second.ID, models.CardPosition{ColumnID: &selected, Index: 99})
+	if err != nil {
+		t.Fatalf("PositionCard returned error: %v", err)
+	}
+	if got := order.Cards[len(order.Cards)-1]; got.ID != second.ID || got.OrderIndex != len(order.Cards)-1 {
+		t.Errorf("selected order = %+v; want %s at the bottom", order.Cards, second.Title)
+	}
+	for i, c := range column(backlog) {
+		if c.OrderIndex != i || c.ID == second.ID {
+			t.Fatalf("backlog after the move = %+v; want it without %s, numbered from 0", column(backlog), second.Title)
+		}
+	}
+	for _, c := range column(selected) {
+		if c.ID == second.ID && c.SelectedDay != 1 {
+			t.Errorf("selected day = %d; want 1", c.SelectedDay)
+		}
+	}
+	doc, _ := svc.ExportGame(ctx, id)
+	if e := doc.Events[len(doc.Events)-1]; e.EventType != "move" || e.CardID == nil || *e.CardID != second.ID {
+		t.Errorf("last event = %+v; want the move of %s", e, second.Title)
+	}
+
+	// the bot starts the backlog from the top
+	stats, err := svc.PlayBotDay(ctx, id, strategy, team)
+	if err != nil {
+		t.Fatalf("PlayBotDay returned error: %v", err)
+	}
+	left := true
+	for _, c := range column(backlog) {
+		left = left && c.ID != last.ID
+	}
+	if stats.Moves > 0 && !left && len(column(backlog)) < len(options)-1 {
+		t.Errorf("the bot started other cards before %s, the top of the backlog", last.Title)
+	}
+
+	// deployed cards stay where they are
+	done := models.Card{ID: uuid.New(), ColumnID: deployed, DeployedDay: 3}
+	mr := &mockRepo{wantGame: models.Game{Status: models.GameRunning}, wantBoard: models.Board{Cards: []models.Card{done}}}
+	if _, err := NewService(mr).PositionCard(ctx, id, done.ID, models.CardPosition{}); !errors.Is(err, ErrCardDeployed) {
+		t.Errorf("PositionCard of a deployed card: err = %v; want %v", err, ErrCardDeployed)
+	}
+}
+
+func TestService_SearchGames(t *testing.T) {
+	ctx := context.Background()
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewService(NewMemoryRepo(memstore.New()))
+	var ids []uuid.UUID
+	for range 5 {
+		id, err := svc.CreateGame(ctx, models.BoardConfig{
+			EffortTypes: cfg.EffortTypes, Columns: cfg.Columns, Cards: cfg.Cards,
+		})
+		if err != nil {
+			t.Fatalf("CreateGame returned error: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	for _, q := range []models.GameQuery{
+		{Sort: "title"},
+		{Status: "archived"},
+		{Limit: -1},
+		{Limit: MaxPageSize + 1},
+	} {
+		if _, err := svc.SearchGames(ctx, q); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("SearchGames(%+v): err = %v; want %v", q, err, ErrInvalidQuery)
+		}
+	}
+	if _, err := svc.SearchGames(ctx, models.GameQuery{Cursor: "not a cursor"}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("SearchGames with a bad cursor: err = %v; want %v", err, ErrInvalidCursor)
+	}
+
+	// pages of two walk through every game once, counting all of them
+	seen := map[uuid.UUID]bool{}
+	q := models.GameQuery{Limit: 2}
+	for pages := 1; ; pages++ {
+		page, err := svc.SearchGames(ctx, q)
+		if err != nil {
+			t.Fatalf("SearchGames returned error: %v", err)
+		}
+		if page.Total != len(ids) {
+			t.Errorf("page %d: total = %d; want %d", pages, page.Total, len(ids))
+		}
+		for _, g := range page.Games {
+			if seen[g.ID] {
+				t.Errorf("page %d: game %s seen twice", pages, g.ID)
+			}
+			seen[g.ID] = true
+		}
+		if page.NextCursor == "" {
+			if pages != 3 {
+				t.Errorf("%d pages; want 3", pages)
+			}
+			break
+		}
+		q.Cursor = page.NextCursor
+	}
+	if len(seen) != len(ids) {
+		t.Errorf("%d games seen; want %d", len(seen), len(ids))
+	}
+
+	// a cursor only continues the sort it was made for
+	first, _ := svc.SearchGames(ctx, models.GameQuery{Limit: 1})
+	if _, err := svc.SearchGames(ctx, models.GameQuery{Limit: 1, Ascending: true, Cursor: first.NextCursor}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("SearchGames with a cursor of another order: err = %v; want %v", err, ErrInvalidCursor)
+	}
+	if _, err := svc.SearchGames(ctx, models.GameQuery{Limit: 1, Sort: SortDay, Cursor: first.NextCursor}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("SearchGames with a cursor of another sort: err = %v; want %v", err, ErrInvalidCursor)
+	}
+
+	// but goes on once its game is gone
+	if err := svc.DeleteGame(ctx, first.Games[0].ID); err != nil {
+		t.Fatalf("DeleteGame returned error: %v", err)
+	}
+	second, err := svc.SearchGames(ctx, models.GameQuery{Limit: 1, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("SearchGames after the cursor's game returned error: %v", err)
+	}
+	if len(second.Games) != 1 || second.Games[0].ID == first.Games[0].ID || second.Total != len(ids)-1 {
+		t.Errorf("page after the cursor's game = %+v; want the next of %d games", second, len(ids)-1)
+	}
+}
+
+func TestService_CleanUp(t *testing.T) {
+	ctx := context.Background()
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewService(NewMemoryRepo(memstore.New()))
+	var idle, played uuid.UUID
+	for _, id := range []*uuid.UUID{&idle, &played} {
+		if *id, err = svc.CreateGame(ctx, models.BoardConfig{
+			EffortTypes: cfg.EffortTypes, Columns: cfg.Columns, Cards: cfg.Cards,
+		}); err != nil {
+			t.Fatalf("CreateGame returned error: %v", err)
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	start := time.Now().UTC()
+	time.Sleep(10 * time.Millisecond)
+	if _, err := svc.ChangeStatus(ctx, played, Start); err != nil {
+		t.Fatalf("ChangeStatus returned error: %v", err)
+	}
+
+	ids := func(list []models.Game) []uuid.UUID {
+		var out []uuid.UUID
+		for _, g := range list {
+			out = append(out, g.ID)
+		}
+		return out
+	}
+	policy := RetentionPolicy{InactiveFor: time.Hour, Grace: 24 * time.Hour}
+	dryRun := policy
+	dryRun.DryRun = true
+	tests := []struct {
+		name            string
+		policy          RetentionPolicy
+		now             time.Time
+		wantSoftDeleted []uuid.UUID
+		wantDeleted     []uuid.UUID
+	}{
+		{"nothing inactive yet", policy, start.Add(30 * time.Minute), nil, nil},
+		{"dry run", dryRun, start.Add(time.Hour), []uuid.UUID{idle}, nil},
+		{"idle game", policy, start.Add(time.Hour), []uuid.UUID{idle}, nil},
+		{"again", policy, start.Add(time.Hour), nil, nil},
+		{"played game goes idle", policy, start.Add(2 * time.Hour), []uuid.UUID{played}, nil},
+		{"within the grace period", policy, start.Add(25 * time.Hour), nil, nil},
+		{"dry run after the grace period", dryRun, start.Add(26 * time.Hour), nil, []uuid.UUID{idle}},
+		{"after the grace period", policy, start.Add(26 * time.Hour), nil, []uuid.UUID{idle}},
+		{"all done", policy, start.Add(26 * time.Hour), nil, nil},
+	}
+	for _, tc := range tests {
+		c, err := svc.CleanUp(ctx, tc.policy, tc.now)
+		if err != nil {
+			t.Fatalf("%s: CleanUp returned error: %v", tc.name, err)
+		}
+		if got := ids(c.SoftDeleted); !slices.Equal(got, tc.wantSoftDeleted) {
+			t.Errorf("%s: soft-deleted %v; want %v", tc.name, got, tc.wantSoftDeleted)
+		}
+		if got := ids(c.Deleted); !slices.Equal(got, tc.wantDeleted) {
+			t.Errorf("%s: deleted %v; want %v", tc.name, got, tc.wantDeleted)
+		}
+	}
+
+	// the idle game was hidden, then deleted; the played one is still hidden
+	if _, err := svc.GetGame(ctx, played); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetGame of a soft-deleted game: err = %v; want %v", err, ErrNotFound)
+	}
+	if err := svc.DeleteGame(ctx, idle); err == nil {
+		t.Errorf("DeleteGame of a deleted game succeeded")
+	}
+}
@@ -3,7 +3,9 @@ package games
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/Germanicus1/kanban-sim/backend/internal/models"
 	"github.com/Germanicus1/kanban-sim/backend/internal/response"
@@ -15,7 +17,8 @@ type sqlRepo struct {
 }
 
 // CreateGame inserts a new game row, then seeds effort_types,
-// columns (and subcolumns), cards and their efforts, all in one TX.
+// columns (and subcolumns), cards and their efforts and snapshots the board
+// under InitialDay, all in one TX.
 func (r *sqlRepo) CreateGame(ctx context.Context, cfg models.BoardConfig) (uuid.UUID, error) {
 	// 1) begin transaction
 	tx, err := r.db.BeginTx(ctx, nil)
@@ -32,15 +35,84 @@ func (r *sqlRepo) CreateGame(ctx context.Context, cfg models.BoardConfig) (uuid.
 	// 2) let Postgres create the game ID and return it
 	var gameID uuid.UUID
 	if err := tx.QueryRowContext(ctx,
-		`INSERT INTO games (created_at, day)
-             VALUES (NOW(), 1)
+		`INSERT INTO games (day, seed, scenario, test_failure, rework, escaped_defect, defect_effort)
+             VALUES (1, $1, $2, $3, $4, $5, $6)
          RETURNING id`,
+		append([]any{cfg.Seed, cfg.Scenario}, qualityValues(cfg.Quality)...)...,
 	).Scan(&gameID); err != nil {
 		tx.Rollback()
 		return uuid.Nil, fmt.Errorf("insert game: %w", err)
 	}
 
-	// 3) seed effort_types, grabbing each new ID
+	// 3) seed the board; nothing has been worked on in a new game
+	if _, err := seedBoard(ctx, tx, gameID, freshBoard(cfg)); err != nil {
+		tx.Rollback()
+		return uuid.Nil, err
+	}
+
+	// 4) the facilitator joins with the game
+	if cfg.Facilitator != "" {
+		var playerID uuid.UUID
+		if err := tx.QueryRowContext(ctx,
+			`INSERT INTO players (game_id, name) VALUES ($1, $2) RETURNING id`,
+			gameID, cfg.Facilitator,
+		).Scan(&playerID); err != nil {
+			tx.Rollback()
+			return uuid.Nil, fmt.Errorf("insert facilitator: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE games SET facilitator_id = $1 WHERE id = $2`, playerID, gameID,
+		); err != nil {
+			tx.Rollback()
+			return uuid.Nil, fmt.Errorf("set facilitator: %w", err)
+		}
+	}
+
+	// 5) the board the game starts with, to reset it to
+	if err := saveSnapshot(ctx, tx, gameID, InitialDay); err != nil {
+		tx.Rollback()
+		return uuid.Nil, err
+	}
+
+	// 6) commit
+	if err = tx.Commit(); err != nil {
+		return uuid.Nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return gameID, nil
+}
+
+// qualityValues returns the test_failure, rework, escaped_defect and
+// defect_effort of a game with the given quality.
+func qualityValues(q models.Quality) []any {
+	return []any{q.TestFailure, q.Rework, q.EscapedDefect, q.DefectEffort}
+}
+
+// freshBoard returns a copy of cfg in which every effort is untouched:
+// all of its estimate remains and nothing has been spent yet.
+func freshBoard(cfg models.BoardConfig) models.BoardConfig {
+	cards := make([]models.Card, len(cfg.Cards))
+	for i, c := range cfg.Cards {
+		efforts := make([]models.Effort, len(c.Efforts))
+		for j, e := range c.Efforts {
+			efforts[j] = models.Effort{
+				EffortType: e.EffortType,
+				Estimate:   e.Estimate,
+				Remaining:  e.Estimate,
+			}
+		}
+		c.Efforts = efforts
+		cards[i] = c
+	}
+	cfg.Cards = cards
+	return cfg
+}
+
+// seedBoard inserts effort_types, columns (and subcolumns), cards and their
+// efforts for gameID inside tx. Cards reference their column by title, using
+// "Parent - Sub" for subcolumns, and efforts their effort type by title. It
+// returns the new card IDs in the order of cfg.Cards.
+func seedBoard(ctx context.Context, tx *sql.Tx, gameID uuid.UUID, cfg models.BoardConfig) ([]uuid.UUID, error) {
+	// 1) seed effort_types, grabbing each new ID
 	effortTypeIDs := make(map[string]uuid.UUID, len(cfg.EffortTypes))
 	for idx, et := range cfg.EffortTypes {
 		var etID uuid.UUID
@@ -50,13 +122,12 @@ func (r *sqlRepo) CreateGame(ctx context.Context, cfg models.BoardConfig) (uuid.
              RETURNING id`,
 			gameID, et.Title, idx,
 		).Scan(&etID); err != nil {
-			tx.Rollback()
-			return uuid.Nil, fmt.Errorf("insert effort type %q: %w", et.Title, err)
+			return nil, fmt.Errorf("insert effort type %q: %w", et.Title, err)
 		}
 		effortTypeIDs[et.Title] = etID
 	}
 
-	// 4) seed columns & subcolumns, grabbing each new ID
+	// 2) seed columns & subcolumns, grabbing each new ID
 	columnIDs := make(map[string]uuid.UUID, len(cfg.Columns)*2)
 	for _, col := range cfg.Columns {
 		var wipLimit int
@@ -84,8 +155,7 @@ func (r *sqlRepo) CreateGame(ctx context.Context, cfg models.BoardConfig) (uuid.
 			wipLimit,
 			cType,
 		).Scan(&mainID); err != nil {
-			tx.Rollback()
-			return uuid.Nil, fmt.Errorf("insert column %q: %w", col.Title, err)
+			return nil, fmt.Errorf("insert column %q: %w", col.Title, err)
 		}
 		columnIDs[col.Title] = mainID
 
@@ -117,8 +187,7 @@ func (r *sqlRepo) CreateGame(ctx context.Context, cfg models.BoardConfig) (uuid.
 				subWIPLimit,
 				subType,
 			).Scan(&subID); err != nil {
-				tx.Rollback()
-				return uuid.Nil, fmt.Errorf("insert subcolumn %q under %q: %w",
+				return nil, fmt.Errorf("insert subcolumn %q under %q: %w",
 					sub.Title, col.Title, err,
 				)
 			}
@@ -126,55 +195,54 @@ func (r *sqlRepo) CreateGame(ctx context.Context, cfg models.BoardConfig) (uuid.
 		}
 	}
 
-	// 5) seed cards & their efforts, grabbing each new ID
-	for _, c := range cfg.Cards {
+	// 3) seed cards & their efforts, grabbing each new ID
+	cardIDs := make([]uuid.UUID, 0, len(cfg.Cards))
+	order := columnOrder(cfg.Cards)
+	for i, c := range cfg.Cards {
 		colID, ok := columnIDs[c.ColumnTitle]
 		if !ok {
-			tx.Rollback()
-			return uuid.Nil, fmt.Errorf("unknown column %q", c.ColumnTitle)
+			return nil, fmt.Errorf("unknown column %q", c.ColumnTitle)
 		}
 
 		var cardID uuid.UUID
+		args := []any{
+			gameID, colID,
+			c.Title, c.ClassOfService, c.ValueEstimate,
+			c.SelectedDay, c.DeployedDay, order[i],
+		}
 		if err := tx.QueryRowContext(ctx,
 			`INSERT INTO cards
-               (game_id, column_id, title, class_of_service, value_estimate, selected_day, deployed_day)
-             VALUES ($1,$2,$3,$4,$5,$6,$7)
+               (game_id, column_id, title, class_of_service, value_estimate, selected_day, deployed_day, order_index,
+                blocked_reason, blocked_by, blocked_day, unblock_effort)
+             VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
          RETURNING id`,
-			gameID, colID,
-			c.Title, c.ClassOfService, c.ValueEstimate,
-			c.SelectedDay, c.DeployedDay,
+			append(args, blockerValues(c.Blocker)...)...,
 		).Scan(&cardID); err != nil {
-			tx.Rollback()
-			return uuid.Nil, fmt.Errorf("insert card %q: %w", c.Title, err)
+			return nil, fmt.Errorf("insert card %q: %w", c.Title, err)
 		}
+		cardIDs = append(cardIDs, cardID)
 
 		for _, e := range c.Efforts {
 			etID, ok := effortTypeIDs[e.EffortType]
 			if !ok {
-				tx.Rollback()
-				return uuid.Nil, fmt.Errorf("unknown effort type %q", e.EffortType)
+				return nil, fmt.Errorf("unknown effort type %q", e.EffortType)
 			}
 			// you may not need the effortID, but we can capture it if you do
 			var effortID uuid.UUID
 			if err := tx.QueryRowContext(ctx,
 				`INSERT INTO efforts (card_id, effort_type_id, estimate, remaining, actual)
-                     VALUES ($1,$2,$3,$3,0)
+                     VALUES ($1,$2,$3,$4,$5)
                  RETURNING id`,
-				cardID, etID, e.Estimate,
+				cardID, etID, e.Estimate, e.Remaining, e.Actual,
 			).Scan(&effortID); err != nil {
-				tx.Rollback()
-				return uuid.Nil, fmt.Errorf("insert effort %q for card %q: %w",
+				return nil, fmt.Errorf("insert effort %q for card %q: %w",
 					e.EffortType, c.Title, err,
 				)
 			}
 		}
 	}
 
-	// 6) commit
-	if err = tx.Commit(); err != nil {
-		return uuid.Nil, fmt.Errorf("commit tx: %w", err)
-	}
-	return gameID, nil
+	return cardIDs, nil
 }
 
 // GetBoard loads an entire board for a given game ID.
@@ -184,7 +252,7 @@ func (r *sqlRepo) GetBoard(ctx context.Context, gameID uuid.UUID) (models.Board,
 
 	// 1) Load all columns
 	colRows, err := r.db.QueryContext(ctx, `
-        SELECT id, parent_id, title, order_index
+        SELECT id, parent_id, title, order_index, wip_limit, col_type
           FROM columns
          WHERE game_id = $1
          ORDER BY order_index
@@ -205,7 +273,6 @@ func (r *sqlRepo) GetBoard(ctx context.Context, gameID uuid.UUID) (models.Board,
 			&c.OrderIndex,
 			&c.WIPLimit,
 			&c.Type,
-			&c.SubColumns,
 		); err != nil {
 			return board, fmt.Errorf("scan column: %w", err)
 		}
@@ -264,10 +331,11 @@ func (r *sqlRepo) GetBoard(ctx context.Context, gameID uuid.UUID) (models.Board,
 	cardRows, err := r.db.QueryContext(ctx, `
         SELECT id, game_id, column_id, title,
                class_of_service, value_estimate,
-               selected_day, deployed_day
+               selected_day, deployed_day, order_index,
+               blocked_reason, blocked_by, blocked_day, unblock_effort
           FROM cards
          WHERE game_id = $1
-         ORDER BY selected_day
+         ORDER BY selected_day, order_index, title
     `, gameID)
 	if err != nil {
 		return board, fmt.Errorf("query cards: %w", err)
@@ -275,7 +343,12 @@ func (r *sqlRepo) GetBoard(ctx context.Context, gameID uuid.UUID) (models.Board,
 	defer cardRows.Close()
 
 	for cardRows.Next() {
-		var c models.Card
+		var (
+			c                  models.Card
+			blockedReason      sql.NullString
+			blockedBy          string
+			blockedDay, effort int
+		)
 		if err := cardRows.Scan(
 			&c.ID,
 			&c.GameID,
@@ -285,9 +358,15 @@ func (r *sqlRepo) GetBoard(ctx context.Context, gameID uuid.UUID) (models.Board,
 			&c.ValueEstimate,
 			&c.SelectedDay,
 			&c.DeployedDay,
+			&c.OrderIndex,
+			&blockedReason,
+			&blockedBy,
+			&blockedDay,
+			&effort,
 		); err != nil {
 			return board, fmt.Errorf("scan card: %w", err)
 		}
+		c.Blocker = scanBlocker(blockedReason, blockedBy, blockedDay, effort)
 
 		// Load efforts for this card
 		erRows, err := r.db.QueryContext(ctx, `
@@ -329,10 +408,13 @@ func (r *sqlRepo) GetBoard(ctx context.Context, gameID uuid.UUID) (models.Board,
 }
 
 func (r *sqlRepo) GetGameByID(ctx context.Context, id uuid.UUID) (models.Game, error) {
-	const q = `SELECT id, created_at, day FROM games WHERE id = $1`
+	const q = `SELECT id, created_at, day, parent_game_id, forked_at_day, seed, facilitator_id, status, scenario,
+	                 test_failure, rework, escaped_defect, defect_effort
+	            FROM games WHERE id = $1 AND deleted_at IS NULL`
 	var g models.Game
 
-	switch err := r.db.QueryRowContext(ctx, q, id).Scan(&g.ID, &g.CreatedAt, &g.Day); err {
+	switch err := r.db.QueryRowContext(ctx, q, id).Scan(&g.ID, &g.CreatedAt, &g.Day, &g.ParentGameID, &g.ForkedAtDay, &g.Seed, &g.FacilitatorID, &g.Status, &g.Scenario,
+		&g.Quality.TestFailure, &g.Quality.Rework, &g.Quality.EscapedDefect, &g.Quality.DefectEffort); err {
 	case nil:
 		return g, nil
 	case sql.ErrNoRows:
@@ -344,29 +426,51 @@ func (r *sqlRepo) GetGameByID(ctx context.Context, id uuid.UUID) (models.Game, e
 
 func (r *sqlRepo) DeleteGame(ctx context.Context, id uuid.UUID) error {
 	const q = `DELETE FROM games WHERE id = $1`
-	if _, err := r.db.ExecContext(ctx, q, id); err != nil {
+	res, err := r.db.ExecContext(ctx, q, id)
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return response.ErrNotFound
 		}
 		return fmt.Errorf("delete game: %w", err)
 	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return response.ErrNotFound
+	}
 	return nil
 }
 
 func (r *sqlRepo) UpdateGame(ctx context.Context, id uuid.UUID, day int) error {
-	const q = `UPDATE games SET day = $1 WHERE id = $2`
-	if _, err := r.db.ExecContext(ctx, q, day, id); err != nil {
+	const q = `UPDATE games SET day = $1 WHERE id = $2 AND deleted_at IS NULL`
+	res, err := r.db.ExecContext(ctx, q, day, id)
+	if err != nil {
 		if err == sql.ErrNoRows {
 			return ErrNotFound
 		}
 		return fmt.Errorf("update game: %w", err)
 	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
 	return nil
 }
 
 func (r *sqlRepo) ListGames(ctx context.Context) ([]models.Game, error) {
-	const q = `SELECT id, created_at, day FROM games ORDER BY created_at DESC`
-	rows, err := r.db.QueryContext(ctx, q)
+	return r.queryGames(ctx, `SELECT `+gameColumns+` FROM games g WHERE g.deleted_at IS NULL ORDER BY g.created_at DESC`)
+}
+
+// gameColumns are the columns queryGames scans, of the games aliased g.
+const gameColumns = `g.id, g.created_at, g.day, g.parent_game_id, g.forked_at_day, g.facilitator_id, g.status, g.scenario,
+	                 g.test_failure, g.rework, g.escaped_defect, g.defect_effort`
+
+// gameFields are where gameColumns are scanned into.
+func gameFields(g *models.Game) []any {
+	return []any{&g.ID, &g.CreatedAt, &g.Day, &g.ParentGameID, &g.ForkedAtDay, &g.FacilitatorID, &g.Status, &g.Scenario,
+		&g.Quality.TestFailure, &g.Quality.Rework, &g.Quality.EscapedDefect, &g.Quality.DefectEffort}
+}
+
+// queryGames runs a query selecting gameColumns and scans the games.
+func (r *sqlRepo) queryGames(ctx context.Context, query string, args ...any) ([]models.Game, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query games: %w", err)
 	}
@@ -375,7 +479,7 @@ func (r *sqlRepo) ListGames(ctx context.Context) ([]models.Game, error) {
 	var games []models.Game
 	for rows.Next() {
 		var g models.Game
-		if err := rows.Scan(&g.ID, &g.CreatedAt, &g.Day); err != nil {
+		if err := rows.Scan(gameFields(&g)...); err != nil {
 			return nil, fmt.Errorf("scan game: %w", err)
 		}
 		games = append(games, g)
@@ -383,6 +487,92 @@ func (r *sqlRepo) ListGames(ctx context.Context) ([]models.Game, error) {
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterate games: %w", err)
 	}
-
 	return games, nil
 }
+
+func (r *sqlRepo) PlayerSeat(ctx context.Context, gameID, playerID uuid.UUID) (int, bool, error) {
+	var seat int
+	switch err := r.db.QueryRowContext(ctx,
+		`SELECT seat FROM players WHERE id = $1 AND game_id = $2`, playerID, gameID,
+	).Scan(&seat); err {
+	case nil:
+		return seat, true, nil
+	case sql.ErrNoRows:
+		return 0, false, nil
+	default:
+		return 0, false, fmt.Errorf("query player: %w", err)
+	}
+}
+
+// SetFacilitator hands the facilitator role of a game to one of its players.
+func (r *sqlRepo) SetFacilitator(ctx context.Context, gameID, playerID uuid.UUID) error {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE games SET facilitator_id = $1
+		  WHERE id = $2 AND deleted_at IS NULL
+		    AND EXISTS (SELECT 1 FROM players WHERE id = $1 AND game_id = $2)`,
+		playerID, gameID,
+	)
+	if err != nil {
+		return fmt.Errorf("set facilitator: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		return nil
+	}
+
+	// nothing changed: either the game or the player is unknown
+	if _, err := r.GetGameByID(ctx, gameID); err != nil {
+		return err
+	}
+	return ErrPlayerNotInGame
+}
+
+// SetStatus moves a game to another status and logs the change, on the
+// game's current day, in one TX.
+func (r *sqlRepo) SetStatus(ctx context.Context, id uuid.UUID, from, to models.GameStatus, eventType string) error {
+	payload, err := json.Marshal(models.StatusChange{From: from, To: to})
+	if err != nil {
+		return fmt.Errorf("marshal status change: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	var day int
+	err = tx.QueryRowContext(ctx,
+		`UPDATE games SET status = $1 WHERE id = $2 AND status = $3 AND deleted_at IS NULL RETURNING day`, to, id, from,
+	).Scan(&day)
+	if err == sql.ErrNoRows {
+		tx.Rollback()
+		// nothing changed: either the game is unknown or in another status
+		if _, err := r.GetGameByID(ctx, id); err != nil {
+			return err
+		}
+		return ErrInvalidTransition
+	}
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("update status: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO game_events (game_id, event_type, payload, day, created_at)
+		      VALUES ($1, $2, $3, $4, $5)`,
+		id, eventType, payload, day, time.Now().UTC(),
+	); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("insert %s event: %w", eventType, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
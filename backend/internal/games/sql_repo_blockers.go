@@ -0,0 +1,142 @@
+package games
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// blockerValues returns the blocked_reason, blocked_by, blocked_day and
+// unblock_effort of a card with the given blocker, or of one not blocked.
+func blockerValues(b *models.Blocker) []any {
+	if b == nil {
+		return []any{nil, "", 0, 0}
+	}
+	return []any{b.Reason, b.BlockedBy, b.Day, b.Effort}
+}
+
+// scanBlocker rebuilds the blocker of a card from its columns. A card
+// without a blocked_reason is not blocked.
+func scanBlocker(reason sql.NullString, by string, day, effort int) *models.Blocker {
+	if !reason.Valid {
+		return nil
+	}
+	return &models.Blocker{Reason: reason.String, BlockedBy: by, Day: day, Effort: effort}
+}
+
+// BlockCard puts a blocker on a card and logs a block event on the
+// blocker's day, in one TX. The blocker is put down to the player with the
+// given ID, if they play the game.
+func (r *sqlRepo) BlockCard(ctx context.Context, gameID, cardID, playerID uuid.UUID, b models.Blocker) (models.Blocker, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Blocker{}, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	// 1) who blocked it; API keys have no player
+	b.BlockedBy = ""
+	switch err := tx.QueryRowContext(ctx,
+		`SELECT name FROM players WHERE id = $1 AND game_id = $2`, playerID, gameID,
+	).Scan(&b.BlockedBy); err {
+	case nil, sql.ErrNoRows:
+	default:
+		tx.Rollback()
+		return models.Blocker{}, fmt.Errorf("query player: %w", err)
+	}
+
+	// 2) the card
+	res, err := tx.ExecContext(ctx,
+		`UPDATE cards
+		    SET blocked_reason = $1, blocked_by = $2, blocked_day = $3, unblock_effort = $4
+		  WHERE id = $5 AND game_id = $6`,
+		append(blockerValues(&b), cardID, gameID)...,
+	)
+	if err != nil {
+		tx.Rollback()
+		return models.Blocker{}, fmt.Errorf("update card: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		tx.Rollback()
+		return models.Blocker{}, fmt.Errorf("update card %s: %w", cardID, ErrNotFound)
+	}
+
+	// 3) the event
+	payload, err := json.Marshal(b)
+	if err != nil {
+		tx.Rollback()
+		return models.Blocker{}, fmt.Errorf("marshal blocker: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO game_events (game_id, card_id, event_type, payload, day, created_at)
+		     VALUES ($1, $2, 'block', $3, $4, $5)`,
+		gameID, cardID, payload, b.Day, time.Now().UTC(),
+	); err != nil {
+		tx.Rollback()
+		return models.Blocker{}, fmt.Errorf("insert block event: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Blocker{}, fmt.Errorf("commit tx: %w", err)
+	}
+	return b, nil
+}
+
+// UnblockCard lifts the blocker of a card and logs an unblock event on the
+// given day, in one TX.
+func (r *sqlRepo) UnblockCard(ctx context.Context, gameID, cardID uuid.UUID, day int, u models.Unblock) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx,
+		`UPDATE cards
+		    SET blocked_reason = NULL, blocked_by = '', blocked_day = 0, unblock_effort = 0
+		  WHERE id = $1 AND game_id = $2`,
+		cardID, gameID,
+	)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("update card: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		tx.Rollback()
+		return fmt.Errorf("update card %s: %w", cardID, ErrNotFound)
+	}
+
+	payload, err := json.Marshal(u)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("marshal unblock: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO game_events (game_id, card_id, event_type, payload, day, created_at)
+		     VALUES ($1, $2, 'unblock', $3, $4, $5)`,
+		gameID, cardID, payload, day, time.Now().UTC(),
+	); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("insert unblock event: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
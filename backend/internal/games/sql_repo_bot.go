@@ -0,0 +1,184 @@
+package games
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func (r *sqlRepo) ApplyDay(ctx context.Context, gameID uuid.UUID, day int, changes []models.CardChange) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	// cards moved to the bottom of a column must not race a reorder
+	if err := lockCardOrder(ctx, tx, gameID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// events of the same day keep the order of the moves
+	at := time.Now().UTC()
+	for _, ch := range changes {
+		if ch.Spawned {
+			if err := insertSpawnedCard(ctx, tx, gameID, ch); err != nil {
+				tx.Rollback()
+				return err
+			}
+			continue
+		}
+
+		// 1) where the card is now; a card new to a column goes to the bottom
+		args := append([]any{ch.ColumnID, ch.SelectedDay, ch.DeployedDay}, blockerValues(ch.Blocker)...)
+		res, err := tx.ExecContext(ctx,
+			`UPDATE cards
+			    SET column_id = $1, selected_day = $2, deployed_day = $3,
+			        blocked_reason = $4, blocked_by = $5, blocked_day = $6, unblock_effort = $7,
+			        order_index = CASE WHEN column_id = $1 THEN order_index
+			                           ELSE (SELECT COALESCE(MAX(order_index), -1) + 1 FROM cards WHERE game_id = $9 AND column_id = $1)
+			                      END
+			  WHERE id = $8 AND game_id = $9`,
+			append(args, ch.CardID, gameID)...,
+		)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("update card: %w", err)
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			tx.Rollback()
+			return fmt.Errorf("update card %s: %w", ch.CardID, ErrNotFound)
+		}
+
+		// 2) the work done on it
+		for _, e := range ch.Efforts {
+			if _, err := tx.ExecContext(ctx,
+				`UPDATE efforts
+				    SET remaining = $1, actual = $2
+				  WHERE card_id = $3
+				    AND effort_type_id = (SELECT id FROM effort_types WHERE game_id = $4 AND title = $5)`,
+				e.Remaining, e.Actual, ch.CardID, gameID, e.EffortType,
+			); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("update effort: %w", err)
+			}
+		}
+
+		// 3) a move event per column crossed
+		for _, m := range ch.Moves {
+			payload, err := json.Marshal(m)
+			if err != nil {
+				tx.Rollback()
+				return fmt.Errorf("marshal move: %w", err)
+			}
+			at = at.Add(time.Microsecond)
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO game_events (game_id, card_id, event_type, payload, day, created_at)
+				     VALUES ($1, $2, 'move', $3, $4, $5)`,
+				gameID, ch.CardID, payload, day, at,
+			); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("insert move event: %w", err)
+			}
+		}
+
+		// 4) an unblock event once its blocker is worked off
+		if ch.Unblock != nil {
+			payload, err := json.Marshal(ch.Unblock)
+			if err != nil {
+				tx.Rollback()
+				return fmt.Errorf("marshal unblock: %w", err)
+			}
+			at = at.Add(time.Microsecond)
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO game_events (game_id, card_id, event_type, payload, day, created_at)
+				     VALUES ($1, $2, 'unblock', $3, $4, $5)`,
+				gameID, ch.CardID, payload, day, at,
+			); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("insert unblock event: %w", err)
+			}
+		}
+
+		// 5) what went wrong with its quality
+		if ch.TestFailure != nil {
+			at = at.Add(time.Microsecond)
+			if err := insertCardEvent(ctx, tx, gameID, ch.CardID, "test_failed", ch.TestFailure, day, at); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+		if ch.Defect != nil {
+			at = at.Add(time.Microsecond)
+			if err := insertCardEvent(ctx, tx, gameID, ch.CardID, "defect_escaped", ch.Defect, day, at); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+
+	// close the gaps cards left in the columns they moved out of
+	if slices.ContainsFunc(changes, func(ch models.CardChange) bool { return len(ch.Moves) > 0 }) {
+		if err := renumberCards(ctx, tx, gameID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+// insertSpawnedCard creates a card spawned during a day, such as an escaped
+// defect, at the bottom of its column.
+func insertSpawnedCard(ctx context.Context, tx *sql.Tx, gameID uuid.UUID, ch models.CardChange) error {
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO cards
+		        (id, game_id, column_id, title, class_of_service, value_estimate, selected_day, deployed_day, order_index)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8,
+		         (SELECT COALESCE(MAX(order_index), -1) + 1 FROM cards WHERE game_id = $2 AND column_id = $3))`,
+		ch.CardID, gameID, ch.ColumnID, ch.Title, ch.ClassOfService, ch.ValueEstimate, ch.SelectedDay, ch.DeployedDay,
+	); err != nil {
+		return fmt.Errorf("insert card %q: %w", ch.Title, err)
+	}
+	for _, e := range ch.Efforts {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO efforts (card_id, effort_type_id, estimate, remaining, actual)
+			 VALUES ($1, (SELECT id FROM effort_types WHERE game_id = $2 AND title = $3), $4, $5, $6)`,
+			ch.CardID, gameID, e.EffortType, e.Estimate, e.Remaining, e.Actual,
+		); err != nil {
+			return fmt.Errorf("insert effort %q for card %q: %w", e.EffortType, ch.Title, err)
+		}
+	}
+	return nil
+}
+
+// insertCardEvent logs an event about a card, with v as its payload.
+func insertCardEvent(ctx context.Context, tx *sql.Tx, gameID, cardID uuid.UUID, eventType string, v any, day int, at time.Time) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", eventType, err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO game_events (game_id, card_id, event_type, payload, day, created_at)
+		     VALUES ($1, $2, $3, $4, $5, $6)`,
+		gameID, cardID, eventType, payload, day, at,
+	); err != nil {
+		return fmt.Errorf("insert %s event: %w", eventType, err)
+	}
+	return nil
+}
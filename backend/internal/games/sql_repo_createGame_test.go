@@ -37,6 +37,7 @@ func TestSQLRepo_CreateGame(t *testing.T) {
 				m.ExpectBegin()
 				m.ExpectQuery(`INSERT INTO games .* RETURNING id`).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(gameID))
+				expectSnapshot(m, gameID, InitialDay)
 				m.ExpectCommit()
 			},
 		},
@@ -132,7 +133,7 @@ func TestSQLRepo_CreateGame(t *testing.T) {
 					).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(readyID))
 
-				// 7) INSERT INTO cards (game_id, column_id, title, class_of_service, value_estimate, selected_day, deployed_day)
+				// 7) INSERT INTO cards (game_id, column_id, title, class_of_service, value_estimate, selected_day, deployed_day, order_index, blocker columns)
 				m.ExpectQuery(`INSERT INTO cards .* RETURNING id`).
 					WithArgs(
 						gameID,       // $1 → game_id
@@ -142,15 +143,23 @@ func TestSQLRepo_CreateGame(t *testing.T) {
 						"high",       // $5 → value_estimate
 						1,            // $6 → selected_day
 						2,            // $7 → deployed_day
+						0,            // $8 → order_index
+						nil,          // $9 → blocked_reason (not blocked)
+						"",           // $10 → blocked_by
+						0,            // $11 → blocked_day
+						0,            // $12 → unblock_effort
 					).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(cardID))
 
 				// 8) INSERT INTO efforts (card_id, effort_type_id, estimate, remaining, actual)
 				m.ExpectQuery(`INSERT INTO efforts .* RETURNING id`).
-					WithArgs(cardID, etID, 3).
+					WithArgs(cardID, etID, 3, 3, 0).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
 
-				// 9) COMMIT
+				// 9) the initial snapshot
+				expectSnapshot(m, gameID, InitialDay)
+
+				// 10) COMMIT
 				m.ExpectCommit()
 			},
 		},
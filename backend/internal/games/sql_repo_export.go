@@ -0,0 +1,197 @@
+package games
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// ExportGame reads a complete game into a portable archive.
+func (r *sqlRepo) ExportGame(ctx context.Context, id uuid.UUID) (models.GameExport, error) {
+	game, err := r.GetGameByID(ctx, id)
+	if err != nil {
+		return models.GameExport{}, err
+	}
+
+	// 1) effort types
+	etRows, err := r.db.QueryContext(ctx,
+		`SELECT id, title, order_index FROM effort_types WHERE game_id = $1 ORDER BY order_index`,
+		id,
+	)
+	if err != nil {
+		return models.GameExport{}, fmt.Errorf("query effort_types: %w", err)
+	}
+	defer etRows.Close()
+	var effortTypes []models.EffortType
+	for etRows.Next() {
+		var et models.EffortType
+		if err := etRows.Scan(&et.ID, &et.Title, &et.OrderIndex); err != nil {
+			return models.GameExport{}, fmt.Errorf("scan effort_type: %w", err)
+		}
+		effortTypes = append(effortTypes, et)
+	}
+	if err := etRows.Err(); err != nil {
+		return models.GameExport{}, fmt.Errorf("iterate effort_types: %w", err)
+	}
+
+	// 2) columns, cards and efforts
+	state, err := loadBoardState(ctx, r.db, id)
+	if err != nil {
+		return models.GameExport{}, fmt.Errorf("load board: %w", err)
+	}
+
+	// 3) players
+	pRows, err := r.db.QueryContext(ctx,
+		`SELECT id, name FROM players WHERE game_id = $1 ORDER BY seat`,
+		id,
+	)
+	if err != nil {
+		return models.GameExport{}, fmt.Errorf("query players: %w", err)
+	}
+	defer pRows.Close()
+	var players []models.ExportPlayer
+	for pRows.Next() {
+		var p models.ExportPlayer
+		if err := pRows.Scan(&p.ID, &p.Name); err != nil {
+			return models.GameExport{}, fmt.Errorf("scan player: %w", err)
+		}
+		players = append(players, p)
+	}
+	if err := pRows.Err(); err != nil {
+		return models.GameExport{}, fmt.Errorf("iterate players: %w", err)
+	}
+
+	// 4) events
+	evRows, err := r.db.QueryContext(ctx,
+		`SELECT id, game_id, card_id, event_type, payload, day, created_at
+		   FROM game_events
+		  WHERE game_id = $1
+		  ORDER BY created_at`,
+		id,
+	)
+	if err != nil {
+		return models.GameExport{}, fmt.Errorf("query events: %w", err)
+	}
+	defer evRows.Close()
+	var events []models.GameEvent
+	for evRows.Next() {
+		var ev models.GameEvent
+		if err := evRows.Scan(&ev.ID, &ev.GameID, &ev.CardID, &ev.EventType, &ev.Payload, &ev.Day, &ev.CreatedAt); err != nil {
+			return models.GameExport{}, fmt.Errorf("scan event: %w", err)
+		}
+		events = append(events, ev)
+	}
+	if err := evRows.Err(); err != nil {
+		return models.GameExport{}, fmt.Errorf("iterate events: %w", err)
+	}
+
+	return buildExport(game, effortTypes, state, players, events), nil
+}
+
+// ImportGame recreates an exported game with fresh IDs, all in one TX. The
+// board is seeded exactly like CreateGame does, but keeps the progress stored
+// in the archive; events are re-pointed at the newly created cards. The
+// imported board is snapshotted under InitialDay.
+func (r *sqlRepo) ImportGame(ctx context.Context, doc models.GameExport) (uuid.UUID, error) {
+	// 1) begin transaction
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	// 2) the game itself, on the day it was exported
+	day := doc.Game.Day
+	if day < 1 {
+		day = 1
+	}
+	var gameID uuid.UUID
+	if err := tx.QueryRowContext(ctx,
+		`INSERT INTO games (day, seed, scenario, test_failure, rework, escaped_defect, defect_effort)
+		     VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 RETURNING id`,
+		append([]any{day, doc.Seed, doc.Game.Scenario}, qualityValues(doc.Game.Quality)...)...,
+	).Scan(&gameID); err != nil {
+		tx.Rollback()
+		return uuid.Nil, fmt.Errorf("insert game: %w", err)
+	}
+
+	// 3) effort types, columns, cards and efforts
+	cardIDs, err := seedBoard(ctx, tx, gameID, models.BoardConfig{
+		EffortTypes: doc.EffortTypes,
+		Columns:     doc.Columns,
+		Cards:       doc.Cards,
+	})
+	if err != nil {
+		tx.Rollback()
+		return uuid.Nil, err
+	}
+
+	// 4) players; the facilitator's copy facilitates the new game
+	for seat, p := range doc.Players {
+		var playerID uuid.UUID
+		if err := tx.QueryRowContext(ctx,
+			`INSERT INTO players (game_id, name, seat) VALUES ($1, $2, $3) RETURNING id`,
+			gameID, p.Name, seat,
+		).Scan(&playerID); err != nil {
+			tx.Rollback()
+			return uuid.Nil, fmt.Errorf("insert player %q: %w", p.Name, err)
+		}
+		if doc.Game.FacilitatorID != nil && *doc.Game.FacilitatorID == p.ID {
+			if _, err := tx.ExecContext(ctx,
+				`UPDATE games SET facilitator_id = $1 WHERE id = $2`, playerID, gameID,
+			); err != nil {
+				tx.Rollback()
+				return uuid.Nil, fmt.Errorf("set facilitator: %w", err)
+			}
+		}
+	}
+
+	// 5) card events, remapped onto the new cards; the new game has a
+	// lifecycle of its own
+	newCardIDs := make(map[uuid.UUID]uuid.UUID, len(cardIDs))
+	for i, c := range doc.Cards {
+		newCardIDs[c.ID] = cardIDs[i]
+	}
+	for _, ev := range doc.Events {
+		if ev.CardID == nil {
+			continue
+		}
+		cardID, ok := newCardIDs[*ev.CardID]
+		if !ok {
+			tx.Rollback()
+			return uuid.Nil, fmt.Errorf("%w: event references unknown card %s", ErrInvalidExport, ev.CardID)
+		}
+		payload := []byte(ev.Payload)
+		if len(payload) == 0 {
+			payload = []byte("{}")
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO game_events (game_id, card_id, event_type, payload, day, created_at)
+			     VALUES ($1, $2, $3, $4, $5, $6)`,
+			gameID, cardID, ev.EventType, payload, ev.Day, ev.CreatedAt,
+		); err != nil {
+			tx.Rollback()
+			return uuid.Nil, fmt.Errorf("insert event %q: %w", ev.EventType, err)
+		}
+	}
+
+	// 6) the imported board, to reset the game to
+	if err := saveSnapshot(ctx, tx, gameID, InitialDay); err != nil {
+		tx.Rollback()
+		return uuid.Nil, err
+	}
+
+	// 7) commit
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return gameID, nil
+}
@@ -0,0 +1,79 @@
+package games
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLRepo_ImportGame(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	oldCardID := uuid.New()
+	gameID := uuid.New()
+	etID := uuid.New()
+	colID := uuid.New()
+	cardID := uuid.New()
+	adaID := uuid.New()
+	newAdaID := uuid.New()
+
+	doc := models.GameExport{
+		Version:     models.GameExportVersion,
+		Game:        models.Game{ID: uuid.New(), Day: 6, Scenario: "default", FacilitatorID: &adaID},
+		Seed:        42,
+		EffortTypes: []models.EffortType{{Title: "Testing"}},
+		Columns:     []models.Column{{Title: "Test", Type: "active", OrderIndex: 4}},
+		Cards: []models.Card{{
+			ID: oldCardID, Title: "S2", ColumnTitle: "Test", ValueEstimate: "high",
+			SelectedDay: 1, OrderIndex: 2,
+			Efforts: []models.Effort{{EffortType: "Testing", Estimate: 5, Remaining: 2, Actual: 3}},
+		}},
+		Players: []models.ExportPlayer{{ID: adaID, Name: "Ada"}},
+		Events: []models.GameEvent{{
+			CardID: &oldCardID, EventType: "move", Payload: []byte(`{"day":2}`), Day: 2,
+		}},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO games .* RETURNING id`).
+		WithArgs(6, 42, "default", 0.0, 0.0, 0.0, 0.0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(gameID))
+	mock.ExpectQuery(`INSERT INTO effort_types .* RETURNING id`).
+		WithArgs(gameID, "Testing", 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(etID))
+	mock.ExpectQuery(`INSERT INTO columns .* RETURNING id`).
+		WithArgs(gameID, "Test", 4, 0, "active").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(colID))
+	// the only card of its column is numbered 0 there
+	mock.ExpectQuery(`INSERT INTO cards .* RETURNING id`).
+		WithArgs(gameID, colID, "S2", "", "high", 1, 0, 0, nil, "", 0, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(cardID))
+	// progress from the archive is kept
+	mock.ExpectQuery(`INSERT INTO efforts .* RETURNING id`).
+		WithArgs(cardID, etID, 5, 2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	// the facilitator's copy facilitates the new game
+	mock.ExpectQuery(`INSERT INTO players .* RETURNING id`).
+		WithArgs(gameID, "Ada", 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(newAdaID))
+	mock.ExpectExec(`UPDATE games SET facilitator_id`).
+		WithArgs(newAdaID, gameID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// the event now points at the new card
+	mock.ExpectExec(`INSERT INTO game_events`).
+		WithArgs(gameID, cardID, "move", []byte(`{"day":2}`), 2, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectSnapshot(mock, gameID, InitialDay)
+	mock.ExpectCommit()
+
+	got, err := NewSQLRepo(db).ImportGame(context.Background(), doc)
+	require.NoError(t, err)
+	require.Equal(t, gameID, got)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,515 @@
+package games
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidDay       = errors.New("invalid day")
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+)
+
+// boardSnapshot is the JSON document stored in game_snapshots. All IDs refer
+// to rows of the game the snapshot was taken from.
+type boardSnapshot struct {
+	Columns []snapshotColumn `json:"columns"`
+	Cards   []snapshotCard   `json:"cards"`
+}
+
+type snapshotColumn struct {
+	ID         uuid.UUID  `json:"id"`
+	ParentID   *uuid.UUID `json:"parentId,omitempty"`
+	Title      string     `json:"title"`
+	WIPLimit   int        `json:"wipLimit"`
+	Type       string     `json:"type"`
+	OrderIndex int        `json:"orderIndex"`
+}
+
+type snapshotCard struct {
+	ID             uuid.UUID        `json:"id"`
+	ColumnID       uuid.UUID        `json:"columnId"`
+	Title          string           `json:"title"`
+	ClassOfService string           `json:"classOfService"`
+	ValueEstimate  string           `json:"valueEstimate"`
+	SelectedDay    int              `json:"selectedDay"`
+	DeployedDay    int              `json:"deployedDay"`
+	OrderIndex     int              `json:"orderIndex"`
+	Efforts        []snapshotEffort `json:"efforts"`
+	Blocker        *models.Blocker  `json:"blocker,omitempty"`
+}
+
+type snapshotEffort struct {
+	EffortTypeID uuid.UUID `json:"effortTypeId"`
+	Estimate     int       `json:"estimate"`
+	Remaining    int       `json:"remaining"`
+	Actual       int       `json:"actual"`
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// execQueryer is satisfied by both *sql.DB and *sql.Tx.
+type execQueryer interface {
+	queryer
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// loadBoardState reads the columns, cards and efforts of a game as they are
+// right now.
+func loadBoardState(ctx context.Context, q queryer, gameID uuid.UUID) (boardSnapshot, error) {
+	var state boardSnapshot
+
+	colRows, err := q.QueryContext(ctx,
+		`SELECT id, parent_id, title, wip_limit, col_type, order_index
+		   FROM columns
+		  WHERE game_id = $1
+		  ORDER BY order_index`,
+		gameID,
+	)
+	if err != nil {
+		return state, fmt.Errorf("query columns: %w", err)
+	}
+	defer colRows.Close()
+	for colRows.Next() {
+		var c snapshotColumn
+		if err := colRows.Scan(&c.ID, &c.ParentID, &c.Title, &c.WIPLimit, &c.Type, &c.OrderIndex); err != nil {
+			return state, fmt.Errorf("scan column: %w", err)
+		}
+		state.Columns = append(state.Columns, c)
+	}
+	if err := colRows.Err(); err != nil {
+		return state, fmt.Errorf("iterate columns: %w", err)
+	}
+
+	cardRows, err := q.QueryContext(ctx,
+		`SELECT id, column_id, title, class_of_service, value_estimate,
+		        selected_day, deployed_day, order_index,
+		        blocked_reason, blocked_by, blocked_day, unblock_effort
+		   FROM cards
+		  WHERE game_id = $1
+		  ORDER BY order_index`,
+		gameID,
+	)
+	if err != nil {
+		return state, fmt.Errorf("query cards: %w", err)
+	}
+	defer cardRows.Close()
+	cardIdx := make(map[uuid.UUID]int)
+	for cardRows.Next() {
+		var (
+			c              snapshotCard
+			classOfService sql.NullString
+			selectedDay    sql.NullInt64
+			deployedDay    sql.NullInt64
+			blockedReason  sql.NullString
+			blockedBy      string
+			blockedDay     int
+			unblockEffort  int
+		)
+		if err := cardRows.Scan(
+			&c.ID, &c.ColumnID, &c.Title, &classOfService, &c.ValueEstimate,
+			&selectedDay, &deployedDay, &c.OrderIndex,
+			&blockedReason, &blockedBy, &blockedDay, &unblockEffort,
+		); err != nil {
+			return state, fmt.Errorf("scan card: %w", err)
+		}
+		c.Blocker = scanBlocker(blockedReason, blockedBy, blockedDay, unblockEffort)
+		c.ClassOfService = classOfService.String
+		c.SelectedDay = int(selectedDay.Int64)
+		c.DeployedDay = int(deployedDay.Int64)
+		cardIdx[c.ID] = len(state.Cards)
+		state.Cards = append(state.Cards, c)
+	}
+	if err := cardRows.Err(); err != nil {
+		return state, fmt.Errorf("iterate cards: %w", err)
+	}
+
+	effRows, err := q.QueryContext(ctx,
+		`SELECT e.card_id, e.effort_type_id, e.estimate, e.remaining, e.actual
+		   FROM efforts e
+		   JOIN cards c ON c.id = e.card_id
+		   JOIN effort_types et ON et.id = e.effort_type_id
+		  WHERE c.game_id = $1
+		  ORDER BY et.order_index`,
+		gameID,
+	)
+	if err != nil {
+		return state, fmt.Errorf("query efforts: %w", err)
+	}
+	defer effRows.Close()
+	for effRows.Next() {
+		var (
+			cardID uuid.UUID
+			e      snapshotEffort
+		)
+		if err := effRows.Scan(&cardID, &e.EffortTypeID, &e.Estimate, &e.Remaining, &e.Actual); err != nil {
+			return state, fmt.Errorf("scan effort: %w", err)
+		}
+		if i, ok := cardIdx[cardID]; ok {
+			state.Cards[i].Efforts = append(state.Cards[i].Efforts, e)
+		}
+	}
+	if err := effRows.Err(); err != nil {
+		return state, fmt.Errorf("iterate efforts: %w", err)
+	}
+
+	return state, nil
+}
+
+// SaveSnapshot stores the current board of a game as its state at the end of
+// the given day, replacing any snapshot already taken for that day.
+func (r *sqlRepo) SaveSnapshot(ctx context.Context, gameID uuid.UUID, day int) error {
+	return saveSnapshot(ctx, r.db, gameID, day)
+}
+
+// saveSnapshot is SaveSnapshot on the database or within a transaction.
+func saveSnapshot(ctx context.Context, q execQueryer, gameID uuid.UUID, day int) error {
+	state, err := loadBoardState(ctx, q, gameID)
+	if err != nil {
+		return fmt.Errorf("load board: %w", err)
+	}
+	board, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal snapshot: %w", err)
+	}
+
+	if _, err := q.ExecContext(ctx,
+		`INSERT INTO game_snapshots (game_id, day, board)
+		     VALUES ($1, $2, $3)
+		 ON CONFLICT (game_id, day)
+		 DO UPDATE SET board = EXCLUDED.board, created_at = EXCLUDED.created_at`,
+		gameID, day, board,
+	); err != nil {
+		return fmt.Errorf("insert snapshot: %w", err)
+	}
+	return nil
+}
+
+// ForkGame creates a new game from the state of an existing one at the end of
+// the given day. Day 0 forks the live board and keeps the current day; any
+// earlier, finished day is restored from its snapshot and the fork resumes on
+// the following day. Effort types, columns, cards, efforts, players and the
+// events up to that day are copied with fresh IDs, and the fork's board is
+// snapshotted under InitialDay.
+func (r *sqlRepo) ForkGame(ctx context.Context, sourceID uuid.UUID, day int) (uuid.UUID, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	// 1) the source must exist and the day must already be over
+	var (
+		currentDay  int
+		seed        int64
+		scenario    string
+		quality     models.Quality
+		facilitator uuid.NullUUID
+	)
+	switch err := tx.QueryRowContext(ctx,
+		`SELECT day, seed, scenario, facilitator_id, test_failure, rework, escaped_defect, defect_effort
+		   FROM games WHERE id = $1 AND deleted_at IS NULL`, sourceID,
+	).Scan(&currentDay, &seed, &scenario, &facilitator,
+		&quality.TestFailure, &quality.Rework, &quality.EscapedDefect, &quality.DefectEffort); err {
+	case nil:
+	case sql.ErrNoRows:
+		tx.Rollback()
+		return uuid.Nil, ErrNotFound
+	default:
+		tx.Rollback()
+		return uuid.Nil, fmt.Errorf("query game: %w", err)
+	}
+
+	var (
+		state  boardSnapshot
+		newDay int
+	)
+	switch {
+	case day == 0:
+		day, newDay = currentDay, currentDay
+		if state, err = loadBoardState(ctx, tx, sourceID); err != nil {
+			tx.Rollback()
+			return uuid.Nil, fmt.Errorf("load board: %w", err)
+		}
+	case day < 1 || day >= currentDay:
+		tx.Rollback()
+		return uuid.Nil, ErrInvalidDay
+	default:
+		newDay = day + 1
+		var raw []byte
+		switch err := tx.QueryRowContext(ctx,
+			`SELECT board FROM game_snapshots WHERE game_id = $1 AND day = $2`,
+			sourceID, day,
+		).Scan(&raw); err {
+		case nil:
+		case sql.ErrNoRows:
+			tx.Rollback()
+			return uuid.Nil, ErrSnapshotNotFound
+		default:
+			tx.Rollback()
+			return uuid.Nil, fmt.Errorf("query snapshot: %w", err)
+		}
+		if err := json.Unmarshal(raw, &state); err != nil {
+			tx.Rollback()
+			return uuid.Nil, fmt.Errorf("decode snapshot: %w", err)
+		}
+	}
+
+	// 2) the new game, linked to its parent; with the seed, the card titles and
+	// the seats all shared, the fork rolls what the parent would have rolled
+	var gameID uuid.UUID
+	if err := tx.QueryRowContext(ctx,
+		`INSERT INTO games (day, parent_game_id, forked_at_day, seed, scenario,
+		                    test_failure, rework, escaped_defect, defect_effort)
+		     VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		 RETURNING id`,
+		append([]any{newDay, sourceID, day, seed, scenario}, qualityValues(quality)...)...,
+	).Scan(&gameID); err != nil {
+		tx.Rollback()
+		return uuid.Nil, fmt.Errorf("insert game: %w", err)
+	}
+
+	// 3) effort types
+	type effortType struct {
+		id         uuid.UUID
+		title      string
+		orderIndex int
+	}
+	etRows, err := tx.QueryContext(ctx,
+		`SELECT id, title, order_index FROM effort_types WHERE game_id = $1 ORDER BY order_index`,
+		sourceID,
+	)
+	if err != nil {
+		tx.Rollback()
+		return uuid.Nil, fmt.Errorf("query effort_types: %w", err)
+	}
+	var effortTypes []effortType
+	for etRows.Next() {
+		var et effortType
+		if err := etRows.Scan(&et.id, &et.title, &et.orderIndex); err != nil {
+			etRows.Close()
+			tx.Rollback()
+			return uuid.Nil, fmt.Errorf("scan effort_type: %w", err)
+		}
+		effortTypes = append(effortTypes, et)
+	}
+	etRows.Close()
+	if err := etRows.Err(); err != nil {
+		tx.Rollback()
+		return uuid.Nil, fmt.Errorf("iterate effort_types: %w", err)
+	}
+
+	effortTypeIDs := make(map[uuid.UUID]uuid.UUID, len(effortTypes))
+	for _, et := range effortTypes {
+		var etID uuid.UUID
+		if err := tx.QueryRowContext(ctx,
+			`INSERT INTO effort_types (game_id, title, order_index)
+			     VALUES ($1, $2, $3)
+			 RETURNING id`,
+			gameID, et.title, et.orderIndex,
+		).Scan(&etID); err != nil {
+			tx.Rollback()
+			return uuid.Nil, fmt.Errorf("insert effort type %q: %w", et.title, err)
+		}
+		effortTypeIDs[et.id] = etID
+	}
+
+	// 4) columns, parents before their subcolumns
+	columnIDs := make(map[uuid.UUID]uuid.UUID, len(state.Columns))
+	for _, topLevel := range []bool{true, false} {
+		for _, col := range state.Columns {
+			if (col.ParentID == nil) != topLevel {
+				continue
+			}
+			var parentID *uuid.UUID
+			if col.ParentID != nil {
+				id, ok := columnIDs[*col.ParentID]
+				if !ok {
+					tx.Rollback()
+					return uuid.Nil, fmt.Errorf("unknown parent of column %q", col.Title)
+				}
+				parentID = &id
+			}
+			var colID uuid.UUID
+			if err := tx.QueryRowContext(ctx,
+				`INSERT INTO columns
+				    (game_id, title, parent_id, order_index, wip_limit, col_type)
+				 VALUES ($1, $2, $3, $4, $5, $6)
+				 RETURNING id`,
+				gameID, col.Title, parentID, col.OrderIndex, col.WIPLimit, col.Type,
+			).Scan(&colID); err != nil {
+				tx.Rollback()
+				return uuid.Nil, fmt.Errorf("insert column %q: %w", col.Title, err)
+			}
+			columnIDs[col.ID] = colID
+		}
+	}
+
+	// 5) cards and their efforts
+	cardIDs := make(map[uuid.UUID]uuid.UUID, len(state.Cards))
+	for _, c := range state.Cards {
+		colID, ok := columnIDs[c.ColumnID]
+		if !ok {
+			tx.Rollback()
+			return uuid.Nil, fmt.Errorf("unknown column of card %q", c.Title)
+		}
+		var cardID uuid.UUID
+		args := []any{
+			gameID, colID, c.Title, c.ClassOfService, c.ValueEstimate,
+			c.SelectedDay, c.DeployedDay, c.OrderIndex,
+		}
+		if err := tx.QueryRowContext(ctx,
+			`INSERT INTO cards
+			    (game_id, column_id, title, class_of_service, value_estimate,
+			     selected_day, deployed_day, order_index,
+			     blocked_reason, blocked_by, blocked_day, unblock_effort)
+			 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
+			 RETURNING id`,
+			append(args, blockerValues(c.Blocker)...)...,
+		).Scan(&cardID); err != nil {
+			tx.Rollback()
+			return uuid.Nil, fmt.Errorf("insert card %q: %w", c.Title, err)
+		}
+		cardIDs[c.ID] = cardID
+
+		for _, e := range c.Efforts {
+			etID, ok := effortTypeIDs[e.EffortTypeID]
+			if !ok {
+				tx.Rollback()
+				return uuid.Nil, fmt.Errorf("unknown effort type of card %q", c.Title)
+			}
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO efforts (card_id, effort_type_id, estimate, remaining, actual)
+				     VALUES ($1,$2,$3,$4,$5)`,
+				cardID, etID, e.Estimate, e.Remaining, e.Actual,
+			); err != nil {
+				tx.Rollback()
+				return uuid.Nil, fmt.Errorf("insert effort for card %q: %w", c.Title, err)
+			}
+		}
+	}
+
+	// 6) players; the facilitator's copy facilitates the fork
+	type player struct {
+		id   uuid.UUID
+		name string
+		seat int
+	}
+	pRows, err := tx.QueryContext(ctx, `SELECT id, name, seat FROM players WHERE game_id = $1`, sourceID)
+	if err != nil {
+		tx.Rollback()
+		return uuid.Nil, fmt.Errorf("query players: %w", err)
+	}
+	var players []player
+	for pRows.Next() {
+		var p player
+		if err := pRows.Scan(&p.id, &p.name, &p.seat); err != nil {
+			pRows.Close()
+			tx.Rollback()
+			return uuid.Nil, fmt.Errorf("scan player: %w", err)
+		}
+		players = append(players, p)
+	}
+	pRows.Close()
+	if err := pRows.Err(); err != nil {
+		tx.Rollback()
+		return uuid.Nil, fmt.Errorf("iterate players: %w", err)
+	}
+	for _, p := range players {
+		var playerID uuid.UUID
+		if err := tx.QueryRowContext(ctx,
+			`INSERT INTO players (game_id, name, seat) VALUES ($1, $2, $3) RETURNING id`,
+			gameID, p.name, p.seat,
+		).Scan(&playerID); err != nil {
+			tx.Rollback()
+			return uuid.Nil, fmt.Errorf("copy player %q: %w", p.name, err)
+		}
+		if facilitator.Valid && facilitator.UUID == p.id {
+			if _, err := tx.ExecContext(ctx,
+				`UPDATE games SET facilitator_id = $1 WHERE id = $2`, playerID, gameID,
+			); err != nil {
+				tx.Rollback()
+				return uuid.Nil, fmt.Errorf("set facilitator: %w", err)
+			}
+		}
+	}
+
+	// 7) card events up to and including the fork day; the fork has a
+	// lifecycle of its own
+	type event struct {
+		cardID    uuid.NullUUID
+		eventType string
+		payload   []byte
+		day       int
+		createdAt time.Time
+	}
+	evRows, err := tx.QueryContext(ctx,
+		`SELECT card_id, event_type, payload, day, created_at
+		   FROM game_events
+		  WHERE game_id = $1 AND day <= $2
+		  ORDER BY created_at`,
+		sourceID, day,
+	)
+	if err != nil {
+		tx.Rollback()
+		return uuid.Nil, fmt.Errorf("query events: %w", err)
+	}
+	var events []event
+	for evRows.Next() {
+		var ev event
+		if err := evRows.Scan(&ev.cardID, &ev.eventType, &ev.payload, &ev.day, &ev.createdAt); err != nil {
+			evRows.Close()
+			tx.Rollback()
+			return uuid.Nil, fmt.Errorf("scan event: %w", err)
+		}
+		events = append(events, ev)
+	}
+	evRows.Close()
+	if err := evRows.Err(); err != nil {
+		tx.Rollback()
+		return uuid.Nil, fmt.Errorf("iterate events: %w", err)
+	}
+
+	for _, ev := range events {
+		cardID, ok := cardIDs[ev.cardID.UUID]
+		if !ev.cardID.Valid || !ok {
+			// a game event, or the card no longer existed at the fork day
+			continue
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO game_events (game_id, card_id, event_type, payload, day, created_at)
+			     VALUES ($1, $2, $3, $4, $5, $6)`,
+			gameID, cardID, ev.eventType, ev.payload, ev.day, ev.createdAt,
+		); err != nil {
+			tx.Rollback()
+			return uuid.Nil, fmt.Errorf("copy event %q: %w", ev.eventType, err)
+		}
+	}
+
+	// 8) the board the fork starts with, to reset it to
+	if err := saveSnapshot(ctx, tx, gameID, InitialDay); err != nil {
+		tx.Rollback()
+		return uuid.Nil, err
+	}
+
+	// 9) commit
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return gameID, nil
+}
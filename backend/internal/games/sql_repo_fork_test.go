@@ -0,0 +1,186 @@
+package games
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLRepo_ForkGame(t *testing.T) {
+	sourceID := uuid.New()
+	forkID := uuid.New()
+	etID := uuid.New()
+	colID := uuid.New()
+	cardID := uuid.New()
+	facilitatorID := uuid.New()
+
+	snapshot, err := json.Marshal(boardSnapshot{
+		Columns: []snapshotColumn{{ID: colID, Title: "Options", Type: "queue"}},
+		Cards: []snapshotCard{{
+			ID: cardID, ColumnID: colID, Title: "S1", ValueEstimate: "high",
+			SelectedDay: 2,
+			Efforts:     []snapshotEffort{{EffortTypeID: etID, Estimate: 4, Remaining: 1, Actual: 3}},
+		}},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		day     int
+		prepare func(m sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "unknown game",
+			day:  3,
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT day, seed, scenario, facilitator_id, test_failure, rework, escaped_defect, defect_effort FROM games WHERE id = $1 AND deleted_at IS NULL`)).
+					WithArgs(sourceID).
+					WillReturnError(sql.ErrNoRows)
+				m.ExpectRollback()
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "day not finished yet",
+			day:  5,
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT day, seed, scenario, facilitator_id, test_failure, rework, escaped_defect, defect_effort FROM games WHERE id = $1 AND deleted_at IS NULL`)).
+					WithArgs(sourceID).
+					WillReturnRows(sqlmock.NewRows([]string{"day", "seed", "scenario", "facilitator_id", "test_failure", "rework", "escaped_defect", "defect_effort"}).
+						AddRow(5, 42, "default", facilitatorID, 0.2, 0.5, 0.1, 0.5))
+				m.ExpectRollback()
+			},
+			wantErr: ErrInvalidDay,
+		},
+		{
+			name: "missing snapshot",
+			day:  3,
+			prepare: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT day, seed, scenario, facilitator_id, test_failure, rework, escaped_defect, defect_effort FROM games WHERE id = $1 AND deleted_at IS NULL`)).
+					WithArgs(sourceID).
+					WillReturnRows(sqlmock.NewRows([]string{"day", "seed", "scenario", "facilitator_id", "test_failure", "rework", "escaped_defect", "defect_effort"}).
+						AddRow(5, 42, "default", facilitatorID, 0.2, 0.5, 0.1, 0.5))
+				m.ExpectQuery(`SELECT board FROM game_snapshots`).
+					WithArgs(sourceID, 3).
+					WillReturnError(sql.ErrNoRows)
+				m.ExpectRollback()
+			},
+			wantErr: ErrSnapshotNotFound,
+		},
+		{
+			name: "from snapshot",
+			day:  3,
+			prepare: func(m sqlmock.Sqlmock) {
+				newEtID := uuid.New()
+				newColID := uuid.New()
+				newCardID := uuid.New()
+
+				m.ExpectBegin()
+				m.ExpectQuery(regexp.QuoteMeta(`SELECT day, seed, scenario, facilitator_id, test_failure, rework, escaped_defect, defect_effort FROM games WHERE id = $1 AND deleted_at IS NULL`)).
+					WithArgs(sourceID).
+					WillReturnRows(sqlmock.NewRows([]string{"day", "seed", "scenario", "facilitator_id", "test_failure", "rework", "escaped_defect", "defect_effort"}).
+						AddRow(5, 42, "default", facilitatorID, 0.2, 0.5, 0.1, 0.5))
+				m.ExpectQuery(`SELECT board FROM game_snapshots`).
+					WithArgs(sourceID, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"board"}).AddRow(snapshot))
+
+				// the fork resumes on the day after the snapshot, with the
+				// parent's dice
+				m.ExpectQuery(`INSERT INTO games .* RETURNING id`).
+					WithArgs(4, sourceID, 3, 42, "default", 0.2, 0.5, 0.1, 0.5).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(forkID))
+
+				m.ExpectQuery(`SELECT id, title, order_index FROM effort_types`).
+					WithArgs(sourceID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "order_index"}).
+						AddRow(etID, "Analysis", 0))
+				m.ExpectQuery(`INSERT INTO effort_types .* RETURNING id`).
+					WithArgs(forkID, "Analysis", 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(newEtID))
+
+				m.ExpectQuery(`INSERT INTO columns .* RETURNING id`).
+					WithArgs(forkID, "Options", nil, 0, 0, "queue").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(newColID))
+
+				m.ExpectQuery(`INSERT INTO cards .* RETURNING id`).
+					WithArgs(forkID, newColID, "S1", "", "high", 2, 0, 0, nil, "", 0, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(newCardID))
+				m.ExpectExec(`INSERT INTO efforts`).
+					WithArgs(newCardID, newEtID, 4, 1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				// players get new IDs but keep their seats; the facilitator's
+				// copy facilitates
+				newFacilitatorID := uuid.New()
+				m.ExpectQuery(`SELECT id, name, seat FROM players`).
+					WithArgs(sourceID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "seat"}).
+						AddRow(uuid.New(), "Alan", 1).
+						AddRow(facilitatorID, "Grace", 0))
+				m.ExpectQuery(`INSERT INTO players .* RETURNING id`).
+					WithArgs(forkID, "Alan", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+				m.ExpectQuery(`INSERT INTO players .* RETURNING id`).
+					WithArgs(forkID, "Grace", 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(newFacilitatorID))
+				m.ExpectExec(`UPDATE games SET facilitator_id`).
+					WithArgs(newFacilitatorID, forkID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				m.ExpectQuery(`SELECT card_id, event_type, payload, day, created_at FROM game_events`).
+					WithArgs(sourceID, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"card_id", "event_type", "payload", "day", "created_at"}))
+
+				expectSnapshot(m, forkID, InitialDay)
+				m.ExpectCommit()
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tc.prepare(mock)
+
+			repo := NewSQLRepo(db)
+			got, err := repo.ForkGame(context.Background(), sourceID, tc.day)
+
+			if tc.wantErr != nil {
+				require.True(t, errors.Is(err, tc.wantErr), "err = %v; want %v", err, tc.wantErr)
+				require.Equal(t, uuid.Nil, got)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, forkID, got)
+			}
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// expectSnapshot expects an empty board of a game to be read and stored as
+// its snapshot of day.
+func expectSnapshot(m sqlmock.Sqlmock, gameID uuid.UUID, day int) {
+	m.ExpectQuery(`FROM columns`).WithArgs(gameID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "parent_id", "title", "wip_limit", "col_type", "order_index"}))
+	m.ExpectQuery(`FROM cards`).WithArgs(gameID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "column_id", "title", "class_of_service", "value_estimate",
+			"selected_day", "deployed_day", "order_index", "blocked_reason", "blocked_by", "blocked_day", "unblock_effort"}))
+	m.ExpectQuery(`FROM efforts`).WithArgs(gameID).
+		WillReturnRows(sqlmock.NewRows([]string{"card_id", "effort_type_id", "estimate", "remaining", "actual"}))
+	m.ExpectExec(`INSERT INTO game_snapshots`).WithArgs(gameID, day, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+}
@@ -0,0 +1,214 @@
+package games
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// PositionCard reads the other cards of the target column in order, puts
+// the card among them and writes the order indexes that changed. When the
+// card came from another column, that column is numbered again to close the
+// gap it left. The card order of the game is locked first, so concurrent
+// placements are applied one after the other.
+func (r *sqlRepo) PositionCard(ctx context.Context, gameID uuid.UUID, p Placement) (models.CardOrder, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.CardOrder{}, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if pv := recover(); pv != nil {
+			tx.Rollback()
+			panic(pv)
+		}
+	}()
+
+	if err := lockCardOrder(ctx, tx, gameID); err != nil {
+		tx.Rollback()
+		return models.CardOrder{}, err
+	}
+
+	// 1) the card, still where the placement was made for
+	card := models.OrderedCard{ID: p.CardID}
+	var from uuid.UUID
+	switch err := tx.QueryRowContext(ctx,
+		`SELECT title, column_id FROM cards WHERE id = $1 AND game_id = $2`, p.CardID, gameID,
+	).Scan(&card.Title, &from); {
+	case errors.Is(err, sql.ErrNoRows):
+		tx.Rollback()
+		return models.CardOrder{}, fmt.Errorf("card %s: %w", p.CardID, ErrNotFound)
+	case err != nil:
+		tx.Rollback()
+		return models.CardOrder{}, fmt.Errorf("query card: %w", err)
+	}
+	if from != p.From {
+		tx.Rollback()
+		return models.CardOrder{}, fmt.Errorf("%w: %s", ErrCardMoved, p.CardID)
+	}
+
+	// 2) the other cards of the column, in order
+	others, err := columnCards(ctx, tx, gameID, p.ColumnID, p.CardID)
+	if err != nil {
+		tx.Rollback()
+		return models.CardOrder{}, err
+	}
+	old := make(map[uuid.UUID]int, len(others))
+	for _, c := range others {
+		old[c.ID] = c.OrderIndex
+	}
+	order := place(others, card, p.Index)
+
+	// 3) the card itself, then its neighbours that shifted
+	if from != p.ColumnID {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE cards SET column_id = $1, selected_day = $2 WHERE id = $3`,
+			p.ColumnID, p.SelectedDay, p.CardID,
+		); err != nil {
+			tx.Rollback()
+			return models.CardOrder{}, fmt.Errorf("move card: %w", err)
+		}
+	}
+	for _, c := range order {
+		if i, ok := old[c.ID]; ok && i == c.OrderIndex {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE cards SET order_index = $1 WHERE id = $2`, c.OrderIndex, c.ID,
+		); err != nil {
+			tx.Rollback()
+			return models.CardOrder{}, fmt.Errorf("order card %s: %w", c.ID, err)
+		}
+	}
+
+	// 4) the column it left, and the move
+	if from != p.ColumnID {
+		if err := renumberCards(ctx, tx, gameID); err != nil {
+			tx.Rollback()
+			return models.CardOrder{}, err
+		}
+	}
+	if p.Move != nil {
+		payload, err := json.Marshal(p.Move)
+		if err != nil {
+			tx.Rollback()
+			return models.CardOrder{}, fmt.Errorf("marshal move: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO game_events (game_id, card_id, event_type, payload, day, created_at)
+			     VALUES ($1, $2, 'move', $3, $4, $5)`,
+			gameID, p.CardID, payload, p.Day, time.Now().UTC(),
+		); err != nil {
+			tx.Rollback()
+			return models.CardOrder{}, fmt.Errorf("insert move event: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.CardOrder{}, fmt.Errorf("commit tx: %w", err)
+	}
+	return models.CardOrder{ColumnID: p.ColumnID, Cards: order}, nil
+}
+
+// lockCardOrder makes a transaction the only one changing the card order of
+// a game until it ends. It writes the game row before anything is read:
+// Postgres keeps the row locked for the transaction, and SQLite takes its
+// write lock then, waiting out other writers, rather than failing to
+// upgrade a read later.
+func lockCardOrder(ctx context.Context, tx *sql.Tx, gameID uuid.UUID) error {
+	res, err := tx.ExecContext(ctx, `UPDATE games SET day = day WHERE id = $1 AND deleted_at IS NULL`, gameID)
+	if err != nil {
+		return fmt.Errorf("lock card order: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("game %s: %w", gameID, ErrNotFound)
+	}
+	return nil
+}
+
+// columnCards lists the cards of a column but one, top first.
+func columnCards(ctx context.Context, tx *sql.Tx, gameID, columnID, except uuid.UUID) ([]models.OrderedCard, error) {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id, title, order_index
+		   FROM cards
+		  WHERE game_id = $1 AND column_id = $2 AND id <> $3
+		  ORDER BY order_index, id`,
+		gameID, columnID, except,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query column cards: %w", err)
+	}
+	defer rows.Close()
+	var cards []models.OrderedCard
+	for rows.Next() {
+		var c models.OrderedCard
+		if err := rows.Scan(&c.ID, &c.Title, &c.OrderIndex); err != nil {
+			return nil, fmt.Errorf("scan column card: %w", err)
+		}
+		cards = append(cards, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate column cards: %w", err)
+	}
+	return cards, nil
+}
+
+// renumberCards numbers the cards of every column of a game from 0 again,
+// keeping their order, after cards left some columns.
+func renumberCards(ctx context.Context, tx *sql.Tx, gameID uuid.UUID) error {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id, column_id, order_index
+		   FROM cards
+		  WHERE game_id = $1
+		  ORDER BY column_id, order_index, id`,
+		gameID,
+	)
+	if err != nil {
+		return fmt.Errorf("query card order: %w", err)
+	}
+	defer rows.Close()
+
+	type renumber struct {
+		id    uuid.UUID
+		index int
+	}
+	var (
+		changes []renumber
+		column  uuid.UUID
+		next    int
+	)
+	for rows.Next() {
+		var (
+			id, col uuid.UUID
+			index   int
+		)
+		if err := rows.Scan(&id, &col, &index); err != nil {
+			return fmt.Errorf("scan card order: %w", err)
+		}
+		if col != column {
+			column, next = col, 0
+		}
+		if index != next {
+			changes = append(changes, renumber{id, next})
+		}
+		next++
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate card order: %w", err)
+	}
+	rows.Close()
+
+	for _, c := range changes {
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE cards SET order_index = $1 WHERE id = $2`, c.index, c.id,
+		); err != nil {
+			return fmt.Errorf("renumber card %s: %w", c.id, err)
+		}
+	}
+	return nil
+}
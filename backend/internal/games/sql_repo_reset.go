@@ -0,0 +1,133 @@
+package games
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// InitialDay is the snapshot day under which the board a game started with
+// is kept. Finished days are numbered from 1.
+const InitialDay = 0
+
+// ResetGame puts a game back to the board it started with, kept as its
+// snapshot of InitialDay. Columns and cards get back their original IDs;
+// events and the snapshots of played days are dropped, the day goes back to
+// 1 and the game to the lobby. Players and the facilitator stay.
+func (r *sqlRepo) ResetGame(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	// 1) the game must exist and have kept its initial board
+	var raw []byte
+	switch err := tx.QueryRowContext(ctx,
+		`SELECT s.board
+		   FROM games g
+		   LEFT JOIN game_snapshots s ON s.game_id = g.id AND s.day = $2
+		  WHERE g.id = $1 AND g.deleted_at IS NULL`,
+		id, InitialDay,
+	).Scan(&raw); err {
+	case nil:
+	case sql.ErrNoRows:
+		tx.Rollback()
+		return ErrNotFound
+	default:
+		tx.Rollback()
+		return fmt.Errorf("query snapshot: %w", err)
+	}
+	if raw == nil {
+		tx.Rollback()
+		return ErrSnapshotNotFound
+	}
+	var state boardSnapshot
+	if err := json.Unmarshal(raw, &state); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("decode snapshot: %w", err)
+	}
+
+	// 2) what was played; efforts and subcolumns go with their cards and
+	// columns
+	for _, q := range []struct {
+		what, sql string
+		args      []any
+	}{
+		{"events", `DELETE FROM game_events WHERE game_id = $1`, []any{id}},
+		{"snapshots", `DELETE FROM game_snapshots WHERE game_id = $1 AND day <> $2`, []any{id, InitialDay}},
+		{"cards", `DELETE FROM cards WHERE game_id = $1`, []any{id}},
+		{"columns", `DELETE FROM columns WHERE game_id = $1`, []any{id}},
+	} {
+		if _, err := tx.ExecContext(ctx, q.sql, q.args...); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("delete %s: %w", q.what, err)
+		}
+	}
+
+	// 3) the board as it started, parents before their subcolumns
+	for _, topLevel := range []bool{true, false} {
+		for _, col := range state.Columns {
+			if (col.ParentID == nil) != topLevel {
+				continue
+			}
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO columns
+				    (id, game_id, title, parent_id, order_index, wip_limit, col_type)
+				 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+				col.ID, id, col.Title, col.ParentID, col.OrderIndex, col.WIPLimit, col.Type,
+			); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("insert column %q: %w", col.Title, err)
+			}
+		}
+	}
+	for _, c := range state.Cards {
+		args := []any{
+			c.ID, id, c.ColumnID, c.Title, c.ClassOfService, c.ValueEstimate,
+			c.SelectedDay, c.DeployedDay, c.OrderIndex,
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO cards
+			    (id, game_id, column_id, title, class_of_service, value_estimate,
+			     selected_day, deployed_day, order_index,
+			     blocked_reason, blocked_by, blocked_day, unblock_effort)
+			 VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)`,
+			append(args, blockerValues(c.Blocker)...)...,
+		); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("insert card %q: %w", c.Title, err)
+		}
+		for _, e := range c.Efforts {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO efforts (card_id, effort_type_id, estimate, remaining, actual)
+				     VALUES ($1,$2,$3,$4,$5)`,
+				c.ID, e.EffortTypeID, e.Estimate, e.Remaining, e.Actual,
+			); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("insert effort for card %q: %w", c.Title, err)
+			}
+		}
+	}
+
+	// 4) back to day 1, in the lobby
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE games SET day = 1, status = 'lobby' WHERE id = $1`, id,
+	); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("update game: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
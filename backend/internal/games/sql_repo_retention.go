@@ -0,0 +1,49 @@
+package games
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// inactiveSince is the condition of a game g, not soft-deleted, without
+// activity at or after the time in parameter n.
+func inactiveSince(n int) string {
+	return fmt.Sprintf(`g.deleted_at IS NULL AND g.created_at < $%[1]d
+	     AND NOT EXISTS (SELECT 1 FROM game_events e WHERE e.game_id = g.id AND e.created_at >= $%[1]d)`, n)
+}
+
+func (r *sqlRepo) InactiveGames(ctx context.Context, before time.Time) ([]models.Game, error) {
+	return r.queryGames(ctx,
+		`SELECT `+gameColumns+` FROM games g WHERE `+inactiveSince(1)+` ORDER BY g.created_at`,
+		before.UTC().Format(createdAtLayout),
+	)
+}
+
+// SoftDeleteGame checks the game is still inactive in the same statement, so
+// a game played after InactiveGames listed it is kept.
+func (r *sqlRepo) SoftDeleteGame(ctx context.Context, id uuid.UUID, inactiveBefore, at time.Time) (bool, error) {
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE games SET deleted_at = $1
+		  WHERE id IN (SELECT g.id FROM games g WHERE g.id = $2 AND `+inactiveSince(3)+`)`,
+		at.UTC().Format(createdAtLayout), id, inactiveBefore.UTC().Format(createdAtLayout),
+	)
+	if err != nil {
+		return false, fmt.Errorf("soft-delete game: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("soft-delete game: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (r *sqlRepo) SoftDeletedGames(ctx context.Context, before time.Time) ([]models.Game, error) {
+	return r.queryGames(ctx,
+		`SELECT `+gameColumns+` FROM games g WHERE g.deleted_at < $1 ORDER BY g.deleted_at`,
+		before.UTC().Format(createdAtLayout),
+	)
+}
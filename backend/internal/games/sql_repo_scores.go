@@ -0,0 +1,80 @@
+package games
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// ScoredGames filters like SearchGames and reads the kept scores along, in
+// one query.
+func (r *sqlRepo) ScoredGames(ctx context.Context, q models.GameQuery) ([]ScoredGame, error) {
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	where := gameFilter(q, arg)
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+gameColumns+`,
+		        s.days, s.deployed, s.profit, s.lead_time, s.throughput, s.fixed_date_hit_rate
+		   FROM games g
+		   LEFT JOIN game_scores s ON s.game_id = g.id
+		  WHERE `+strings.Join(where, "\n\t\t    AND ")+`
+		  ORDER BY g.created_at, g.id`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query scored games: %w", err)
+	}
+	defer rows.Close()
+
+	var games []ScoredGame
+	for rows.Next() {
+		var (
+			game                             ScoredGame
+			days, deployed, profit, leadTime sql.NullInt64
+			throughput, fixedDateHitRate     sql.NullFloat64
+		)
+		fields := append(gameFields(&game.Game), &days, &deployed, &profit, &leadTime, &throughput, &fixedDateHitRate)
+		if err := rows.Scan(fields...); err != nil {
+			return nil, fmt.Errorf("scan scored game: %w", err)
+		}
+		if days.Valid {
+			game.Score = &GameScore{
+				Days:             int(days.Int64),
+				Deployed:         int(deployed.Int64),
+				Profit:           int(profit.Int64),
+				LeadTime:         int(leadTime.Int64),
+				Throughput:       throughput.Float64,
+				FixedDateHitRate: fixedDateHitRate.Float64,
+			}
+		}
+		games = append(games, game)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate scored games: %w", err)
+	}
+	return games, nil
+}
+
+// SaveScore keeps the score of a game, replacing any kept before.
+func (r *sqlRepo) SaveScore(ctx context.Context, gameID uuid.UUID, s GameScore) error {
+	if _, err := r.db.ExecContext(ctx,
+		`INSERT INTO game_scores (game_id, days, deployed, profit, lead_time, throughput, fixed_date_hit_rate)
+		     VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 ON CONFLICT (game_id)
+		 DO UPDATE SET days = EXCLUDED.days, deployed = EXCLUDED.deployed, profit = EXCLUDED.profit,
+		               lead_time = EXCLUDED.lead_time, throughput = EXCLUDED.throughput,
+		               fixed_date_hit_rate = EXCLUDED.fixed_date_hit_rate`,
+		gameID, s.Days, s.Deployed, s.Profit, s.LeadTime, s.Throughput, s.FixedDateHitRate,
+	); err != nil {
+		return fmt.Errorf("insert score: %w", err)
+	}
+	return nil
+}
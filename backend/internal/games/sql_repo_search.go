@@ -0,0 +1,117 @@
+package games
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+)
+
+// gameSortKey is the ORDER BY of each sort of the game list, ascending. The
+// key of the cursor is compared against it as a row value.
+var gameSortKey = map[string][]string{
+	SortCreated: {"g.created_at", "g.id"},
+	SortDay:     {"g.day", "g.created_at", "g.id"},
+}
+
+// sortValues are the values of a key in the columns of a gameSortKey.
+func sortValues(sort string, k GameKey) []any {
+	values := []any{keyTime(k.CreatedAt), k.ID}
+	if sort == SortDay {
+		values = append([]any{k.Day}, values...)
+	}
+	return values
+}
+
+// createdAtLayout is how SQLite stores created_at, as text compared
+// character by character; Postgres reads it as a TIMESTAMP. Times compared
+// with stored ones are passed in it rather than as time.Time, which the
+// SQLite driver would write with a zone that breaks the comparison.
+const createdAtLayout = "2006-01-02 15:04:05.000"
+
+// keyTime formats a created_at read back from the database to compare it
+// with the stored one exactly: to the millisecond as SQLite stores it, or
+// to the microsecond if it has more, as only Postgres does.
+func keyTime(t time.Time) string {
+	if t.Nanosecond()%int(time.Millisecond) == 0 {
+		return t.UTC().Format(createdAtLayout)
+	}
+	return t.UTC().Format(createdAtLayout + "000")
+}
+
+// gameFilter returns the conditions a game, aliased g, must meet to pass the
+// filters of a query; soft-deleted games never do. arg adds a parameter and
+// returns its placeholder.
+func gameFilter(q models.GameQuery, arg func(v any) string) []string {
+	where := []string{`g.deleted_at IS NULL`}
+	if q.Status != "" {
+		where = append(where, `g.status = `+arg(string(q.Status)))
+	}
+	if q.Scenario != "" {
+		where = append(where, `g.scenario = `+arg(q.Scenario))
+	}
+	if q.SessionID != nil {
+		where = append(where, `EXISTS (SELECT 1 FROM session_games sg WHERE sg.game_id = g.id AND sg.session_id = `+arg(*q.SessionID)+`)`)
+	}
+	if !q.From.IsZero() {
+		where = append(where, `g.created_at >= `+arg(q.From.UTC().Format(createdAtLayout)))
+	}
+	if !q.To.IsZero() {
+		where = append(where, `g.created_at < `+arg(q.To.UTC().Format(createdAtLayout)))
+	}
+	return where
+}
+
+// SearchGames filters in SQL, counts every game that passes, and reads one
+// game past the page to know whether another page follows.
+func (r *sqlRepo) SearchGames(ctx context.Context, s GameSearch) ([]models.Game, int, bool, error) {
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	where := gameFilter(s.GameQuery, arg)
+	filter := "\n\t\t   WHERE " + strings.Join(where, "\n\t\t     AND ")
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM games g`+filter, args...).Scan(&total); err != nil {
+		return nil, 0, false, fmt.Errorf("count games: %w", err)
+	}
+
+	key := gameSortKey[s.Sort]
+	order := make([]string, len(key))
+	for i, k := range key {
+		order[i] = k
+		if !s.Ascending {
+			order[i] += " DESC"
+		}
+	}
+	if s.After != nil {
+		op := "<"
+		if s.Ascending {
+			op = ">"
+		}
+		var params []string
+		for _, v := range sortValues(s.Sort, *s.After) {
+			params = append(params, arg(v))
+		}
+		where = append(where, `(`+strings.Join(key, ", ")+`) `+op+` (`+strings.Join(params, ", ")+`)`)
+	}
+
+	query := `SELECT ` + gameColumns + ` FROM games g
+		   WHERE ` + strings.Join(where, "\n\t\t     AND ")
+	query += "\n\t\t   ORDER BY " + strings.Join(order, ", ") + "\n\t\t   LIMIT " + arg(s.Limit+1)
+
+	games, err := r.queryGames(ctx, query, args...)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	more := len(games) > s.Limit
+	if more {
+		games = games[:s.Limit]
+	}
+	return games, total, more, nil
+}
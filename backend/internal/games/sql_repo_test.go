@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
 	"github.com/Germanicus1/kanban-sim/backend/internal/response"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -24,10 +25,11 @@ func TestSQLRepo_GetGameByID_Success(t *testing.T) {
 	day := 1
 
 	// Expect the query and return one row
-	rows := sqlmock.NewRows([]string{"id", "created_at", "day"}).
-		AddRow(id, createdAt, day)
+	rows := sqlmock.NewRows([]string{"id", "created_at", "day", "parent_game_id", "forked_at_day", "seed", "facilitator_id", "status", "scenario",
+		"test_failure", "rework", "escaped_defect", "defect_effort"}).
+		AddRow(id, createdAt, day, nil, nil, 42, nil, "running", "default", 0.2, 0.5, 0.1, 0.5)
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, created_at, day FROM games WHERE id = $1"),
+		"SELECT id, created_at, day, parent_game_id, forked_at_day, seed, facilitator_id, status, scenario, test_failure, rework, escaped_defect, defect_effort FROM games WHERE id = $1"),
 	).
 		WithArgs(id).
 		WillReturnRows(rows)
@@ -37,6 +39,9 @@ func TestSQLRepo_GetGameByID_Success(t *testing.T) {
 	require.Equal(t, id, g.ID)
 	require.Equal(t, createdAt, g.CreatedAt)
 	require.Equal(t, day, g.Day)
+	require.Equal(t, int64(42), g.Seed)
+	require.Equal(t, "running", string(g.Status))
+	require.Equal(t, models.Quality{TestFailure: 0.2, Rework: 0.5, EscapedDefect: 0.1, DefectEffort: 0.5}, g.Quality)
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -49,7 +54,7 @@ func TestSQLRepo_GetGameByID_NotFound(t *testing.T) {
 	id := uuid.New()
 
 	mock.ExpectQuery(regexp.QuoteMeta(
-		"SELECT id, created_at, day FROM games WHERE id = $1"),
+		"SELECT id, created_at, day, parent_game_id, forked_at_day, seed, facilitator_id, status, scenario, test_failure, rework, escaped_defect, defect_effort FROM games WHERE id = $1"),
 	).
 		WithArgs(id).
 		WillReturnError(sql.ErrNoRows)
@@ -68,9 +73,9 @@ func TestSQLRepo_GetBoard_Empty(t *testing.T) {
 	id := uuid.New()
 
 	// 1) columns returns no rows
-	mock.ExpectQuery("SELECT id, parent_id, title, order_index FROM columns").
+	mock.ExpectQuery("SELECT id, parent_id, title, order_index, wip_limit, col_type FROM columns").
 		WithArgs(id).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "parent_id", "title", "order_index"}))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "parent_id", "title", "order_index", "wip_limit", "col_type"}))
 
 	// 2) effort_types returns no rows
 	mock.ExpectQuery("SELECT id, title, order_index FROM effort_types").
@@ -78,9 +83,9 @@ func TestSQLRepo_GetBoard_Empty(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "order_index"}))
 
 	// 3) cards returns no rows
-	mock.ExpectQuery("SELECT id, game_id, column_id, title, class_of_service, value_estimate, selected_day, deployed_day FROM cards").
+	mock.ExpectQuery("SELECT id, game_id, column_id, title, class_of_service, value_estimate, selected_day, deployed_day, order_index, blocked_reason, blocked_by, blocked_day, unblock_effort FROM cards").
 		WithArgs(id).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "game_id", "column_id", "title", "class_of_service", "value_estimate", "selected_day", "deployed_day"}))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "game_id", "column_id", "title", "class_of_service", "value_estimate", "selected_day", "deployed_day", "order_index", "blocked_reason", "blocked_by", "blocked_day", "unblock_effort"}))
 
 	board, err := repo.GetBoard(context.Background(), id)
 	require.NoError(t, err)
@@ -130,7 +135,7 @@ func TestSQLRepo_UpdateGame_Success(t *testing.T) {
 	id := uuid.New()
 
 	mock.ExpectExec(regexp.QuoteMeta(
-		"UPDATE games SET day = $1 WHERE id = $2"),
+		"UPDATE games SET day = $1 WHERE id = $2 AND deleted_at IS NULL"),
 	).WithArgs(1, id).WillReturnResult(sqlmock.NewResult(0, 1))
 
 	err := repo.UpdateGame(context.Background(), id, 1)
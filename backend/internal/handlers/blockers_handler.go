@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/auth"
+	"github.com/Germanicus1/kanban-sim/backend/internal/games"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/Germanicus1/kanban-sim/backend/internal/response"
+	"github.com/google/uuid"
+)
+
+// BlockCard marks a card of a running game as blocked.
+// @Summary      Block a card
+// @Description  Blocks a card on the game's current day, with the reason and the player who blocked it. A blocked card is not worked on and cannot move. With an effort, work put into the card goes into unblocking it until that much is done; without one, the card stays blocked until it is unblocked by hand. The days it spends blocked show in the card flow export and the CFD.
+// @Tags         games
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                   true  "Game ID"  Format(uuid)
+// @Param        cardId   path      string                   true  "Card ID"  Format(uuid)
+// @Param        blocker  body      models.BlockCardRequest  true  "Reason and unblocking effort"
+// @Success      201  {object}  response.BlockerResponse  "Card blocked"
+// @Failure      400  {object}  response.ErrorResponse    "Invalid IDs, body, reason or effort"
+// @Failure      403  {object}  response.ErrorResponse    "Missing or invalid token"
+// @Failure      404  {object}  response.ErrorResponse    "Game or card not found"
+// @Failure      405  {object}  response.ErrorResponse    "Method not allowed"
+// @Failure      409  {object}  response.ErrorResponse    "Game is not running, or the card is blocked or deployed already"
+// @Failure      500  {object}  response.ErrorResponse    "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/cards/{cardId}/block [post]
+func (h *GameHandler) BlockCard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	gameID, cardID, ok := gameCardIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.BlockCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidJSON)
+		return
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrMissingRequiredField)
+		return
+	}
+
+	caller, _ := auth.CallerFrom(r.Context())
+	blocker, err := h.Service.BlockCard(r.Context(), gameID, cardID, caller.PlayerID, strings.TrimSpace(req.Reason), req.Effort)
+	if err != nil {
+		switch {
+		case errors.Is(err, games.ErrInvalidEffort):
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidEffort)
+		case errors.Is(err, games.ErrNotFound):
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+		case errors.Is(err, games.ErrCardNotFound):
+			response.RespondWithError(w, http.StatusNotFound, response.ErrCardNotFound)
+		case errors.Is(err, games.ErrGameNotRunning):
+			response.RespondWithError(w, http.StatusConflict, response.ErrGameNotRunning)
+		case errors.Is(err, games.ErrCardBlocked):
+			response.RespondWithError(w, http.StatusConflict, response.ErrCardBlocked)
+		case errors.Is(err, games.ErrCardDeployed):
+			response.RespondWithError(w, http.StatusConflict, response.ErrCardDeployed)
+		default:
+			log.Printf("BlockCard: failed to block card %s of game %s: %v", cardID, gameID, err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	response.RespondWithData(w, blocker)
+}
+
+// UnblockCard lifts the blocker of a card of a running game.
+// @Summary      Unblock a card
+// @Description  Lifts the blocker of a card, whatever unblocking effort it still needed. The card can be worked on again the same day.
+// @Tags         games
+// @Produce      json
+// @Param        id      path  string  true  "Game ID"  Format(uuid)
+// @Param        cardId  path  string  true  "Card ID"  Format(uuid)
+// @Success      204  "Card unblocked"
+// @Failure      400  {object}  response.ErrorResponse  "Invalid IDs"
+// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token"
+// @Failure      404  {object}  response.ErrorResponse  "Game or card not found"
+// @Failure      405  {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      409  {object}  response.ErrorResponse  "Game is not running, or the card is not blocked"
+// @Failure      500  {object}  response.ErrorResponse  "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/cards/{cardId}/block [delete]
+func (h *GameHandler) UnblockCard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		w.Header().Set("Allow", http.MethodDelete)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	gameID, cardID, ok := gameCardIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.Service.UnblockCard(r.Context(), gameID, cardID); err != nil {
+		switch {
+		case errors.Is(err, games.ErrNotFound):
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+		case errors.Is(err, games.ErrCardNotFound):
+			response.RespondWithError(w, http.StatusNotFound, response.ErrCardNotFound)
+		case errors.Is(err, games.ErrGameNotRunning):
+			response.RespondWithError(w, http.StatusConflict, response.ErrGameNotRunning)
+		case errors.Is(err, games.ErrCardNotBlocked):
+			response.RespondWithError(w, http.StatusConflict, response.ErrCardNotBlocked)
+		default:
+			log.Printf("UnblockCard: failed to unblock card %s of game %s: %v", cardID, gameID, err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// gameCardIDs parses the {id} and {cardId} path values, answering the
+// request itself when either is not a UUID.
+func gameCardIDs(w http.ResponseWriter, r *http.Request) (gameID, cardID uuid.UUID, ok bool) {
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidGameID)
+		return uuid.Nil, uuid.Nil, false
+	}
+	cardID, err = uuid.Parse(r.PathValue("cardId"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidCardID)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return gameID, cardID, true
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/auth"
+	"github.com/Germanicus1/kanban-sim/backend/internal/games"
+	"github.com/Germanicus1/kanban-sim/backend/internal/response"
+	"github.com/google/uuid"
+)
+
+func TestGameHandler_BlockCard(t *testing.T) {
+	tests := []struct {
+		name       string
+		card       string
+		body       string
+		retErr     error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "blocked",
+			card:       uuid.NewString(),
+			body:       `{"reason":"Waiting for the database team","effort":5}`,
+			wantStatus: http.StatusCreated,
+			wantBody:   `"reason":"Waiting for the database team"`,
+		},
+		{
+			name:       "missing reason",
+			card:       uuid.NewString(),
+			body:       `{"reason":"  "}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   response.ErrMissingRequiredField,
+		},
+		{
+			name:       "bad card id",
+			card:       "not-a-uuid",
+			body:       `{"reason":"On hold"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   response.ErrInvalidCardID,
+		},
+		{
+			name:       "negative effort",
+			card:       uuid.NewString(),
+			body:       `{"reason":"On hold","effort":-1}`,
+			retErr:     games.ErrInvalidEffort,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   response.ErrInvalidEffort,
+		},
+		{
+			name:       "unknown card",
+			card:       uuid.NewString(),
+			body:       `{"reason":"On hold"}`,
+			retErr:     games.ErrCardNotFound,
+			wantStatus: http.StatusNotFound,
+			wantBody:   response.ErrCardNotFound,
+		},
+		{
+			name:       "already blocked",
+			card:       uuid.NewString(),
+			body:       `{"reason":"On hold"}`,
+			retErr:     games.ErrCardBlocked,
+			wantStatus: http.StatusConflict,
+			wantBody:   response.ErrCardBlocked,
+		},
+		{
+			name:       "game not running",
+			card:       uuid.NewString(),
+			body:       `{"reason":"On hold"}`,
+			retErr:     games.ErrGameNotRunning,
+			wantStatus: http.StatusConflict,
+			wantBody:   response.ErrGameNotRunning,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeService{retErr: tc.retErr}
+			h := NewGameHandler(svc, testSigner)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /games/{id}/cards/{cardId}/block", h.BlockCard)
+
+			gameID := uuid.New()
+			req := asPlayer(httptest.NewRequest("POST", "/games/"+gameID.String()+"/cards/"+tc.card+"/block", strings.NewReader(tc.body)), gameID, false)
+			rr := httptest.NewRecorder()
+
+			mux.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d", rr.Code, tc.wantStatus)
+			}
+			if !strings.Contains(rr.Body.String(), tc.wantBody) {
+				t.Errorf("body = %q; want it to contain %q", rr.Body.String(), tc.wantBody)
+			}
+			if tc.wantStatus == http.StatusCreated {
+				// the blocker is put down to the calling player
+				caller, _ := auth.CallerFrom(req.Context())
+				if svc.calledPlayer != caller.PlayerID {
+					t.Errorf("blocked by %s; want the caller %s", svc.calledPlayer, caller.PlayerID)
+				}
+			}
+		})
+	}
+}
+
+func TestGameHandler_UnblockCard(t *testing.T) {
+	tests := []struct {
+		name       string
+		retErr     error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "unblocked", wantStatus: http.StatusNoContent},
+		{name: "not blocked", retErr: games.ErrCardNotBlocked, wantStatus: http.StatusConflict, wantBody: response.ErrCardNotBlocked},
+		{name: "unknown game", retErr: games.ErrNotFound, wantStatus: http.StatusNotFound, wantBody: response.ErrGameNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewGameHandler(&fakeService{retErr: tc.retErr}, testSigner)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /games/{id}/cards/{cardId}/block", h.UnblockCard)
+
+			req := httptest.NewRequest("DELETE", "/games/"+uuid.NewString()+"/cards/"+uuid.NewString()+"/block", nil)
+			rr := httptest.NewRecorder()
+
+			mux.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d", rr.Code, tc.wantStatus)
+			}
+			if !strings.Contains(rr.Body.String(), tc.wantBody) {
+				t.Errorf("body = %q; want it to contain %q", rr.Body.String(), tc.wantBody)
+			}
+		})
+	}
+}
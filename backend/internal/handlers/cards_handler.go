@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/cards"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/Germanicus1/kanban-sim/backend/internal/response"
+	"github.com/google/uuid"
+)
+
+// CardsHandler groups the card endpoints.
+type CardsHandler struct {
+	Service cards.CardsServiceInterface
+}
+
+func NewCardsHandler(svc cards.CardsServiceInterface) *CardsHandler {
+	return &CardsHandler{Service: svc}
+}
+
+// ListCards searches the cards of a game.
+// @Summary      Search cards
+// @Description  Lists the cards of a game that pass the filters, a page at a time. Each card comes with its column ("Parent - Sub" for sub-columns), its blocker and its age: the days since it was selected, up to its deployment. Pass the nextCursor of a page as cursor, with the same filters and sort, to get the next one; the last page has none.
+// @Tags         cards
+// @Produce      json
+// @Param        id       path   string  true   "Game ID"  Format(uuid)
+// @Param        column   query  string  false  "Column ID; a top-level column includes its sub-columns"  Format(uuid)
+// @Param        cos      query  string  false  "Class of service"  example(S)
+// @Param        value    query  string  false  "Value estimate"  example(high)
+// @Param        blocked  query  bool    false  "Only blocked cards, or only cards that are not"
+// @Param        minAge   query  int     false  "Only selected cards at least this many days old"
+// @Param        sort     query  string  false  "Sort order"  Enums(position, age, title)  default(position)
+// @Param        cursor   query  string  false  "nextCursor of the page before"
+// @Param        limit    query  int     false  "Cards per page, up to 200"  default(50)
+// @Success      200  {object}  response.CardPageResponse  "Page of cards"
+// @Failure      400  {object}  response.ErrorResponse     "Invalid game or column ID, filter, sort, page size or cursor"
+// @Failure      403  {object}  response.ErrorResponse     "Missing or invalid token"
+// @Failure      404  {object}  response.ErrorResponse     "Game not found"
+// @Failure      405  {object}  response.ErrorResponse     "Method not allowed"
+// @Failure      500  {object}  response.ErrorResponse     "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/cards [get]
+func (h *CardsHandler) ListCards(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidGameID)
+		return
+	}
+
+	q := r.URL.Query()
+	query := models.CardQuery{
+		ClassOfService: q.Get("cos"),
+		ValueEstimate:  q.Get("value"),
+		Sort:           q.Get("sort"),
+		Cursor:         q.Get("cursor"),
+	}
+	if v := q.Get("column"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidColumnID)
+			return
+		}
+		query.ColumnID = &id
+	}
+	if v := q.Get("blocked"); v != "" {
+		blocked, err := strconv.ParseBool(v)
+		if err != nil {
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidQuery)
+			return
+		}
+		query.Blocked = &blocked
+	}
+	for name, dst := range map[string]*int{"minAge": &query.MinAge, "limit": &query.Limit} {
+		if v := q.Get(name); v != "" {
+			if *dst, err = strconv.Atoi(v); err != nil {
+				response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidQuery)
+				return
+			}
+		}
+	}
+
+	page, err := h.Service.SearchCards(r.Context(), gameID, query)
+	if err != nil {
+		switch {
+		case errors.Is(err, cards.ErrInvalidQuery):
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidQuery)
+		case errors.Is(err, cards.ErrInvalidCursor):
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidCursor)
+		case errors.Is(err, cards.ErrNotFound):
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+		default:
+			log.Printf("ListCards: failed to search the cards of game %s: %v", gameID, err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		}
+		return
+	}
+
+	response.RespondWithData(w, page)
+}
+
+// csvWriter sets the CSV response headers on the first write, so errors
+// that happen before any row is produced can still be answered with JSON.
+type csvWriter struct {
+	w        http.ResponseWriter
+	filename string
+	started  bool
+}
+
+func (cw *csvWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if !cw.started {
+		cw.started = true
+		cw.w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		cw.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, cw.filename))
+		cw.w.WriteHeader(http.StatusOK)
+	}
+	return cw.w.Write(p)
+}
+
+// GetCardsCSV streams card-level flow data of a game as CSV.
+// @Summary      Export card flow data as CSV
+// @Description  Streams one row per card: title, class of service, value, selected day, deployed day, lead time, estimate and actual per effort type, and the days spent in each column derived from move events.
+// @Tags         cards
+// @Produce      text/csv
+// @Param        id   path      string  true  "Game ID"  Format(uuid)
+// @Success      200  {string}  string                  "CSV document"
+// @Failure      400  {object}  response.ErrorResponse  "Invalid or missing game ID"
+// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token"
+// @Failure      404  {object}  response.ErrorResponse  "Game not found"
+// @Failure      405  {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      500  {object}  response.ErrorResponse  "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/cards.csv [get]
+func (h *CardsHandler) GetCardsCSV(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidGameID)
+		return
+	}
+
+	cw := &csvWriter{w: w, filename: "cards-" + gameID.String() + ".csv"}
+	if err := h.Service.WriteFlowCSV(r.Context(), gameID, cw); err != nil {
+		if cw.started {
+			// the status line is gone; all we can do is stop the stream
+			log.Printf("GetCardsCSV: streaming cards of game %s failed: %v", gameID, err)
+			return
+		}
+		if errors.Is(err, cards.ErrNotFound) {
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+		} else {
+			log.Printf("GetCardsCSV: failed to export cards of game %s: %v", gameID, err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		}
+	}
+}
+
+// GetCFD returns the cumulative flow diagram of a game.
+// @Summary      Get the cumulative flow diagram
+// @Description  Counts the cards in every leaf column at the end of each day so far, worked out from move events, and annotates the stretches of days cards were blocked, with their reasons.
+// @Tags         cards
+// @Produce      json
+// @Param        id   path      string  true  "Game ID"  Format(uuid)
+// @Success      200  {object}  response.CFDResponse    "Cumulative flow diagram"
+// @Failure      400  {object}  response.ErrorResponse  "Invalid or missing game ID"
+// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token"
+// @Failure      404  {object}  response.ErrorResponse  "Game not found"
+// @Failure      405  {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      500  {object}  response.ErrorResponse  "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/cfd [get]
+func (h *CardsHandler) GetCFD(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidGameID)
+		return
+	}
+
+	cfd, err := h.Service.GetCFD(r.Context(), gameID)
+	if err != nil {
+		if errors.Is(err, cards.ErrNotFound) {
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+		} else {
+			log.Printf("GetCFD: failed to build the CFD of game %s: %v", gameID, err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		}
+		return
+	}
+
+	response.RespondWithData(w, cfd)
+}
+
+// GetAging returns the aging work-in-progress report of a game.
+// @Summary      Get the aging work in progress
+// @Description  Lists every card selected but not deployed, oldest first, with its age in days since it was selected, its column, the days since its last move and whether it is blocked. Each card is compared with the lead times of the cards of its class of service deployed so far, or of all cards if none of its class is: on_track up to the median, at_risk up to the 85th percentile and breached beyond it; unknown while nothing is deployed.
+// @Tags         cards
+// @Produce      json
+// @Param        id   path      string  true  "Game ID"  Format(uuid)
+// @Success      200  {object}  response.AgingResponse  "Aging report"
+// @Failure      400  {object}  response.ErrorResponse  "Invalid or missing game ID"
+// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token"
+// @Failure      404  {object}  response.ErrorResponse  "Game not found"
+// @Failure      405  {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      500  {object}  response.ErrorResponse  "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/metrics/aging [get]
+func (h *CardsHandler) GetAging(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidGameID)
+		return
+	}
+
+	aging, err := h.Service.GetAging(r.Context(), gameID)
+	if err != nil {
+		if errors.Is(err, cards.ErrNotFound) {
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+		} else {
+			log.Printf("GetAging: failed to build the aging report of game %s: %v", gameID, err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		}
+		return
+	}
+
+	response.RespondWithData(w, aging)
+}
+
+// GetFlowEfficiency returns the flow efficiency of a game.
+// @Summary      Get the flow efficiency
+// @Description  Works out, for every card that has started, the days it spent in active columns and in queue columns from its move events, up to its deployment or the current day, and its flow efficiency: active days over active and queue days. The days are added up by class of service, and for all cards as the total. Cards come least efficient first.
+// @Tags         cards
+// @Produce      json
+// @Param        id   path      string  true  "Game ID"  Format(uuid)
+// @Success      200  {object}  response.FlowEfficiencyResponse  "Flow efficiency"
+// @Failure      400  {object}  response.ErrorResponse           "Invalid or missing game ID"
+// @Failure      403  {object}  response.ErrorResponse           "Missing or invalid token"
+// @Failure      404  {object}  response.ErrorResponse           "Game not found"
+// @Failure      405  {object}  response.ErrorResponse           "Method not allowed"
+// @Failure      500  {object}  response.ErrorResponse           "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/metrics/flow-efficiency [get]
+func (h *CardsHandler) GetFlowEfficiency(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidGameID)
+		return
+	}
+
+	fe, err := h.Service.GetFlowEfficiency(r.Context(), gameID)
+	if err != nil {
+		if errors.Is(err, cards.ErrNotFound) {
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+		} else {
+			log.Printf("GetFlowEfficiency: failed to work out the flow efficiency of game %s: %v", gameID, err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		}
+		return
+	}
+
+	response.RespondWithData(w, fe)
+}
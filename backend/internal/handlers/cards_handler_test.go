@@ -0,0 +1,334 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/cards"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/Germanicus1/kanban-sim/backend/internal/response"
+	"github.com/google/uuid"
+)
+
+// fakeCardsService implements cards.CardsServiceInterface for testing.
+type fakeCardsService struct {
+	calledID    uuid.UUID
+	calledQuery models.CardQuery
+	csv         string
+	cfd         models.CFD
+	aging       models.Aging
+	efficiency  models.FlowEfficiency
+	retErr      error
+}
+
+func (f *fakeCardsService) GetCardsByGameID(ctx context.Context, gameID uuid.UUID) ([]models.Card, error) {
+	panic("unused")
+}
+
+func (f *fakeCardsService) WriteFlowCSV(ctx context.Context, gameID uuid.UUID, w io.Writer) error {
+	f.calledID = gameID
+	if _, err := io.WriteString(w, f.csv); err != nil {
+		return err
+	}
+	return f.retErr
+}
+
+func (f *fakeCardsService) SearchCards(ctx context.Context, gameID uuid.UUID, q models.CardQuery) (models.CardPage, error) {
+	f.calledID = gameID
+	f.calledQuery = q
+	if f.retErr != nil {
+		return models.CardPage{}, f.retErr
+	}
+	return models.CardPage{Cards: []models.Card{{ID: uuid.New(), Title: "S1", Age: 3}}, NextCursor: "next"}, nil
+}
+
+func (f *fakeCardsService) GetAging(ctx context.Context, gameID uuid.UUID) (models.Aging, error) {
+	f.calledID = gameID
+	return f.aging, f.retErr
+}
+
+func (f *fakeCardsService) GetFlowEfficiency(ctx context.Context, gameID uuid.UUID) (models.FlowEfficiency, error) {
+	f.calledID = gameID
+	return f.efficiency, f.retErr
+}
+
+func (f *fakeCardsService) GetCFD(ctx context.Context, gameID uuid.UUID) (models.CFD, error) {
+	f.calledID = gameID
+	return f.cfd, f.retErr
+}
+
+func TestCardsHandler_ListCards(t *testing.T) {
+	column := uuid.New()
+	tests := []struct {
+		name       string
+		query      string
+		retErr     error
+		wantStatus int
+		wantBody   string
+		wantQuery  models.CardQuery
+	}{
+		{
+			name:       "first page",
+			wantStatus: http.StatusOK,
+			wantBody:   `"nextCursor":"next"`,
+		},
+		{
+			name:       "filtered",
+			query:      "?column=" + column.String() + "&cos=E&value=high&blocked=true&minAge=3&sort=age&cursor=abc&limit=20",
+			wantStatus: http.StatusOK,
+			wantBody:   `"age":3`,
+			wantQuery: models.CardQuery{
+				ColumnID: &column, ClassOfService: "E", ValueEstimate: "high", MinAge: 3,
+				Sort: "age", Cursor: "abc", Limit: 20,
+			},
+		},
+		{name: "bad column", query: "?column=backlog", wantStatus: http.StatusBadRequest, wantBody: response.ErrInvalidColumnID},
+		{name: "bad blocked", query: "?blocked=maybe", wantStatus: http.StatusBadRequest, wantBody: response.ErrInvalidQuery},
+		{name: "bad limit", query: "?limit=ten", wantStatus: http.StatusBadRequest, wantBody: response.ErrInvalidQuery},
+		{name: "invalid query", query: "?sort=value", retErr: cards.ErrInvalidQuery, wantStatus: http.StatusBadRequest, wantBody: response.ErrInvalidQuery},
+		{name: "invalid cursor", query: "?cursor=abc", retErr: cards.ErrInvalidCursor, wantStatus: http.StatusBadRequest, wantBody: response.ErrInvalidCursor},
+		{name: "unknown game", retErr: cards.ErrNotFound, wantStatus: http.StatusNotFound, wantBody: response.ErrGameNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeCardsService{retErr: tc.retErr}
+			h := NewCardsHandler(svc)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /games/{id}/cards", h.ListCards)
+
+			req := httptest.NewRequest("GET", "/games/"+uuid.NewString()+"/cards"+tc.query, nil)
+			rr := httptest.NewRecorder()
+
+			mux.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d", rr.Code, tc.wantStatus)
+			}
+			if !strings.Contains(rr.Body.String(), tc.wantBody) {
+				t.Errorf("body = %q; want it to contain %q", rr.Body.String(), tc.wantBody)
+			}
+			if tc.wantQuery.Sort != "" {
+				got := svc.calledQuery
+				if got.Blocked == nil || !*got.Blocked {
+					t.Errorf("blocked = %v; want true", got.Blocked)
+				}
+				got.Blocked = nil
+				if !reflect.DeepEqual(got, tc.wantQuery) {
+					t.Errorf("query = %+v; want %+v", got, tc.wantQuery)
+				}
+			}
+		})
+	}
+}
+
+func TestCardsHandler_GetCardsCSV(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		svc        *fakeCardsService
+		wantStatus int
+		wantBody   string
+		wantCSV    bool
+	}{
+		{
+			name:       "streams csv",
+			path:       "/games/" + uuid.NewString() + "/cards.csv",
+			svc:        &fakeCardsService{csv: "title,class_of_service\nS1,standard\n"},
+			wantStatus: http.StatusOK,
+			wantBody:   "title,class_of_service\nS1,standard\n",
+			wantCSV:    true,
+		},
+		{
+			name:       "unknown game",
+			path:       "/games/" + uuid.NewString() + "/cards.csv",
+			svc:        &fakeCardsService{retErr: cards.ErrNotFound},
+			wantStatus: http.StatusNotFound,
+			wantBody:   response.ErrGameNotFound,
+		},
+		{
+			name:       "bad id",
+			path:       "/games/not-a-uuid/cards.csv",
+			svc:        &fakeCardsService{},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `"success":false`,
+		},
+		{
+			name:       "failure after streaming started",
+			path:       "/games/" + uuid.NewString() + "/cards.csv",
+			svc:        &fakeCardsService{csv: "title\n", retErr: io.ErrUnexpectedEOF},
+			wantStatus: http.StatusOK,
+			wantBody:   "title\n",
+			wantCSV:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewCardsHandler(tc.svc)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /games/{id}/cards.csv", h.GetCardsCSV)
+
+			req := httptest.NewRequest("GET", tc.path, nil)
+			rr := httptest.NewRecorder()
+
+			mux.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d", rr.Code, tc.wantStatus)
+			}
+			if !strings.Contains(rr.Body.String(), tc.wantBody) {
+				t.Errorf("body = %q; want it to contain %q", rr.Body.String(), tc.wantBody)
+			}
+			isCSV := strings.HasPrefix(rr.Header().Get("Content-Type"), "text/csv")
+			if isCSV != tc.wantCSV {
+				t.Errorf("Content-Type = %q; want csv %v", rr.Header().Get("Content-Type"), tc.wantCSV)
+			}
+			if tc.wantCSV && !strings.Contains(rr.Header().Get("Content-Disposition"), "attachment") {
+				t.Errorf("Content-Disposition = %q; want attachment", rr.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
+
+func TestCardsHandler_GetCFD(t *testing.T) {
+	cfd := models.CFD{
+		Columns: []string{"Options", "Deployed"},
+		Days:    []models.CFDDay{{Day: 1, Cards: []int{2, 0}}},
+		Annotations: []models.CFDAnnotation{
+			{Title: "S1", Reason: "On hold", Day: 1, Days: 0},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		svc        *fakeCardsService
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "cfd",
+			path:       "/games/" + uuid.NewString() + "/cfd",
+			svc:        &fakeCardsService{cfd: cfd},
+			wantStatus: http.StatusOK,
+			wantBody:   `"days":[{"day":1,"cards":[2,0]}]`,
+		},
+		{
+			name:       "unknown game",
+			path:       "/games/" + uuid.NewString() + "/cfd",
+			svc:        &fakeCardsService{retErr: cards.ErrNotFound},
+			wantStatus: http.StatusNotFound,
+			wantBody:   response.ErrGameNotFound,
+		},
+		{
+			name:       "bad id",
+			path:       "/games/not-a-uuid/cfd",
+			svc:        &fakeCardsService{},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   response.ErrInvalidGameID,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewCardsHandler(tc.svc)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /games/{id}/cfd", h.GetCFD)
+
+			req := httptest.NewRequest("GET", tc.path, nil)
+			rr := httptest.NewRecorder()
+
+			mux.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d", rr.Code, tc.wantStatus)
+			}
+			if !strings.Contains(rr.Body.String(), tc.wantBody) {
+				t.Errorf("body = %q; want it to contain %q", rr.Body.String(), tc.wantBody)
+			}
+		})
+	}
+}
+
+func TestCardsHandler_GetAging(t *testing.T) {
+	aging := models.Aging{Day: 6, Cards: []models.AgingCard{{Title: "S1", Column: "Test", Age: 4, Status: models.AgingAtRisk}}}
+
+	tests := []struct {
+		name       string
+		path       string
+		svc        *fakeCardsService
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "report", path: "/games/" + uuid.NewString() + "/metrics/aging", svc: &fakeCardsService{aging: aging}, wantStatus: http.StatusOK, wantBody: `"status":"at_risk"`},
+		{name: "unknown game", path: "/games/" + uuid.NewString() + "/metrics/aging", svc: &fakeCardsService{retErr: cards.ErrNotFound}, wantStatus: http.StatusNotFound, wantBody: response.ErrGameNotFound},
+		{name: "bad id", path: "/games/not-a-uuid/metrics/aging", svc: &fakeCardsService{}, wantStatus: http.StatusBadRequest, wantBody: response.ErrInvalidGameID},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewCardsHandler(tc.svc)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /games/{id}/metrics/aging", h.GetAging)
+
+			req := httptest.NewRequest("GET", tc.path, nil)
+			rr := httptest.NewRecorder()
+
+			mux.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d", rr.Code, tc.wantStatus)
+			}
+			if !strings.Contains(rr.Body.String(), tc.wantBody) {
+				t.Errorf("body = %q; want it to contain %q", rr.Body.String(), tc.wantBody)
+			}
+		})
+	}
+}
+
+func TestCardsHandler_GetFlowEfficiency(t *testing.T) {
+	fe := models.FlowEfficiency{Day: 6, Total: models.ClassFlowEfficiency{Cards: 1, ActiveDays: 1, QueueDays: 3, Efficiency: 0.25}}
+
+	tests := []struct {
+		name       string
+		path       string
+		svc        *fakeCardsService
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "report", path: "/games/" + uuid.NewString() + "/metrics/flow-efficiency", svc: &fakeCardsService{efficiency: fe}, wantStatus: http.StatusOK, wantBody: `"total":{"cards":1,"activeDays":1,"queueDays":3,"efficiency":0.25}`},
+		{name: "unknown game", path: "/games/" + uuid.NewString() + "/metrics/flow-efficiency", svc: &fakeCardsService{retErr: cards.ErrNotFound}, wantStatus: http.StatusNotFound, wantBody: response.ErrGameNotFound},
+		{name: "bad id", path: "/games/not-a-uuid/metrics/flow-efficiency", svc: &fakeCardsService{}, wantStatus: http.StatusBadRequest, wantBody: response.ErrInvalidGameID},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewCardsHandler(tc.svc)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /games/{id}/metrics/flow-efficiency", h.GetFlowEfficiency)
+
+			req := httptest.NewRequest("GET", tc.path, nil)
+			rr := httptest.NewRecorder()
+
+			mux.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d", rr.Code, tc.wantStatus)
+			}
+			if !strings.Contains(rr.Body.String(), tc.wantBody) {
+				t.Errorf("body = %q; want it to contain %q", rr.Body.String(), tc.wantBody)
+			}
+		})
+	}
+}
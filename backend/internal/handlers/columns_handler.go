@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"encoding/json"
 	"errors"
+	"log"
 	"net/http"
 
 	"github.com/Germanicus1/kanban-sim/backend/internal/columns"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
 	"github.com/Germanicus1/kanban-sim/backend/internal/response"
 	"github.com/google/uuid"
 )
@@ -59,3 +62,70 @@ func (h *ColumnsHandler) GetColumnsByGameID(w http.ResponseWriter, r *http.Reque
 	}
 	response.RespondWithData(w, columns)
 }
+
+// UpdateColumn changes a column of a game while it is played.
+// @Summary      Edit a column
+// @Description  Changes the title or WIP limit of a column, or the sub-columns of a top-level column, while the game is played. Sub-columns are listed in the order wanted: existing ones by ID, new ones by title and type; the ones left out are removed. A column holding cards cannot be removed or split into sub-columns, "done" columns get no sub-columns, and a column keeps its active stage as it is. Only the facilitator may edit columns, and not once the game has ended. Every change is logged as a "wip_changed" or "column_changed" event on the current day.
+// @Tags         columns
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string               true  "Game ID"    Format(uuid)
+// @Param        columnId  path      string               true  "Column ID"  Format(uuid)
+// @Param        update    body      models.ColumnUpdate  true  "Changes to the column"
+// @Success      200  {object}  response.ColumnResponse  "Column as updated, with its sub-columns"
+// @Failure      400  {object}  response.ErrorResponse   "Invalid IDs, body or change"
+// @Failure      403  {object}  response.ErrorResponse   "Missing or invalid token, or not the facilitator"
+// @Failure      404  {object}  response.ErrorResponse   "Game or column not found"
+// @Failure      405  {object}  response.ErrorResponse   "Method not allowed"
+// @Failure      409  {object}  response.ErrorResponse   "Game has ended, or the column holds cards"
+// @Failure      500  {object}  response.ErrorResponse   "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/columns/{columnId} [patch]
+func (h *ColumnsHandler) UpdateColumn(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		w.Header().Set("Allow", http.MethodPatch)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidGameID)
+		return
+	}
+	columnID, err := uuid.Parse(r.PathValue("columnId"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidColumnID)
+		return
+	}
+	if !requireFacilitator(w, r, gameID) {
+		return
+	}
+
+	var u models.ColumnUpdate
+	if err := json.NewDecoder(r.Body).Decode(&u); err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidJSON)
+		return
+	}
+
+	col, err := h.Service.UpdateColumn(r.Context(), gameID, columnID, u)
+	if err != nil {
+		switch {
+		case errors.Is(err, columns.ErrInvalidColumn):
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidColumn)
+		case errors.Is(err, columns.ErrNotFound):
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+		case errors.Is(err, columns.ErrColumnNotFound):
+			response.RespondWithError(w, http.StatusNotFound, response.ErrColumnNotFound)
+		case errors.Is(err, columns.ErrGameEnded):
+			response.RespondWithError(w, http.StatusConflict, response.ErrGameEnded)
+		case errors.Is(err, columns.ErrColumnHasCards):
+			response.RespondWithError(w, http.StatusConflict, response.ErrColumnHasCards)
+		default:
+			log.Printf("UpdateColumn: failed to update column %s of game %s: %v", columnID, gameID, err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		}
+		return
+	}
+	response.RespondWithData(w, col)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/columns"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/Germanicus1/kanban-sim/backend/internal/response"
+	"github.com/google/uuid"
+)
+
+// fakeColumnService implements columns.ColumnServiceInterface for testing
+// UpdateColumn.
+type fakeColumnService struct {
+	calledUpdate models.ColumnUpdate
+	retErr       error
+}
+
+func (f *fakeColumnService) GetColumnsByGameID(ctx context.Context, gameID uuid.UUID) ([]models.Column, error) {
+	return nil, f.retErr
+}
+
+func (f *fakeColumnService) UpdateColumn(ctx context.Context, gameID, columnID uuid.UUID, u models.ColumnUpdate) (models.Column, error) {
+	f.calledUpdate = u
+	if f.retErr != nil {
+		return models.Column{}, f.retErr
+	}
+	col := models.Column{ID: columnID, Title: "Development", Type: "active"}
+	if u.WIPLimit != nil {
+		col.WIPLimit = *u.WIPLimit
+	}
+	return col, nil
+}
+
+func TestColumnsHandler_UpdateColumn(t *testing.T) {
+	tests := []struct {
+		name        string
+		column      string
+		body        string
+		facilitator bool
+		retErr      error
+		wantStatus  int
+		wantBody    string
+	}{
+		{name: "updated", column: uuid.NewString(), body: `{"wipLimit":3}`, facilitator: true, wantStatus: http.StatusOK, wantBody: `"wipLimit":3`},
+		{name: "not the facilitator", column: uuid.NewString(), body: `{"wipLimit":3}`, wantStatus: http.StatusForbidden, wantBody: response.ErrNotFacilitator},
+		{name: "bad column id", column: "not-a-uuid", body: `{"wipLimit":3}`, facilitator: true, wantStatus: http.StatusBadRequest, wantBody: response.ErrInvalidColumnID},
+		{name: "bad body", column: uuid.NewString(), body: `{`, facilitator: true, wantStatus: http.StatusBadRequest, wantBody: response.ErrInvalidJSON},
+		{name: "invalid change", column: uuid.NewString(), body: `{"wipLimit":-1}`, facilitator: true, retErr: columns.ErrInvalidColumn, wantStatus: http.StatusBadRequest, wantBody: response.ErrInvalidColumn},
+		{name: "unknown column", column: uuid.NewString(), body: `{"title":"Build"}`, facilitator: true, retErr: columns.ErrColumnNotFound, wantStatus: http.StatusNotFound, wantBody: response.ErrColumnNotFound},
+		{name: "column holds cards", column: uuid.NewString(), body: `{"subColumns":[]}`, facilitator: true, retErr: columns.ErrColumnHasCards, wantStatus: http.StatusConflict, wantBody: response.ErrColumnHasCards},
+		{name: "game ended", column: uuid.NewString(), body: `{"wipLimit":3}`, facilitator: true, retErr: columns.ErrGameEnded, wantStatus: http.StatusConflict, wantBody: response.ErrGameEnded},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeColumnService{retErr: tc.retErr}
+			h := NewColumnHandler(svc)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("PATCH /games/{id}/columns/{columnId}", h.UpdateColumn)
+
+			gameID := uuid.New()
+			req := asPlayer(httptest.NewRequest("PATCH", "/games/"+gameID.String()+"/columns/"+tc.column, strings.NewReader(tc.body)), gameID, tc.facilitator)
+			rr := httptest.NewRecorder()
+
+			mux.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d", rr.Code, tc.wantStatus)
+			}
+			if !strings.Contains(rr.Body.String(), tc.wantBody) {
+				t.Errorf("body = %q; want it to contain %q", rr.Body.String(), tc.wantBody)
+			}
+		})
+	}
+}
@@ -1,15 +1,23 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/Germanicus1/kanban-sim/backend/internal/auth"
 	"github.com/Germanicus1/kanban-sim/backend/internal/config"
 	"github.com/Germanicus1/kanban-sim/backend/internal/database"
+	"github.com/Germanicus1/kanban-sim/backend/internal/dice"
+	"github.com/Germanicus1/kanban-sim/backend/internal/engine"
 	"github.com/Germanicus1/kanban-sim/backend/internal/games"
 	"github.com/Germanicus1/kanban-sim/backend/internal/models"
 	"github.com/Germanicus1/kanban-sim/backend/internal/response"
@@ -19,23 +27,53 @@ import (
 // GameHandler groups your game endpoints.
 type GameHandler struct {
 	Service games.ServiceInterface
+	Tokens  *auth.Signer
 }
 
 type updateGameRequest struct {
 	Day int `json:"day"`
 }
 
-// NewGameHandler constructs a GameHandler.
-func NewGameHandler(svc games.ServiceInterface) *GameHandler {
-	return &GameHandler{Service: svc}
+type createGameRequest struct {
+	Seed        int64  `json:"seed"`
+	Facilitator string `json:"facilitator" example:"Grace"` // name of a player to create as the facilitator
+}
+
+type setFacilitatorRequest struct {
+	PlayerID uuid.UUID `json:"playerId"`
+}
+
+// facilitatorGame is a game as its facilitator sees it, dice seed included.
+type facilitatorGame struct {
+	models.Game
+	Seed int64 `json:"seed"`
+}
+
+type rollDiceRequest struct {
+	PlayerID uuid.UUID `json:"playerId"`
+	CardID   uuid.UUID `json:"cardId"`
+}
+
+type playBotDayRequest struct {
+	Strategy string `json:"strategy" example:"strict-wip"`
+	Team     string `json:"team,omitempty" example:"Analysis=2,Development=3,Testing=2"` // defaults to the standard team
+}
+
+// NewGameHandler constructs a GameHandler. Tokens signs the token of a
+// facilitator created with a game.
+func NewGameHandler(svc games.ServiceInterface, tokens *auth.Signer) *GameHandler {
+	return &GameHandler{Service: svc, Tokens: tokens}
 }
 
 // CreateGame creates a new game with the default board.
 // @Summary      Create a new game
-// @Description  Creates a new game using the embedded default board; no request body required
+// @Description  Creates a new game using the embedded default board. The body is optional; it may set the dice seed to replay a known game: with the same seed, the same players joining in the same order roll the same dice. Without one a random seed is picked. The seed is only returned here, to the game's creator, and to its facilitator. Naming a facilitator creates them as the game's first player; their token comes with the response.
 // @Tags         games
+// @Accept       json
 // @Produce      json
+// @Param        game  body      createGameRequest  false  "Optional dice seed and facilitator"
 // @Success      201  {object}  response.CreateGameResponse "New game created"
+// @Failure      400  {object}  response.ErrorResponse  "Invalid JSON body"
 // @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token"
 // @Failure      500  {object}  response.ErrorResponse  "Internal server error"
 // @Security    BearerAuth
@@ -48,22 +86,74 @@ func (h *GameHandler) CreateGame(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 2) Load the board config from embedded JSON
-	cfg, err := config.LoadBoardConfig()
+	// 2) Optional body; an empty one keeps all defaults
+	var req createGameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidJSON)
+		return
+	}
+	if req.Seed == 0 {
+		req.Seed = dice.NewSeed()
+	}
+
+	// 3) Load the board config from embedded JSON
+	gameCfg, err := defaultGameConfig()
 	if err != nil {
 		response.RespondWithError(w, http.StatusInternalServerError,
 			"failed to load board config: "+err.Error())
 		return
 	}
+	gameCfg.Seed = req.Seed
+	gameCfg.Facilitator = req.Facilitator
 
-	// 3) Pre‐allocate the Cards slice just once
+	// 4) Call the service (which in turn calls your SQL repo)
+	gameID, err := h.Service.CreateGame(r.Context(), gameCfg)
+	if err != nil {
+		response.RespondWithError(w, http.StatusInternalServerError,
+			"could not create game: "+err.Error())
+		return
+	}
+
+	data := response.CreateGameData{ID: gameID.String(), Seed: req.Seed}
+
+	// 5) Hand the facilitator their token
+	if req.Facilitator != "" {
+		game, err := h.Service.GetGame(r.Context(), gameID)
+		if err != nil || game.FacilitatorID == nil {
+			log.Printf("CreateGame: failed to load facilitator of game %s: %v", gameID, err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+			return
+		}
+		data.Facilitator = &models.PlayerToken{
+			PlayerID: *game.FacilitatorID,
+			GameID:   gameID,
+			Token:    h.Tokens.Issue(*game.FacilitatorID, gameID),
+		}
+	}
+
+	// 6) Return 201 Created + { "id": "<uuid>", "seed": <seed> }
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	response.RespondWithData(w, data)
+}
+
+// defaultGameConfig returns the embedded default board as the config of a
+// new game.
+func defaultGameConfig() (models.BoardConfig, error) {
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		return models.BoardConfig{}, err
+	}
+
+	// Pre‐allocate the Cards slice just once
 	gameCfg := models.BoardConfig{
 		EffortTypes: cfg.EffortTypes,
 		Columns:     cfg.Columns,
 		Cards:       make([]models.Card, len(cfg.Cards)),
+		Quality:     cfg.Quality,
 	}
 
-	// 4) Populate each Card exactly once (no inner re‐make)
+	// Populate each Card exactly once (no inner re‐make)
 	for i, cc := range cfg.Cards {
 		// Build that card’s list of Efforts:
 		efforts := make([]models.Effort, len(cc.Efforts))
@@ -83,24 +173,12 @@ func (h *GameHandler) CreateGame(w http.ResponseWriter, r *http.Request) {
 			Efforts:        efforts,
 		}
 	}
-
-	// 5) Call the service (which in turn calls your SQL repo)
-	gameID, err := h.Service.CreateGame(r.Context(), gameCfg)
-	if err != nil {
-		response.RespondWithError(w, http.StatusInternalServerError,
-			"could not create game: "+err.Error())
-		return
-	}
-
-	// 6) Return 201 Created + { "id": "<uuid>" }
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	response.RespondWithData(w, map[string]string{"id": gameID.String()})
+	return gameCfg, nil
 }
 
 // GetGame retrieves a game by its UUID.
 // @Summary      Get game by ID
-// @Description  Returns the full game record for the given UUID.
+// @Description  Returns the full game record for the given UUID. The dice seed is only included for the game's facilitator.
 // @Tags         games
 // @Produce      json
 // @Param        id   path      string  true  "Game ID" Format(uuid)
@@ -137,6 +215,10 @@ func (h *GameHandler) GetGame(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if canFacilitate(r, gameID) {
+		response.RespondWithData(w, facilitatorGame{Game: game, Seed: game.Seed})
+		return
+	}
 	response.RespondWithData(w, game)
 }
 
@@ -163,7 +245,18 @@ func (h *GameHandler) GetBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 3) Step 1: Fetch every card for this game, along with exactly the parent column title.
+	// 3) The game must exist and not be soft-deleted.
+	if _, err := h.Service.GetGame(r.Context(), gameID); err != nil {
+		if errors.Is(err, games.ErrNotFound) {
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+			return
+		}
+		log.Printf("GetBoard: failed to load game %s: %v", gameID, err)
+		response.RespondWithError(w, http.StatusInternalServerError, "failed to load board")
+		return
+	}
+
+	// 4) Step 1: Fetch every card for this game, along with exactly the parent column title.
 	//    If a card’s column has parent_id NULL, then parent_title = col.title.
 	//    If a card’s column has parent_id non‐NULL (i.e. it’s a subcolumn), we grab parent.title.
 	//    We also select all of the fields needed to populate a models.Card (minus efforts).
@@ -176,6 +269,10 @@ func (h *GameHandler) GetBoard(w http.ResponseWriter, r *http.Request) {
 			c.selected_day,
 			c.deployed_day,
 			c.order_index,
+			c.blocked_reason,
+			c.blocked_by,
+			c.blocked_day,
+			c.unblock_effort,
 			COALESCE(parent.title, col.title) AS parent_title
 		FROM cards c
 		JOIN columns col        ON col.id = c.column_id
@@ -190,7 +287,7 @@ func (h *GameHandler) GetBoard(w http.ResponseWriter, r *http.Request) {
 	}
 	defer rows.Close()
 
-	// 4) Build a map[parentColumnTitle] → []models.Card
+	// 5) Build a map[parentColumnTitle] → []models.Card
 	//    We will fill it with each card under its parent title.
 	boardMap := make(map[string][]models.Card)
 	for rows.Next() {
@@ -202,6 +299,8 @@ func (h *GameHandler) GetBoard(w http.ResponseWriter, r *http.Request) {
 			selectedDay    sql.NullInt64
 			deployedDay    sql.NullInt64
 			orderIndex     int
+			blockedReason  sql.NullString // NULL unless the card is blocked
+			blocker        models.Blocker
 			parentTitle    string
 		)
 		if err := rows.Scan(
@@ -212,6 +311,10 @@ func (h *GameHandler) GetBoard(w http.ResponseWriter, r *http.Request) {
 			&selectedDay,
 			&deployedDay,
 			&orderIndex,
+			&blockedReason,
+			&blocker.BlockedBy,
+			&blocker.Day,
+			&blocker.Effort,
 			&parentTitle,
 		); err != nil {
 			log.Printf("GetBoard: scan error: %v", err)
@@ -233,6 +336,10 @@ func (h *GameHandler) GetBoard(w http.ResponseWriter, r *http.Request) {
 		if deployedDay.Valid {
 			card.DeployedDay = int(deployedDay.Int64)
 		}
+		if blockedReason.Valid {
+			blocker.Reason = blockedReason.String
+			card.Blocker = &blocker
+		}
 
 		// If parentTitle contains “ – ” (e.g. “Development – Done”), strip off the “ – Done” part
 		parentKey := parentTitle
@@ -249,7 +356,7 @@ func (h *GameHandler) GetBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 5) Step 2: Ensure every top‐level column title appears in boardMap, even if it has no cards.
+	// 6) Step 2: Ensure every top‐level column title appears in boardMap, even if it has no cards.
 	//    We query the DB for all columns where parent_id IS NULL (i.e. top‐level)
 	//    and game_id = $1. That returns all parent column titles in the exact order they
 	//    were created. We then guarantee boardMap[parentTitle] exists (possibly empty slice).
@@ -285,7 +392,7 @@ func (h *GameHandler) GetBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 6) Return the grouped map as JSON:
+	// 7) Return the grouped map as JSON:
 	response.RespondWithData(w, boardMap)
 }
 
@@ -299,9 +406,10 @@ func (h *GameHandler) GetBoard(w http.ResponseWriter, r *http.Request) {
 // @Param        body  body      updateGameRequest   true  "New game day"
 // @Success      204
 // @Failure      400   {object}  response.ErrorResponse  "Invalid game ID or JSON payload"
-// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token"
+// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token, or not the facilitator"
 // @Failure      404   {object}  response.ErrorResponse  "Game not found"
 // @Failure      405   {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      409   {object}  response.ErrorResponse  "Game is not running"
 // @Failure      500   {object}  response.ErrorResponse  "Internal server error"
 // @Security    BearerAuth
 // @Router       /games/{id} [patch]
@@ -319,6 +427,10 @@ func (h *GameHandler) UpdateGame(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !requireFacilitator(w, r, gameID) {
+		return
+	}
+
 	var req updateGameRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidJSON)
@@ -326,8 +438,10 @@ func (h *GameHandler) UpdateGame(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.Service.UpdateGame(r.Context(), gameID, req.Day); err != nil {
-		if errors.Is(err, response.ErrNotFound) {
+		if errors.Is(err, response.ErrNotFound) || errors.Is(err, games.ErrNotFound) {
 			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+		} else if errors.Is(err, games.ErrGameNotRunning) {
+			response.RespondWithError(w, http.StatusConflict, response.ErrGameNotRunning)
 		} else {
 			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
 		}
@@ -345,7 +459,7 @@ func (h *GameHandler) UpdateGame(w http.ResponseWriter, r *http.Request) {
 // @Param        id   path      string  true  "Game ID"  Format(uuid)
 // @Success      204  "No Content"
 // @Failure      400   {object}  response.ErrorResponse  "Invalid or missing game ID"
-// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token"
+// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token, or not the facilitator"
 // @Failure      404   {object}  response.ErrorResponse  "Game not found"
 // @Failure      405   {object}  response.ErrorResponse  "Method not allowed"
 // @Failure      500   {object}  response.ErrorResponse  "Internal server error"
@@ -365,8 +479,12 @@ func (h *GameHandler) DeleteGame(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !requireFacilitator(w, r, gameID) {
+		return
+	}
+
 	if err := h.Service.DeleteGame(r.Context(), gameID); err != nil {
-		if errors.Is(err, response.ErrNotFound) {
+		if errors.Is(err, response.ErrNotFound) || errors.Is(err, games.ErrNotFound) {
 			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
 		} else {
 			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
@@ -377,12 +495,24 @@ func (h *GameHandler) DeleteGame(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// ListGames retrieves all games.
-// @Summary      List all games
-// @Description  Returns a list of all games in the system.
+// ListGames pages through the games, or ranks the ended ones.
+// @Summary      List games
+// @Description  Returns a page of the games that pass the filters, newest first by default, with the number of games that pass them on all pages. Games can be filtered by status, scenario, session and creation date, both dates included, and sorted by creation time or by day (most played first, then newest). The next_cursor of a page fetches the one after it with the same filters and sort; it is left out on the last page. With view=leaderboard it ranks the ended games instead, by profit (highest first, the default), lead_time (median, shortest first), throughput or fixed_date (share of fixed-date cards deployed). Games with equal scores share a rank. The leaderboard can be narrowed to one scenario and to games created between two dates, both included. Profit uses the standard team and card values for every game. Each entry of the leaderboard is a models.LeaderboardEntry.
 // @Tags         games
 // @Produce      json
-// @Success      200  {array}   []models.Game  "List of games"
+// @Param        view      query     string  false  "leaderboard to rank ended games"  Enums(leaderboard)
+// @Param        score     query     string  false  "Score to rank by"  Enums(profit, lead_time, throughput, fixed_date)
+// @Param        status    query     string  false  "Only games in this status"  Enums(lobby, running, paused, ended)
+// @Param        scenario  query     string  false  "Only games of this scenario"
+// @Param        session   query     string  false  "Only games of this session"  Format(uuid)
+// @Param        from      query     string  false  "Only games created on or after this date"  Format(date)
+// @Param        to        query     string  false  "Only games created on or before this date"  Format(date)
+// @Param        sort      query     string  false  "Sort order"  Enums(created, day)  default(created)
+// @Param        order     query     string  false  "Direction of the sort"  Enums(desc, asc)  default(desc)
+// @Param        cursor    query     string  false  "next_cursor of the previous page"
+// @Param        limit     query     int     false  "Games per page, up to 200"  default(50)
+// @Success      200  {object}  models.GamePage  "Page of games"
+// @Failure      400  {object}  response.ErrorResponse  "Unknown view, score, status, sort or order, invalid date, session or cursor, or limit out of range"
 // @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token"
 // @Failure      500  {object}  response.ErrorResponse  "Internal server error"
 // @Security    BearerAuth
@@ -394,12 +524,668 @@ func (h *GameHandler) ListGames(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	games, err := h.Service.ListGames(r.Context())
+	q := r.URL.Query()
+	switch q.Get("view") {
+	case "":
+	case "leaderboard":
+		h.leaderboard(w, r)
+		return
+	default:
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidView)
+		return
+	}
+
+	query := models.GameQuery{
+		Status:   models.GameStatus(q.Get("status")),
+		Scenario: q.Get("scenario"),
+		Sort:     q.Get("sort"),
+		Cursor:   q.Get("cursor"),
+	}
+	if v := q.Get("session"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidSessionID)
+			return
+		}
+		query.SessionID = &id
+	}
+	// dates are whole days in UTC, as on the leaderboard; to includes its day
+	if v := q.Get("from"); v != "" {
+		day, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidDate)
+			return
+		}
+		query.From = day
+	}
+	if v := q.Get("to"); v != "" {
+		day, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidDate)
+			return
+		}
+		query.To = day.AddDate(0, 0, 1)
+	}
+	switch q.Get("order") {
+	case "", "desc":
+	case "asc":
+		query.Ascending = true
+	default:
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidQuery)
+		return
+	}
+	if v := q.Get("limit"); v != "" {
+		var err error
+		if query.Limit, err = strconv.Atoi(v); err != nil {
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidQuery)
+			return
+		}
+	}
+
+	page, err := h.Service.SearchGames(r.Context(), query)
+	if err != nil {
+		switch {
+		case errors.Is(err, games.ErrInvalidQuery):
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidQuery)
+		case errors.Is(err, games.ErrInvalidCursor):
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidCursor)
+		default:
+			log.Printf("ListGames: failed to retrieve games: %v", err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		}
+		return
+	}
+
+	response.RespondWithData(w, page)
+}
+
+// leaderboard answers ListGames with view=leaderboard.
+func (h *GameHandler) leaderboard(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	score := games.ScoreProfit
+	if name := q.Get("score"); name != "" {
+		var err error
+		if score, err = games.ParseScore(name); err != nil {
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidScore)
+			return
+		}
+	}
+
+	// dates are whole days in UTC; to includes its day
+	f := models.LeaderboardFilter{Scenario: q.Get("scenario")}
+	if v := q.Get("from"); v != "" {
+		day, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidDate)
+			return
+		}
+		f.From = day
+	}
+	if v := q.Get("to"); v != "" {
+		day, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidDate)
+			return
+		}
+		f.To = day.AddDate(0, 0, 1)
+	}
+
+	entries, err := h.Service.Leaderboard(r.Context(), score, f)
+	if err != nil {
+		log.Printf("ListGames: failed to rank games: %v", err)
+		response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		return
+	}
+	response.RespondWithData(w, entries)
+}
+
+// ForkGame branches a new game off an existing one.
+// @Summary      Fork a game
+// @Description  Creates a new game whose columns, cards, efforts, players and events are copied from the source game at the end of the given day. Without a day the current state is forked. The copy of the source game's facilitator runs the fork, and their token is returned.
+// @Tags         games
+// @Produce      json
+// @Param        id   path      string  true   "Source game ID"  Format(uuid)
+// @Param        day  query     int     false  "Finished day to fork from"
+// @Success      201  {object}  response.CreateGameResponse "Forked game created"
+// @Failure      400  {object}  response.ErrorResponse  "Invalid game ID or day"
+// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token, or not the facilitator"
+// @Failure      404  {object}  response.ErrorResponse  "Game or snapshot not found"
+// @Failure      405  {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      500  {object}  response.ErrorResponse  "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/fork [post]
+func (h *GameHandler) ForkGame(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidGameID)
+		return
+	}
+
+	if !requireFacilitator(w, r, gameID) {
+		return
+	}
+
+	day := 0
+	if dayStr := r.URL.Query().Get("day"); dayStr != "" {
+		day, err = strconv.Atoi(dayStr)
+		if err != nil || day < 1 {
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidDay)
+			return
+		}
+	}
+
+	forkID, err := h.Service.ForkGame(r.Context(), gameID, day)
+	if err != nil {
+		switch {
+		case errors.Is(err, games.ErrNotFound):
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+		case errors.Is(err, games.ErrInvalidDay):
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidDay)
+		case errors.Is(err, games.ErrSnapshotNotFound):
+			response.RespondWithError(w, http.StatusNotFound, response.ErrSnapshotNotFound)
+		default:
+			log.Printf("ForkGame: failed to fork game %s at day %d: %v", gameID, day, err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		}
+		return
+	}
+
+	data := response.CreateGameData{ID: forkID.String()}
+	if data.Facilitator, err = h.facilitatorToken(r.Context(), forkID); err != nil {
+		log.Printf("ForkGame: failed to load facilitator of game %s: %v", forkID, err)
+		response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	response.RespondWithData(w, data)
+}
+
+// facilitatorToken hands out a token for the facilitator of a copied game,
+// or returns nil if the game has none.
+func (h *GameHandler) facilitatorToken(ctx context.Context, gameID uuid.UUID) (*models.PlayerToken, error) {
+	game, err := h.Service.GetGame(ctx, gameID)
+	if err != nil || game.FacilitatorID == nil {
+		return nil, err
+	}
+	return &models.PlayerToken{
+		PlayerID: *game.FacilitatorID,
+		GameID:   gameID,
+		Token:    h.Tokens.Issue(*game.FacilitatorID, gameID),
+	}, nil
+}
+
+// RollDice rolls a player's die for a card on the game's current day.
+// @Summary      Roll a die
+// @Description  Returns the die of the given player working on the given card today. Rolls are derived from the game's seed, so the same player, card and day always give the same value. A player token only rolls for its own player; the player and the card must be of the game.
+// @Tags         games
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string           true  "Game ID"  Format(uuid)
+// @Param        roll  body      rollDiceRequest  true  "Player and card"
+// @Success      200  {object}  response.DiceRollResponse  "Die rolled"
+// @Failure      400  {object}  response.ErrorResponse     "Invalid game ID or body"
+// @Failure      403  {object}  response.ErrorResponse     "Missing or invalid token, or another player's die"
+// @Failure      404  {object}  response.ErrorResponse     "Game, player or card not found"
+// @Failure      405  {object}  response.ErrorResponse     "Method not allowed"
+// @Failure      409  {object}  response.ErrorResponse     "Game is not running"
+// @Failure      500  {object}  response.ErrorResponse     "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/roll [post]
+func (h *GameHandler) RollDice(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidGameID)
+		return
+	}
+
+	var req rollDiceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidJSON)
+		return
+	}
+	if req.PlayerID == uuid.Nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidPlayerID)
+		return
+	}
+	if req.CardID == uuid.Nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidCardID)
+		return
+	}
+
+	// players roll their own dice; API keys roll for anyone
+	if caller, _ := auth.CallerFrom(r.Context()); caller.PlayerID != uuid.Nil && caller.PlayerID != req.PlayerID {
+		response.RespondWithError(w, http.StatusForbidden, response.ErrForbidden)
+		return
+	}
+
+	roll, err := h.Service.RollDice(r.Context(), gameID, req.PlayerID, req.CardID)
+	if err != nil {
+		if errors.Is(err, games.ErrNotFound) {
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+			return
+		}
+		if errors.Is(err, games.ErrPlayerNotInGame) {
+			response.RespondWithError(w, http.StatusNotFound, response.ErrPlayerNotFound)
+			return
+		}
+		if errors.Is(err, games.ErrCardNotFound) {
+			response.RespondWithError(w, http.StatusNotFound, response.ErrCardNotFound)
+			return
+		}
+		if errors.Is(err, games.ErrGameNotRunning) {
+			response.RespondWithError(w, http.StatusConflict, response.ErrGameNotRunning)
+			return
+		}
+		log.Printf("RollDice: failed to roll for game %s: %v", gameID, err)
+		response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		return
+	}
+
+	response.RespondWithData(w, roll)
+}
+
+// PlayBotDay lets an automated strategy play the game's current day.
+// @Summary      Let a bot play a day
+// @Description  A built-in strategy (strict-wip, maximise-utilisation, highest-value-first or finish-before-start) makes the day's moves and puts a team of bot workers on cards. Their dice come from the game's seed. The work, the moves and their events are stored, and the game moves on to the next day.
+// @Tags         games
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string             true  "Game ID"  Format(uuid)
+// @Param        bot  body      playBotDayRequest  true  "Strategy and team"
+// @Success      200  {object}  response.BotDayResponse  "Day played"
+// @Failure      400  {object}  response.ErrorResponse   "Invalid game ID, strategy or team"
+// @Failure      403  {object}  response.ErrorResponse   "Missing or invalid token, or not the facilitator"
+// @Failure      404  {object}  response.ErrorResponse   "Game not found"
+// @Failure      405  {object}  response.ErrorResponse   "Method not allowed"
+// @Failure      409  {object}  response.ErrorResponse   "Game is not running"
+// @Failure      422  {object}  response.ErrorResponse   "Board cannot be played by a bot"
+// @Failure      500  {object}  response.ErrorResponse   "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/bot [post]
+func (h *GameHandler) PlayBotDay(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidGameID)
+		return
+	}
+
+	if !requireFacilitator(w, r, gameID) {
+		return
+	}
+
+	var req playBotDayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidJSON)
+		return
+	}
+	strategy, err := engine.StrategyByName(req.Strategy)
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidStrategy)
+		return
+	}
+	if req.Team == "" {
+		req.Team = engine.DefaultTeam
+	}
+	team, err := engine.ParseTeam(req.Team)
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidTeam)
+		return
+	}
+
+	day, err := h.Service.PlayBotDay(r.Context(), gameID, strategy, team)
+	if err != nil {
+		switch {
+		case errors.Is(err, games.ErrNotFound):
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+		case errors.Is(err, games.ErrGameNotRunning):
+			response.RespondWithError(w, http.StatusConflict, response.ErrGameNotRunning)
+		case errors.Is(err, games.ErrInvalidBoard):
+			response.RespondWithError(w, http.StatusUnprocessableEntity, response.ErrInvalidBoard)
+		default:
+			log.Printf("PlayBotDay: failed to play game %s: %v", gameID, err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		}
+		return
+	}
+
+	response.RespondWithData(w, day)
+}
+
+// ExportGame downloads a complete game as a portable JSON archive.
+// @Summary      Export a game
+// @Description  Returns a versioned JSON document with the game, effort types, column tree, cards with efforts, players and events. The document is returned as-is (not wrapped in the response envelope) so it can be posted to /games/import unchanged.
+// @Tags         games
+// @Produce      json
+// @Param        id   path      string  true  "Game ID"  Format(uuid)
+// @Success      200  {object}  models.GameExport       "Game archive"
+// @Failure      400  {object}  response.ErrorResponse  "Invalid or missing game ID"
+// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token, or not the facilitator"
+// @Failure      404  {object}  response.ErrorResponse  "Game not found"
+// @Failure      405  {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      500  {object}  response.ErrorResponse  "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/export [get]
+func (h *GameHandler) ExportGame(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	gameID, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
-		log.Printf("ListGames: failed to retrieve games: %v", err)
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidGameID)
+		return
+	}
+
+	if !requireFacilitator(w, r, gameID) {
+		return
+	}
+
+	doc, err := h.Service.ExportGame(r.Context(), gameID)
+	if err != nil {
+		if errors.Is(err, games.ErrNotFound) {
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+		} else {
+			log.Printf("ExportGame: failed to export game %s: %v", gameID, err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="game-%s.json"`, gameID))
+	json.NewEncoder(w).Encode(doc)
+}
+
+// ImportGame recreates a game from an archive produced by ExportGame.
+// @Summary      Import a game
+// @Description  Creates a new game from an exported JSON document. All rows get fresh UUIDs and events are re-pointed at the new cards; everything is written in a single transaction. The copy of the archived game's facilitator runs the new game, and their token is returned.
+// @Tags         games
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.GameExport  true  "Game archive"
+// @Success      201   {object}  response.CreateGameResponse "Game imported"
+// @Failure      400   {object}  response.ErrorResponse  "Invalid JSON or archive"
+// @Failure      403   {object}  response.ErrorResponse  "Missing or invalid token"
+// @Failure      405   {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      500   {object}  response.ErrorResponse  "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/import [post]
+func (h *GameHandler) ImportGame(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	var doc models.GameExport
+	if err := json.NewDecoder(r.Body).Decode(&doc); err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidJSON)
+		return
+	}
+
+	gameID, err := h.Service.ImportGame(r.Context(), doc)
+	if err != nil {
+		if errors.Is(err, games.ErrInvalidExport) {
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidExport)
+		} else {
+			log.Printf("ImportGame: failed to import game: %v", err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		}
+		return
+	}
+
+	data := response.CreateGameData{ID: gameID.String()}
+	if data.Facilitator, err = h.facilitatorToken(r.Context(), gameID); err != nil {
+		log.Printf("ImportGame: failed to load facilitator of game %s: %v", gameID, err)
 		response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
 		return
 	}
 
-	response.RespondWithData(w, games)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	response.RespondWithData(w, data)
+}
+
+// SetFacilitator hands the facilitator role to another player of the game.
+// @Summary      Hand over the facilitator role
+// @Description  Makes another player of the game its facilitator. Only the current facilitator (or a key with the facilitate scope) may do so; the previous facilitator becomes a regular player.
+// @Tags         games
+// @Accept       json
+// @Param        id    path      string                 true  "Game ID"  Format(uuid)
+// @Param        body  body      setFacilitatorRequest  true  "New facilitator"
+// @Success      204   "No Content"
+// @Failure      400   {object}  response.ErrorResponse  "Invalid game ID or body"
+// @Failure      403   {object}  response.ErrorResponse  "Missing or invalid token, or not the facilitator"
+// @Failure      404   {object}  response.ErrorResponse  "Game not found, or player not in the game"
+// @Failure      405   {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      409   {object}  response.ErrorResponse  "Game has ended"
+// @Failure      500   {object}  response.ErrorResponse  "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/facilitator [put]
+func (h *GameHandler) SetFacilitator(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.Header().Set("Allow", http.MethodPut)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidGameID)
+		return
+	}
+	if !requireFacilitator(w, r, gameID) {
+		return
+	}
+
+	var req setFacilitatorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidJSON)
+		return
+	}
+	if req.PlayerID == uuid.Nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidPlayerID)
+		return
+	}
+
+	if err := h.Service.SetFacilitator(r.Context(), gameID, req.PlayerID); err != nil {
+		switch {
+		case errors.Is(err, games.ErrNotFound):
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+		case errors.Is(err, games.ErrPlayerNotInGame):
+			response.RespondWithError(w, http.StatusNotFound, response.ErrPlayerNotFound)
+		case errors.Is(err, games.ErrGameEnded):
+			response.RespondWithError(w, http.StatusConflict, response.ErrGameEnded)
+		default:
+			log.Printf("SetFacilitator: failed to hand game %s to %s: %v", gameID, req.PlayerID, err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// StartGame starts a game waiting in the lobby.
+// @Summary      Start a game
+// @Description  Moves a game from the lobby to running. Cards can only be worked on, dice rolled and days advanced while a game is running.
+// @Tags         games
+// @Produce      json
+// @Param        id   path      string  true  "Game ID"  Format(uuid)
+// @Success      200  {object}  response.GameResponse   "Game in its new status"
+// @Failure      400  {object}  response.ErrorResponse  "Invalid game ID"
+// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token, or not the facilitator"
+// @Failure      404  {object}  response.ErrorResponse  "Game not found"
+// @Failure      405  {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      409  {object}  response.ErrorResponse  "Game is not in the lobby"
+// @Failure      500  {object}  response.ErrorResponse  "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/start [post]
+func (h *GameHandler) StartGame(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, games.Start)
+}
+
+// PauseGame pauses a running game.
+// @Summary      Pause a game
+// @Description  Moves a running game to paused. Gameplay is refused until the game is resumed.
+// @Tags         games
+// @Produce      json
+// @Param        id   path      string  true  "Game ID"  Format(uuid)
+// @Success      200  {object}  response.GameResponse   "Game in its new status"
+// @Failure      400  {object}  response.ErrorResponse  "Invalid game ID"
+// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token, or not the facilitator"
+// @Failure      404  {object}  response.ErrorResponse  "Game not found"
+// @Failure      405  {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      409  {object}  response.ErrorResponse  "Game is not running"
+// @Failure      500  {object}  response.ErrorResponse  "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/pause [post]
+func (h *GameHandler) PauseGame(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, games.Pause)
+}
+
+// ResumeGame resumes a paused game.
+// @Summary      Resume a game
+// @Description  Moves a paused game back to running.
+// @Tags         games
+// @Produce      json
+// @Param        id   path      string  true  "Game ID"  Format(uuid)
+// @Success      200  {object}  response.GameResponse   "Game in its new status"
+// @Failure      400  {object}  response.ErrorResponse  "Invalid game ID"
+// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token, or not the facilitator"
+// @Failure      404  {object}  response.ErrorResponse  "Game not found"
+// @Failure      405  {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      409  {object}  response.ErrorResponse  "Game is not paused"
+// @Failure      500  {object}  response.ErrorResponse  "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/resume [post]
+func (h *GameHandler) ResumeGame(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, games.Resume)
+}
+
+// EndGame ends a game for good.
+// @Summary      End a game
+// @Description  Ends a game from any other status. An ended game is read-only: it can still be looked at, exported and forked, but not played, joined or handed over.
+// @Tags         games
+// @Produce      json
+// @Param        id   path      string  true  "Game ID"  Format(uuid)
+// @Success      200  {object}  response.GameResponse   "Game in its new status"
+// @Failure      400  {object}  response.ErrorResponse  "Invalid game ID"
+// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token, or not the facilitator"
+// @Failure      404  {object}  response.ErrorResponse  "Game not found"
+// @Failure      405  {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      409  {object}  response.ErrorResponse  "Game has already ended"
+// @Failure      500  {object}  response.ErrorResponse  "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/end [post]
+func (h *GameHandler) EndGame(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, games.End)
+}
+
+// ResetGame restarts a game from the board it started with.
+// @Summary      Reset a game
+// @Description  Puts the columns, cards and efforts back as they were when the game was created (or imported, or forked), clears its events and sends it back to the lobby on day 1. Players, their tokens and the facilitator stay, so the same group can play again.
+// @Tags         games
+// @Produce      json
+// @Param        id   path      string  true  "Game ID"  Format(uuid)
+// @Success      200  {object}  response.GameResponse   "Game as reset"
+// @Failure      400  {object}  response.ErrorResponse  "Invalid game ID"
+// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token, or not the facilitator"
+// @Failure      404  {object}  response.ErrorResponse  "Game not found, or created before its initial board was kept"
+// @Failure      405  {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      409  {object}  response.ErrorResponse  "Game has ended"
+// @Failure      500  {object}  response.ErrorResponse  "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/reset [post]
+func (h *GameHandler) ResetGame(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidGameID)
+		return
+	}
+	if !requireFacilitator(w, r, gameID) {
+		return
+	}
+
+	game, err := h.Service.ResetGame(r.Context(), gameID)
+	if err != nil {
+		switch {
+		case errors.Is(err, games.ErrNotFound):
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+		case errors.Is(err, games.ErrSnapshotNotFound):
+			response.RespondWithError(w, http.StatusNotFound, response.ErrSnapshotNotFound)
+		case errors.Is(err, games.ErrGameEnded):
+			response.RespondWithError(w, http.StatusConflict, response.ErrGameEnded)
+		default:
+			log.Printf("ResetGame: failed to reset game %s: %v", gameID, err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		}
+		return
+	}
+
+	response.RespondWithData(w, facilitatorGame{Game: game, Seed: game.Seed})
+}
+
+// changeStatus takes the game of the request through lifecycle step t on
+// behalf of its facilitator.
+func (h *GameHandler) changeStatus(w http.ResponseWriter, r *http.Request, t games.Transition) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	gameID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidGameID)
+		return
+	}
+	if !requireFacilitator(w, r, gameID) {
+		return
+	}
+
+	game, err := h.Service.ChangeStatus(r.Context(), gameID, t)
+	if err != nil {
+		switch {
+		case errors.Is(err, games.ErrNotFound):
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+		case errors.Is(err, games.ErrInvalidTransition):
+			response.RespondWithError(w, http.StatusConflict, response.ErrInvalidTransition)
+		default:
+			log.Printf("changeStatus: failed to %s game %s: %v", t.Name, gameID, err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		}
+		return
+	}
+
+	response.RespondWithData(w, facilitatorGame{Game: game, Seed: game.Seed})
 }
@@ -2,31 +2,56 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/Germanicus1/kanban-sim/backend/internal/auth"
+	"github.com/Germanicus1/kanban-sim/backend/internal/engine"
+	"github.com/Germanicus1/kanban-sim/backend/internal/games"
 	"github.com/Germanicus1/kanban-sim/backend/internal/models"
 	"github.com/Germanicus1/kanban-sim/backend/internal/response"
 	"github.com/google/uuid"
 )
 
+var testSigner = auth.NewSigner([]byte("test secret"))
+
+// asAdmin returns r as made with the shared API key.
+func asAdmin(r *http.Request) *http.Request {
+	return r.WithContext(auth.WithCaller(r.Context(), auth.Caller{Admin: true}))
+}
+
 // fakeService implements games.ServiceInterface for testing DeleteGame.
 type fakeService struct {
-	calledID uuid.UUID
-	retErr   error
+	calledID     uuid.UUID
+	calledDay    int
+	calledCfg    models.BoardConfig
+	calledTeam   []engine.Worker
+	calledPlayer uuid.UUID
+	calledScore  games.Score
+	calledFilter models.LeaderboardFilter
+	calledQuery  models.GameQuery
+	forkedFrom   uuid.UUID
+	game         models.Game
+	retErr       error
 }
 
 func (f *fakeService) CreateGame(ctx context.Context, cfg models.BoardConfig) (uuid.UUID, error) {
-	panic("unused")
+	f.calledCfg = cfg
+	return uuid.New(), f.retErr
 }
 func (f *fakeService) GetBoard(ctx context.Context, id uuid.UUID) (models.Board, error) {
 	panic("unused")
 }
 func (f *fakeService) GetGame(ctx context.Context, id uuid.UUID) (models.Game, error) {
 	f.calledID = id
-	return models.Game{}, f.retErr
+	return f.game, f.retErr
 }
 func (f *fakeService) DeleteGame(ctx context.Context, id uuid.UUID) error {
 	f.calledID = id
@@ -38,14 +63,220 @@ func (f *fakeService) UpdateGame(ctx context.Context, id uuid.UUID, day int) err
 	return f.retErr
 }
 
-func (f *fakeService) ListGames(ctx context.Context) ([]models.Game, error) {
-	f.calledID = uuid.Nil // Not used in this test
-	return nil, f.retErr
+func (f *fakeService) SearchGames(ctx context.Context, q models.GameQuery) (models.GamePage, error) {
+	f.calledQuery = q
+	return models.GamePage{Games: []models.Game{}}, f.retErr
+}
+
+func (f *fakeService) ExportGame(ctx context.Context, id uuid.UUID) (models.GameExport, error) {
+	f.calledID = id
+	return models.GameExport{Version: models.GameExportVersion, Game: models.Game{ID: id}}, f.retErr
+}
+
+func (f *fakeService) ImportGame(ctx context.Context, doc models.GameExport) (uuid.UUID, error) {
+	return uuid.New(), f.retErr
+}
+
+func (f *fakeService) ForkGame(ctx context.Context, id uuid.UUID, day int) (uuid.UUID, error) {
+	f.forkedFrom = id
+	f.calledDay = day
+	return uuid.New(), f.retErr
+}
+
+func (f *fakeService) RollDice(ctx context.Context, id, playerID, cardID uuid.UUID) (models.DiceRoll, error) {
+	f.calledID = id
+	return models.DiceRoll{Day: 2, PlayerID: playerID, CardID: cardID, Value: 5}, f.retErr
+}
+
+func (f *fakeService) PlayBotDay(ctx context.Context, id uuid.UUID, s engine.Strategy, team []engine.Worker) (models.BotDay, error) {
+	f.calledID = id
+	f.calledTeam = team
+	return models.BotDay{Strategy: s.Name(), Day: 2, Working: len(team)}, f.retErr
+}
+
+func (f *fakeService) SetFacilitator(ctx context.Context, id, playerID uuid.UUID) error {
+	f.calledID = id
+	f.calledPlayer = playerID
+	return f.retErr
+}
+
+func (f *fakeService) ChangeStatus(ctx context.Context, id uuid.UUID, t games.Transition) (models.Game, error) {
+	f.calledID = id
+	return models.Game{ID: id, Status: t.To}, f.retErr
+}
+
+func (f *fakeService) ResetGame(ctx context.Context, id uuid.UUID) (models.Game, error) {
+	f.calledID = id
+	return models.Game{ID: id, Day: 1, Status: models.GameLobby}, f.retErr
+}
+
+func (f *fakeService) Leaderboard(ctx context.Context, score games.Score, filter models.LeaderboardFilter) ([]models.LeaderboardEntry, error) {
+	f.calledScore, f.calledFilter = score, filter
+	return []models.LeaderboardEntry{{Rank: 1, Profit: 12400}}, f.retErr
+}
+
+func (f *fakeService) BlockCard(ctx context.Context, id, cardID, playerID uuid.UUID, reason string, effort int) (models.Blocker, error) {
+	f.calledID = id
+	f.calledPlayer = playerID
+	return models.Blocker{Reason: reason, Day: 3, Effort: effort}, f.retErr
+}
+
+func (f *fakeService) UnblockCard(ctx context.Context, id, cardID uuid.UUID) error {
+	f.calledID = id
+	return f.retErr
+}
+
+func (f *fakeService) PositionCard(ctx context.Context, id, cardID uuid.UUID, pos models.CardPosition) (models.CardOrder, error) {
+	f.calledID = id
+	if f.retErr != nil {
+		return models.CardOrder{}, f.retErr
+	}
+	return models.CardOrder{Cards: []models.OrderedCard{{ID: cardID, Title: "S1", OrderIndex: pos.Index}}}, nil
+}
+
+func TestGameHandler_CreateGame_Seed(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantSeed int64 // 0: any random seed
+	}{
+		{name: "no body", body: ""},
+		{name: "seed given", body: `{"seed":1234}`, wantSeed: 1234},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeService{}
+			h := NewGameHandler(svc, testSigner)
+
+			req := httptest.NewRequest("POST", "/games", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+
+			h.CreateGame(rr, req)
+
+			if rr.Code != http.StatusCreated {
+				t.Fatalf("status = %d; want %d", rr.Code, http.StatusCreated)
+			}
+			if svc.calledCfg.Seed == 0 {
+				t.Fatal("game created without a seed")
+			}
+			if tc.wantSeed != 0 && svc.calledCfg.Seed != tc.wantSeed {
+				t.Errorf("seed = %d; want %d", svc.calledCfg.Seed, tc.wantSeed)
+			}
+			if want := fmt.Sprintf(`"seed":%d`, svc.calledCfg.Seed); !strings.Contains(rr.Body.String(), want) {
+				t.Errorf("body = %q; want it to contain %s", rr.Body.String(), want)
+			}
+		})
+	}
+}
+
+func TestGameHandler_CreateGame_InvalidJSON(t *testing.T) {
+	h := NewGameHandler(&fakeService{}, testSigner)
+
+	req := httptest.NewRequest("POST", "/games", strings.NewReader(`{"seed":`))
+	rr := httptest.NewRecorder()
+
+	h.CreateGame(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d; want %d", rr.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGameHandler_RollDice(t *testing.T) {
+	gameID := uuid.New()
+	playerID := uuid.New()
+	cardID := uuid.New()
+	asSelf := func(r *http.Request) *http.Request {
+		return r.WithContext(auth.WithCaller(r.Context(), auth.Caller{PlayerID: playerID, GameID: gameID}))
+	}
+	asOther := func(r *http.Request) *http.Request { return asPlayer(r, gameID, true) }
+
+	tests := []struct {
+		name       string
+		as         func(*http.Request) *http.Request
+		body       string
+		retErr     error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "rolled",
+			body:       fmt.Sprintf(`{"playerId":%q,"cardId":%q}`, playerID, cardID),
+			wantStatus: http.StatusOK,
+			wantBody:   `"value":5`,
+		},
+		{
+			name:       "own die",
+			as:         asSelf,
+			body:       fmt.Sprintf(`{"playerId":%q,"cardId":%q}`, playerID, cardID),
+			wantStatus: http.StatusOK,
+			wantBody:   `"value":5`,
+		},
+		{
+			name:       "another player's die",
+			as:         asOther,
+			body:       fmt.Sprintf(`{"playerId":%q,"cardId":%q}`, playerID, cardID),
+			wantStatus: http.StatusForbidden,
+			wantBody:   response.ErrForbidden,
+		},
+		{
+			name:       "missing card",
+			body:       fmt.Sprintf(`{"playerId":%q}`, playerID),
+			wantStatus: http.StatusBadRequest,
+			wantBody:   response.ErrInvalidCardID,
+		},
+		{
+			name:       "unknown game",
+			body:       fmt.Sprintf(`{"playerId":%q,"cardId":%q}`, playerID, cardID),
+			retErr:     games.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+			wantBody:   response.ErrGameNotFound,
+		},
+		{
+			name:       "player of another game",
+			body:       fmt.Sprintf(`{"playerId":%q,"cardId":%q}`, playerID, cardID),
+			retErr:     games.ErrPlayerNotInGame,
+			wantStatus: http.StatusNotFound,
+			wantBody:   response.ErrPlayerNotFound,
+		},
+		{
+			name:       "card of another game",
+			body:       fmt.Sprintf(`{"playerId":%q,"cardId":%q}`, playerID, cardID),
+			retErr:     games.ErrCardNotFound,
+			wantStatus: http.StatusNotFound,
+			wantBody:   response.ErrCardNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewGameHandler(&fakeService{retErr: tc.retErr}, testSigner)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /games/{id}/roll", h.RollDice)
+
+			req := httptest.NewRequest("POST", "/games/"+gameID.String()+"/roll", strings.NewReader(tc.body))
+			if tc.as != nil {
+				req = tc.as(req)
+			}
+			rr := httptest.NewRecorder()
+
+			mux.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d", rr.Code, tc.wantStatus)
+			}
+			if !strings.Contains(rr.Body.String(), tc.wantBody) {
+				t.Errorf("body = %q; want it to contain %q", rr.Body.String(), tc.wantBody)
+			}
+		})
+	}
 }
 
 func TestGameHandler_GetGame_Success(t *testing.T) {
 	svc := &fakeService{retErr: nil}
-	h := NewGameHandler(svc)
+	h := NewGameHandler(svc, testSigner)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("GET /games/{id}", h.GetGame)
@@ -66,7 +297,7 @@ func TestGameHandler_GetGame_Success(t *testing.T) {
 
 func TestGameHandler_UpdateGame_Success(t *testing.T) {
 	svc := &fakeService{retErr: nil}
-	h := NewGameHandler(svc)
+	h := NewGameHandler(svc, testSigner)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("PATCH /games/{id}", h.UpdateGame)
@@ -77,7 +308,7 @@ func TestGameHandler_UpdateGame_Success(t *testing.T) {
 	req := httptest.NewRequest("PATCH", "/games/"+id.String(), strings.NewReader(body))
 	rr := httptest.NewRecorder()
 
-	mux.ServeHTTP(rr, req)
+	mux.ServeHTTP(rr, asAdmin(req))
 
 	if rr.Code != http.StatusNoContent {
 		t.Fatalf("status = %d; want %d", rr.Code, http.StatusNoContent)
@@ -89,7 +320,7 @@ func TestGameHandler_UpdateGame_Success(t *testing.T) {
 
 func TestGameHandler_DeleteGame_Success(t *testing.T) {
 	svc := &fakeService{retErr: nil}
-	h := NewGameHandler(svc)
+	h := NewGameHandler(svc, testSigner)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("DELETE /games/{id}", h.DeleteGame)
@@ -98,7 +329,7 @@ func TestGameHandler_DeleteGame_Success(t *testing.T) {
 	req := httptest.NewRequest("DELETE", "/games/"+id.String(), nil)
 	rr := httptest.NewRecorder()
 
-	mux.ServeHTTP(rr, req)
+	mux.ServeHTTP(rr, asAdmin(req))
 
 	if rr.Code != http.StatusNoContent {
 		t.Fatalf("status = %d; want %d", rr.Code, http.StatusNoContent)
@@ -110,7 +341,7 @@ func TestGameHandler_DeleteGame_Success(t *testing.T) {
 
 func TestGameHandler_DeleteGame_NotFound(t *testing.T) {
 	svc := &fakeService{retErr: response.ErrNotFound}
-	h := NewGameHandler(svc)
+	h := NewGameHandler(svc, testSigner)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("DELETE /games/{id}", h.DeleteGame)
@@ -119,7 +350,7 @@ func TestGameHandler_DeleteGame_NotFound(t *testing.T) {
 	req := httptest.NewRequest("DELETE", "/games/"+id.String(), nil)
 	rr := httptest.NewRecorder()
 
-	mux.ServeHTTP(rr, req)
+	mux.ServeHTTP(rr, asAdmin(req))
 
 	if rr.Code != http.StatusNotFound {
 		t.Fatalf("status = %d; want %d", rr.Code, http.StatusNotFound)
@@ -132,7 +363,7 @@ func TestGameHandler_DeleteGame_NotFound(t *testing.T) {
 
 func TestGameHandler_DeleteGame_BadID(t *testing.T) {
 	svc := &fakeService{retErr: nil}
-	h := NewGameHandler(svc)
+	h := NewGameHandler(svc, testSigner)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("DELETE /games/{id}", h.DeleteGame)
@@ -140,7 +371,7 @@ func TestGameHandler_DeleteGame_BadID(t *testing.T) {
 	req := httptest.NewRequest("DELETE", "/games/not-a-uuid", nil)
 	rr := httptest.NewRecorder()
 
-	mux.ServeHTTP(rr, req)
+	mux.ServeHTTP(rr, asAdmin(req))
 
 	if rr.Code != http.StatusBadRequest {
 		t.Fatalf("status = %d; want %d", rr.Code, http.StatusBadRequest)
@@ -151,21 +382,483 @@ func TestGameHandler_DeleteGame_BadID(t *testing.T) {
 }
 
 func TestGameHandler_ListGames(t *testing.T) {
+	session := uuid.New()
+	tests := []struct {
+		name       string
+		query      string
+		retErr     error
+		wantStatus int
+		wantQuery  models.GameQuery
+	}{
+		{"defaults", "", nil, http.StatusOK, models.GameQuery{}},
+		{"filters", "status=ended&scenario=standard&session=" + session.String() + "&from=2026-03-01&to=2026-03-31", nil, http.StatusOK,
+			models.GameQuery{
+				Status: models.GameEnded, Scenario: "standard", SessionID: &session,
+				From: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			}},
+		{"sort and page", "sort=day&order=asc&cursor=abc&limit=10", nil, http.StatusOK,
+			models.GameQuery{Sort: "day", Ascending: true, Cursor: "abc", Limit: 10}},
+		{"bad session", "session=one", nil, http.StatusBadRequest, models.GameQuery{}},
+		{"bad date", "from=March", nil, http.StatusBadRequest, models.GameQuery{}},
+		{"bad order", "order=up", nil, http.StatusBadRequest, models.GameQuery{}},
+		{"bad limit", "limit=ten", nil, http.StatusBadRequest, models.GameQuery{}},
+		{"invalid query", "sort=title", games.ErrInvalidQuery, http.StatusBadRequest, models.GameQuery{Sort: "title"}},
+		{"invalid cursor", "cursor=abc", games.ErrInvalidCursor, http.StatusBadRequest, models.GameQuery{Cursor: "abc"}},
+		{"service error", "", errors.New("db down"), http.StatusInternalServerError, models.GameQuery{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeService{retErr: tc.retErr}
+			h := NewGameHandler(svc, testSigner)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /games", h.ListGames)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, httptest.NewRequest("GET", "/games?"+tc.query, nil))
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d", rr.Code, tc.wantStatus)
+			}
+			if !reflect.DeepEqual(svc.calledQuery, tc.wantQuery) {
+				t.Errorf("service.SearchGames called with %+v; want %+v", svc.calledQuery, tc.wantQuery)
+			}
+		})
+	}
+}
+
+func TestGameHandler_ForkGame(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		retErr     error
+		wantStatus int
+		wantDay    int
+	}{
+		{"current state", "", nil, http.StatusCreated, 0},
+		{"finished day", "?day=9", nil, http.StatusCreated, 9},
+		{"bad day", "?day=zero", nil, http.StatusBadRequest, 0},
+		{"day not finished", "?day=12", games.ErrInvalidDay, http.StatusBadRequest, 12},
+		{"unknown game", "?day=3", games.ErrNotFound, http.StatusNotFound, 3},
+		{"missing snapshot", "?day=3", games.ErrSnapshotNotFound, http.StatusNotFound, 3},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			facilitator := uuid.New()
+			svc := &fakeService{retErr: tc.retErr, game: models.Game{FacilitatorID: &facilitator}}
+			h := NewGameHandler(svc, testSigner)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /games/{id}/fork", h.ForkGame)
+
+			id := uuid.New()
+			req := httptest.NewRequest("POST", "/games/"+id.String()+"/fork"+tc.query, nil)
+			rr := httptest.NewRecorder()
+
+			mux.ServeHTTP(rr, asAdmin(req))
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d", rr.Code, tc.wantStatus)
+			}
+			if tc.wantStatus == http.StatusBadRequest && tc.retErr == nil {
+				return // rejected before reaching the service
+			}
+			if svc.forkedFrom != id || svc.calledDay != tc.wantDay {
+				t.Errorf("service.ForkGame called with (%v, %d); want (%v, %d)", svc.forkedFrom, svc.calledDay, id, tc.wantDay)
+			}
+			// the facilitator of the fork gets a token for it
+			if tc.wantStatus == http.StatusCreated && !strings.Contains(rr.Body.String(), `"playerId":"`+facilitator.String()+`"`) {
+				t.Errorf("body = %s; want a token for facilitator %s", rr.Body.String(), facilitator)
+			}
+		})
+	}
+}
+
+func TestGameHandler_ExportGame(t *testing.T) {
 	svc := &fakeService{retErr: nil}
-	h := NewGameHandler(svc)
+	h := NewGameHandler(svc, testSigner)
 
 	mux := http.NewServeMux()
-	mux.HandleFunc("GET /games", h.ListGames)
+	mux.HandleFunc("GET /games/{id}/export", h.ExportGame)
 
-	req := httptest.NewRequest("GET", "/games", nil)
+	id := uuid.New()
+	req := httptest.NewRequest("GET", "/games/"+id.String()+"/export", nil)
 	rr := httptest.NewRecorder()
 
-	mux.ServeHTTP(rr, req)
+	mux.ServeHTTP(rr, asAdmin(req))
 
 	if rr.Code != http.StatusOK {
 		t.Fatalf("status = %d; want %d", rr.Code, http.StatusOK)
 	}
-	if svc.calledID != uuid.Nil {
-		t.Errorf("service.ListGames called with %v; want uuid.Nil", svc.calledID)
+	if !strings.Contains(rr.Header().Get("Content-Disposition"), id.String()) {
+		t.Errorf("Content-Disposition = %q; want attachment named after the game", rr.Header().Get("Content-Disposition"))
+	}
+	// the archive is returned bare so it can be re-imported as-is
+	if strings.Contains(rr.Body.String(), `"success"`) || !strings.Contains(rr.Body.String(), `"version":1`) {
+		t.Errorf("body = %q; want bare archive document", rr.Body.String())
+	}
+}
+
+func TestGameHandler_ImportGame(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		retErr     error
+		wantStatus int
+	}{
+		{"success", `{"version":1}`, nil, http.StatusCreated},
+		{"malformed JSON", `{`, nil, http.StatusBadRequest},
+		{"invalid archive", `{"version":9}`, games.ErrInvalidExport, http.StatusBadRequest},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeService{retErr: tc.retErr}
+			h := NewGameHandler(svc, testSigner)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /games/import", h.ImportGame)
+
+			req := httptest.NewRequest("POST", "/games/import", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+
+			mux.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d", rr.Code, tc.wantStatus)
+			}
+		})
+	}
+}
+
+func TestGameHandler_PlayBotDay(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		retErr     error
+		wantStatus int
+		wantBody   string
+		wantTeam   int
+	}{
+		{
+			name:       "played with the default team",
+			body:       `{"strategy":"strict-wip"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `"strategy":"strict-wip"`,
+			wantTeam:   7,
+		},
+		{
+			name:       "played with a given team",
+			body:       `{"strategy":"finish-before-start","team":"Development=2"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `"working":2`,
+			wantTeam:   2,
+		},
+		{
+			name:       "unknown strategy",
+			body:       `{"strategy":"coin-flip"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   response.ErrInvalidStrategy,
+		},
+		{
+			name:       "invalid team",
+			body:       `{"strategy":"strict-wip","team":"Development=lots"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   response.ErrInvalidTeam,
+		},
+		{
+			name:       "unknown game",
+			body:       `{"strategy":"strict-wip"}`,
+			retErr:     games.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+			wantBody:   response.ErrGameNotFound,
+		},
+		{
+			name:       "board without a done column",
+			body:       `{"strategy":"strict-wip"}`,
+			retErr:     games.ErrInvalidBoard,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   response.ErrInvalidBoard,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeService{retErr: tc.retErr}
+			h := NewGameHandler(svc, testSigner)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /games/{id}/bot", h.PlayBotDay)
+
+			req := httptest.NewRequest("POST", "/games/"+uuid.NewString()+"/bot", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+
+			mux.ServeHTTP(rr, asAdmin(req))
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d", rr.Code, tc.wantStatus)
+			}
+			if !strings.Contains(rr.Body.String(), tc.wantBody) {
+				t.Errorf("body = %q; want it to contain %q", rr.Body.String(), tc.wantBody)
+			}
+			if tc.wantTeam != 0 && len(svc.calledTeam) != tc.wantTeam {
+				t.Errorf("team = %d workers; want %d", len(svc.calledTeam), tc.wantTeam)
+			}
+		})
+	}
+}
+
+// asPlayer returns r as made with the token of a player of gameID.
+func asPlayer(r *http.Request, gameID uuid.UUID, facilitator bool) *http.Request {
+	c := auth.Caller{PlayerID: uuid.New(), GameID: gameID, Facilitator: facilitator}
+	return r.WithContext(auth.WithCaller(r.Context(), c))
+}
+
+func TestGameHandler_CreateGame_Facilitator(t *testing.T) {
+	facilitator := uuid.New()
+	svc := &fakeService{game: models.Game{FacilitatorID: &facilitator}}
+	h := NewGameHandler(svc, testSigner)
+
+	req := httptest.NewRequest("POST", "/games", strings.NewReader(`{"facilitator":"Grace"}`))
+	rr := httptest.NewRecorder()
+
+	h.CreateGame(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("status = %d; want %d", rr.Code, http.StatusCreated)
+	}
+	if svc.calledCfg.Facilitator != "Grace" {
+		t.Errorf("facilitator = %q; want %q", svc.calledCfg.Facilitator, "Grace")
+	}
+	var got response.APIResponse[response.CreateGameData]
+	if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	ft := got.Data.Facilitator
+	if ft == nil || ft.PlayerID != facilitator {
+		t.Fatalf("facilitator = %+v; want a token for %s", ft, facilitator)
+	}
+	if c, err := testSigner.Verify(ft.Token); err != nil || c.PlayerID != facilitator || c.GameID.String() != got.Data.ID {
+		t.Errorf("token verifies to (%+v, %v); want player %s of game %s", c, err, facilitator, got.Data.ID)
+	}
+}
+
+func TestGameHandler_GetGame_Seed(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name     string
+		as       func(*http.Request) *http.Request
+		wantSeed bool
+	}{
+		{name: "admin", as: asAdmin, wantSeed: true},
+		{name: "facilitator", as: func(r *http.Request) *http.Request { return asPlayer(r, id, true) }, wantSeed: true},
+		{name: "player", as: func(r *http.Request) *http.Request { return asPlayer(r, id, false) }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewGameHandler(&fakeService{game: models.Game{ID: id, Seed: 1234}}, testSigner)
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /games/{id}", h.GetGame)
+
+			req := httptest.NewRequest("GET", "/games/"+id.String(), nil)
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, tc.as(req))
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("status = %d; want %d", rr.Code, http.StatusOK)
+			}
+			if got := strings.Contains(rr.Body.String(), `"seed":1234`); got != tc.wantSeed {
+				t.Errorf("seed shown = %v; want %v (body %s)", got, tc.wantSeed, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestGameHandler_SetFacilitator(t *testing.T) {
+	id, player := uuid.New(), uuid.New()
+	tests := []struct {
+		name       string
+		as         func(*http.Request) *http.Request
+		body       string
+		retErr     error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "admin", as: asAdmin, body: fmt.Sprintf(`{"playerId":%q}`, player), wantStatus: http.StatusNoContent},
+		{name: "facilitator", as: func(r *http.Request) *http.Request { return asPlayer(r, id, true) }, body: fmt.Sprintf(`{"playerId":%q}`, player), wantStatus: http.StatusNoContent},
+		{name: "player", as: func(r *http.Request) *http.Request { return asPlayer(r, id, false) }, body: fmt.Sprintf(`{"playerId":%q}`, player), wantStatus: http.StatusForbidden, wantCode: response.ErrNotFacilitator},
+		{name: "no player", as: asAdmin, body: `{}`, wantStatus: http.StatusBadRequest, wantCode: response.ErrInvalidPlayerID},
+		{name: "player of another game", as: asAdmin, body: fmt.Sprintf(`{"playerId":%q}`, player), retErr: games.ErrPlayerNotInGame, wantStatus: http.StatusNotFound, wantCode: response.ErrPlayerNotFound},
+		{name: "unknown game", as: asAdmin, body: fmt.Sprintf(`{"playerId":%q}`, player), retErr: games.ErrNotFound, wantStatus: http.StatusNotFound, wantCode: response.ErrGameNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeService{retErr: tc.retErr}
+			h := NewGameHandler(svc, testSigner)
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /games/{id}/facilitator", h.SetFacilitator)
+
+			req := httptest.NewRequest("PUT", "/games/"+id.String()+"/facilitator", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, tc.as(req))
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d (body %s)", rr.Code, tc.wantStatus, rr.Body.String())
+			}
+			if tc.wantCode != "" && !strings.Contains(rr.Body.String(), tc.wantCode) {
+				t.Errorf("body = %s; want error %s", rr.Body.String(), tc.wantCode)
+			}
+			if tc.wantStatus == http.StatusNoContent && (svc.calledID != id || svc.calledPlayer != player) {
+				t.Errorf("SetFacilitator(%s, %s); want (%s, %s)", svc.calledID, svc.calledPlayer, id, player)
+			}
+		})
+	}
+}
+
+func TestGameHandler_ChangeStatus(t *testing.T) {
+	id := uuid.New()
+	facilitator := func(r *http.Request) *http.Request { return asPlayer(r, id, true) }
+	tests := []struct {
+		name       string
+		path       string
+		as         func(*http.Request) *http.Request
+		retErr     error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "start", path: "start", as: facilitator, wantStatus: http.StatusOK, wantCode: `"status":"running"`},
+		{name: "pause", path: "pause", as: facilitator, wantStatus: http.StatusOK, wantCode: `"status":"paused"`},
+		{name: "resume", path: "resume", as: asAdmin, wantStatus: http.StatusOK, wantCode: `"status":"running"`},
+		{name: "end", path: "end", as: facilitator, wantStatus: http.StatusOK, wantCode: `"status":"ended"`},
+		{name: "player", path: "start", as: func(r *http.Request) *http.Request { return asPlayer(r, id, false) }, wantStatus: http.StatusForbidden, wantCode: response.ErrNotFacilitator},
+		{name: "invalid transition", path: "resume", as: facilitator, retErr: games.ErrInvalidTransition, wantStatus: http.StatusConflict, wantCode: response.ErrInvalidTransition},
+		{name: "unknown game", path: "end", as: asAdmin, retErr: games.ErrNotFound, wantStatus: http.StatusNotFound, wantCode: response.ErrGameNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewGameHandler(&fakeService{retErr: tc.retErr}, testSigner)
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /games/{id}/start", h.StartGame)
+			mux.HandleFunc("POST /games/{id}/pause", h.PauseGame)
+			mux.HandleFunc("POST /games/{id}/resume", h.ResumeGame)
+			mux.HandleFunc("POST /games/{id}/end", h.EndGame)
+
+			req := httptest.NewRequest("POST", "/games/"+id.String()+"/"+tc.path, nil)
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, tc.as(req))
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d (body %s)", rr.Code, tc.wantStatus, rr.Body.String())
+			}
+			if !strings.Contains(rr.Body.String(), tc.wantCode) {
+				t.Errorf("body = %s; want %s", rr.Body.String(), tc.wantCode)
+			}
+		})
+	}
+}
+
+func TestGameHandler_NotRunning(t *testing.T) {
+	id := uuid.New()
+	h := NewGameHandler(&fakeService{retErr: games.ErrGameNotRunning}, testSigner)
+	mux := http.NewServeMux()
+	mux.HandleFunc("PATCH /games/{id}", h.UpdateGame)
+	mux.HandleFunc("POST /games/{id}/roll", h.RollDice)
+	mux.HandleFunc("POST /games/{id}/bot", h.PlayBotDay)
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest("PATCH", "/games/"+id.String(), strings.NewReader(`{"day":2}`)),
+		httptest.NewRequest("POST", "/games/"+id.String()+"/roll", strings.NewReader(fmt.Sprintf(`{"playerId":%q,"cardId":%q}`, uuid.New(), uuid.New()))),
+		httptest.NewRequest("POST", "/games/"+id.String()+"/bot", strings.NewReader(`{"strategy":"strict-wip"}`)),
+	} {
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, asAdmin(req))
+		if rr.Code != http.StatusConflict || !strings.Contains(rr.Body.String(), response.ErrGameNotRunning) {
+			t.Errorf("%s %s = %d %s; want 409 %s", req.Method, req.URL.Path, rr.Code, rr.Body.String(), response.ErrGameNotRunning)
+		}
+	}
+}
+
+func TestGameHandler_ResetGame(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name       string
+		as         func(*http.Request) *http.Request
+		retErr     error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "facilitator", as: func(r *http.Request) *http.Request { return asPlayer(r, id, true) }, wantStatus: http.StatusOK, wantCode: `"status":"lobby"`},
+		{name: "player", as: func(r *http.Request) *http.Request { return asPlayer(r, id, false) }, wantStatus: http.StatusForbidden, wantCode: response.ErrNotFacilitator},
+		{name: "ended", as: asAdmin, retErr: games.ErrGameEnded, wantStatus: http.StatusConflict, wantCode: response.ErrGameEnded},
+		{name: "no initial board", as: asAdmin, retErr: games.ErrSnapshotNotFound, wantStatus: http.StatusNotFound, wantCode: response.ErrSnapshotNotFound},
+		{name: "unknown game", as: asAdmin, retErr: games.ErrNotFound, wantStatus: http.StatusNotFound, wantCode: response.ErrGameNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeService{retErr: tc.retErr}
+			h := NewGameHandler(svc, testSigner)
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /games/{id}/reset", h.ResetGame)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, tc.as(httptest.NewRequest("POST", "/games/"+id.String()+"/reset", nil)))
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d (body %s)", rr.Code, tc.wantStatus, rr.Body.String())
+			}
+			if !strings.Contains(rr.Body.String(), tc.wantCode) {
+				t.Errorf("body = %s; want %s", rr.Body.String(), tc.wantCode)
+			}
+		})
+	}
+}
+
+func TestGameHandler_ListGames_Leaderboard(t *testing.T) {
+	day := func(s string) time.Time {
+		d, _ := time.Parse(time.DateOnly, s)
+		return d
+	}
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantScore  string
+		wantFilter models.LeaderboardFilter
+		wantCode   string
+	}{
+		{"default score", "view=leaderboard", http.StatusOK, "profit", models.LeaderboardFilter{}, `"profit":12400`},
+		{"filtered", "view=leaderboard&score=lead_time&scenario=default&from=2026-01-01&to=2026-01-31", http.StatusOK, "lead_time",
+			models.LeaderboardFilter{Scenario: "default", From: day("2026-01-01"), To: day("2026-02-01")}, `"rank":1`},
+		{"unknown score", "view=leaderboard&score=fun", http.StatusBadRequest, "", models.LeaderboardFilter{}, response.ErrInvalidScore},
+		{"invalid date", "view=leaderboard&from=01/02/2026", http.StatusBadRequest, "", models.LeaderboardFilter{}, response.ErrInvalidDate},
+		{"unknown view", "view=chart", http.StatusBadRequest, "", models.LeaderboardFilter{}, response.ErrInvalidView},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeService{}
+			h := NewGameHandler(svc, testSigner)
+
+			rr := httptest.NewRecorder()
+			h.ListGames(rr, httptest.NewRequest("GET", "/games?"+tc.query, nil))
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d (body %s)", rr.Code, tc.wantStatus, rr.Body.String())
+			}
+			if !strings.Contains(rr.Body.String(), tc.wantCode) {
+				t.Errorf("body = %s; want %s", rr.Body.String(), tc.wantCode)
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			if svc.calledScore.Name != tc.wantScore || svc.calledFilter != tc.wantFilter {
+				t.Errorf("Leaderboard(%s, %+v); want (%s, %+v)", svc.calledScore.Name, svc.calledFilter, tc.wantScore, tc.wantFilter)
+			}
+		})
 	}
 }
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/apikeys"
+	"github.com/Germanicus1/kanban-sim/backend/internal/auth"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/Germanicus1/kanban-sim/backend/internal/response"
+	"github.com/google/uuid"
+)
+
+type KeysHandler struct {
+	Service apikeys.ServiceInterface
+}
+
+func NewKeysHandler(svc apikeys.ServiceInterface) *KeysHandler {
+	return &KeysHandler{Service: svc}
+}
+
+// CreateKey handles POST /keys.
+// @Summary      Create an API key
+// @Description  Creates a key for an integration. Scopes are read (look at games), play (also make moves), facilitate (also create, import and run games) and admin (also manage keys); each includes the ones before it. The key is returned once and cannot be read back.
+// @Tags         keys
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CreateAPIKeyRequest  true  "Name, scopes and optional expiry"
+// @Success      201      {object}  response.NewAPIKeyResponse
+// @Failure      400      {object}  response.ErrorResponse  "Invalid JSON, missing name, unknown scope or expiry in the past"
+// @Failure      403      {object}  response.ErrorResponse  "Missing or invalid key, or not an admin key"
+// @Failure      405      {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      500      {object}  response.ErrorResponse  "Internal server error"
+// @Security     BearerAuth
+// @Router       /keys [post]
+func (h *KeysHandler) CreateKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	var req models.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidJSON)
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrMissingRequiredField)
+		return
+	}
+	if len(req.Scopes) == 0 {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidScope)
+		return
+	}
+	scopes := make([]auth.Scope, 0, len(req.Scopes))
+	for _, s := range req.Scopes {
+		scope, err := auth.ParseScope(s)
+		if err != nil {
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidScope)
+			return
+		}
+		scopes = append(scopes, scope)
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidExpiry)
+		return
+	}
+
+	key, err := h.Service.CreateKey(r.Context(), req.Name, scopes, req.ExpiresAt)
+	if err != nil {
+		log.Printf("CreateKey: failed to create key %q: %v", req.Name, err)
+		response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	response.RespondWithData(w, key)
+}
+
+// ListKeys handles GET /keys.
+// @Summary      List API keys
+// @Description  Returns every key, revoked and expired ones included, with its scopes and last use. The keys themselves are not stored and not shown.
+// @Tags         keys
+// @Produce      json
+// @Success      200  {object}  response.APIKeysResponse
+// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid key, or not an admin key"
+// @Failure      405  {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      500  {object}  response.ErrorResponse  "Internal server error"
+// @Security     BearerAuth
+// @Router       /keys [get]
+func (h *KeysHandler) ListKeys(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	keys, err := h.Service.ListKeys(r.Context())
+	if err != nil {
+		log.Printf("ListKeys: failed to list keys: %v", err)
+		response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		return
+	}
+	response.RespondWithData(w, keys)
+}
+
+// RevokeKey handles DELETE /keys/{id}.
+// @Summary      Revoke an API key
+// @Description  Turns a key away from now on. The key stays in the list, marked revoked.
+// @Tags         keys
+// @Param        id   path  string  true  "Key ID"  Format(uuid)
+// @Success      204  "No Content"
+// @Failure      400  {object}  response.ErrorResponse  "Invalid key ID"
+// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid key, or not an admin key"
+// @Failure      404  {object}  response.ErrorResponse  "Key not found or already revoked"
+// @Failure      405  {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      500  {object}  response.ErrorResponse  "Internal server error"
+// @Security     BearerAuth
+// @Router       /keys/{id} [delete]
+func (h *KeysHandler) RevokeKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		w.Header().Set("Allow", http.MethodDelete)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidKeyID)
+		return
+	}
+
+	if err := h.Service.RevokeKey(r.Context(), id); err != nil {
+		keyServiceError(w, "RevokeKey", id, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RotateKey handles POST /keys/{id}/rotate.
+// @Summary      Rotate an API key
+// @Description  Creates a new key with the name, scopes and expiry of the given one and revokes the old key. The new key is returned once.
+// @Tags         keys
+// @Produce      json
+// @Param        id   path      string  true  "Key ID"  Format(uuid)
+// @Success      201  {object}  response.NewAPIKeyResponse
+// @Failure      400  {object}  response.ErrorResponse  "Invalid key ID"
+// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid key, or not an admin key"
+// @Failure      404  {object}  response.ErrorResponse  "Key not found or already revoked"
+// @Failure      405  {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      500  {object}  response.ErrorResponse  "Internal server error"
+// @Security     BearerAuth
+// @Router       /keys/{id}/rotate [post]
+func (h *KeysHandler) RotateKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidKeyID)
+		return
+	}
+
+	key, err := h.Service.RotateKey(r.Context(), id)
+	if err != nil {
+		keyServiceError(w, "RotateKey", id, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	response.RespondWithData(w, key)
+}
+
+// keyServiceError answers a request on key id that the service refused.
+func keyServiceError(w http.ResponseWriter, op string, id uuid.UUID, err error) {
+	if errors.Is(err, apikeys.ErrNotFound) {
+		response.RespondWithError(w, http.StatusNotFound, response.ErrKeyNotFound)
+		return
+	}
+	log.Printf("%s: failed on key %s: %v", op, id, err)
+	response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/apikeys"
+	"github.com/Germanicus1/kanban-sim/backend/internal/auth"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/Germanicus1/kanban-sim/backend/internal/response"
+	"github.com/google/uuid"
+)
+
+// fakeKeys implements apikeys.ServiceInterface.
+type fakeKeys struct {
+	calledName   string
+	calledScopes []auth.Scope
+	calledID     uuid.UUID
+	retErr       error
+}
+
+func (f *fakeKeys) CreateKey(ctx context.Context, name string, scopes []auth.Scope, expiresAt *time.Time) (models.NewAPIKey, error) {
+	f.calledName, f.calledScopes = name, scopes
+	return models.NewAPIKey{APIKey: models.APIKey{ID: uuid.New(), Name: name}, Key: "ksk_new"}, f.retErr
+}
+
+func (f *fakeKeys) ListKeys(ctx context.Context) ([]models.APIKey, error) {
+	return []models.APIKey{{ID: uuid.New(), Name: "LMS"}}, f.retErr
+}
+
+func (f *fakeKeys) RevokeKey(ctx context.Context, id uuid.UUID) error {
+	f.calledID = id
+	return f.retErr
+}
+
+func (f *fakeKeys) RotateKey(ctx context.Context, id uuid.UUID) (models.NewAPIKey, error) {
+	f.calledID = id
+	return models.NewAPIKey{Key: "ksk_rotated"}, f.retErr
+}
+
+func TestKeysHandler_CreateKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantCode   string
+	}{
+		{"valid", `{"name":"LMS","scopes":["read","play"]}`, http.StatusCreated, ""},
+		{"with expiry", `{"name":"LMS","scopes":["read"],"expires_at":"2099-01-01T00:00:00Z"}`, http.StatusCreated, ""},
+		{"invalid JSON", `{"name":`, http.StatusBadRequest, response.ErrInvalidJSON},
+		{"no name", `{"name":" ","scopes":["read"]}`, http.StatusBadRequest, response.ErrMissingRequiredField},
+		{"no scopes", `{"name":"LMS"}`, http.StatusBadRequest, response.ErrInvalidScope},
+		{"unknown scope", `{"name":"LMS","scopes":["write"]}`, http.StatusBadRequest, response.ErrInvalidScope},
+		{"expired", `{"name":"LMS","scopes":["read"],"expires_at":"2001-01-01T00:00:00Z"}`, http.StatusBadRequest, response.ErrInvalidExpiry},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeKeys{}
+			h := NewKeysHandler(svc)
+
+			req := httptest.NewRequest("POST", "/keys", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+			h.CreateKey(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d (body %s)", rr.Code, tc.wantStatus, rr.Body.String())
+			}
+			if tc.wantCode != "" && !strings.Contains(rr.Body.String(), tc.wantCode) {
+				t.Errorf("body = %s; want error %s", rr.Body.String(), tc.wantCode)
+			}
+			if tc.wantStatus == http.StatusCreated && (svc.calledName != "LMS" || !strings.Contains(rr.Body.String(), `"key":"ksk_new"`)) {
+				t.Errorf("created %q, body %s; want LMS and the key shown", svc.calledName, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestKeysHandler_RevokeKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		retErr     error
+		wantStatus int
+	}{
+		{"revoked", uuid.NewString(), nil, http.StatusNoContent},
+		{"bad ID", "nope", nil, http.StatusBadRequest},
+		{"unknown", uuid.NewString(), apikeys.ErrNotFound, http.StatusNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewKeysHandler(&fakeKeys{retErr: tc.retErr})
+			mux := http.NewServeMux()
+			mux.HandleFunc("DELETE /keys/{id}", h.RevokeKey)
+
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, httptest.NewRequest("DELETE", "/keys/"+tc.id, nil))
+
+			if rr.Code != tc.wantStatus {
+				t.Errorf("status = %d; want %d", rr.Code, tc.wantStatus)
+			}
+		})
+	}
+}
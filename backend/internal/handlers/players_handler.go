@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/Germanicus1/kanban-sim/backend/internal/auth"
 	"github.com/Germanicus1/kanban-sim/backend/internal/models"
 	"github.com/Germanicus1/kanban-sim/backend/internal/players"
 	"github.com/Germanicus1/kanban-sim/backend/internal/response"
@@ -14,10 +15,11 @@ import (
 // GameHandler groups your player endpoints.
 type PlayerHandler struct {
 	Service players.ServiceInterface
+	Tokens  *auth.Signer
 }
 
-func NewPlayerHandler(svc players.ServiceInterface) *PlayerHandler {
-	return &PlayerHandler{Service: svc}
+func NewPlayerHandler(svc players.ServiceInterface, tokens *auth.Signer) *PlayerHandler {
+	return &PlayerHandler{Service: svc, Tokens: tokens}
 }
 
 type Player struct {
@@ -27,19 +29,19 @@ type Player struct {
 	JoinedAt string    `json:"joined_at,omitempty"`
 }
 
-// CreatePlayer creates a new player in a game.
+// CreatePlayer creates a new player in a game and hands out their token.
 // @Summary      Create a new player
 // @Description.markdown player_create
 // @Tags         players
 // @Accept       json
 // @Produce      json
 // @Param        payload  body      models.CreatePlayerRequest  true  "Player creation payload"
-// @Success      200      {string}  string                     "Created player UUID"
+// @Success      200      {object}  response.PlayerTokenResponse  "Created player and their token"
 // @Failure      400      {object}  response.ErrorResponse     "Invalid game ID or player name"
-// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token"
+// @Failure      404      {object}  response.ErrorResponse     "Game not found"
 // @Failure      405      {object}  response.ErrorResponse     "Method not allowed"
+// @Failure      409      {object}  response.ErrorResponse     "Game has ended"
 // @Failure      500      {object}  response.ErrorResponse     "Internal server error"
-// @Security    BearerAuth
 // @Router       /players [post]
 func (h *PlayerHandler) CreatePlayer(w http.ResponseWriter, r *http.Request) {
 	// Only accept POST
@@ -59,14 +61,26 @@ func (h *PlayerHandler) CreatePlayer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := h.Service.CreatePlayer(r.Context(), payload.GameID, payload.Name)
+	playerID, err := h.Service.CreatePlayer(r.Context(), payload.GameID, payload.Name)
 	if err != nil {
+		if errors.Is(err, players.ErrGameEnded) {
+			response.RespondWithError(w, http.StatusConflict, response.ErrGameEnded)
+			return
+		}
+		if errors.Is(err, players.ErrGameNotFound) {
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+			return
+		}
 		status, code := response.MapPostgresError(err)
 		response.RespondWithError(w, status, code)
 		return
 	}
 
-	response.RespondWithData(w, player)
+	response.RespondWithData(w, models.PlayerToken{
+		PlayerID: playerID,
+		GameID:   payload.GameID,
+		Token:    h.Tokens.Issue(playerID, payload.GameID),
+	})
 }
 
 // GetPlayerByID retrieves a player by UUID.
@@ -103,7 +117,7 @@ func (h *PlayerHandler) GetPlayerByID(w http.ResponseWriter, r *http.Request) {
 
 	player, err := h.Service.GetPlayerByID(r.Context(), playerID)
 	if err != nil {
-		if errors.Is(err, errors.New(response.ErrPlayerNotFound)) {
+		if errors.Is(err, players.ErrNotFound) {
 			response.RespondWithError(w, http.StatusNotFound, response.ErrPlayerNotFound)
 		} else {
 			status, code := response.MapPostgresError(err)
@@ -112,6 +126,10 @@ func (h *PlayerHandler) GetPlayerByID(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	if !canAccessGame(r, player.GameID) {
+		response.RespondWithError(w, http.StatusForbidden, response.ErrForbidden)
+		return
+	}
 
 	response.RespondWithData(w, player)
 }
@@ -125,7 +143,9 @@ func (h *PlayerHandler) GetPlayerByID(w http.ResponseWriter, r *http.Request) {
 // @Success      200      {string}  string                      "Update successful (empty response)"
 // @Failure      400      {object}  response.ErrorResponse     "Invalid player ID or name"
 // @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token"
+// @Failure      404      {object}  response.ErrorResponse     "Player not found"
 // @Failure      405      {object}  response.ErrorResponse     "Method not allowed"
+// @Failure      409      {object}  response.ErrorResponse     "Game has ended"
 // @Failure      500      {object}  response.ErrorResponse     "Internal server error"
 // @Security    BearerAuth
 // @Router       /players [patch]
@@ -146,7 +166,19 @@ func (h *PlayerHandler) UpdatePlayer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, ok := h.authorizePlayer(w, r, payload.ID); !ok {
+		return
+	}
+
 	if err := h.Service.UpdatePlayer(r.Context(), payload.ID, payload.Name); err != nil {
+		if errors.Is(err, players.ErrNotFound) {
+			response.RespondWithError(w, http.StatusNotFound, response.ErrPlayerNotFound)
+			return
+		}
+		if errors.Is(err, players.ErrGameEnded) {
+			response.RespondWithError(w, http.StatusConflict, response.ErrGameEnded)
+			return
+		}
 		status, code := response.MapPostgresError(err)
 		response.RespondWithError(w, status, code)
 		return
@@ -157,7 +189,7 @@ func (h *PlayerHandler) UpdatePlayer(w http.ResponseWriter, r *http.Request) {
 
 // DeletePlayer removes a player by UUID.
 // @Summary      Delete a player
-// @Description  Deletes the player identified by the given UUID.
+// @Description  Deletes the player identified by the given UUID. Players may remove themselves; removing another player takes the game's facilitator.
 // @Tags         players
 // @Accept       json
 // @Produce      json
@@ -167,6 +199,7 @@ func (h *PlayerHandler) UpdatePlayer(w http.ResponseWriter, r *http.Request) {
 // @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token"
 // @Failure      404      {object}  response.ErrorResponse      "Player not found"
 // @Failure      405      {object}  response.ErrorResponse      "Method not allowed"
+// @Failure      409      {object}  response.ErrorResponse      "Game has ended"
 // @Failure      500      {object}  response.ErrorResponse      "Internal server error"
 // @Security    BearerAuth
 // @Router       /players [delete]
@@ -186,9 +219,19 @@ func (h *PlayerHandler) DeletePlayer(w http.ResponseWriter, r *http.Request) {
 		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidPlayerID)
 		return
 	}
+	player, ok := h.authorizePlayer(w, r, payload.ID)
+	if !ok {
+		return
+	}
+	// players may leave; removing somebody else is up to the facilitator
+	if caller, _ := auth.CallerFrom(r.Context()); caller.PlayerID != player.ID && !requireFacilitator(w, r, player.GameID) {
+		return
+	}
 	if err := h.Service.DeletePlayer(r.Context(), payload.ID); err != nil {
 		if errors.Is(err, players.ErrNotFound) {
 			response.RespondWithError(w, http.StatusNotFound, response.ErrPlayerNotFound)
+		} else if errors.Is(err, players.ErrGameEnded) {
+			response.RespondWithError(w, http.StatusConflict, response.ErrGameEnded)
 		} else {
 			status, code := response.MapPostgresError(err)
 			response.RespondWithError(w, status, code)
@@ -203,7 +246,7 @@ func (h *PlayerHandler) DeletePlayer(w http.ResponseWriter, r *http.Request) {
 // @Description  Returns a list of players belonging to the given game UUID.
 // @Tags         players
 // @Produce      json
-// @Param        game_id   path      string           true  "Game ID"  Format(uuid)
+// @Param        id        path      string           true  "Game ID"  Format(uuid)
 // @Success      200       {array}   models.Player    "List of players"
 // @Failure      400       {object}  response.ErrorResponse  "Invalid or missing game ID"
 // @Failure      403  {object}  response.ErrorResponse  "Missing or invalid token"
@@ -211,14 +254,14 @@ func (h *PlayerHandler) DeletePlayer(w http.ResponseWriter, r *http.Request) {
 // @Failure      405       {object}  response.ErrorResponse  "Method not allowed"
 // @Failure      500       {object}  response.ErrorResponse  "Internal server error"
 // @Security    BearerAuth
-// @Router       /games/{game_id}/players [get]
+// @Router       /games/{id}/players [get]
 func (h *PlayerHandler) ListPlayersByGameID(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		w.Header().Set("Allow", http.MethodGet)
 		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
 		return
 	}
-	gameIDStr := r.PathValue("game_id")
+	gameIDStr := r.PathValue("id")
 	if gameIDStr == "" {
 		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidGameID)
 		return
@@ -240,3 +283,28 @@ func (h *PlayerHandler) ListPlayersByGameID(w http.ResponseWriter, r *http.Reque
 	}
 	response.RespondWithData(w, players)
 }
+
+// authorizePlayer looks up a player and checks the caller may act on their
+// game: look at it for GET, play it otherwise. It answers the request itself
+// when not.
+func (h *PlayerHandler) authorizePlayer(w http.ResponseWriter, r *http.Request, playerID uuid.UUID) (*models.Player, bool) {
+	player, err := h.Service.GetPlayerByID(r.Context(), playerID)
+	if err != nil {
+		if errors.Is(err, players.ErrNotFound) {
+			response.RespondWithError(w, http.StatusNotFound, response.ErrPlayerNotFound)
+		} else {
+			status, code := response.MapPostgresError(err)
+			response.RespondWithError(w, status, code)
+		}
+		return nil, false
+	}
+	allowed := canAccessGame(r, player.GameID)
+	if r.Method != http.MethodGet {
+		allowed = canPlayGame(r, player.GameID)
+	}
+	if !allowed {
+		response.RespondWithError(w, http.StatusForbidden, response.ErrForbidden)
+		return nil, false
+	}
+	return player, true
+}
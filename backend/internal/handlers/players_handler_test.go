@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,14 +10,25 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/Germanicus1/kanban-sim/backend/internal/auth"
 	"github.com/Germanicus1/kanban-sim/backend/internal/handlers"
 	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/Germanicus1/kanban-sim/backend/internal/players"
+	"github.com/Germanicus1/kanban-sim/backend/internal/response"
 	"github.com/google/uuid"
 )
 
+var signer = auth.NewSigner([]byte("test secret"))
+
+// asCaller returns r as made by c.
+func asCaller(r *http.Request, c auth.Caller) *http.Request {
+	return r.WithContext(auth.WithCaller(r.Context(), c))
+}
+
 // fakeService implements players.ServiceInterface.
 type fakeService struct {
 	calledID uuid.UUID
+	gameID   uuid.UUID // game of every player; a new one per call if nil
 	retErr   error
 }
 
@@ -35,7 +47,11 @@ func (f *fakeService) GetPlayerByID(ctx context.Context, id uuid.UUID) (*models.
 	if f.retErr != nil {
 		return nil, f.retErr
 	}
-	return &models.Player{ID: id, Name: "Test Player", GameID: uuid.New()}, nil
+	gameID := f.gameID
+	if gameID == uuid.Nil {
+		gameID = uuid.New()
+	}
+	return &models.Player{ID: id, Name: "Test Player", GameID: gameID}, nil
 }
 func (f *fakeService) UpdatePlayer(ctx context.Context, id uuid.UUID, name string) error {
 	f.calledID = id
@@ -51,7 +67,7 @@ func (f *fakeService) ListPlayersByGameID(ctx context.Context, gameID uuid.UUID)
 
 func TestPlayerHandler_CreatePlayer(t *testing.T) {
 	svc := &fakeService{retErr: nil}
-	h := handlers.NewPlayerHandler(svc)
+	h := handlers.NewPlayerHandler(svc, signer)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/players", h.CreatePlayer)
@@ -75,9 +91,24 @@ func TestPlayerHandler_CreatePlayer(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to read response body: %v", err)
 	}
-	playerID := strings.TrimSpace(string(respBytes))
-	if playerID == "" {
-		t.Fatalf("expected player ID to be returned, got empty string")
+	var envelope struct {
+		Data models.PlayerToken `json:"data"`
+	}
+	if err := json.Unmarshal(respBytes, &envelope); err != nil {
+		t.Fatalf("failed to decode response %q: %v", respBytes, err)
+	}
+	got := envelope.Data
+	if got.PlayerID == uuid.Nil || got.GameID != gameID {
+		t.Fatalf("response = %+v; want the new player of game %s", got, gameID)
+	}
+
+	// the token names the player and their game
+	caller, err := signer.Verify(got.Token)
+	if err != nil {
+		t.Fatalf("token does not verify: %v", err)
+	}
+	if caller.PlayerID != got.PlayerID || caller.GameID != gameID {
+		t.Errorf("token caller = %+v; want player %s of game %s", caller, got.PlayerID, gameID)
 	}
 
 	// 3) Verify the service was called with the correct game ID
@@ -89,9 +120,33 @@ func TestPlayerHandler_CreatePlayer(t *testing.T) {
 	}
 }
 
+func TestPlayerHandler_CreatePlayer_GameNotFound(t *testing.T) {
+	h := handlers.NewPlayerHandler(&fakeService{retErr: players.ErrGameNotFound}, signer)
+
+	body := fmt.Sprintf(`{"game_id": %q, "name": "Late Player"}`, uuid.New())
+	rr := httptest.NewRecorder()
+	h.CreatePlayer(rr, httptest.NewRequest("POST", "/players", strings.NewReader(body)))
+
+	if rr.Code != http.StatusNotFound || !strings.Contains(rr.Body.String(), response.ErrGameNotFound) {
+		t.Errorf("joining a missing game = %d %s; want 404 %s", rr.Code, rr.Body.String(), response.ErrGameNotFound)
+	}
+}
+
+func TestPlayerHandler_CreatePlayer_GameEnded(t *testing.T) {
+	h := handlers.NewPlayerHandler(&fakeService{retErr: players.ErrGameEnded}, signer)
+
+	body := fmt.Sprintf(`{"game_id": %q, "name": "Late Player"}`, uuid.New())
+	rr := httptest.NewRecorder()
+	h.CreatePlayer(rr, httptest.NewRequest("POST", "/players", strings.NewReader(body)))
+
+	if rr.Code != http.StatusConflict || !strings.Contains(rr.Body.String(), response.ErrGameEnded) {
+		t.Errorf("joining an ended game = %d %s; want 409 %s", rr.Code, rr.Body.String(), response.ErrGameEnded)
+	}
+}
+
 func TestPlayerHandler_DeletePlayer(t *testing.T) {
 	svc := &fakeService{retErr: nil}
-	h := handlers.NewPlayerHandler(svc)
+	h := handlers.NewPlayerHandler(svc, signer)
 	mux := http.NewServeMux()
 	mux.HandleFunc("/players/", h.DeletePlayer)
 	// Create a request to delete a player with a specific ID
@@ -99,6 +154,7 @@ func TestPlayerHandler_DeletePlayer(t *testing.T) {
 	reqBody := fmt.Sprintf(`{"id":"%s"}`, playerID)
 
 	req := httptest.NewRequest("DELETE", "/players/", strings.NewReader(reqBody))
+	req = asCaller(req, auth.Caller{Admin: true})
 	rr := httptest.NewRecorder()
 	mux.ServeHTTP(rr, req)
 	// 1) Check the status code
@@ -116,9 +172,9 @@ func TestPlayerHandler_DeletePlayer(t *testing.T) {
 
 func TestPlayerHandler_ListPlayersByGameID(t *testing.T) {
 	svc := &fakeService{retErr: nil}
-	h := handlers.NewPlayerHandler(svc)
+	h := handlers.NewPlayerHandler(svc, signer)
 	mux := http.NewServeMux()
-	mux.HandleFunc("GET /games/{game_id}/players", h.ListPlayersByGameID)
+	mux.HandleFunc("GET /games/{id}/players", h.ListPlayersByGameID)
 
 	gameID := uuid.New()
 	req := httptest.NewRequest("GET", "/games/"+gameID.String()+"/players", nil)
@@ -138,3 +194,48 @@ func TestPlayerHandler_ListPlayersByGameID(t *testing.T) {
 		t.Errorf("expected service called with game ID %s, got %s", gameID, svc.calledID)
 	}
 }
+
+func TestPlayerHandler_OtherGamesPlayers(t *testing.T) {
+	gameID := uuid.New()
+	own := auth.Caller{PlayerID: uuid.New(), GameID: gameID}
+	stranger := auth.Caller{PlayerID: uuid.New(), GameID: uuid.New()}
+	facilitator := auth.Caller{PlayerID: uuid.New(), GameID: gameID, Facilitator: true}
+
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		caller     auth.Caller
+		wantStatus int
+	}{
+		{"get own game's player", "GET", "/players/" + uuid.NewString(), "", own, http.StatusOK},
+		{"get other game's player", "GET", "/players/" + uuid.NewString(), "", stranger, http.StatusForbidden},
+		{"rename own game's player", "PATCH", "/players/x", `{"id":"` + uuid.NewString() + `","name":"Bo"}`, own, http.StatusOK},
+		{"rename other game's player", "PATCH", "/players/x", `{"id":"` + uuid.NewString() + `","name":"Bo"}`, stranger, http.StatusForbidden},
+		{"delete other game's player", "DELETE", "/players", `{"id":"` + uuid.NewString() + `"}`, stranger, http.StatusForbidden},
+		{"leave own game", "DELETE", "/players", `{"id":"` + own.PlayerID.String() + `"}`, own, http.StatusOK},
+		{"remove a fellow player", "DELETE", "/players", `{"id":"` + uuid.NewString() + `"}`, own, http.StatusForbidden},
+		{"facilitator removes a player", "DELETE", "/players", `{"id":"` + uuid.NewString() + `"}`, facilitator, http.StatusOK},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeService{gameID: gameID}
+			h := handlers.NewPlayerHandler(svc, signer)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /players/{id}", h.GetPlayerByID)
+			mux.HandleFunc("PATCH /players/{id}", h.UpdatePlayer)
+			mux.HandleFunc("DELETE /players", h.DeletePlayer)
+
+			req := asCaller(httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body)), tc.caller)
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d (body %q)", rr.Code, tc.wantStatus, rr.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/games"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/Germanicus1/kanban-sim/backend/internal/response"
+)
+
+// PositionCard puts a card of a running game at a place in a column.
+// @Summary      Reorder a card
+// @Description  Puts a card at an index of a column, 0 being the top, and returns the new order of that column. The other cards shift to make room, so each column stays numbered from 0 without gaps; an index past the end puts the card at the bottom. Without a column the card stays in its own. A card put into another column is moved there like in play: the move is logged on the current day and a card leaving the backlog is selected. WIP limits are not checked. Blocked cards can be reordered but not moved, and deployed cards cannot be positioned at all. Placements of a game are applied one at a time; a card moved by another request meanwhile is left alone. Bots pull cards top first, so the order is the team's priority.
+// @Tags         games
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string               true  "Game ID"  Format(uuid)
+// @Param        cardId    path      string               true  "Card ID"  Format(uuid)
+// @Param        position  body      models.CardPosition  true  "Target column and index"
+// @Success      200  {object}  response.CardOrderResponse  "New order of the column"
+// @Failure      400  {object}  response.ErrorResponse      "Invalid IDs, body, column or index"
+// @Failure      403  {object}  response.ErrorResponse      "Missing or invalid token"
+// @Failure      404  {object}  response.ErrorResponse      "Game or card not found"
+// @Failure      405  {object}  response.ErrorResponse      "Method not allowed"
+// @Failure      409  {object}  response.ErrorResponse      "Game is not running, or the card is blocked, deployed or was moved meanwhile"
+// @Failure      500  {object}  response.ErrorResponse      "Internal server error"
+// @Security    BearerAuth
+// @Router       /games/{id}/cards/{cardId}/position [post]
+func (h *GameHandler) PositionCard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	gameID, cardID, ok := gameCardIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var pos models.CardPosition
+	if err := json.NewDecoder(r.Body).Decode(&pos); err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidJSON)
+		return
+	}
+
+	order, err := h.Service.PositionCard(r.Context(), gameID, cardID, pos)
+	if err != nil {
+		switch {
+		case errors.Is(err, games.ErrInvalidPosition):
+			response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidPosition)
+		case errors.Is(err, games.ErrNotFound):
+			response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+		case errors.Is(err, games.ErrCardNotFound):
+			response.RespondWithError(w, http.StatusNotFound, response.ErrCardNotFound)
+		case errors.Is(err, games.ErrGameNotRunning):
+			response.RespondWithError(w, http.StatusConflict, response.ErrGameNotRunning)
+		case errors.Is(err, games.ErrCardBlocked):
+			response.RespondWithError(w, http.StatusConflict, response.ErrCardBlocked)
+		case errors.Is(err, games.ErrCardDeployed):
+			response.RespondWithError(w, http.StatusConflict, response.ErrCardDeployed)
+		case errors.Is(err, games.ErrCardMoved):
+			response.RespondWithError(w, http.StatusConflict, response.ErrCardMoved)
+		default:
+			log.Printf("PositionCard: failed to position card %s of game %s: %v", cardID, gameID, err)
+			response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		}
+		return
+	}
+
+	response.RespondWithData(w, order)
+}
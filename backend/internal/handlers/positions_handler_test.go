@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/games"
+	"github.com/Germanicus1/kanban-sim/backend/internal/response"
+	"github.com/google/uuid"
+)
+
+func TestGameHandler_PositionCard(t *testing.T) {
+	tests := []struct {
+		name       string
+		card       string
+		body       string
+		retErr     error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "positioned", card: uuid.NewString(), body: `{"index":2}`, wantStatus: http.StatusOK, wantBody: `"orderIndex":2`},
+		{name: "bad card id", card: "not-a-uuid", body: `{"index":0}`, wantStatus: http.StatusBadRequest, wantBody: response.ErrInvalidCardID},
+		{name: "bad body", card: uuid.NewString(), body: `{`, wantStatus: http.StatusBadRequest, wantBody: response.ErrInvalidJSON},
+		{name: "invalid position", card: uuid.NewString(), body: `{"index":-1}`, retErr: games.ErrInvalidPosition, wantStatus: http.StatusBadRequest, wantBody: response.ErrInvalidPosition},
+		{name: "unknown card", card: uuid.NewString(), body: `{"index":0}`, retErr: games.ErrCardNotFound, wantStatus: http.StatusNotFound, wantBody: response.ErrCardNotFound},
+		{name: "blocked card", card: uuid.NewString(), body: `{"columnId":"` + uuid.NewString() + `","index":0}`, retErr: games.ErrCardBlocked, wantStatus: http.StatusConflict, wantBody: response.ErrCardBlocked},
+		{name: "moved meanwhile", card: uuid.NewString(), body: `{"index":0}`, retErr: games.ErrCardMoved, wantStatus: http.StatusConflict, wantBody: response.ErrCardMoved},
+		{name: "game not running", card: uuid.NewString(), body: `{"index":0}`, retErr: games.ErrGameNotRunning, wantStatus: http.StatusConflict, wantBody: response.ErrGameNotRunning},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewGameHandler(&fakeService{retErr: tc.retErr}, testSigner)
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /games/{id}/cards/{cardId}/position", h.PositionCard)
+
+			gameID := uuid.New()
+			req := asPlayer(httptest.NewRequest("POST", "/games/"+gameID.String()+"/cards/"+tc.card+"/position", strings.NewReader(tc.body)), gameID, false)
+			rr := httptest.NewRecorder()
+
+			mux.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d", rr.Code, tc.wantStatus)
+			}
+			if !strings.Contains(rr.Body.String(), tc.wantBody) {
+				t.Errorf("body = %q; want it to contain %q", rr.Body.String(), tc.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/games"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/Germanicus1/kanban-sim/backend/internal/response"
+	"github.com/Germanicus1/kanban-sim/backend/internal/sessions"
+	"github.com/google/uuid"
+)
+
+type SessionsHandler struct {
+	Service sessions.ServiceInterface
+}
+
+func NewSessionsHandler(svc sessions.ServiceInterface) *SessionsHandler {
+	return &SessionsHandler{Service: svc}
+}
+
+// CreateSession handles POST /sessions.
+// @Summary      Create a training session
+// @Description  Creates a session and one game with the default board for each of its teams, named "Team 1", "Team 2" and so on. All games get the same dice seed, the one given or a random one, so that every team faces the same dice: a card rolls alike in every game, and so do the players who joined their games in the same order. The games start in the lobby without a facilitator.
+// @Tags         sessions
+// @Accept       json
+// @Produce      json
+// @Param        session  body      models.CreateSessionRequest  true  "Name, number of teams and optional dice seed"
+// @Success      201      {object}  response.SessionResponse
+// @Failure      400      {object}  response.ErrorResponse  "Invalid JSON, missing name or invalid number of teams"
+// @Failure      403      {object}  response.ErrorResponse  "Missing or invalid key, or not a facilitate key"
+// @Failure      405      {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      500      {object}  response.ErrorResponse  "Internal server error"
+// @Security     BearerAuth
+// @Router       /sessions [post]
+func (h *SessionsHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	var req models.CreateSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidJSON)
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrMissingRequiredField)
+		return
+	}
+
+	cfg, err := defaultGameConfig()
+	if err != nil {
+		log.Printf("CreateSession: failed to load board config: %v", err)
+		response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		return
+	}
+	cfg.Seed = req.Seed
+
+	session, err := h.Service.CreateSession(r.Context(), req.Name, req.Teams, cfg)
+	if errors.Is(err, sessions.ErrInvalidTeams) {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidTeams)
+		return
+	}
+	if err != nil {
+		log.Printf("CreateSession: failed to create session %q: %v", req.Name, err)
+		response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	response.RespondWithData(w, session)
+}
+
+// GetSessionDashboard handles GET /sessions/{id}/dashboard.
+// @Summary      Compare the teams of a session
+// @Description  Returns, for every team of the session, its game's status and current day with the WIP, cards deployed, throughput, lead time and profit of the days played so far. Profit is worked out with the card values and team of the standard scenario, so that all teams are measured alike.
+// @Tags         sessions
+// @Produce      json
+// @Param        id   path      string  true  "Session ID"  Format(uuid)
+// @Success      200  {object}  response.SessionDashboardResponse
+// @Failure      400  {object}  response.ErrorResponse  "Invalid session ID"
+// @Failure      403  {object}  response.ErrorResponse  "Missing or invalid key"
+// @Failure      404  {object}  response.ErrorResponse  "Session not found"
+// @Failure      405  {object}  response.ErrorResponse  "Method not allowed"
+// @Failure      500  {object}  response.ErrorResponse  "Internal server error"
+// @Security     BearerAuth
+// @Router       /sessions/{id}/dashboard [get]
+func (h *SessionsHandler) GetSessionDashboard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		response.RespondWithError(w, http.StatusMethodNotAllowed, response.ErrMethodNotAllowed)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		response.RespondWithError(w, http.StatusBadRequest, response.ErrInvalidSessionID)
+		return
+	}
+
+	d, err := h.Service.GetDashboard(r.Context(), id)
+	switch {
+	case err == nil:
+		response.RespondWithData(w, d)
+	case errors.Is(err, sessions.ErrNotFound):
+		response.RespondWithError(w, http.StatusNotFound, response.ErrSessionNotFound)
+	case errors.Is(err, games.ErrNotFound):
+		// a game deleted while the dashboard was being put together
+		response.RespondWithError(w, http.StatusNotFound, response.ErrGameNotFound)
+	default:
+		log.Printf("GetSessionDashboard: failed to compare session %s: %v", id, err)
+		response.RespondWithError(w, http.StatusInternalServerError, response.ErrInternalServerError)
+	}
+}
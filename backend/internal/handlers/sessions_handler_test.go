@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/Germanicus1/kanban-sim/backend/internal/response"
+	"github.com/Germanicus1/kanban-sim/backend/internal/sessions"
+	"github.com/google/uuid"
+)
+
+// fakeSessions implements sessions.ServiceInterface.
+type fakeSessions struct {
+	calledName  string
+	calledTeams int
+	calledCfg   models.BoardConfig
+	calledID    uuid.UUID
+	retErr      error
+}
+
+func (f *fakeSessions) CreateSession(ctx context.Context, name string, teams int, cfg models.BoardConfig) (models.Session, error) {
+	f.calledName, f.calledTeams, f.calledCfg = name, teams, cfg
+	return models.Session{ID: uuid.New(), Name: name, Seed: cfg.Seed}, f.retErr
+}
+
+func (f *fakeSessions) GetDashboard(ctx context.Context, id uuid.UUID) (models.SessionDashboard, error) {
+	f.calledID = id
+	return models.SessionDashboard{SessionID: id, Teams: []models.TeamStanding{{Team: "Team 1", Profit: 850}}}, f.retErr
+}
+
+func TestSessionsHandler_CreateSession(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		retErr     error
+		wantStatus int
+		wantCode   string
+	}{
+		{"valid", `{"name":"Monday training","teams":4,"seed":42}`, nil, http.StatusCreated, ""},
+		{"invalid JSON", `{"name":`, nil, http.StatusBadRequest, response.ErrInvalidJSON},
+		{"no name", `{"name":" ","teams":4}`, nil, http.StatusBadRequest, response.ErrMissingRequiredField},
+		{"invalid teams", `{"name":"Monday training","teams":0}`, fmt.Errorf("%w: 0", sessions.ErrInvalidTeams), http.StatusBadRequest, response.ErrInvalidTeams},
+		{"service error", `{"name":"Monday training","teams":4}`, fmt.Errorf("boom"), http.StatusInternalServerError, response.ErrInternalServerError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeSessions{retErr: tc.retErr}
+			h := NewSessionsHandler(svc)
+
+			req := httptest.NewRequest("POST", "/sessions", strings.NewReader(tc.body))
+			rr := httptest.NewRecorder()
+			h.CreateSession(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d (body %s)", rr.Code, tc.wantStatus, rr.Body.String())
+			}
+			if tc.wantCode != "" && !strings.Contains(rr.Body.String(), tc.wantCode) {
+				t.Errorf("body = %s; want error %s", rr.Body.String(), tc.wantCode)
+			}
+			if tc.wantStatus != http.StatusCreated {
+				return
+			}
+			if svc.calledName != "Monday training" || svc.calledTeams != 4 || svc.calledCfg.Seed != 42 || len(svc.calledCfg.Cards) == 0 {
+				t.Errorf("CreateSession(%q, %d, seed %d, %d cards); want the default board for 4 teams with seed 42",
+					svc.calledName, svc.calledTeams, svc.calledCfg.Seed, len(svc.calledCfg.Cards))
+			}
+			if !strings.Contains(rr.Body.String(), `"seed":42`) {
+				t.Errorf("body = %s; want the session with its seed", rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestSessionsHandler_GetSessionDashboard(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name       string
+		id         string
+		retErr     error
+		wantStatus int
+		wantCode   string
+	}{
+		{"valid", id.String(), nil, http.StatusOK, ""},
+		{"invalid ID", "not-a-uuid", nil, http.StatusBadRequest, response.ErrInvalidSessionID},
+		{"unknown session", id.String(), sessions.ErrNotFound, http.StatusNotFound, response.ErrSessionNotFound},
+		{"service error", id.String(), fmt.Errorf("boom"), http.StatusInternalServerError, response.ErrInternalServerError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeSessions{retErr: tc.retErr}
+			h := NewSessionsHandler(svc)
+
+			req := httptest.NewRequest("GET", "/sessions/"+tc.id+"/dashboard", nil)
+			req.SetPathValue("id", tc.id)
+			rr := httptest.NewRecorder()
+			h.GetSessionDashboard(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("status = %d; want %d (body %s)", rr.Code, tc.wantStatus, rr.Body.String())
+			}
+			if tc.wantCode != "" && !strings.Contains(rr.Body.String(), tc.wantCode) {
+				t.Errorf("body = %s; want error %s", rr.Body.String(), tc.wantCode)
+			}
+			if tc.wantStatus == http.StatusOK && (svc.calledID != id || !strings.Contains(rr.Body.String(), `"profit":850`)) {
+				t.Errorf("dashboard of %s, body %s; want the teams of %s", svc.calledID, rr.Body.String(), id)
+			}
+		})
+	}
+}
@@ -1,9 +1,43 @@
 package handlers
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/auth"
+	"github.com/Germanicus1/kanban-sim/backend/internal/response"
+	"github.com/google/uuid"
+)
 
 func enableCORS(w http.ResponseWriter) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
 	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
 }
+
+// canAccessGame reports whether the caller of r may look at a game.
+func canAccessGame(r *http.Request, gameID uuid.UUID) bool {
+	caller, ok := auth.CallerFrom(r.Context())
+	return ok && caller.CanAccess(gameID)
+}
+
+// canPlayGame reports whether the caller of r may make moves in a game.
+func canPlayGame(r *http.Request, gameID uuid.UUID) bool {
+	caller, ok := auth.CallerFrom(r.Context())
+	return ok && caller.CanPlay(gameID)
+}
+
+// canFacilitate reports whether the caller of r may run a game.
+func canFacilitate(r *http.Request, gameID uuid.UUID) bool {
+	caller, ok := auth.CallerFrom(r.Context())
+	return ok && caller.CanFacilitate(gameID)
+}
+
+// requireFacilitator checks that the caller of r may run a game. It answers
+// the request itself when not.
+func requireFacilitator(w http.ResponseWriter, r *http.Request, gameID uuid.UUID) bool {
+	if !canFacilitate(r, gameID) {
+		response.RespondWithError(w, http.StatusForbidden, response.ErrNotFacilitator)
+		return false
+	}
+	return true
+}
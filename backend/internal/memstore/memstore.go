@@ -0,0 +1,332 @@
+// Package memstore keeps the tables of the in-memory repositories. The
+// repositories of all packages share one Store, so that deleting a game
+// removes its columns, cards, players and events just like the foreign keys
+// of the SQL schema do.
+package memstore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Game is a row of the games table.
+type Game struct {
+	ID            uuid.UUID
+	CreatedAt     time.Time
+	Day           int
+	ParentGameID  *uuid.UUID
+	ForkedAtDay   *int
+	Seed          int64
+	FacilitatorID *uuid.UUID
+	Status        string
+	Scenario      string
+	TestFailure   float64
+	Rework        float64
+	EscapedDefect float64
+	DefectEffort  float64
+	DeletedAt     time.Time // zero unless soft-deleted
+}
+
+// EffortType is a row of the effort_types table.
+type EffortType struct {
+	ID         uuid.UUID
+	GameID     uuid.UUID
+	Title      string
+	OrderIndex int
+}
+
+// Column is a row of the columns table.
+type Column struct {
+	ID         uuid.UUID
+	GameID     uuid.UUID
+	ParentID   *uuid.UUID
+	Title      string
+	WIPLimit   int
+	Type       string
+	OrderIndex int
+}
+
+// Card is a row of the cards table.
+type Card struct {
+	ID             uuid.UUID
+	GameID         uuid.UUID
+	ColumnID       uuid.UUID
+	Title          string
+	ClassOfService string
+	ValueEstimate  string
+	SelectedDay    int
+	DeployedDay    int
+	OrderIndex     int
+	BlockedReason  *string // nil while the card is not blocked
+	BlockedBy      string
+	BlockedDay     int
+	UnblockEffort  int
+}
+
+// Effort is a row of the efforts table.
+type Effort struct {
+	ID           uuid.UUID
+	CardID       uuid.UUID
+	EffortTypeID uuid.UUID
+	Estimate     int
+	Remaining    int
+	Actual       int
+}
+
+// Player is a row of the players table.
+type Player struct {
+	ID     uuid.UUID
+	GameID uuid.UUID
+	Name   string
+	Seat   int
+}
+
+// Event is a row of the game_events table.
+type Event struct {
+	ID        uuid.UUID
+	GameID    uuid.UUID
+	CardID    uuid.UUID // uuid.Nil for events about the whole game
+	EventType string
+	Payload   []byte
+	Day       int
+	CreatedAt time.Time
+}
+
+// Snapshot is a row of the game_snapshots table.
+type Snapshot struct {
+	GameID    uuid.UUID
+	Day       int
+	Board     []byte
+	CreatedAt time.Time
+}
+
+// Score is a row of the game_scores table.
+type Score struct {
+	GameID           uuid.UUID
+	Days             int
+	Deployed         int
+	Profit           int
+	LeadTime         int
+	Throughput       float64
+	FixedDateHitRate float64
+}
+
+// APIKey is a row of the api_keys table.
+type APIKey struct {
+	ID         uuid.UUID
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// Session is a row of the sessions table.
+type Session struct {
+	ID        uuid.UUID
+	Name      string
+	Seed      int64
+	CreatedAt time.Time
+}
+
+// SessionGame is a row of the session_games table.
+type SessionGame struct {
+	SessionID  uuid.UUID
+	GameID     uuid.UUID
+	Team       string
+	OrderIndex int
+}
+
+// Tables holds every row, in insertion order. Rows are values; change them
+// by assigning to the slice element, never through a shared pointer.
+type Tables struct {
+	Games        []Game
+	EffortTypes  []EffortType
+	Columns      []Column
+	Cards        []Card
+	Efforts      []Effort
+	Players      []Player
+	Events       []Event
+	Snapshots    []Snapshot
+	Scores       []Score
+	APIKeys      []APIKey
+	Sessions     []Session
+	SessionGames []SessionGame
+}
+
+// Store guards the tables.
+type Store struct {
+	mu     sync.RWMutex
+	tables Tables
+}
+
+// New returns an empty store.
+func New() *Store {
+	return &Store{}
+}
+
+// View runs fn with read access to the tables. fn must not modify them.
+func (s *Store) View(fn func(t *Tables) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(&s.tables)
+}
+
+// Update runs fn on a copy of the tables and keeps the copy only if fn
+// succeeds, so a failed update leaves no trace, like a rolled back TX.
+func (s *Store) Update(fn func(t *Tables) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := s.tables.clone()
+	if err := fn(&next); err != nil {
+		return err
+	}
+	s.tables = next
+	return nil
+}
+
+func (t *Tables) clone() Tables {
+	return Tables{
+		Games:        append([]Game(nil), t.Games...),
+		EffortTypes:  append([]EffortType(nil), t.EffortTypes...),
+		Columns:      append([]Column(nil), t.Columns...),
+		Cards:        append([]Card(nil), t.Cards...),
+		Efforts:      append([]Effort(nil), t.Efforts...),
+		Players:      append([]Player(nil), t.Players...),
+		Events:       append([]Event(nil), t.Events...),
+		Snapshots:    append([]Snapshot(nil), t.Snapshots...),
+		Scores:       append([]Score(nil), t.Scores...),
+		APIKeys:      append([]APIKey(nil), t.APIKeys...),
+		Sessions:     append([]Session(nil), t.Sessions...),
+		SessionGames: append([]SessionGame(nil), t.SessionGames...),
+	}
+}
+
+// Now is the timestamp given to new rows.
+func Now() time.Time {
+	return time.Now().UTC()
+}
+
+// Game returns the index of a game, or -1.
+func (t *Tables) Game(id uuid.UUID) int {
+	for i, g := range t.Games {
+		if g.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// LiveGame returns the index of a game that is not soft-deleted, or -1.
+func (t *Tables) LiveGame(id uuid.UUID) int {
+	if i := t.Game(id); i >= 0 && t.Games[i].DeletedAt.IsZero() {
+		return i
+	}
+	return -1
+}
+
+// Player returns the index of a player, or -1.
+func (t *Tables) Player(id uuid.UUID) int {
+	for i, p := range t.Players {
+		if p.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// NextSeat returns the seat of the next player to join a game: one past the
+// highest taken, or 0.
+func (t *Tables) NextSeat(gameID uuid.UUID) int {
+	seat := 0
+	for _, p := range t.Players {
+		if p.GameID == gameID && p.Seat >= seat {
+			seat = p.Seat + 1
+		}
+	}
+	return seat
+}
+
+// Card returns the index of a card, or -1.
+func (t *Tables) Card(id uuid.UUID) int {
+	for i, c := range t.Cards {
+		if c.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// DeleteGame removes a game and everything that belongs to it. Forks of the
+// game stay, but lose the link to their parent. It reports whether the game
+// existed.
+func (t *Tables) DeleteGame(id uuid.UUID) bool {
+	i := t.Game(id)
+	if i < 0 {
+		return false
+	}
+	t.Games = append(t.Games[:i:i], t.Games[i+1:]...)
+
+	for i, g := range t.Games {
+		if g.ParentGameID != nil && *g.ParentGameID == id {
+			t.Games[i].ParentGameID = nil
+		}
+	}
+
+	t.ClearBoard(id)
+	t.EffortTypes = filter(t.EffortTypes, func(et EffortType) bool { return et.GameID != id })
+	t.Players = filter(t.Players, func(p Player) bool { return p.GameID != id })
+	t.Snapshots = filter(t.Snapshots, func(s Snapshot) bool { return s.GameID != id })
+	t.Scores = filter(t.Scores, func(s Score) bool { return s.GameID != id })
+	t.SessionGames = filter(t.SessionGames, func(sg SessionGame) bool { return sg.GameID != id })
+	return true
+}
+
+// ClearBoard removes the columns, cards, efforts and events of a game.
+func (t *Tables) ClearBoard(id uuid.UUID) {
+	cards := make(map[uuid.UUID]bool)
+	for _, c := range t.Cards {
+		if c.GameID == id {
+			cards[c.ID] = true
+		}
+	}
+
+	t.Columns = filter(t.Columns, func(c Column) bool { return c.GameID != id })
+	t.Cards = filter(t.Cards, func(c Card) bool { return c.GameID != id })
+	t.Efforts = filter(t.Efforts, func(e Effort) bool { return !cards[e.CardID] })
+	t.Events = filter(t.Events, func(e Event) bool { return e.GameID != id })
+}
+
+// DeletePlayer removes a player and reports whether it existed. A game the
+// player facilitated is left without a facilitator.
+func (t *Tables) DeletePlayer(id uuid.UUID) bool {
+	i := t.Player(id)
+	if i < 0 {
+		return false
+	}
+	t.Players = append(t.Players[:i:i], t.Players[i+1:]...)
+
+	for i, g := range t.Games {
+		if g.FacilitatorID != nil && *g.FacilitatorID == id {
+			t.Games[i].FacilitatorID = nil
+		}
+	}
+	return true
+}
+
+// filter returns the rows for which keep is true, in a new slice.
+func filter[T any](rows []T, keep func(T) bool) []T {
+	out := make([]T, 0, len(rows))
+	for _, r := range rows {
+		if keep(r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
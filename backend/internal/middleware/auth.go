@@ -1,48 +1,155 @@
 package middleware
 
 import (
+	"errors"
+	"log"
 	"net/http"
-	"os"
 	"strings"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/auth"
+	"github.com/google/uuid"
 )
 
-// APIKeyAuth is a simple middleware that enforces a static API key.
-// It expects: Authorization: Bearer <GAME_API_KEY>
-func APIKeyAuth(next http.Handler) http.Handler {
-	key := os.Getenv("API_KEY")
+// APIKeyAuth lets a request through only if it carries an API key with the
+// given scope: Authorization: Bearer <key>. The caller is stored in the
+// request context.
+func APIKeyAuth(keys auth.KeyLookup, scope auth.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(w, r)
+			if !ok {
+				return
+			}
+
+			if !auth.IsKey(token) || keys == nil {
+				http.Error(w, "invalid API key", http.StatusForbidden)
+				return
+			}
+			caller, err := keys(r.Context(), token)
+			if err != nil {
+				keyError(w, err)
+				return
+			}
+			if !caller.Has(scope) {
+				http.Error(w, "API key lacks the "+string(scope)+" scope", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithCaller(r.Context(), caller)))
+		})
+	}
+}
+
+// PlayerAuth accepts API keys as well as the tokens players get when they
+// join a game, and stores the caller in the request context. Whether a
+// player still plays in their game and whether they facilitate it are looked
+// up on every request, so a removed player's token is refused and handing
+// the role over takes effect at once. A nil player lookup accepts every
+// signed token, a nil facilitator lookup makes nobody a facilitator, a nil
+// key lookup accepts player tokens only.
+func PlayerAuth(signer *auth.Signer, players auth.PlayerLookup, facilitators auth.FacilitatorLookup, keys auth.KeyLookup) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(w, r)
+			if !ok {
+				return
+			}
+
+			var caller auth.Caller
+			switch {
+			case token == "":
+				http.Error(w, "invalid token", http.StatusForbidden)
+				return
+			case auth.IsKey(token) && keys != nil:
+				c, err := keys(r.Context(), token)
+				if err != nil {
+					keyError(w, err)
+					return
+				}
+				caller = c
+			default:
+				c, err := signer.Verify(token)
+				if err != nil {
+					http.Error(w, "invalid token", http.StatusForbidden)
+					return
+				}
+				if players != nil {
+					plays, err := players(r.Context(), c.GameID, c.PlayerID)
+					if err != nil {
+						log.Printf("auth: failed to look up player: %v", err)
+						http.Error(w, "internal server error", http.StatusInternalServerError)
+						return
+					}
+					if !plays {
+						http.Error(w, "player has left the game", http.StatusUnauthorized)
+						return
+					}
+				}
+				// a game that is gone has no facilitator
+				if facilitators != nil {
+					if id, err := facilitators(r.Context(), c.GameID); err == nil {
+						c.Facilitator = id == c.PlayerID
+					}
+				}
+				caller = c
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithCaller(r.Context(), caller)))
+		})
+	}
+}
+
+// keyError answers a request whose API key could not be looked up.
+func keyError(w http.ResponseWriter, err error) {
+	if errors.Is(err, auth.ErrInvalidToken) {
+		http.Error(w, "invalid API key", http.StatusForbidden)
+		return
+	}
+	log.Printf("auth: failed to look up API key: %v", err)
+	http.Error(w, "internal server error", http.StatusInternalServerError)
+}
+
+// GameAccess lets a request through only if its caller may act on the game
+// named by the route's {id}: look at it for GET and HEAD, play it otherwise.
+// It runs after PlayerAuth.
+func GameAccess(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// 1) Grab "Authorization" header
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			http.Error(w, "missing Authorization header", http.StatusUnauthorized)
+		caller, ok := auth.CallerFrom(r.Context())
+		if !ok {
+			http.Error(w, "missing caller", http.StatusUnauthorized)
 			return
 		}
-
-		// 2) Expect "Bearer <token>"
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
-			http.Error(w, "invalid Authorization format", http.StatusUnauthorized)
+		// a malformed ID is left to the handler to report
+		gameID, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			next.ServeHTTP(w, r)
 			return
 		}
-		token := parts[1]
-
-		// 3) Compare to environment variable
-		if token == "" || token != key {
-			http.Error(w, "invalid API key", http.StatusForbidden)
+		if !caller.CanAccess(gameID) {
+			http.Error(w, "not a player of this game", http.StatusForbidden)
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead && !caller.CanPlay(gameID) {
+			http.Error(w, "read-only access to this game", http.StatusForbidden)
 			return
 		}
-
-		// 4) (Optional) Check Origin or Referrer if you want an extra layer—
-		//    e.g., r.Header.Get("Origin") == "https://yourgame.com"
-		//    For now, we’ll skip that. If you want, uncomment below:
-		//
-		// origin := r.Header.Get("Origin")
-		// if origin != "https://yourgame.com" {
-		//     http.Error(w, "forbidden origin", http.StatusForbidden)
-		//     return
-		// }
-
-		// 5) All good → call the next handler
 		next.ServeHTTP(w, r)
 	})
 }
+
+// bearerToken reads "Authorization: Bearer <token>". It answers the request
+// itself when the header is missing or malformed.
+func bearerToken(w http.ResponseWriter, r *http.Request) (string, bool) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		http.Error(w, "missing Authorization header", http.StatusUnauthorized)
+		return "", false
+	}
+
+	parts := strings.SplitN(authHeader, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		http.Error(w, "invalid Authorization format", http.StatusUnauthorized)
+		return "", false
+	}
+	return parts[1], true
+}
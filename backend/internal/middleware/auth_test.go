@@ -1,22 +1,32 @@
 package middleware_test
 
 import (
-	"log"
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
-	"os"
+	"strings"
 	"testing"
 
+	"github.com/Germanicus1/kanban-sim/backend/internal/auth"
 	"github.com/Germanicus1/kanban-sim/backend/internal/middleware"
-	"github.com/joho/godotenv"
+	"github.com/google/uuid"
 )
 
-func TestAuth(t *testing.T) {
-	if err := godotenv.Load("../../.env"); err != nil {
-		log.Printf("Failed to load .env: %v", err)
+// testKeys is a key lookup knowing one key per scope, "ksk_<scope>".
+func testKeys(ctx context.Context, key string) (auth.Caller, error) {
+	switch key {
+	case "ksk_read", "ksk_play", "ksk_facilitate", "ksk_admin":
+		s := auth.Scope(strings.TrimPrefix(key, auth.KeyPrefix))
+		return auth.Caller{Scope: s, Admin: s == auth.ScopeAdmin}, nil
+	case "ksk_broken":
+		return auth.Caller{}, errors.New("database down")
 	}
+	return auth.Caller{}, auth.ErrInvalidToken
+}
 
-	token := os.Getenv("API_KEY")
+func TestAuth(t *testing.T) {
+	token := "ksk_facilitate"
 
 	called := false
 
@@ -74,6 +84,30 @@ func TestAuth(t *testing.T) {
 			wantStatus:     http.StatusOK,
 			expectNextCall: true,
 		},
+		{
+			name:           "broader scope",
+			headerValue:    "Bearer ksk_admin",
+			wantStatus:     http.StatusOK,
+			expectNextCall: true,
+		},
+		{
+			name:           "narrower scope",
+			headerValue:    "Bearer ksk_play",
+			wantStatus:     http.StatusForbidden,
+			expectNextCall: false,
+		},
+		{
+			name:           "player token",
+			headerValue:    "Bearer " + auth.NewSigner([]byte("test secret")).Issue(uuid.New(), uuid.New()),
+			wantStatus:     http.StatusForbidden,
+			expectNextCall: false,
+		},
+		{
+			name:           "lookup fails",
+			headerValue:    "Bearer ksk_broken",
+			wantStatus:     http.StatusInternalServerError,
+			expectNextCall: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -85,7 +119,7 @@ func TestAuth(t *testing.T) {
 			}
 			rec := httptest.NewRecorder()
 			// Create a new instance of the middleware with the next handler
-			handler := middleware.APIKeyAuth(next)
+			handler := middleware.APIKeyAuth(testKeys, auth.ScopeFacilitate)(next)
 			// Serve the HTTP request using the middleware
 			handler.ServeHTTP(rec, req)
 			// Check the response status code and body
@@ -108,3 +142,73 @@ func TestAuth(t *testing.T) {
 	}
 
 }
+
+func TestPlayerAuth(t *testing.T) {
+	signer := auth.NewSigner([]byte("test secret"))
+	gameID := uuid.New()
+	playerID := uuid.New()
+
+	var got auth.Caller
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = auth.CallerFrom(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	mux := http.NewServeMux()
+	facilitatorID := uuid.New()
+	facilitators := func(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+		if id != gameID {
+			return uuid.Nil, errors.New("not found")
+		}
+		return facilitatorID, nil
+	}
+	removedID := uuid.New()
+	players := func(ctx context.Context, game, player uuid.UUID) (bool, error) {
+		return game == gameID && player != removedID, nil
+	}
+	playerAuth := middleware.PlayerAuth(signer, players, facilitators, testKeys)
+	mux.Handle("GET /games/{id}", playerAuth(middleware.GameAccess(next)))
+	mux.Handle("PATCH /games/{id}", playerAuth(middleware.GameAccess(next)))
+
+	tests := []struct {
+		name       string
+		method     string
+		header     string
+		game       uuid.UUID
+		wantStatus int
+		wantCaller auth.Caller
+	}{
+		{"admin key", "GET", "Bearer ksk_admin", uuid.New(), http.StatusOK, auth.Caller{Scope: auth.ScopeAdmin, Admin: true}},
+		{"read key", "GET", "Bearer ksk_read", uuid.New(), http.StatusOK, auth.Caller{Scope: auth.ScopeRead}},
+		{"read key making a move", "PATCH", "Bearer ksk_read", uuid.New(), http.StatusForbidden, auth.Caller{}},
+		{"play key making a move", "PATCH", "Bearer ksk_play", uuid.New(), http.StatusOK, auth.Caller{Scope: auth.ScopePlay}},
+		{"unknown key", "GET", "Bearer ksk_unknown", gameID, http.StatusForbidden, auth.Caller{}},
+		{"own game", "GET", "Bearer " + signer.Issue(playerID, gameID), gameID, http.StatusOK, auth.Caller{PlayerID: playerID, GameID: gameID}},
+		{"move in own game", "PATCH", "Bearer " + signer.Issue(playerID, gameID), gameID, http.StatusOK, auth.Caller{PlayerID: playerID, GameID: gameID}},
+		{"facilitator", "GET", "Bearer " + signer.Issue(facilitatorID, gameID), gameID, http.StatusOK,
+			auth.Caller{PlayerID: facilitatorID, GameID: gameID, Facilitator: true}},
+		{"other game", "GET", "Bearer " + signer.Issue(playerID, gameID), uuid.New(), http.StatusForbidden, auth.Caller{}},
+		{"removed player", "GET", "Bearer " + signer.Issue(removedID, gameID), gameID, http.StatusUnauthorized, auth.Caller{}},
+		{"invalid token", "GET", "Bearer not-a-token", gameID, http.StatusForbidden, auth.Caller{}},
+		{"empty token", "GET", "Bearer ", gameID, http.StatusForbidden, auth.Caller{}},
+		{"no header", "GET", "", gameID, http.StatusUnauthorized, auth.Caller{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = auth.Caller{}
+			req := httptest.NewRequest(tt.method, "/games/"+tt.game.String(), nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d; want %d", rec.Code, tt.wantStatus)
+			}
+			if got != tt.wantCaller {
+				t.Errorf("caller = %+v; want %+v", got, tt.wantCaller)
+			}
+		})
+	}
+}
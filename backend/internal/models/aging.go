@@ -0,0 +1,46 @@
+package models
+
+import "github.com/google/uuid"
+
+// Statuses of a card in progress, by its age against the lead times of the
+// cards deployed so far.
+const (
+	AgingOnTrack  = "on_track" // not older than half the cards took
+	AgingAtRisk   = "at_risk"  // older than the median, within the 85th percentile
+	AgingBreached = "breached" // older than 85% of the cards took
+	AgingUnknown  = "unknown"  // no card deployed yet to compare with
+)
+
+// Aging is the aging work-in-progress report of a game: how long the cards
+// in progress have been under way, against how long the cards deployed so
+// far took.
+// swagger:model Aging
+type Aging struct {
+	Day       int             `json:"day" example:"9"`
+	LeadTimes []ClassLeadTime `json:"leadTimes"`       // by class of service
+	Total     *ClassLeadTime  `json:"total,omitempty"` // of all cards; nil until one is deployed
+	Cards     []AgingCard     `json:"cards"`           // oldest first
+}
+
+// ClassLeadTime holds the lead-time percentiles of the cards of a class of
+// service deployed so far, or of all cards as the total.
+type ClassLeadTime struct {
+	ClassOfService string `json:"classOfService,omitempty" example:"S"`
+	Deployed       int    `json:"deployed" example:"6"`
+	P50            int    `json:"p50" example:"5"`
+	P85            int    `json:"p85" example:"8"`
+}
+
+// AgingCard is a card in progress on the aging report. Its age is the days
+// since it was selected; cards of a class of service with nothing deployed
+// yet are measured against all cards.
+type AgingCard struct {
+	CardID         uuid.UUID `json:"cardId"`
+	Title          string    `json:"title" example:"S12"`
+	ClassOfService string    `json:"classOfService,omitempty" example:"S"`
+	Column         string    `json:"column" example:"Development - In Progress"`
+	Age            int       `json:"age" example:"6"`
+	DaysInColumn   int       `json:"daysInColumn" example:"2"` // since its last move
+	Blocked        bool      `json:"blocked"`
+	Status         string    `json:"status" example:"at_risk"`
+}
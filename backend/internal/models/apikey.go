@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey is a key an integration calls the API with. The key itself is shown
+// once, when it is created; only its hash is stored.
+// swagger:model
+type APIKey struct {
+	ID         uuid.UUID  `json:"id" example:"5f0c7a1e-3b2d-4c8e-9a6f-1d2e3f4a5b6c"`
+	Name       string     `json:"name" example:"LMS"`
+	Prefix     string     `json:"prefix" example:"ksk_Q2x9"` // start of the key, to tell keys apart
+	Scopes     []string   `json:"scopes" example:"read,play"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// CreateAPIKeyRequest is the payload for CreateKey.
+// swagger:model
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" example:"LMS"`
+	Scopes    []string   `json:"scopes" example:"read,play"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2027-01-01T00:00:00Z"`
+}
+
+// NewAPIKey is a key just created. Key is not stored and cannot be shown
+// again.
+// swagger:model
+type NewAPIKey struct {
+	APIKey
+	Key string `json:"key" example:"ksk_Q2x9..."`
+}
@@ -8,4 +8,5 @@ type Board struct {
 	Columns     []Column     `json:"columns"`
 	EffortTypes []EffortType `json:"effortTypes"`
 	Cards       []Card       `json:"cards"`
+	Quality     Quality      `json:"quality"` // of scenarios only
 }
@@ -0,0 +1,44 @@
+package models
+
+import "github.com/google/uuid"
+
+// ColumnMove is a card crossing from one column into the next, by
+// "Parent - Sub" title.
+type ColumnMove struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// CardChange is what one played day did to a card.
+type CardChange struct {
+	CardID      uuid.UUID
+	ColumnID    uuid.UUID
+	SelectedDay int
+	DeployedDay int
+	Efforts     []Effort     // all efforts of the card, by effort type title
+	Moves       []ColumnMove // in the order they were made
+	Blocker     *Blocker     // still holding the card up at the end of the day
+	Unblock     *Unblock     // set when the day's work cleared the blocker
+	TestFailure *TestFailure // set when the card failed its testing
+	Defect      *Defect      // set when a defect was found in the card
+
+	// Spawned cards are new to the board, like escaped defects, and are
+	// created with the title, class and value given.
+	Spawned        bool
+	Title          string
+	ClassOfService string
+	ValueEstimate  string
+}
+
+// BotDay sums up a day a bot played in a live game.
+// swagger:model BotDay
+type BotDay struct {
+	Strategy string `json:"strategy" example:"strict-wip"`
+	Day      int    `json:"day" example:"3"` // the day played
+	Moves    int    `json:"moves" example:"4"`
+	Deployed int    `json:"deployed" example:"1"`
+	Working  int    `json:"working" example:"7"` // workers who did any work
+	WIP      int    `json:"wip" example:"6"`     // cards in progress at the end of the day
+	Failed   int    `json:"failed" example:"1"`  // cards that failed their testing
+	Defects  int    `json:"defects" example:"0"` // defects found in cards deployed
+}
@@ -14,5 +14,67 @@ type Card struct {
 	SelectedDay    int       `json:"selectedDay,omitempty"`
 	DeployedDay    int       `json:"deployedDay,omitempty"`
 	OrderIndex     int       `json:"orderIndex,omitempty"`
+	Age            int       `json:"age,omitempty"` // days since selected, to deployment; set by card searches
 	Efforts        []Effort  `json:"efforts"`
+	Blocker        *Blocker  `json:"blocker,omitempty"` // nil unless the card is blocked
+}
+
+// Blocker is what holds up a blocked card. No work is done on a blocked card
+// and it cannot move; work put into it goes into unblocking instead.
+// swagger:model Blocker
+type Blocker struct {
+	Reason    string `json:"reason" example:"Waiting for the database team"`
+	BlockedBy string `json:"blockedBy,omitempty" example:"Alice"` // player who blocked the card
+	Day       int    `json:"day" example:"4"`                     // day the card was blocked
+	// Effort is the unblocking work still needed. At 0 the blocker stays
+	// until a player lifts it.
+	Effort int `json:"effort,omitempty" example:"5"`
+}
+
+// BlockCardRequest is the payload for BlockCard.
+// swagger:model
+type BlockCardRequest struct {
+	Reason string `json:"reason" example:"Waiting for the database team"`
+	Effort int    `json:"effort,omitempty" example:"5"` // unblocking work needed; 0 for none
+}
+
+// CardPosition is where a card should go: a place in a column, top first.
+// swagger:model CardPosition
+type CardPosition struct {
+	ColumnID *uuid.UUID `json:"columnId,omitempty"` // the card's own column if left out
+	Index    int        `json:"index" example:"0"`  // 0 for the top; past the end for the bottom
+}
+
+// CardOrder is the order of the cards in a column, top first.
+// swagger:model CardOrder
+type CardOrder struct {
+	ColumnID uuid.UUID     `json:"columnId"`
+	Cards    []OrderedCard `json:"cards"`
+}
+
+// OrderedCard is a card in a CardOrder.
+type OrderedCard struct {
+	ID         uuid.UUID `json:"id"`
+	Title      string    `json:"title" example:"S10"`
+	OrderIndex int       `json:"orderIndex" example:"0"`
+}
+
+// CardQuery filters, sorts and pages the cards of a game. Zero fields do not
+// filter.
+type CardQuery struct {
+	ColumnID       *uuid.UUID // the column or one of its sub-columns
+	ClassOfService string
+	ValueEstimate  string
+	Blocked        *bool
+	MinAge         int    // selected cards at least this many days old
+	Sort           string // "position" (the default), "age" or "title"
+	Cursor         string // nextCursor of the page before
+	Limit          int    // cards per page; 0 for the default
+}
+
+// CardPage is a page of a card search.
+// swagger:model CardPage
+type CardPage struct {
+	Cards      []Card `json:"cards"`
+	NextCursor string `json:"nextCursor,omitempty" example:"eyJzIjoicG9zaXRpb24ifQ"` // empty on the last page
 }
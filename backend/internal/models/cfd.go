@@ -0,0 +1,30 @@
+package models
+
+import "github.com/google/uuid"
+
+// CFD is the data of a cumulative flow diagram of a game: how many cards
+// were in each column on every day, and when cards were blocked.
+// swagger:model CFD
+type CFD struct {
+	Columns     []string        `json:"columns" example:"Options,Analysis - In Progress"` // leaf columns in board order
+	Days        []CFDDay        `json:"days"`
+	Annotations []CFDAnnotation `json:"annotations"`
+}
+
+// CFDDay counts the cards in each column at the end of a day, or as they are
+// now for the current day, in the order of CFD.Columns.
+type CFDDay struct {
+	Day   int   `json:"day" example:"3"`
+	Cards []int `json:"cards" example:"12,2,1"`
+}
+
+// CFDAnnotation marks a stretch of days a card was blocked.
+type CFDAnnotation struct {
+	CardID    uuid.UUID `json:"cardId"`
+	Title     string    `json:"title" example:"S1"`
+	Reason    string    `json:"reason" example:"Waiting for the database team"`
+	BlockedBy string    `json:"blockedBy,omitempty" example:"Alice"`
+	Day       int       `json:"day" example:"4"`  // day the card was blocked
+	Days      int       `json:"days" example:"2"` // blocked days, so far if still blocked
+	Unblocked bool      `json:"unblocked"`
+}
@@ -13,3 +13,44 @@ type Column struct {
 	Type       string     `json:"type"`                 // "active", queue", "done"
 	SubColumns []Column   `json:"subColumns,omitempty"` // built in memory
 }
+
+// ColumnUpdate changes a column of a game. Fields left out stay as they are.
+// swagger:model ColumnUpdate
+type ColumnUpdate struct {
+	Title    *string `json:"title,omitempty" example:"Development"`
+	WIPLimit *int    `json:"wipLimit,omitempty" example:"4"` // 0 for none; top-level columns only
+	// SubColumns are the sub-columns the column should have, in order:
+	// existing ones are kept, new ones added and the rest removed. Top-level
+	// columns only.
+	SubColumns *[]SubColumnSpec `json:"subColumns,omitempty"`
+}
+
+// SubColumnSpec is a sub-column wanted by a ColumnUpdate: an existing one by
+// ID, or a new one by title.
+type SubColumnSpec struct {
+	ID    *uuid.UUID `json:"id,omitempty"`
+	Title string     `json:"title,omitempty" example:"Review"` // of a new sub-column
+	Type  string     `json:"type,omitempty" example:"queue"`   // of a new sub-column, "active" or "queue"; the parent's if empty
+}
+
+// WIPChange is the payload of a "wip_changed" event.
+type WIPChange struct {
+	ColumnID uuid.UUID `json:"columnId"`
+	Column   string    `json:"column"` // title of the column
+	From     int       `json:"from"`
+	To       int       `json:"to"`
+}
+
+// ColumnChange is the payload of a "column_changed" event: a column renamed,
+// or its sub-columns added, removed or reordered.
+type ColumnChange struct {
+	ColumnID   uuid.UUID `json:"columnId"`
+	Column     string    `json:"column"`               // title before the change
+	Title      string    `json:"title,omitempty"`      // new title, when renamed
+	Added      []string  `json:"added,omitempty"`      // sub-columns added
+	Removed    []string  `json:"removed,omitempty"`    // sub-columns removed
+	SubColumns []string  `json:"subColumns,omitempty"` // sub-columns in order, when they changed
+	// Renamed maps the "Parent - Sub" titles cards moved between before a
+	// rename to the titles they have since.
+	Renamed map[string]string `json:"renamed,omitempty"`
+}
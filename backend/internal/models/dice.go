@@ -0,0 +1,12 @@
+package models
+
+import "github.com/google/uuid"
+
+// DiceRoll is the outcome of one player's die for one card on one day.
+// swagger:model DiceRoll
+type DiceRoll struct {
+	Day      int       `json:"day" example:"3"`
+	PlayerID uuid.UUID `json:"playerId"`
+	CardID   uuid.UUID `json:"cardId"`
+	Value    int       `json:"value" example:"4"`
+}
@@ -7,11 +7,21 @@ import (
 	"github.com/google/uuid"
 )
 
+// GameEvent is an entry of a game's event log. Events about the game as a
+// whole, like its lifecycle, have no card.
 type GameEvent struct {
-	ID        uuid.UUID       `db:"id"`
-	GameID    uuid.UUID       `db:"game_id"`
-	CardID    uuid.UUID       `db:"card_id"`
-	EventType string          `db:"event_type"`
-	Payload   json.RawMessage `db:"payload"`
-	CreatedAt time.Time       `db:"created_at"`
+	ID        uuid.UUID       `db:"id" json:"id"`
+	GameID    uuid.UUID       `db:"game_id" json:"gameId"`
+	CardID    *uuid.UUID      `db:"card_id" json:"cardId,omitempty"`
+	EventType string          `db:"event_type" json:"eventType"`
+	Payload   json.RawMessage `db:"payload" json:"payload" swaggertype:"object"`
+	Day       int             `db:"day" json:"day"`
+	CreatedAt time.Time       `db:"created_at" json:"createdAt"`
+}
+
+// Unblock is the payload of an "unblock" event. The payload of a "block"
+// event is the Blocker put on the card.
+type Unblock struct {
+	Days   int  `json:"days"`   // days the card was blocked
+	Worked bool `json:"worked"` // cleared by unblocking work rather than lifted by a player
 }
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GameExportVersion is the archive format written by GET /games/{id}/export.
+// Bump it whenever the shape of GameExport changes incompatibly.
+const GameExportVersion = 1
+
+// GameExport is a portable archive of one complete game. Cards reference
+// their column by title ("Parent - Sub" for subcolumns) and efforts their
+// effort type by title, so the archive carries no server-specific IDs except
+// the card IDs that events point at.
+// swagger:model GameExport
+type GameExport struct {
+	Version     int            `json:"version" example:"1"`
+	ExportedAt  time.Time      `json:"exportedAt"`
+	Game        Game           `json:"game"`
+	Seed        int64          `json:"seed"`
+	EffortTypes []EffortType   `json:"effortTypes"`
+	Columns     []Column       `json:"columns"`
+	Cards       []Card         `json:"cards"`
+	Players     []ExportPlayer `json:"players"`
+	Events      []GameEvent    `json:"events"`
+}
+
+// ExportPlayer is a player as stored in a GameExport. Players are listed in
+// the order they joined the game, which an import seats them in.
+type ExportPlayer struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
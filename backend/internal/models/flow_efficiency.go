@@ -0,0 +1,36 @@
+package models
+
+import "github.com/google/uuid"
+
+// FlowEfficiency is the share of the days cards spent in active columns,
+// being worked on, out of the days they spent in active and queue columns
+// together. Most of the lead time is usually waiting.
+// swagger:model FlowEfficiency
+type FlowEfficiency struct {
+	Day     int                   `json:"day" example:"9"`
+	Classes []ClassFlowEfficiency `json:"classes"` // by class of service
+	Total   ClassFlowEfficiency   `json:"total"`   // of all cards
+	Cards   []CardFlowEfficiency  `json:"cards"`   // least efficient first
+}
+
+// ClassFlowEfficiency adds up the days of the cards of a class of service,
+// or of all cards as the total.
+type ClassFlowEfficiency struct {
+	ClassOfService string  `json:"classOfService,omitempty" example:"S"`
+	Cards          int     `json:"cards" example:"12"`
+	ActiveDays     int     `json:"activeDays" example:"20"`
+	QueueDays      int     `json:"queueDays" example:"46"`
+	Efficiency     float64 `json:"efficiency" example:"0.3"` // active days over active and queue days
+}
+
+// CardFlowEfficiency is the flow efficiency of a card, so far if it is not
+// deployed yet.
+type CardFlowEfficiency struct {
+	CardID         uuid.UUID `json:"cardId"`
+	Title          string    `json:"title" example:"S12"`
+	ClassOfService string    `json:"classOfService,omitempty" example:"S"`
+	Deployed       bool      `json:"deployed"`
+	ActiveDays     int       `json:"activeDays" example:"3"`
+	QueueDays      int       `json:"queueDays" example:"5"`
+	Efficiency     float64   `json:"efficiency" example:"0.38"`
+}
@@ -1,11 +1,59 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
+// GameStatus is where a game is in its lifecycle.
+type GameStatus string
+
+const (
+	GameLobby   GameStatus = "lobby"   // players join; nothing is played yet
+	GameRunning GameStatus = "running" // the only status in which the game is played
+	GamePaused  GameStatus = "paused"
+	GameEnded   GameStatus = "ended" // read-only from now on
+)
+
+// StatusChange is the payload of a lifecycle event.
+type StatusChange struct {
+	From GameStatus `json:"from"`
+	To   GameStatus `json:"to"`
+}
+
 type Game struct {
-	ID        uuid.UUID `json:"id"`
-	CreatedAt string    `json:"created_at"`
-	Day       int       `json:"day"`
+	ID           uuid.UUID  `json:"id"`
+	CreatedAt    string     `json:"created_at"`
+	Day          int        `json:"day"`
+	Status       GameStatus `json:"status" example:"running"`
+	Scenario     string     `json:"scenario" example:"default"` // board the game was created from
+	ParentGameID *uuid.UUID `json:"parent_game_id,omitempty"`   // set on forks only
+	ForkedAtDay  *int       `json:"forked_at_day,omitempty"`    // day of the parent the fork was taken from
+	Seed         int64      `json:"-"`                          // dice seed, for facilitators only
+	Quality      Quality    `json:"quality"`                    // how often work goes wrong
+	// FacilitatorID is the player running the game, nil while nobody is.
+	FacilitatorID *uuid.UUID `json:"facilitator_id,omitempty"`
+}
+
+// GameQuery filters, sorts and pages the game list. Zero fields do not
+// filter.
+type GameQuery struct {
+	Status    GameStatus
+	Scenario  string
+	SessionID *uuid.UUID
+	From      time.Time // games created at or after
+	To        time.Time // games created before
+	Sort      string    // "created" (the default) or "day"
+	Ascending bool      // oldest or least played first, instead of newest or most played
+	Cursor    string    // next_cursor of the page before
+	Limit     int       // games per page; 0 for the default
+}
+
+// GamePage is a page of the game list.
+// swagger:model GamePage
+type GamePage struct {
+	Games      []Game `json:"games"`
+	Total      int    `json:"total" example:"120"`   // games that pass the filters, on all pages
+	NextCursor string `json:"next_cursor,omitempty"` // empty on the last page
 }
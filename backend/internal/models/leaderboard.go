@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LeaderboardFilter narrows the games a leaderboard ranks. Zero fields do
+// not filter.
+type LeaderboardFilter struct {
+	Scenario string
+	From     time.Time // games created at or after
+	To       time.Time // games created before
+}
+
+// LeaderboardEntry is a finished game on the leaderboard, with every score
+// it can be ranked by. Games with equal scores share a rank.
+type LeaderboardEntry struct {
+	Rank             int       `json:"rank" example:"1"`
+	GameID           uuid.UUID `json:"game_id"`
+	Scenario         string    `json:"scenario" example:"default"`
+	CreatedAt        string    `json:"created_at"`
+	Days             int       `json:"days" example:"21"` // days played
+	Deployed         int       `json:"deployed" example:"14"`
+	Profit           int       `json:"profit" example:"12400"`
+	LeadTime         int       `json:"lead_time" example:"5"` // median, in days
+	Throughput       float64   `json:"throughput" example:"0.67"`
+	FixedDateHitRate float64   `json:"fixed_date_hit_rate" example:"0.5"` // share of fixed-date cards deployed
+}
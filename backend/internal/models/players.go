@@ -15,6 +15,16 @@ type CreatePlayerRequest struct {
 	Name   string    `json:"name" example:"John"`
 }
 
+// PlayerToken is what a player gets when joining a game. The token goes in
+// the Authorization header as "Bearer <token>" and is valid for this game
+// only.
+// swagger:model
+type PlayerToken struct {
+	PlayerID uuid.UUID `json:"playerId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	GameID   uuid.UUID `json:"gameId" example:"9b2f8c3e-1d4a-4e7b-8f6a-2c5d7e9f1a3b"`
+	Token    string    `json:"token" example:"EjRWeJ..."`
+}
+
 // swagger:model
 type UpdatePlayerRequest struct {
 	ID   uuid.UUID `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
@@ -0,0 +1,38 @@
+package models
+
+import "github.com/google/uuid"
+
+// Quality sets how often work goes wrong in a game. Every field is a chance
+// or a share between 0 and 1; the zero value is a game where nothing does.
+// swagger:model Quality
+type Quality struct {
+	TestFailure   float64 `json:"testFailure,omitempty" example:"0.2"`   // chance a card fails once its testing is done
+	Rework        float64 `json:"rework,omitempty" example:"0.5"`        // share of its development estimate a failed card needs again
+	EscapedDefect float64 `json:"escapedDefect,omitempty" example:"0.1"` // chance a deployed card spawns a defect
+	DefectEffort  float64 `json:"defectEffort,omitempty" example:"0.5"`  // share of each estimate of the deployed card the defect needs
+}
+
+// Valid reports whether every chance and share is between 0 and 1.
+func (q Quality) Valid() bool {
+	for _, v := range []float64{q.TestFailure, q.Rework, q.EscapedDefect, q.DefectEffort} {
+		if !(v >= 0 && v <= 1) {
+			return false
+		}
+	}
+	return true
+}
+
+// TestFailure is the payload of a "test_failed" event: the card failed its
+// testing and went back to be reworked.
+type TestFailure struct {
+	From   string `json:"from"`   // column the card failed in
+	To     string `json:"to"`     // column it went back to
+	Rework int    `json:"rework"` // development effort restored
+}
+
+// Defect is the payload of a "defect_escaped" event, logged on the deployed
+// card the defect was found in.
+type Defect struct {
+	CardID uuid.UUID `json:"cardId"` // the defect card, spawned into the first column
+	Title  string    `json:"title"`
+}
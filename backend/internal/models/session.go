@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session groups the games of one training: every team plays its own game,
+// all created from the same scenario and dice seed.
+// swagger:model
+type Session struct {
+	ID        uuid.UUID     `json:"id" example:"9b2d4f6a-1c3e-4a5b-8d7f-0e1a2b3c4d5e"`
+	Name      string        `json:"name" example:"Monday training"`
+	Seed      int64         `json:"seed" example:"42"`
+	CreatedAt time.Time     `json:"created_at"`
+	Games     []SessionGame `json:"games"`
+}
+
+// SessionGame is the game one team of a session plays.
+type SessionGame struct {
+	Team   string    `json:"team" example:"Team 1"`
+	GameID uuid.UUID `json:"game_id"`
+}
+
+// CreateSessionRequest is the payload for CreateSession.
+// swagger:model
+type CreateSessionRequest struct {
+	Name  string `json:"name" example:"Monday training"`
+	Teams int    `json:"teams" example:"4"`           // number of games to create
+	Seed  int64  `json:"seed,omitempty" example:"42"` // 0 picks a random one
+}
+
+// SessionDashboard compares the teams of a session side by side.
+// swagger:model
+type SessionDashboard struct {
+	SessionID uuid.UUID      `json:"session_id"`
+	Name      string         `json:"name" example:"Monday training"`
+	Teams     []TeamStanding `json:"teams"`
+}
+
+// TeamStanding is where one team's game stands on its current day. Figures
+// cover the days played so far, which are the days before Day.
+type TeamStanding struct {
+	Team       string     `json:"team" example:"Team 1"`
+	GameID     uuid.UUID  `json:"game_id"`
+	Status     GameStatus `json:"status" example:"running"`
+	Day        int        `json:"day" example:"9"`
+	WIP        int        `json:"wip" example:"6"`           // cards selected but not deployed
+	Deployed   int        `json:"deployed" example:"3"`      // cards deployed during the game
+	Throughput float64    `json:"throughput" example:"0.38"` // cards deployed per day
+	LeadTime   LeadTime   `json:"lead_time"`
+	Profit     int        `json:"profit" example:"850"`
+}
+
+// LeadTime describes the days from selection to deployment of the cards
+// deployed during a game.
+type LeadTime struct {
+	Mean float64 `json:"mean" example:"5.5"`
+	P85  int     `json:"p85" example:"7"`
+}
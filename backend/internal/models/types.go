@@ -1,9 +1,13 @@
 package models
 
 type BoardConfig struct {
+	Seed        int64        `json:"seed,omitempty"`        // dice seed of the game; 0 picks a random one
+	Facilitator string       `json:"facilitator,omitempty"` // name of a player to create as the game's facilitator
+	Scenario    string       `json:"scenario,omitempty"`    // name of the board; DefaultScenario if empty
 	EffortTypes []EffortType `json:"effortTypes"`
 	Columns     []Column     `json:"columns"`
 	Cards       []Card       `json:"cards"`
+	Quality     Quality      `json:"quality"` // test failures and escaped defects; none if empty
 }
 
 type BoardColumn struct {
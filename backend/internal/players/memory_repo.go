@@ -0,0 +1,91 @@
+package players
+
+import (
+	"context"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// memoryRepo implements the Repository interface on a memstore.Store.
+type memoryRepo struct {
+	store *memstore.Store
+}
+
+func (r *memoryRepo) CreatePlayer(ctx context.Context, gameID uuid.UUID, name string) (uuid.UUID, error) {
+	playerID := uuid.New()
+	err := r.store.Update(func(t *memstore.Tables) error {
+		gi := t.LiveGame(gameID)
+		if gi < 0 {
+			return ErrGameNotFound
+		}
+		if t.Games[gi].Status == string(models.GameEnded) {
+			return ErrGameEnded
+		}
+		t.Players = append(t.Players, memstore.Player{ID: playerID, GameID: gameID, Name: name, Seat: t.NextSeat(gameID)})
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return playerID, nil
+}
+
+func (r *memoryRepo) GetPlayerByID(ctx context.Context, id uuid.UUID) (*models.Player, error) {
+	var player *models.Player
+	err := r.store.View(func(t *memstore.Tables) error {
+		i := t.Player(id)
+		if i < 0 || t.LiveGame(t.Players[i].GameID) < 0 {
+			return ErrNotFound
+		}
+		p := t.Players[i]
+		player = &models.Player{ID: p.ID, Name: p.Name, GameID: p.GameID}
+		return nil
+	})
+	return player, err
+}
+
+func (r *memoryRepo) UpdatePlayer(ctx context.Context, id uuid.UUID, name string) error {
+	return r.store.Update(func(t *memstore.Tables) error {
+		i := t.Player(id)
+		if i < 0 || t.LiveGame(t.Players[i].GameID) < 0 {
+			return ErrNotFound
+		}
+		if gi := t.Game(t.Players[i].GameID); t.Games[gi].Status == string(models.GameEnded) {
+			return ErrGameEnded
+		}
+		t.Players[i].Name = name
+		return nil
+	})
+}
+
+func (r *memoryRepo) DeletePlayer(ctx context.Context, id uuid.UUID) error {
+	return r.store.Update(func(t *memstore.Tables) error {
+		i := t.Player(id)
+		if i < 0 || t.LiveGame(t.Players[i].GameID) < 0 {
+			return ErrNotFound
+		}
+		if gi := t.Game(t.Players[i].GameID); t.Games[gi].Status == string(models.GameEnded) {
+			return ErrGameEnded
+		}
+		t.DeletePlayer(id)
+		return nil
+	})
+}
+
+func (r *memoryRepo) ListPlayersByGameID(ctx context.Context, gameID uuid.UUID) ([]*models.Player, error) {
+	players := make([]*models.Player, 0)
+	err := r.store.View(func(t *memstore.Tables) error {
+		if t.LiveGame(gameID) < 0 {
+			return nil
+		}
+		for _, p := range t.Players {
+			if p.GameID == gameID {
+				players = append(players, &models.Player{ID: p.ID, Name: p.Name, GameID: p.GameID})
+			}
+		}
+		return nil
+	})
+	return players, err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
 	"github.com/Germanicus1/kanban-sim/backend/internal/models"
 	"github.com/google/uuid"
 )
@@ -20,3 +21,9 @@ type Repository interface {
 func NewSQLRepo(db *sql.DB) Repository {
 	return &sqlRepo{db: db}
 }
+
+// NewMemoryRepo constructs a players.Repository on an in-memory store. Share
+// one store between the repositories of all packages.
+func NewMemoryRepo(store *memstore.Store) Repository {
+	return &memoryRepo{store: store}
+}
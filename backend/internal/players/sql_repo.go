@@ -26,10 +26,27 @@ func (r *sqlRepo) CreatePlayer(ctx context.Context, gameID uuid.UUID, name strin
 		}
 	}()
 
+	// writing the game row first makes players join it one at a time, so no
+	// two take the same seat
+	var status string
+	switch err := tx.QueryRowContext(ctx,
+		`UPDATE games SET day = day WHERE id = $1 AND deleted_at IS NULL RETURNING status`, gameID,
+	).Scan(&status); {
+	case err == sql.ErrNoRows:
+		tx.Rollback()
+		return uuid.Nil, ErrGameNotFound
+	case err != nil:
+		tx.Rollback()
+		return uuid.Nil, fmt.Errorf("query game status: %w", err)
+	case status == string(models.GameEnded):
+		tx.Rollback()
+		return uuid.Nil, ErrGameEnded
+	}
+
 	var playerID uuid.UUID
 	if err := tx.QueryRowContext(ctx,
-		`INSERT INTO players (name, game_id)
-			 VALUES ($1, $2)
+		`INSERT INTO players (name, game_id, seat)
+		 SELECT $1, $2, COALESCE(MAX(seat) + 1, 0) FROM players WHERE game_id = $2
 		 RETURNING id`,
 		name, gameID,
 	).Scan(&playerID); err != nil {
@@ -60,13 +77,16 @@ func (r *sqlRepo) GetPlayerByID(ctx context.Context, id uuid.UUID) (*models.Play
 
 	var player models.Player
 	if err := tx.QueryRowContext(ctx,
-		`SELECT id, name, game_id FROM players WHERE id = $1`,
+		`SELECT p.id, p.name, p.game_id
+		   FROM players p
+		   JOIN games g ON g.id = p.game_id
+		  WHERE p.id = $1 AND g.deleted_at IS NULL`,
 		id,
 	).Scan(&player.ID, &player.Name, &player.GameID); err != nil {
+		tx.Rollback()
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("player not found: %w", err)
+			return nil, ErrNotFound
 		}
-		tx.Rollback()
 		return nil, fmt.Errorf("query player: %w", err)
 	}
 	if err := tx.Commit(); err != nil {
@@ -90,21 +110,40 @@ func (r *sqlRepo) GetPlayerByID(ctx context.Context, id uuid.UUID) (*models.Play
 
 var ErrNotFound = errors.New("player not found")
 
+// ErrGameEnded is returned for joining, renaming or leaving a game that has
+// ended.
+var ErrGameEnded = errors.New("game ended")
+
+// ErrGameNotFound is returned for joining a game that does not exist, or
+// that the retention policy soft-deleted.
+var ErrGameNotFound = errors.New("game not found")
+
 func (r *sqlRepo) UpdatePlayer(ctx context.Context, id uuid.UUID, name string) error {
-	const q = `UPDATE players SET name = $1 WHERE id = $2`
+	const q = `UPDATE players SET name = $1
+	            WHERE id = $2
+	              AND NOT EXISTS (SELECT 1 FROM games g WHERE g.id = players.game_id AND g.status = 'ended')
+	              AND NOT EXISTS (SELECT 1 FROM games g WHERE g.id = players.game_id AND g.deleted_at IS NOT NULL)`
 	result, err := r.db.ExecContext(ctx, q, name, id)
 	if err != nil {
 		return fmt.Errorf("update player: %w", err)
 	}
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
-		return ErrNotFound
+		// nothing changed: either the player is unknown, their game is
+		// soft-deleted, or it ended
+		if _, err := r.GetPlayerByID(ctx, id); err != nil {
+			return err
+		}
+		return ErrGameEnded
 	}
 	return nil
 }
 
 func (r *sqlRepo) DeletePlayer(ctx context.Context, id uuid.UUID) error {
-	const q = `DELETE FROM players WHERE id = $1`
+	const q = `DELETE FROM players
+	            WHERE id = $1
+	              AND NOT EXISTS (SELECT 1 FROM games g WHERE g.id = players.game_id AND g.status = 'ended')
+	              AND NOT EXISTS (SELECT 1 FROM games g WHERE g.id = players.game_id AND g.deleted_at IS NOT NULL)`
 
 	res, err := r.db.ExecContext(ctx, q, id)
 	if err != nil {
@@ -116,14 +155,20 @@ func (r *sqlRepo) DeletePlayer(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("delete player (rows affected): %w", err)
 	}
 	if n == 0 {
-		return ErrNotFound
+		if _, err := r.GetPlayerByID(ctx, id); err != nil {
+			return err
+		}
+		return ErrGameEnded
 	}
 
 	return nil
 }
 
 func (r *sqlRepo) ListPlayersByGameID(ctx context.Context, gameID uuid.UUID) ([]*models.Player, error) {
-	const q = `SELECT id, name, game_id FROM players WHERE game_id = $1`
+	const q = `SELECT p.id, p.name, p.game_id
+	             FROM players p
+	             JOIN games g ON g.id = p.game_id
+	            WHERE p.game_id = $1 AND g.deleted_at IS NULL`
 	rows, err := r.db.QueryContext(ctx, q, gameID)
 	if err != nil {
 		return nil, fmt.Errorf("query players: %w", err)
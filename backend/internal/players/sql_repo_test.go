@@ -17,7 +17,12 @@ import (
 
 // CreatePlayer tests
 func TestSQLRepo_CreatePlayer(t *testing.T) {
-	const insertQuery = `INSERT INTO players (name, game_id) VALUES ($1, $2) RETURNING id`
+	const insertQuery = `INSERT INTO players (name, game_id, seat) SELECT $1, $2, COALESCE(MAX(seat) + 1, 0) FROM players WHERE game_id = $2 RETURNING id`
+	const statusQuery = `UPDATE games SET day = day WHERE id = $1 AND deleted_at IS NULL RETURNING status`
+	running := func(m sqlmock.Sqlmock) {
+		m.ExpectQuery(regexp.QuoteMeta(statusQuery)).
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("running"))
+	}
 
 	tests := []struct {
 		name           string
@@ -36,6 +41,7 @@ func TestSQLRepo_CreatePlayer(t *testing.T) {
 			name: "Insert error",
 			setupMock: func(m sqlmock.Sqlmock, _ uuid.UUID) {
 				m.ExpectBegin()
+				running(m)
 				m.
 					ExpectQuery(regexp.QuoteMeta(insertQuery)).
 					WithArgs("Alice", sqlmock.AnyArg()).
@@ -44,10 +50,31 @@ func TestSQLRepo_CreatePlayer(t *testing.T) {
 			},
 			wantErrContain: "insert player",
 		},
+		{
+			name: "Game ended",
+			setupMock: func(m sqlmock.Sqlmock, _ uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectQuery(regexp.QuoteMeta(statusQuery)).
+					WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("ended"))
+				m.ExpectRollback()
+			},
+			wantErrContain: "game ended",
+		},
+		{
+			name: "Game not found",
+			setupMock: func(m sqlmock.Sqlmock, _ uuid.UUID) {
+				m.ExpectBegin()
+				m.ExpectQuery(regexp.QuoteMeta(statusQuery)).
+					WillReturnError(sql.ErrNoRows)
+				m.ExpectRollback()
+			},
+			wantErrContain: "game not found",
+		},
 		{
 			name: "Commit error",
 			setupMock: func(m sqlmock.Sqlmock, expectedID uuid.UUID) {
 				m.ExpectBegin()
+				running(m)
 				m.
 					ExpectQuery(regexp.QuoteMeta(insertQuery)).
 					WithArgs("Alice", sqlmock.AnyArg()).
@@ -64,6 +91,7 @@ func TestSQLRepo_CreatePlayer(t *testing.T) {
 			name: "Nil UUID returned",
 			setupMock: func(m sqlmock.Sqlmock, _ uuid.UUID) {
 				m.ExpectBegin()
+				running(m)
 				m.
 					ExpectQuery(regexp.QuoteMeta(insertQuery)).
 					WithArgs("Alice", sqlmock.AnyArg()).
@@ -79,6 +107,7 @@ func TestSQLRepo_CreatePlayer(t *testing.T) {
 			name: "Success",
 			setupMock: func(m sqlmock.Sqlmock, expectedID uuid.UUID) {
 				m.ExpectBegin()
+				running(m)
 				m.
 					ExpectQuery(regexp.QuoteMeta(insertQuery)).
 					WithArgs("Alice", sqlmock.AnyArg()).
@@ -126,7 +155,7 @@ func TestSQLRepo_CreatePlayer(t *testing.T) {
 
 // GetPlayerByID tests
 func TestSQLRepo_GetPlayerByID(t *testing.T) {
-	const query = `SELECT id, name, game_id FROM players WHERE id = $1`
+	const query = `SELECT p.id, p.name, p.game_id FROM players p JOIN games g ON g.id = p.game_id WHERE p.id = $1 AND g.deleted_at IS NULL`
 	tests := []struct {
 		name           string
 		setupMock      func(mock sqlmock.Sqlmock, id uuid.UUID)
@@ -299,9 +328,20 @@ func TestSQLRepo_UpdatePlayer(t *testing.T) {
 				mock.ExpectExec(regexp.QuoteMeta(updateQuery)).
 					WithArgs(name, id).
 					WillReturnResult(sqlmock.NewResult(0, 0))
+				expectPlayerLookup(mock, id, false)
 			},
 			wantErrContain: "player not found",
 		},
+		{
+			name: "Game ended",
+			setupMock: func(mock sqlmock.Sqlmock, id uuid.UUID, name string) {
+				mock.ExpectExec(regexp.QuoteMeta(updateQuery)).
+					WithArgs(name, id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				expectPlayerLookup(mock, id, true)
+			},
+			wantErrContain: "game ended",
+		},
 		{
 			name: "Success",
 			setupMock: func(mock sqlmock.Sqlmock, id uuid.UUID, name string) {
@@ -359,9 +399,20 @@ func TestSQLRepo_DeletePlayer(t *testing.T) {
 				mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
 					WithArgs(id).
 					WillReturnResult(sqlmock.NewResult(0, 0))
+				expectPlayerLookup(mock, id, false)
 			},
 			wantErrContain: "player not found",
 		},
+		{
+			name: "Game ended",
+			setupMock: func(mock sqlmock.Sqlmock, id uuid.UUID) {
+				mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+					WithArgs(id).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				expectPlayerLookup(mock, id, true)
+			},
+			wantErrContain: "game ended",
+		},
 		{
 			name: "Success",
 			setupMock: func(mock sqlmock.Sqlmock, id uuid.UUID) {
@@ -397,7 +448,7 @@ func TestSQLRepo_DeletePlayer(t *testing.T) {
 }
 
 func TestSQLRepo_ListPlayerByGameID(t *testing.T) {
-	const query = `SELECT id, name, game_id FROM players WHERE game_id = $1`
+	const query = `SELECT p.id, p.name, p.game_id FROM players p JOIN games g ON g.id = p.game_id WHERE p.game_id = $1 AND g.deleted_at IS NULL`
 	tests := []struct {
 		name           string
 		setupMock      func(mock sqlmock.Sqlmock, gameID uuid.UUID)
@@ -477,3 +528,17 @@ func TestSQLRepo_ListPlayerByGameID(t *testing.T) {
 		})
 	}
 }
+
+// expectPlayerLookup expects the lookup an update or delete that changed
+// nothing makes to tell an unknown player from an ended game.
+func expectPlayerLookup(mock sqlmock.Sqlmock, id uuid.UUID, exists bool) {
+	mock.ExpectBegin()
+	q := mock.ExpectQuery(regexp.QuoteMeta(`SELECT p.id, p.name, p.game_id FROM players p JOIN games g ON g.id = p.game_id WHERE p.id = $1 AND g.deleted_at IS NULL`)).WithArgs(id)
+	if !exists {
+		q.WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+		return
+	}
+	q.WillReturnRows(sqlmock.NewRows([]string{"id", "name", "game_id"}).AddRow(id, "Bob", uuid.New()))
+	mock.ExpectCommit()
+}
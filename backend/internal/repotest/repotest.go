@@ -0,0 +1,2294 @@
+// Package repotest holds the behaviour every implementation of the
+// repository interfaces must share. Each backend runs the same suite, so the
+// in-memory repositories cannot drift from the SQL ones.
+package repotest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/apikeys"
+	"github.com/Germanicus1/kanban-sim/backend/internal/cards"
+	"github.com/Germanicus1/kanban-sim/backend/internal/columns"
+	"github.com/Germanicus1/kanban-sim/backend/internal/config"
+	"github.com/Germanicus1/kanban-sim/backend/internal/engine"
+	"github.com/Germanicus1/kanban-sim/backend/internal/games"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/Germanicus1/kanban-sim/backend/internal/players"
+	"github.com/Germanicus1/kanban-sim/backend/internal/response"
+	"github.com/Germanicus1/kanban-sim/backend/internal/sessions"
+	"github.com/google/uuid"
+)
+
+// Repos is one backend's set of repositories, sharing the same storage.
+type Repos struct {
+	Games    games.Repository
+	Players  players.Repository
+	Columns  columns.Repository
+	Cards    cards.Repository
+	Keys     apikeys.Repository
+	Sessions sessions.Repository
+}
+
+// Run runs the suite. open is called once per subtest and must return
+// repositories on empty storage.
+func Run(t *testing.T, open func(t *testing.T) Repos) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, r Repos)
+	}{
+		{"Games", testGames},
+		{"GamesNotFound", testGamesNotFound},
+		{"CreateGameRollsBack", testCreateGameRollsBack},
+		{"Board", testBoard},
+		{"Players", testPlayers},
+		{"PlayersNotFound", testPlayersNotFound},
+		{"Columns", testColumns},
+		{"UpdateColumn", testUpdateColumn},
+		{"Cards", testCards},
+		{"DeleteGameCascades", testDeleteGameCascades},
+		{"Fork", testFork},
+		{"ForkRollsLikeParent", testForkRollsLikeParent},
+		{"SessionGamesRollAlike", testSessionGamesRollAlike},
+		{"ExportImport", testExportImport},
+		{"ApplyDay", testApplyDay},
+		{"ApplyDayQuality", testApplyDayQuality},
+		{"Facilitator", testFacilitator},
+		{"Lifecycle", testLifecycle},
+		{"Reset", testReset},
+		{"Blockers", testBlockers},
+		{"PositionCard", testPositionCard},
+		{"PositionCardConcurrently", testPositionCardConcurrently},
+		{"SearchCards", testSearchCards},
+		{"SearchGames", testSearchGames},
+		{"Leaderboard", testLeaderboard},
+		{"Retention", testRetention},
+		{"SoftDeletedGame", testSoftDeletedGame},
+		{"APIKeys", testAPIKeys},
+		{"Sessions", testSessions},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.fn(t, open(t))
+		})
+	}
+}
+
+// board is a small scenario: two effort types, one column split in two,
+// and a card in every stage that can hold one.
+func board() models.BoardConfig {
+	return models.BoardConfig{
+		Seed:     42,
+		Scenario: "small",
+		Quality:  models.Quality{TestFailure: 0.25, Rework: 0.5, EscapedDefect: 0.1, DefectEffort: 0.5},
+		EffortTypes: []models.EffortType{
+			{Title: "Build", OrderIndex: 0},
+			{Title: "Test", OrderIndex: 1},
+		},
+		Columns: []models.Column{
+			{Title: "Backlog", Type: "queue", OrderIndex: 0},
+			{Title: "Build", Type: "active", WIPLimit: 2, OrderIndex: 1, SubColumns: []models.Column{
+				{Title: "In Progress", Type: "active", OrderIndex: 0},
+				{Title: "Done", Type: "queue", OrderIndex: 1},
+			}},
+			{Title: "Deployed", Type: "done", OrderIndex: 2},
+		},
+		Cards: []models.Card{
+			{Title: "C1", ColumnTitle: "Backlog", ValueEstimate: "low", OrderIndex: 0, Efforts: []models.Effort{
+				{EffortType: "Build", Estimate: 3}, {EffortType: "Test", Estimate: 2},
+			}},
+			{Title: "C2", ColumnTitle: "Build - In Progress", ValueEstimate: "high", SelectedDay: 1, OrderIndex: 1, Efforts: []models.Effort{
+				{EffortType: "Build", Estimate: 5}, {EffortType: "Test", Estimate: 4},
+			}},
+			{Title: "C3", ColumnTitle: "Deployed", ValueEstimate: "medium", SelectedDay: 1, DeployedDay: 1, OrderIndex: 2},
+		},
+	}
+}
+
+func createGame(t *testing.T, r Repos) uuid.UUID {
+	t.Helper()
+	id, err := r.Games.CreateGame(context.Background(), board())
+	if err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	if id == uuid.Nil {
+		t.Fatal("CreateGame returned a nil ID")
+	}
+	return id
+}
+
+func testGames(t *testing.T, r Repos) {
+	ctx := context.Background()
+	first := createGame(t, r)
+	time.Sleep(10 * time.Millisecond) // distinct created_at for the ordering check
+	second := createGame(t, r)
+
+	g, err := r.Games.GetGameByID(ctx, first)
+	if err != nil {
+		t.Fatalf("GetGameByID: %v", err)
+	}
+	if g.ID != first || g.Day != 1 || g.Seed != 42 || g.Scenario != "small" || g.ParentGameID != nil || g.CreatedAt == "" {
+		t.Errorf("game = %+v; want day 1, seed 42, scenario small, no parent", g)
+	}
+	if g.Quality != board().Quality {
+		t.Errorf("quality = %+v; want %+v", g.Quality, board().Quality)
+	}
+
+	if err := r.Games.UpdateGame(ctx, first, 4); err != nil {
+		t.Fatalf("UpdateGame: %v", err)
+	}
+	if g, _ := r.Games.GetGameByID(ctx, first); g.Day != 4 {
+		t.Errorf("day after update = %d; want 4", g.Day)
+	}
+
+	list, err := r.Games.ListGames(ctx)
+	if err != nil {
+		t.Fatalf("ListGames: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != second || list[1].ID != first || list[1].Scenario != "small" ||
+		list[1].Quality != board().Quality {
+		t.Errorf("ListGames = %+v; want the newest game first", list)
+	}
+	if _, err := time.Parse(time.RFC3339Nano, list[0].CreatedAt); err != nil {
+		t.Errorf("created_at %q of a listed game: %v", list[0].CreatedAt, err)
+	}
+
+	if err := r.Games.DeleteGame(ctx, first); err != nil {
+		t.Fatalf("DeleteGame: %v", err)
+	}
+	if _, err := r.Games.GetGameByID(ctx, first); !errors.Is(err, games.ErrNotFound) {
+		t.Errorf("GetGameByID after delete: err = %v; want %v", err, games.ErrNotFound)
+	}
+}
+
+func testGamesNotFound(t *testing.T, r Repos) {
+	ctx := context.Background()
+	unknown := uuid.New()
+
+	if _, err := r.Games.GetGameByID(ctx, unknown); !errors.Is(err, games.ErrNotFound) {
+		t.Errorf("GetGameByID: err = %v; want %v", err, games.ErrNotFound)
+	}
+	if err := r.Games.UpdateGame(ctx, unknown, 2); !errors.Is(err, games.ErrNotFound) {
+		t.Errorf("UpdateGame: err = %v; want %v", err, games.ErrNotFound)
+	}
+	if err := r.Games.DeleteGame(ctx, unknown); !errors.Is(err, response.ErrNotFound) {
+		t.Errorf("DeleteGame: err = %v; want %v", err, response.ErrNotFound)
+	}
+	if _, err := r.Games.ForkGame(ctx, unknown, 0); !errors.Is(err, games.ErrNotFound) {
+		t.Errorf("ForkGame: err = %v; want %v", err, games.ErrNotFound)
+	}
+	if _, err := r.Games.ExportGame(ctx, unknown); !errors.Is(err, games.ErrNotFound) {
+		t.Errorf("ExportGame: err = %v; want %v", err, games.ErrNotFound)
+	}
+	if _, err := r.Cards.GetFlowLayout(ctx, unknown); !errors.Is(err, cards.ErrNotFound) {
+		t.Errorf("GetFlowLayout: err = %v; want %v", err, cards.ErrNotFound)
+	}
+
+	b, err := r.Games.GetBoard(ctx, unknown)
+	if err != nil {
+		t.Fatalf("GetBoard: %v", err)
+	}
+	if b.GameID != unknown || len(b.Columns)+len(b.Cards)+len(b.EffortTypes) != 0 {
+		t.Errorf("board of an unknown game = %+v; want an empty board", b)
+	}
+}
+
+func testCreateGameRollsBack(t *testing.T, r Repos) {
+	ctx := context.Background()
+	cfg := board()
+	cfg.Cards = append(cfg.Cards, models.Card{Title: "C4", ColumnTitle: "Nowhere"})
+
+	if _, err := r.Games.CreateGame(ctx, cfg); err == nil {
+		t.Fatal("CreateGame with a card in an unknown column: expected an error")
+	}
+	list, err := r.Games.ListGames(ctx)
+	if err != nil {
+		t.Fatalf("ListGames: %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("a failed CreateGame left %d games behind", len(list))
+	}
+}
+
+func testBoard(t *testing.T, r Repos) {
+	id := createGame(t, r)
+
+	b, err := r.Games.GetBoard(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetBoard: %v", err)
+	}
+
+	var titles []string
+	for _, c := range b.Columns {
+		titles = append(titles, c.Title)
+	}
+	if len(b.Columns) != 3 || titles[0] != "Backlog" || titles[1] != "Build" || titles[2] != "Deployed" {
+		t.Fatalf("columns = %v; want Backlog, Build, Deployed", titles)
+	}
+	build := b.Columns[1]
+	if build.Type != "active" || build.WIPLimit != 2 || len(build.SubColumns) != 2 ||
+		build.SubColumns[0].Title != "In Progress" || build.SubColumns[1].Title != "Done" {
+		t.Errorf("Build column = %+v; want active, WIP 2, In Progress and Done", build)
+	}
+	if b.Columns[0].Type != "queue" {
+		t.Errorf("Backlog type = %q; want queue", b.Columns[0].Type)
+	}
+
+	if len(b.EffortTypes) != 2 || b.EffortTypes[0].Title != "Build" || b.EffortTypes[1].Title != "Test" {
+		t.Errorf("effort types = %+v; want Build, Test", b.EffortTypes)
+	}
+
+	if len(b.Cards) != 3 {
+		t.Fatalf("cards = %d; want 3", len(b.Cards))
+	}
+	for _, c := range b.Cards {
+		if c.Title != "C2" {
+			continue
+		}
+		if c.ColumnID != build.SubColumns[0].ID || c.SelectedDay != 1 {
+			t.Errorf("C2 = %+v; want selected on day 1, in Build - In Progress", c)
+		}
+		want := []models.Effort{
+			{EffortType: "Build", Estimate: 5, Remaining: 5},
+			{EffortType: "Test", Estimate: 4, Remaining: 4},
+		}
+		if len(c.Efforts) != 2 || c.Efforts[0] != want[0] || c.Efforts[1] != want[1] {
+			t.Errorf("C2 efforts = %+v; want %+v", c.Efforts, want)
+		}
+	}
+}
+
+func testPlayers(t *testing.T, r Repos) {
+	ctx := context.Background()
+	gameID := createGame(t, r)
+	other := createGame(t, r)
+
+	alice, err := r.Players.CreatePlayer(ctx, gameID, "Alice")
+	if err != nil {
+		t.Fatalf("CreatePlayer: %v", err)
+	}
+	if _, err := r.Players.CreatePlayer(ctx, gameID, "Bob"); err != nil {
+		t.Fatalf("CreatePlayer: %v", err)
+	}
+	if _, err := r.Players.CreatePlayer(ctx, other, "Carol"); err != nil {
+		t.Fatalf("CreatePlayer: %v", err)
+	}
+
+	p, err := r.Players.GetPlayerByID(ctx, alice)
+	if err != nil {
+		t.Fatalf("GetPlayerByID: %v", err)
+	}
+	if p.ID != alice || p.Name != "Alice" || p.GameID != gameID {
+		t.Errorf("player = %+v; want Alice in %s", p, gameID)
+	}
+
+	if err := r.Players.UpdatePlayer(ctx, alice, "Alicia"); err != nil {
+		t.Fatalf("UpdatePlayer: %v", err)
+	}
+	if p, _ := r.Players.GetPlayerByID(ctx, alice); p == nil || p.Name != "Alicia" {
+		t.Errorf("player after update = %+v; want Alicia", p)
+	}
+
+	list, err := r.Players.ListPlayersByGameID(ctx, gameID)
+	if err != nil {
+		t.Fatalf("ListPlayersByGameID: %v", err)
+	}
+	if len(list) != 2 {
+		t.Errorf("players of the game = %d; want 2", len(list))
+	}
+	for _, p := range list {
+		seat, ok, err := r.Games.PlayerSeat(ctx, gameID, p.ID)
+		if err != nil {
+			t.Fatalf("PlayerSeat: %v", err)
+		}
+		want := map[string]int{"Alicia": 0, "Bob": 1}[p.Name]
+		if !ok || seat != want {
+			t.Errorf("seat of %s = %d, %v; want %d, true", p.Name, seat, ok, want)
+		}
+	}
+	if _, ok, _ := r.Games.PlayerSeat(ctx, other, alice); ok {
+		t.Error("PlayerSeat found Alice in another game")
+	}
+
+	if err := r.Players.DeletePlayer(ctx, alice); err != nil {
+		t.Fatalf("DeletePlayer: %v", err)
+	}
+	if _, err := r.Players.GetPlayerByID(ctx, alice); !errors.Is(err, players.ErrNotFound) {
+		t.Errorf("GetPlayerByID after delete: err = %v; want %v", err, players.ErrNotFound)
+	}
+
+	empty, err := r.Players.ListPlayersByGameID(ctx, uuid.New())
+	if err != nil {
+		t.Fatalf("ListPlayersByGameID: %v", err)
+	}
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("players of an unknown game = %#v; want an empty, non-nil list", empty)
+	}
+}
+
+func testPlayersNotFound(t *testing.T, r Repos) {
+	ctx := context.Background()
+	unknown := uuid.New()
+
+	if _, err := r.Players.GetPlayerByID(ctx, unknown); !errors.Is(err, players.ErrNotFound) {
+		t.Errorf("GetPlayerByID: err = %v; want %v", err, players.ErrNotFound)
+	}
+	if err := r.Players.UpdatePlayer(ctx, unknown, "Nobody"); !errors.Is(err, players.ErrNotFound) {
+		t.Errorf("UpdatePlayer: err = %v; want %v", err, players.ErrNotFound)
+	}
+	if err := r.Players.DeletePlayer(ctx, unknown); !errors.Is(err, players.ErrNotFound) {
+		t.Errorf("DeletePlayer: err = %v; want %v", err, players.ErrNotFound)
+	}
+	if _, err := r.Players.CreatePlayer(ctx, unknown, "Nobody"); err == nil {
+		t.Error("CreatePlayer in an unknown game: expected an error")
+	}
+}
+
+func testColumns(t *testing.T, r Repos) {
+	ctx := context.Background()
+	id := createGame(t, r)
+
+	cols, err := r.Columns.GetColumnsByGameID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetColumnsByGameID: %v", err)
+	}
+	var titles []string
+	for _, c := range cols {
+		titles = append(titles, c.Title)
+	}
+	// subcolumns come first, top-level columns (no parent) last
+	want := []string{"In Progress", "Done", "Backlog", "Build", "Deployed"}
+	if len(titles) != len(want) {
+		t.Fatalf("columns = %v; want %v", titles, want)
+	}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Fatalf("columns = %v; want %v", titles, want)
+		}
+	}
+	if cols[0].ParentID == nil || *cols[0].ParentID != cols[3].ID {
+		t.Errorf("In Progress parent = %v; want %s", cols[0].ParentID, cols[3].ID)
+	}
+
+	empty, err := r.Columns.GetColumnsByGameID(ctx, uuid.New())
+	if err != nil {
+		t.Fatalf("GetColumnsByGameID: %v", err)
+	}
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("columns of an unknown game = %#v; want an empty, non-nil list", empty)
+	}
+}
+
+func testUpdateColumn(t *testing.T, r Repos) {
+	ctx := context.Background()
+	id := createGame(t, r)
+	cols, err := r.Columns.GetColumnsByGameID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetColumnsByGameID: %v", err)
+	}
+	byTitle := make(map[string]models.Column)
+	for _, c := range cols {
+		byTitle[c.Title] = c
+	}
+	build, inProgress, done := byTitle["Build"], byTitle["In Progress"], byTitle["Done"]
+	ptr := func(v int) *int { return &v }
+	str := func(v string) *string { return &v }
+	titles := func(cols []models.Column) []string {
+		var got []string
+		for _, c := range cols {
+			got = append(got, c.Title)
+		}
+		return got
+	}
+
+	// 1) WIP limit and title together
+	col, err := r.Columns.UpdateColumn(ctx, id, build.ID, models.ColumnUpdate{Title: str(" Develop "), WIPLimit: ptr(3)})
+	if err != nil {
+		t.Fatalf("UpdateColumn: %v", err)
+	}
+	if col.Title != "Develop" || col.WIPLimit != 3 || !slices.Equal(titles(col.SubColumns), []string{"In Progress", "Done"}) {
+		t.Errorf("column = %+v; want Develop with WIP limit 3 and its two sub-columns", col)
+	}
+	if _, err := r.Columns.UpdateColumn(ctx, id, done.ID, models.ColumnUpdate{Title: str("Tested")}); err != nil {
+		t.Fatalf("UpdateColumn of a sub-column: %v", err)
+	}
+
+	// 2) sub-columns reordered, added and removed; order_index stays dense
+	col, err = r.Columns.UpdateColumn(ctx, id, build.ID, models.ColumnUpdate{SubColumns: &[]models.SubColumnSpec{
+		{Title: "Review", Type: "queue"},
+		{ID: &inProgress.ID},
+	}})
+	if err != nil {
+		t.Fatalf("UpdateColumn with sub-columns: %v", err)
+	}
+	if !slices.Equal(titles(col.SubColumns), []string{"Review", "In Progress"}) ||
+		col.SubColumns[0].OrderIndex != 0 || col.SubColumns[1].OrderIndex != 1 || col.SubColumns[1].ID != inProgress.ID {
+		t.Errorf("sub-columns = %+v; want Review, then In Progress", col.SubColumns)
+	}
+	board, err := r.Games.GetBoard(ctx, id)
+	if err != nil {
+		t.Fatalf("GetBoard: %v", err)
+	}
+	for _, c := range board.Columns {
+		if c.ID == build.ID && !slices.Equal(titles(c.SubColumns), []string{"Review", "In Progress"}) {
+			t.Errorf("board sub-columns of Develop = %v; want Review, In Progress", titles(c.SubColumns))
+		}
+	}
+
+	// 3) changes the board cannot take leave it as it is
+	rejected := []struct {
+		name   string
+		column uuid.UUID
+		update models.ColumnUpdate
+		want   error
+	}{
+		{"removing a sub-column with cards", build.ID, models.ColumnUpdate{SubColumns: &[]models.SubColumnSpec{{Title: "Review"}}}, columns.ErrColumnHasCards},
+		{"splitting a column with cards", byTitle["Backlog"].ID, models.ColumnUpdate{SubColumns: &[]models.SubColumnSpec{{Title: "New"}}}, columns.ErrColumnHasCards},
+		{"sub-columns in a done column", byTitle["Deployed"].ID, models.ColumnUpdate{SubColumns: &[]models.SubColumnSpec{{Title: "Live", Type: "queue"}}}, columns.ErrInvalidColumn},
+		{"WIP limit of a sub-column", inProgress.ID, models.ColumnUpdate{WIPLimit: ptr(1)}, columns.ErrInvalidColumn},
+		{"negative WIP limit", build.ID, models.ColumnUpdate{WIPLimit: ptr(-1)}, columns.ErrInvalidColumn},
+		{"title taken", byTitle["Backlog"].ID, models.ColumnUpdate{Title: str("Deployed")}, columns.ErrInvalidColumn},
+		{"unknown column", uuid.New(), models.ColumnUpdate{Title: str("X")}, columns.ErrColumnNotFound},
+	}
+	for _, tc := range rejected {
+		if _, err := r.Columns.UpdateColumn(ctx, id, tc.column, tc.update); !errors.Is(err, tc.want) {
+			t.Errorf("%s: err = %v; want %v", tc.name, err, tc.want)
+		}
+	}
+	if _, err := r.Columns.UpdateColumn(ctx, uuid.New(), build.ID, models.ColumnUpdate{Title: str("X")}); !errors.Is(err, columns.ErrNotFound) {
+		t.Errorf("UpdateColumn of an unknown game: err = %v; want %v", err, columns.ErrNotFound)
+	}
+	if _, err := r.Columns.UpdateColumn(ctx, createGame(t, r), build.ID, models.ColumnUpdate{Title: str("X")}); !errors.Is(err, columns.ErrColumnNotFound) {
+		t.Errorf("UpdateColumn with a column of another game: err = %v; want %v", err, columns.ErrColumnNotFound)
+	}
+
+	// 4) every change is logged on the current day
+	doc, err := r.Games.ExportGame(ctx, id)
+	if err != nil {
+		t.Fatalf("ExportGame: %v", err)
+	}
+	var types []string
+	for _, e := range doc.Events {
+		if e.CardID != nil || e.Day != 1 {
+			t.Errorf("event %+v; want one of the whole game on day 1", e)
+		}
+		types = append(types, e.EventType)
+	}
+	want := []string{"wip_changed", "column_changed", "column_changed", "column_changed"}
+	if !slices.Equal(types, want) {
+		t.Fatalf("events = %v; want %v", types, want)
+	}
+	var wip models.WIPChange
+	if err := json.Unmarshal(doc.Events[0].Payload, &wip); err != nil || wip != (models.WIPChange{ColumnID: build.ID, Column: "Build", From: 2, To: 3}) {
+		t.Errorf("wip_changed = %+v (%v); want Build from 2 to 3", wip, err)
+	}
+	var change models.ColumnChange
+	if err := json.Unmarshal(doc.Events[3].Payload, &change); err != nil ||
+		!slices.Equal(change.Added, []string{"Review"}) || !slices.Equal(change.Removed, []string{"Tested"}) ||
+		!slices.Equal(change.SubColumns, []string{"Review", "In Progress"}) {
+		t.Errorf("column_changed = %+v (%v); want Review added and Tested removed", change, err)
+	}
+
+	// 5) moves logged under the old titles still resolve
+	layout, err := r.Cards.GetFlowLayout(ctx, id)
+	if err != nil {
+		t.Fatalf("GetFlowLayout: %v", err)
+	}
+	if got := layout.Renamed["Build - In Progress"]; got != "Develop - In Progress" {
+		t.Errorf("Build - In Progress renamed to %q; want Develop - In Progress", got)
+	}
+	if got := layout.Renamed["Develop - Done"]; got != "Develop - Tested" {
+		t.Errorf("Develop - Done renamed to %q; want Develop - Tested", got)
+	}
+
+	// 6) an ended game is read-only
+	if err := r.Games.SetStatus(ctx, id, models.GameLobby, models.GameEnded, "game_ended"); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	if _, err := r.Columns.UpdateColumn(ctx, id, build.ID, models.ColumnUpdate{WIPLimit: ptr(4)}); !errors.Is(err, columns.ErrGameEnded) {
+		t.Errorf("UpdateColumn of an ended game: err = %v; want %v", err, columns.ErrGameEnded)
+	}
+}
+
+func testCards(t *testing.T, r Repos) {
+	ctx := context.Background()
+	id := createGame(t, r)
+
+	list, err := r.Cards.GetCardsByGameID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetCardsByGameID: %v", err)
+	}
+	if len(list) != 3 {
+		t.Fatalf("cards = %d; want 3", len(list))
+	}
+	for _, c := range list {
+		if c.GameID != id || c.ColumnID == uuid.Nil {
+			t.Errorf("card = %+v; want it in a column of game %s", c, id)
+		}
+	}
+
+	layout, err := r.Cards.GetFlowLayout(ctx, id)
+	if err != nil {
+		t.Fatalf("GetFlowLayout: %v", err)
+	}
+	var titles []string
+	for _, c := range layout.Columns {
+		titles = append(titles, c.Title+" ("+c.Type+")")
+	}
+	want := []string{"Backlog (queue)", "Build - In Progress (active)", "Build - Done (queue)", "Deployed (done)"}
+	if layout.Day != 1 || len(titles) != len(want) || len(layout.EffortTypes) != 2 {
+		t.Fatalf("layout = %+v; want day 1, columns %v and 2 effort types", layout, want)
+	}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Fatalf("layout columns = %v; want %v", titles, want)
+		}
+	}
+
+	var (
+		seen []string
+		last string
+	)
+	err = r.Cards.StreamCardFlows(ctx, id, func(f cards.CardFlow) error {
+		if id := f.Card.ID.String(); id < last {
+			t.Errorf("card %s streamed after %s; want ID order", id, last)
+		} else {
+			last = id
+		}
+		seen = append(seen, f.Card.Title)
+		if f.Card.Title == "C1" && (len(f.Card.Efforts) != 2 || f.Card.Efforts[0].EffortType != "Build") {
+			t.Errorf("C1 efforts = %+v; want Build then Test", f.Card.Efforts)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamCardFlows: %v", err)
+	}
+	if len(seen) != 3 {
+		t.Errorf("streamed cards = %v; want 3", seen)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = r.Cards.StreamCardFlows(ctx, id, func(cards.CardFlow) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("StreamCardFlows with a failing callback: err = %v after %d calls; want %v after 1", err, calls, stop)
+	}
+
+	none, err := r.Cards.GetCardsByGameID(ctx, uuid.New())
+	if err != nil || len(none) != 0 {
+		t.Errorf("cards of an unknown game = %v, %v; want none", none, err)
+	}
+}
+
+func testDeleteGameCascades(t *testing.T, r Repos) {
+	ctx := context.Background()
+	id := createGame(t, r)
+	kept := createGame(t, r)
+
+	playerID, err := r.Players.CreatePlayer(ctx, id, "Alice")
+	if err != nil {
+		t.Fatalf("CreatePlayer: %v", err)
+	}
+	if _, err := r.Players.CreatePlayer(ctx, kept, "Bob"); err != nil {
+		t.Fatalf("CreatePlayer: %v", err)
+	}
+	if err := r.Games.SaveSnapshot(ctx, id, 1); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+	if err := r.Games.UpdateGame(ctx, id, 2); err != nil {
+		t.Fatalf("UpdateGame: %v", err)
+	}
+	fork, err := r.Games.ForkGame(ctx, id, 1)
+	if err != nil {
+		t.Fatalf("ForkGame: %v", err)
+	}
+
+	if err := r.Games.DeleteGame(ctx, id); err != nil {
+		t.Fatalf("DeleteGame: %v", err)
+	}
+
+	if _, err := r.Players.GetPlayerByID(ctx, playerID); !errors.Is(err, players.ErrNotFound) {
+		t.Errorf("player of a deleted game: err = %v; want %v", err, players.ErrNotFound)
+	}
+	if cols, _ := r.Columns.GetColumnsByGameID(ctx, id); len(cols) != 0 {
+		t.Errorf("columns of a deleted game = %d; want 0", len(cols))
+	}
+	if list, _ := r.Cards.GetCardsByGameID(ctx, id); len(list) != 0 {
+		t.Errorf("cards of a deleted game = %d; want 0", len(list))
+	}
+
+	// other games are untouched; the fork just loses its parent
+	if list, _ := r.Players.ListPlayersByGameID(ctx, kept); len(list) != 1 {
+		t.Errorf("players of another game = %d; want 1", len(list))
+	}
+	if list, _ := r.Cards.GetCardsByGameID(ctx, kept); len(list) != 3 {
+		t.Errorf("cards of another game = %d; want 3", len(list))
+	}
+	g, err := r.Games.GetGameByID(ctx, fork)
+	if err != nil {
+		t.Fatalf("fork of a deleted game: %v", err)
+	}
+	if g.ParentGameID != nil {
+		t.Errorf("fork parent = %v; want none", g.ParentGameID)
+	}
+}
+
+func testFork(t *testing.T, r Repos) {
+	ctx := context.Background()
+	id := createGame(t, r)
+	alice, err := r.Players.CreatePlayer(ctx, id, "Alice")
+	if err != nil {
+		t.Fatalf("CreatePlayer: %v", err)
+	}
+	if err := r.Games.SetFacilitator(ctx, id, alice); err != nil {
+		t.Fatalf("SetFacilitator: %v", err)
+	}
+
+	// day 1 ends and is snapshotted; saving twice replaces the first one
+	if err := r.Games.SaveSnapshot(ctx, id, 1); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+	if err := r.Games.SaveSnapshot(ctx, id, 1); err != nil {
+		t.Fatalf("SaveSnapshot again: %v", err)
+	}
+	if err := r.Games.UpdateGame(ctx, id, 3); err != nil {
+		t.Fatalf("UpdateGame: %v", err)
+	}
+
+	fork, err := r.Games.ForkGame(ctx, id, 1)
+	if err != nil {
+		t.Fatalf("ForkGame: %v", err)
+	}
+	g, err := r.Games.GetGameByID(ctx, fork)
+	if err != nil {
+		t.Fatalf("GetGameByID: %v", err)
+	}
+	if g.Day != 2 || g.Seed != 42 || g.Scenario != "small" || g.ParentGameID == nil || *g.ParentGameID != id ||
+		g.ForkedAtDay == nil || *g.ForkedAtDay != 1 || g.Quality != board().Quality {
+		t.Errorf("fork = %+v; want day 2, seed 42, scenario small and its quality, forked from %s at day 1", g, id)
+	}
+	list, _ := r.Players.ListPlayersByGameID(ctx, fork)
+	if len(list) != 1 || list[0].Name != "Alice" {
+		t.Fatalf("players of the fork = %+v; want Alice", list)
+	}
+	if g.FacilitatorID == nil || *g.FacilitatorID != list[0].ID {
+		t.Errorf("facilitator of the fork = %v; want its Alice, %s", g.FacilitatorID, list[0].ID)
+	}
+	if list, _ := r.Cards.GetCardsByGameID(ctx, fork); len(list) != 3 {
+		t.Errorf("cards of the fork = %d; want 3", len(list))
+	}
+
+	current, err := r.Games.ForkGame(ctx, id, 0)
+	if err != nil {
+		t.Fatalf("ForkGame of the current day: %v", err)
+	}
+	if g, _ := r.Games.GetGameByID(ctx, current); g.Day != 3 || g.ForkedAtDay == nil || *g.ForkedAtDay != 3 {
+		t.Errorf("fork of the current day = %+v; want day 3", g)
+	}
+
+	if _, err := r.Games.ForkGame(ctx, id, 3); !errors.Is(err, games.ErrInvalidDay) {
+		t.Errorf("ForkGame of a day not over yet: err = %v; want %v", err, games.ErrInvalidDay)
+	}
+	if _, err := r.Games.ForkGame(ctx, id, 2); !errors.Is(err, games.ErrSnapshotNotFound) {
+		t.Errorf("ForkGame of a day without snapshot: err = %v; want %v", err, games.ErrSnapshotNotFound)
+	}
+}
+
+// testForkRollsLikeParent plays a game, a fork of it and an import of its
+// export on with the same bot. The copies have the seed of the game and roll
+// the same dice, though their cards and players get new IDs, so all boards
+// stay alike day after day, and a player rolls what the same seat rolls in
+// the game.
+func testForkRollsLikeParent(t *testing.T, r Repos) {
+	ctx := context.Background()
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, err := engine.ParseTeam(engine.DefaultTeam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	strategy, err := engine.StrategyByName("strict-wip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := games.NewService(r.Games)
+	play := func(id uuid.UUID, days int) {
+		t.Helper()
+		for range days {
+			if _, err := svc.PlayBotDay(ctx, id, strategy, team); err != nil {
+				t.Fatalf("PlayBotDay: %v", err)
+			}
+		}
+	}
+
+	id, err := svc.CreateGame(ctx, models.BoardConfig{
+		Seed: 11, EffortTypes: cfg.EffortTypes, Columns: cfg.Columns, Cards: cfg.Cards,
+		Quality: models.Quality{TestFailure: 0.3, Rework: 0.5, EscapedDefect: 0.3, DefectEffort: 0.5},
+	})
+	if err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	for _, name := range []string{"Alice", "Bob"} {
+		if _, err := r.Players.CreatePlayer(ctx, id, name); err != nil {
+			t.Fatalf("CreatePlayer: %v", err)
+		}
+	}
+	if _, err := svc.ChangeStatus(ctx, id, games.Start); err != nil {
+		t.Fatalf("ChangeStatus: %v", err)
+	}
+	play(id, 3)
+
+	fork, err := svc.ForkGame(ctx, id, 0)
+	if err != nil {
+		t.Fatalf("ForkGame: %v", err)
+	}
+	doc, err := svc.ExportGame(ctx, id)
+	if err != nil {
+		t.Fatalf("ExportGame: %v", err)
+	}
+	imported, err := svc.ImportGame(ctx, doc)
+	if err != nil {
+		t.Fatalf("ImportGame: %v", err)
+	}
+	copies := map[string]uuid.UUID{"fork": fork, "import": imported}
+	for _, c := range copies {
+		if _, err := svc.ChangeStatus(ctx, c, games.Start); err != nil {
+			t.Fatalf("ChangeStatus of a copy: %v", err)
+		}
+	}
+	play(id, 10)
+	want := boardByTitle(t, r, id)
+	for name, c := range copies {
+		play(c, 10)
+		if got := boardByTitle(t, r, c); !reflect.DeepEqual(got, want) {
+			for title, card := range want {
+				if got[title] != card {
+					t.Errorf("card %s of the %s = %s; want %s as in the game", title, name, got[title], card)
+				}
+			}
+			t.Errorf("%s has %d cards; want %d as the game", name, len(got), len(want))
+		}
+	}
+
+	roll := func(game uuid.UUID) int {
+		t.Helper()
+		players, err := r.Players.ListPlayersByGameID(ctx, game)
+		if err != nil {
+			t.Fatalf("ListPlayersByGameID: %v", err)
+		}
+		b, err := r.Games.GetBoard(ctx, game)
+		if err != nil {
+			t.Fatalf("GetBoard: %v", err)
+		}
+		for _, p := range players {
+			for _, c := range b.Cards {
+				if p.Name == "Bob" && c.Title == "S1" {
+					d, err := svc.RollDice(ctx, game, p.ID, c.ID)
+					if err != nil {
+						t.Fatalf("RollDice: %v", err)
+					}
+					return d.Value
+				}
+			}
+		}
+		t.Fatalf("no Bob or S1 in game %s", game)
+		return 0
+	}
+	rolled := roll(id)
+	for name, c := range copies {
+		if got := roll(c); got != rolled {
+			t.Errorf("Bob rolls %d in the %s; want %d as in the game", got, name, rolled)
+		}
+	}
+}
+
+// testSessionGamesRollAlike plays the games of a session on with the same
+// bot. The teams' cards and players have IDs and names of their own, but the
+// games share the seed and the board, so they roll the same dice: the boards
+// stay alike, and the players who joined second roll the same.
+func testSessionGamesRollAlike(t *testing.T, r Repos) {
+	ctx := context.Background()
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, err := engine.ParseTeam(engine.DefaultTeam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	strategy, err := engine.StrategyByName("strict-wip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := games.NewService(r.Games)
+
+	session, err := sessions.NewService(r.Sessions, svc).CreateSession(ctx, "Workshop", 2, models.BoardConfig{
+		EffortTypes: cfg.EffortTypes, Columns: cfg.Columns, Cards: cfg.Cards,
+		Quality: models.Quality{TestFailure: 0.3, Rework: 0.5, EscapedDefect: 0.3, DefectEffort: 0.5},
+	})
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	rolls := make([]int, len(session.Games))
+	for i, g := range session.Games {
+		var second uuid.UUID
+		for j := range 2 {
+			id, err := r.Players.CreatePlayer(ctx, g.GameID, fmt.Sprintf("%s player %d", g.Team, j+1))
+			if err != nil {
+				t.Fatalf("CreatePlayer: %v", err)
+			}
+			second = id
+		}
+		if _, err := svc.ChangeStatus(ctx, g.GameID, games.Start); err != nil {
+			t.Fatalf("ChangeStatus: %v", err)
+		}
+		for range 10 {
+			if _, err := svc.PlayBotDay(ctx, g.GameID, strategy, team); err != nil {
+				t.Fatalf("PlayBotDay: %v", err)
+			}
+		}
+
+		b, err := r.Games.GetBoard(ctx, g.GameID)
+		if err != nil {
+			t.Fatalf("GetBoard: %v", err)
+		}
+		card := slices.IndexFunc(b.Cards, func(c models.Card) bool { return c.Title == "S1" })
+		if card < 0 {
+			t.Fatalf("no S1 in game of %s", g.Team)
+		}
+		d, err := svc.RollDice(ctx, g.GameID, second, b.Cards[card].ID)
+		if err != nil {
+			t.Fatalf("RollDice: %v", err)
+		}
+		rolls[i] = d.Value
+	}
+
+	first, second := session.Games[0], session.Games[1]
+	if got, want := boardByTitle(t, r, second.GameID), boardByTitle(t, r, first.GameID); !reflect.DeepEqual(got, want) {
+		for title, card := range want {
+			if got[title] != card {
+				t.Errorf("card %s of %s = %s; want %s as of %s", title, second.Team, got[title], card, first.Team)
+			}
+		}
+	}
+	if rolls[1] != rolls[0] {
+		t.Errorf("second player of %s rolls %d; want %d as of %s", second.Team, rolls[1], rolls[0], first.Team)
+	}
+}
+
+// boardByTitle describes where each card of a game stands, by title.
+func boardByTitle(t *testing.T, r Repos, id uuid.UUID) map[string]string {
+	t.Helper()
+	b, err := r.Games.GetBoard(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetBoard: %v", err)
+	}
+	columns := make(map[uuid.UUID]string)
+	for _, c := range b.Columns {
+		columns[c.ID] = c.Title
+		for _, sub := range c.SubColumns {
+			columns[sub.ID] = c.Title + " - " + sub.Title
+		}
+	}
+	cards := make(map[string]string, len(b.Cards))
+	for _, c := range b.Cards {
+		cards[c.Title] = fmt.Sprintf("%s, days %d-%d, efforts %v", columns[c.ColumnID], c.SelectedDay, c.DeployedDay, c.Efforts)
+	}
+	return cards
+}
+
+func testExportImport(t *testing.T, r Repos) {
+	ctx := context.Background()
+	id := createGame(t, r)
+	alice, err := r.Players.CreatePlayer(ctx, id, "Alice")
+	if err != nil {
+		t.Fatalf("CreatePlayer: %v", err)
+	}
+	if err := r.Games.SetFacilitator(ctx, id, alice); err != nil {
+		t.Fatalf("SetFacilitator: %v", err)
+	}
+
+	doc, err := r.Games.ExportGame(ctx, id)
+	if err != nil {
+		t.Fatalf("ExportGame: %v", err)
+	}
+	if doc.Game.ID != id || doc.Seed != 42 || len(doc.Columns) != 3 || len(doc.Cards) != 3 ||
+		len(doc.EffortTypes) != 2 || len(doc.Players) != 1 {
+		t.Fatalf("export = %+v; want the whole game", doc)
+	}
+
+	// the archive gets a move history for C2 before it is imported
+	var c2 models.Card
+	for _, c := range doc.Cards {
+		if c.Title == "C2" {
+			c2 = c
+		}
+	}
+	if c2.ColumnTitle != "Build - In Progress" {
+		t.Fatalf("C2 column title = %q; want Build - In Progress", c2.ColumnTitle)
+	}
+	payload, _ := json.Marshal(map[string]string{"from": "Backlog", "to": "Build - In Progress"})
+	doc.Events = append(doc.Events, models.GameEvent{
+		CardID: &c2.ID, EventType: "move", Payload: payload, Day: 1, CreatedAt: time.Now().UTC(),
+	})
+
+	imported, err := r.Games.ImportGame(ctx, doc)
+	if err != nil {
+		t.Fatalf("ImportGame: %v", err)
+	}
+	if imported == id {
+		t.Fatal("ImportGame reused the exported ID")
+	}
+	g, err := r.Games.GetGameByID(ctx, imported)
+	if err != nil {
+		t.Fatalf("GetGameByID of the import: %v", err)
+	}
+	if list, _ := r.Players.ListPlayersByGameID(ctx, imported); len(list) != 1 || g.FacilitatorID == nil || *g.FacilitatorID != list[0].ID {
+		t.Errorf("facilitator of the import = %v, players %+v; want its Alice", g.FacilitatorID, list)
+	}
+
+	again, err := r.Games.ExportGame(ctx, imported)
+	if err != nil {
+		t.Fatalf("ExportGame of the import: %v", err)
+	}
+	if again.Seed != doc.Seed || again.Game.Day != doc.Game.Day || again.Game.Scenario != "small" || len(again.Cards) != len(doc.Cards) ||
+		len(again.Players) != 1 || len(again.Events) != 1 {
+		t.Errorf("re-export = %+v; want the same game", again)
+	}
+
+	var moves []cards.CardMove
+	err = r.Cards.StreamCardFlows(ctx, imported, func(f cards.CardFlow) error {
+		if f.Card.Title == "C2" {
+			moves = f.Moves
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamCardFlows: %v", err)
+	}
+	want := cards.CardMove{Day: 1, From: "Backlog", To: "Build - In Progress"}
+	if len(moves) != 1 || moves[0] != want {
+		t.Errorf("C2 moves = %+v; want [%+v]", moves, want)
+	}
+
+	bad := doc
+	unknown := uuid.New()
+	bad.Events = []models.GameEvent{{CardID: &unknown, EventType: "move", Payload: payload, Day: 1}}
+	if _, err := r.Games.ImportGame(ctx, bad); !errors.Is(err, games.ErrInvalidExport) {
+		t.Errorf("ImportGame with an event of an unknown card: err = %v; want %v", err, games.ErrInvalidExport)
+	}
+}
+
+func testApplyDay(t *testing.T, r Repos) {
+	ctx := context.Background()
+	id := createGame(t, r)
+	b, err := r.Games.GetBoard(ctx, id)
+	if err != nil {
+		t.Fatalf("GetBoard: %v", err)
+	}
+	var c1 models.Card
+	for _, c := range b.Cards {
+		if c.Title == "C1" {
+			c1 = c
+		}
+	}
+
+	// C1 is pulled through to Build - Done on day 1
+	change := models.CardChange{
+		CardID:      c1.ID,
+		ColumnID:    b.Columns[1].SubColumns[1].ID,
+		SelectedDay: 1,
+		Efforts: []models.Effort{
+			{EffortType: "Build", Estimate: 3, Remaining: 0, Actual: 3},
+			{EffortType: "Test", Estimate: 2, Remaining: 2},
+		},
+		Moves: []models.ColumnMove{
+			{From: "Backlog", To: "Build - In Progress"},
+			{From: "Build - In Progress", To: "Build - Done"},
+		},
+	}
+	if err := r.Games.ApplyDay(ctx, id, 1, []models.CardChange{change}); err != nil {
+		t.Fatalf("ApplyDay: %v", err)
+	}
+
+	after, err := r.Games.GetBoard(ctx, id)
+	if err != nil {
+		t.Fatalf("GetBoard after ApplyDay: %v", err)
+	}
+	var got models.Card
+	for _, c := range after.Cards {
+		if c.ID == c1.ID {
+			got = c
+		}
+	}
+	if got.ColumnID != change.ColumnID || got.SelectedDay != 1 || len(got.Efforts) != 2 ||
+		got.Efforts[0].Remaining != 0 || got.Efforts[0].Actual != 3 || got.Efforts[1].Remaining != 2 {
+		t.Errorf("C1 = %+v; want selected on day 1, in Build - Done, Build effort done", got)
+	}
+
+	var moves []cards.CardMove
+	err = r.Cards.StreamCardFlows(ctx, id, func(f cards.CardFlow) error {
+		if f.Card.ID == c1.ID {
+			moves = f.Moves
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamCardFlows: %v", err)
+	}
+	want := []cards.CardMove{
+		{Day: 1, From: "Backlog", To: "Build - In Progress"},
+		{Day: 1, From: "Build - In Progress", To: "Build - Done"},
+	}
+	if len(moves) != 2 || moves[0] != want[0] || moves[1] != want[1] {
+		t.Errorf("C1 moves = %+v; want %+v", moves, want)
+	}
+
+	// the cards of one game cannot be changed through another
+	other := createGame(t, r)
+	if err := r.Games.ApplyDay(ctx, other, 2, []models.CardChange{change}); !errors.Is(err, games.ErrNotFound) {
+		t.Errorf("ApplyDay with a card of another game: err = %v; want %v", err, games.ErrNotFound)
+	}
+}
+
+func testApplyDayQuality(t *testing.T, r Repos) {
+	ctx := context.Background()
+	id := createGame(t, r)
+	b, err := r.Games.GetBoard(ctx, id)
+	if err != nil {
+		t.Fatalf("GetBoard: %v", err)
+	}
+	byTitle := make(map[string]models.Card)
+	for _, c := range b.Cards {
+		byTitle[c.Title] = c
+	}
+	c2, c3 := byTitle["C2"], byTitle["C3"]
+	defectID := uuid.New()
+
+	// C2 fails on day 1 and goes back to work; C3 turns out to have a
+	// defect, which is spawned into the backlog
+	failure := &models.TestFailure{From: "Build - Done", To: "Build - In Progress", Rework: 3}
+	changes := []models.CardChange{
+		{
+			CardID: c2.ID, ColumnID: b.Columns[1].SubColumns[0].ID, SelectedDay: 1,
+			Efforts: []models.Effort{
+				{EffortType: "Build", Estimate: 5, Remaining: 3, Actual: 5},
+				{EffortType: "Test", Estimate: 4, Remaining: 4},
+			},
+			Moves: []models.ColumnMove{
+				{From: "Build - In Progress", To: "Build - Done"},
+				{From: "Build - Done", To: "Build - In Progress"},
+			},
+			TestFailure: failure,
+		},
+		{
+			CardID: c3.ID, ColumnID: c3.ColumnID, SelectedDay: 1, DeployedDay: 1,
+			Defect: &models.Defect{CardID: defectID, Title: "C3 defect"},
+		},
+		{
+			CardID: defectID, ColumnID: b.Columns[0].ID, Spawned: true,
+			Title: "C3 defect", ClassOfService: "E",
+			Efforts: []models.Effort{
+				{EffortType: "Build", Estimate: 1, Remaining: 1},
+				{EffortType: "Test", Estimate: 1, Remaining: 1},
+			},
+		},
+	}
+	if err := r.Games.ApplyDay(ctx, id, 1, changes); err != nil {
+		t.Fatalf("ApplyDay: %v", err)
+	}
+
+	after, err := r.Games.GetBoard(ctx, id)
+	if err != nil {
+		t.Fatalf("GetBoard after ApplyDay: %v", err)
+	}
+	var defect *models.Card
+	for i, c := range after.Cards {
+		if c.ID == defectID {
+			defect = &after.Cards[i]
+		}
+	}
+	if len(after.Cards) != 4 || defect == nil {
+		t.Fatalf("cards = %+v; want the defect added", after.Cards)
+	}
+	if defect.Title != "C3 defect" || defect.ClassOfService != "E" || defect.ColumnID != b.Columns[0].ID ||
+		len(defect.Efforts) != 2 || defect.Efforts[0].Remaining != 1 {
+		t.Errorf("defect = %+v; want an expedite card in Backlog", *defect)
+	}
+
+	doc, err := r.Games.ExportGame(ctx, id)
+	if err != nil {
+		t.Fatalf("ExportGame: %v", err)
+	}
+	var failed, escaped []models.GameEvent
+	for _, ev := range doc.Events {
+		switch ev.EventType {
+		case "test_failed":
+			failed = append(failed, ev)
+		case "defect_escaped":
+			escaped = append(escaped, ev)
+		}
+	}
+	var gotFailure models.TestFailure
+	if len(failed) != 1 || *failed[0].CardID != c2.ID || json.Unmarshal(failed[0].Payload, &gotFailure) != nil ||
+		gotFailure != *failure {
+		t.Errorf("test_failed events = %+v; want one on C2 with %+v", failed, *failure)
+	}
+	var gotDefect models.Defect
+	if len(escaped) != 1 || *escaped[0].CardID != c3.ID || json.Unmarshal(escaped[0].Payload, &gotDefect) != nil ||
+		gotDefect.CardID != defectID {
+		t.Errorf("defect_escaped events = %+v; want one on C3 naming the defect", escaped)
+	}
+}
+
+func testFacilitator(t *testing.T, r Repos) {
+	ctx := context.Background()
+	cfg := board()
+	cfg.Facilitator = "Grace"
+	id, err := r.Games.CreateGame(ctx, cfg)
+	if err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+
+	// the facilitator joins with the game
+	g, err := r.Games.GetGameByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetGameByID: %v", err)
+	}
+	list, err := r.Players.ListPlayersByGameID(ctx, id)
+	if err != nil {
+		t.Fatalf("ListPlayersByGameID: %v", err)
+	}
+	if len(list) != 1 || list[0].Name != "Grace" || g.FacilitatorID == nil || *g.FacilitatorID != list[0].ID {
+		t.Fatalf("game = %+v, players = %+v; want Grace as the facilitator", g, list)
+	}
+	grace := list[0].ID
+
+	// the role goes to another player of the game
+	alan, err := r.Players.CreatePlayer(ctx, id, "Alan")
+	if err != nil {
+		t.Fatalf("CreatePlayer: %v", err)
+	}
+	if err := r.Games.SetFacilitator(ctx, id, alan); err != nil {
+		t.Fatalf("SetFacilitator: %v", err)
+	}
+	if g, _ := r.Games.GetGameByID(ctx, id); g.FacilitatorID == nil || *g.FacilitatorID != alan {
+		t.Errorf("facilitator after handover = %v; want %s", g.FacilitatorID, alan)
+	}
+
+	// but not to a player of another game, nor of an unknown game
+	other := createGame(t, r)
+	stranger, err := r.Players.CreatePlayer(ctx, other, "Ada")
+	if err != nil {
+		t.Fatalf("CreatePlayer: %v", err)
+	}
+	if err := r.Games.SetFacilitator(ctx, id, stranger); !errors.Is(err, games.ErrPlayerNotInGame) {
+		t.Errorf("SetFacilitator with a player of another game: err = %v; want %v", err, games.ErrPlayerNotInGame)
+	}
+	if err := r.Games.SetFacilitator(ctx, uuid.New(), grace); !errors.Is(err, games.ErrNotFound) {
+		t.Errorf("SetFacilitator of an unknown game: err = %v; want %v", err, games.ErrNotFound)
+	}
+	if g, _ := r.Games.GetGameByID(ctx, other); g.FacilitatorID != nil {
+		t.Errorf("game created without a facilitator has %v", g.FacilitatorID)
+	}
+
+	// a facilitator who leaves leaves the role vacant
+	if err := r.Players.DeletePlayer(ctx, alan); err != nil {
+		t.Fatalf("DeletePlayer: %v", err)
+	}
+	if g, _ := r.Games.GetGameByID(ctx, id); g.FacilitatorID != nil {
+		t.Errorf("facilitator after leaving = %v; want none", g.FacilitatorID)
+	}
+}
+
+func testLifecycle(t *testing.T, r Repos) {
+	ctx := context.Background()
+	id := createGame(t, r)
+	alice, err := r.Players.CreatePlayer(ctx, id, "Alice")
+	if err != nil {
+		t.Fatalf("CreatePlayer: %v", err)
+	}
+	if g, _ := r.Games.GetGameByID(ctx, id); g.Status != models.GameLobby {
+		t.Fatalf("status of a new game = %q; want %q", g.Status, models.GameLobby)
+	}
+
+	if err := r.Games.SetStatus(ctx, id, models.GameLobby, models.GameRunning, "game_started"); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	if g, _ := r.Games.GetGameByID(ctx, id); g.Status != models.GameRunning {
+		t.Errorf("status after start = %q; want %q", g.Status, models.GameRunning)
+	}
+	// the game has moved on, so a second start finds it in the wrong status
+	if err := r.Games.SetStatus(ctx, id, models.GameLobby, models.GameRunning, "game_started"); !errors.Is(err, games.ErrInvalidTransition) {
+		t.Errorf("SetStatus from a stale status: err = %v; want %v", err, games.ErrInvalidTransition)
+	}
+	if err := r.Games.SetStatus(ctx, uuid.New(), models.GameLobby, models.GameRunning, "game_started"); !errors.Is(err, games.ErrNotFound) {
+		t.Errorf("SetStatus of an unknown game: err = %v; want %v", err, games.ErrNotFound)
+	}
+
+	// the step is logged as an event of the whole game
+	doc, err := r.Games.ExportGame(ctx, id)
+	if err != nil {
+		t.Fatalf("ExportGame: %v", err)
+	}
+	if len(doc.Events) != 1 || doc.Events[0].CardID != nil || doc.Events[0].EventType != "game_started" || doc.Events[0].Day != 1 {
+		t.Fatalf("events = %+v; want game_started on day 1", doc.Events)
+	}
+	var change models.StatusChange
+	if err := json.Unmarshal(doc.Events[0].Payload, &change); err != nil || change != (models.StatusChange{From: models.GameLobby, To: models.GameRunning}) {
+		t.Errorf("payload = %s; want the change from lobby to running", doc.Events[0].Payload)
+	}
+
+	// copies start over in the lobby, without the lifecycle of the original
+	imported, err := r.Games.ImportGame(ctx, doc)
+	if err != nil {
+		t.Fatalf("ImportGame: %v", err)
+	}
+	if again, _ := r.Games.ExportGame(ctx, imported); again.Game.Status != models.GameLobby || len(again.Events) != 0 {
+		t.Errorf("import = %q with %d events; want a lobby game without events", again.Game.Status, len(again.Events))
+	}
+	if err := r.Games.SaveSnapshot(ctx, id, 1); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+	if err := r.Games.UpdateGame(ctx, id, 2); err != nil {
+		t.Fatalf("UpdateGame: %v", err)
+	}
+	fork, err := r.Games.ForkGame(ctx, id, 1)
+	if err != nil {
+		t.Fatalf("ForkGame: %v", err)
+	}
+	if g, _ := r.Games.GetGameByID(ctx, fork); g.Status != models.GameLobby {
+		t.Errorf("status of the fork = %q; want %q", g.Status, models.GameLobby)
+	}
+
+	// once ended, players can no longer join, rename or leave
+	if err := r.Games.SetStatus(ctx, id, models.GameRunning, models.GameEnded, "game_ended"); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	if _, err := r.Players.CreatePlayer(ctx, id, "Bob"); !errors.Is(err, players.ErrGameEnded) {
+		t.Errorf("CreatePlayer in an ended game: err = %v; want %v", err, players.ErrGameEnded)
+	}
+	if err := r.Players.UpdatePlayer(ctx, alice, "Alicia"); !errors.Is(err, players.ErrGameEnded) {
+		t.Errorf("UpdatePlayer in an ended game: err = %v; want %v", err, players.ErrGameEnded)
+	}
+	if err := r.Players.DeletePlayer(ctx, alice); !errors.Is(err, players.ErrGameEnded) {
+		t.Errorf("DeletePlayer in an ended game: err = %v; want %v", err, players.ErrGameEnded)
+	}
+	if p, err := r.Players.GetPlayerByID(ctx, alice); err != nil || p.Name != "Alice" {
+		t.Errorf("player after the end = %+v, %v; want Alice unchanged", p, err)
+	}
+}
+
+func testReset(t *testing.T, r Repos) {
+	ctx := context.Background()
+	id := createGame(t, r)
+	alice, err := r.Players.CreatePlayer(ctx, id, "Alice")
+	if err != nil {
+		t.Fatalf("CreatePlayer: %v", err)
+	}
+	// CreateGame keeps the board the game starts with
+	initial, err := r.Games.GetBoard(ctx, id)
+	if err != nil {
+		t.Fatalf("GetBoard: %v", err)
+	}
+
+	// a day is played and the next one started
+	if err := r.Games.SetStatus(ctx, id, models.GameLobby, models.GameRunning, "game_started"); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	c1 := initial.Cards[0]
+	change := models.CardChange{
+		CardID:   c1.ID,
+		ColumnID: initial.Columns[1].SubColumns[0].ID,
+		Efforts:  []models.Effort{{EffortType: "Build", Estimate: 3, Remaining: 1, Actual: 2}},
+		Moves:    []models.ColumnMove{{From: "Backlog", To: "Build - In Progress"}},
+	}
+	if err := r.Games.ApplyDay(ctx, id, 1, []models.CardChange{change}); err != nil {
+		t.Fatalf("ApplyDay: %v", err)
+	}
+	if err := r.Games.SaveSnapshot(ctx, id, 1); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+	if err := r.Games.UpdateGame(ctx, id, 2); err != nil {
+		t.Fatalf("UpdateGame: %v", err)
+	}
+
+	// so do ForkGame and ImportGame, with the board they copied
+	played := boardByTitle(t, r, id)
+	fork, err := r.Games.ForkGame(ctx, id, 0)
+	if err != nil {
+		t.Fatalf("ForkGame: %v", err)
+	}
+	doc, err := r.Games.ExportGame(ctx, id)
+	if err != nil {
+		t.Fatalf("ExportGame: %v", err)
+	}
+	imported, err := r.Games.ImportGame(ctx, doc)
+	if err != nil {
+		t.Fatalf("ImportGame: %v", err)
+	}
+	for name, copyID := range map[string]uuid.UUID{"fork": fork, "import": imported} {
+		if err := r.Games.ResetGame(ctx, copyID); err != nil {
+			t.Fatalf("ResetGame of the %s: %v", name, err)
+		}
+		if after := boardByTitle(t, r, copyID); !reflect.DeepEqual(after, played) {
+			t.Errorf("%s after reset = %v; want the board it copied, %v", name, after, played)
+		}
+	}
+
+	if err := r.Games.ResetGame(ctx, id); err != nil {
+		t.Fatalf("ResetGame: %v", err)
+	}
+	if after, _ := r.Games.GetBoard(ctx, id); !reflect.DeepEqual(cardsByID(after), cardsByID(initial)) {
+		t.Errorf("board after reset = %+v; want %+v", after, initial)
+	}
+	if g, _ := r.Games.GetGameByID(ctx, id); g.Day != 1 || g.Status != models.GameLobby {
+		t.Errorf("game after reset = day %d, %q; want day 1 in the lobby", g.Day, g.Status)
+	}
+	if p, err := r.Players.GetPlayerByID(ctx, alice); err != nil || p.GameID != id {
+		t.Errorf("player after reset = %+v, %v; want Alice kept", p, err)
+	}
+	if doc, _ := r.Games.ExportGame(ctx, id); len(doc.Events) != 0 {
+		t.Errorf("events after reset = %+v; want none", doc.Events)
+	}
+	// the played day is forgotten, the initial board is not
+	if err := r.Games.UpdateGame(ctx, id, 3); err != nil {
+		t.Fatalf("UpdateGame: %v", err)
+	}
+	if _, err := r.Games.ForkGame(ctx, id, 1); !errors.Is(err, games.ErrSnapshotNotFound) {
+		t.Errorf("ForkGame of a day played before the reset: err = %v; want %v", err, games.ErrSnapshotNotFound)
+	}
+	if err := r.Games.ResetGame(ctx, id); err != nil {
+		t.Errorf("second ResetGame: %v", err)
+	}
+
+	if err := r.Games.ResetGame(ctx, uuid.New()); !errors.Is(err, games.ErrNotFound) {
+		t.Errorf("ResetGame of an unknown game: err = %v; want %v", err, games.ErrNotFound)
+	}
+}
+
+// cardsByID sorts the cards of b by ID; cards picked on the same day come
+// in no particular order.
+func testBlockers(t *testing.T, r Repos) {
+	ctx := context.Background()
+	id := createGame(t, r)
+	alice, err := r.Players.CreatePlayer(ctx, id, "Alice")
+	if err != nil {
+		t.Fatalf("CreatePlayer: %v", err)
+	}
+	card := func(gameID uuid.UUID, title string) models.Card {
+		t.Helper()
+		b, err := r.Games.GetBoard(ctx, gameID)
+		if err != nil {
+			t.Fatalf("GetBoard: %v", err)
+		}
+		for _, c := range b.Cards {
+			if c.Title == title {
+				return c
+			}
+		}
+		t.Fatalf("no card %s in game %s", title, gameID)
+		return models.Card{}
+	}
+	blocks := func(cardID uuid.UUID) []cards.CardBlock {
+		t.Helper()
+		var got []cards.CardBlock
+		err := r.Cards.StreamCardFlows(ctx, id, func(f cards.CardFlow) error {
+			if f.Card.ID == cardID {
+				got = f.Blocks
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("StreamCardFlows: %v", err)
+		}
+		return got
+	}
+	c2 := card(id, "C2")
+
+	b, err := r.Games.BlockCard(ctx, id, c2.ID, alice, models.Blocker{Reason: "Waiting for the customer", Day: 1, Effort: 2})
+	if err != nil {
+		t.Fatalf("BlockCard: %v", err)
+	}
+	want := models.Blocker{Reason: "Waiting for the customer", BlockedBy: "Alice", Day: 1, Effort: 2}
+	if b != want {
+		t.Errorf("blocker = %+v; want %+v", b, want)
+	}
+	if got := card(id, "C2").Blocker; got == nil || *got != want {
+		t.Errorf("C2 blocker = %+v; want %+v", got, want)
+	}
+
+	// the blocker is part of the card's state: snapshots, forks and exports
+	if err := r.Games.SaveSnapshot(ctx, id, 1); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+	if err := r.Games.UpdateGame(ctx, id, 2); err != nil {
+		t.Fatalf("UpdateGame: %v", err)
+	}
+	fork, err := r.Games.ForkGame(ctx, id, 1)
+	if err != nil {
+		t.Fatalf("ForkGame: %v", err)
+	}
+	if got := card(fork, "C2").Blocker; got == nil || *got != want {
+		t.Errorf("C2 blocker in the fork = %+v; want %+v", got, want)
+	}
+	doc, err := r.Games.ExportGame(ctx, id)
+	if err != nil {
+		t.Fatalf("ExportGame: %v", err)
+	}
+	for _, c := range doc.Cards {
+		if c.Title == "C2" && (c.Blocker == nil || *c.Blocker != want) {
+			t.Errorf("exported C2 blocker = %+v; want %+v", c.Blocker, want)
+		}
+	}
+
+	// working the blocker off on day 2 clears it
+	change := models.CardChange{
+		CardID:      c2.ID,
+		ColumnID:    c2.ColumnID,
+		SelectedDay: c2.SelectedDay,
+		Efforts:     c2.Efforts,
+		Unblock:     &models.Unblock{Days: 2, Worked: true},
+	}
+	if err := r.Games.ApplyDay(ctx, id, 2, []models.CardChange{change}); err != nil {
+		t.Fatalf("ApplyDay: %v", err)
+	}
+	if got := card(id, "C2").Blocker; got != nil {
+		t.Errorf("C2 blocker after it was worked off = %+v; want none", got)
+	}
+
+	// a blocker put down by an API key has nobody to put it down to
+	if _, err := r.Games.BlockCard(ctx, id, c2.ID, uuid.Nil, models.Blocker{Reason: "On hold", Day: 3}); err != nil {
+		t.Fatalf("BlockCard: %v", err)
+	}
+	if got := card(id, "C2").Blocker; got == nil || got.BlockedBy != "" || got.Effort != 0 {
+		t.Errorf("C2 blocker = %+v; want one without player or effort", got)
+	}
+	if err := r.Games.UnblockCard(ctx, id, c2.ID, 5, models.Unblock{Days: 2}); err != nil {
+		t.Fatalf("UnblockCard: %v", err)
+	}
+	if got := card(id, "C2").Blocker; got != nil {
+		t.Errorf("C2 blocker after unblocking = %+v; want none", got)
+	}
+
+	got := blocks(c2.ID)
+	if len(got) != 2 || got[0].Blocker != want || got[0].Unblock == nil || *got[0].Unblock != (models.Unblock{Days: 2, Worked: true}) ||
+		got[1].Blocker.Reason != "On hold" || got[1].Unblock == nil || got[1].Unblock.Days != 2 || got[1].Unblock.Worked {
+		t.Errorf("C2 blocks = %+v; want the worked off one, then the one lifted by hand", got)
+	}
+
+	// the cards of one game cannot be blocked through another
+	other := createGame(t, r)
+	if _, err := r.Games.BlockCard(ctx, other, c2.ID, uuid.Nil, models.Blocker{Reason: "On hold", Day: 1}); !errors.Is(err, games.ErrNotFound) {
+		t.Errorf("BlockCard with a card of another game: err = %v; want %v", err, games.ErrNotFound)
+	}
+	if err := r.Games.UnblockCard(ctx, other, c2.ID, 1, models.Unblock{}); !errors.Is(err, games.ErrNotFound) {
+		t.Errorf("UnblockCard with a card of another game: err = %v; want %v", err, games.ErrNotFound)
+	}
+}
+
+func testPositionCard(t *testing.T, r Repos) {
+	ctx := context.Background()
+	cfg := board()
+	cfg.Cards = append(cfg.Cards,
+		models.Card{Title: "C4", ColumnTitle: "Backlog", OrderIndex: 0},
+		models.Card{Title: "C5", ColumnTitle: "Backlog", OrderIndex: 5},
+	)
+	id, err := r.Games.CreateGame(ctx, cfg)
+	if err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	ids := make(map[string]uuid.UUID)
+	columns := make(map[string]uuid.UUID)
+	order := func(column string) []string {
+		t.Helper()
+		b, err := r.Games.GetBoard(ctx, id)
+		if err != nil {
+			t.Fatalf("GetBoard: %v", err)
+		}
+		var cards []models.Card
+		for _, c := range b.Cards {
+			ids[c.Title] = c.ID
+			if c.ColumnID == columns[column] {
+				cards = append(cards, c)
+			}
+		}
+		slices.SortFunc(cards, func(x, y models.Card) int { return x.OrderIndex - y.OrderIndex })
+		var titles []string
+		for i, c := range cards {
+			if c.OrderIndex != i {
+				t.Errorf("%s order = %+v; want it numbered from 0", column, cards)
+			}
+			titles = append(titles, c.Title)
+		}
+		return titles
+	}
+	b, _ := r.Games.GetBoard(ctx, id)
+	for _, c := range b.Columns {
+		columns[c.Title] = c.ID
+		for _, sub := range c.SubColumns {
+			columns[c.Title+" - "+sub.Title] = sub.ID
+		}
+	}
+
+	// a new game numbers every column from 0; equal indexes keep the
+	// order they were listed in
+	if got := order("Backlog"); !slices.Equal(got, []string{"C1", "C4", "C5"}) {
+		t.Fatalf("backlog = %v; want C1, C4, C5", got)
+	}
+
+	// 1) within the column; past the end is the bottom
+	got, err := r.Games.PositionCard(ctx, id, games.Placement{CardID: ids["C5"], ColumnID: columns["Backlog"], From: columns["Backlog"], Index: 0})
+	if err != nil {
+		t.Fatalf("PositionCard: %v", err)
+	}
+	if got.ColumnID != columns["Backlog"] || len(got.Cards) != 3 || got.Cards[0].Title != "C5" || got.Cards[2].OrderIndex != 2 {
+		t.Errorf("order = %+v; want C5 on top of 3 cards", got)
+	}
+	if _, err := r.Games.PositionCard(ctx, id, games.Placement{CardID: ids["C1"], ColumnID: columns["Backlog"], From: columns["Backlog"], Index: 10}); err != nil {
+		t.Fatalf("PositionCard: %v", err)
+	}
+	if got := order("Backlog"); !slices.Equal(got, []string{"C5", "C4", "C1"}) {
+		t.Errorf("backlog = %v; want C5, C4, C1", got)
+	}
+
+	// 2) into another column, closing the gap and logging the move
+	move := &models.ColumnMove{From: "Backlog", To: "Build - In Progress"}
+	if _, err := r.Games.PositionCard(ctx, id, games.Placement{
+		CardID: ids["C4"], ColumnID: columns["Build - In Progress"], From: columns["Backlog"], Index: 0, Day: 1, SelectedDay: 1, Move: move,
+	}); err != nil {
+		t.Fatalf("PositionCard: %v", err)
+	}
+	if got := order("Build - In Progress"); !slices.Equal(got, []string{"C4", "C2"}) {
+		t.Errorf("Build - In Progress = %v; want C4, C2", got)
+	}
+	if got := order("Backlog"); !slices.Equal(got, []string{"C5", "C1"}) {
+		t.Errorf("backlog = %v; want C5, C1", got)
+	}
+	if _, err := r.Games.PositionCard(ctx, id, games.Placement{CardID: ids["C4"], ColumnID: columns["Backlog"], From: columns["Backlog"]}); !errors.Is(err, games.ErrCardMoved) {
+		t.Errorf("PositionCard of a card no longer in its column: err = %v; want %v", err, games.ErrCardMoved)
+	}
+	doc, err := r.Games.ExportGame(ctx, id)
+	if err != nil {
+		t.Fatalf("ExportGame: %v", err)
+	}
+	var moves int
+	for _, e := range doc.Events {
+		var m models.ColumnMove
+		if e.EventType == "move" && e.CardID != nil && *e.CardID == ids["C4"] && json.Unmarshal(e.Payload, &m) == nil && m == *move && e.Day == 1 {
+			moves++
+		}
+	}
+	if moves != 1 {
+		t.Errorf("events = %+v; want the move of C4 on day 1", doc.Events)
+	}
+
+	// 3) a day played keeps every column numbered from 0: moved cards go
+	// to the bottom of their new column
+	if err := r.Games.ApplyDay(ctx, id, 2, []models.CardChange{{
+		CardID: ids["C5"], ColumnID: columns["Build - In Progress"], SelectedDay: 2,
+		Moves: []models.ColumnMove{{From: "Backlog", To: "Build - In Progress"}},
+	}}); err != nil {
+		t.Fatalf("ApplyDay: %v", err)
+	}
+	if got := order("Build - In Progress"); !slices.Equal(got, []string{"C4", "C2", "C5"}) {
+		t.Errorf("Build - In Progress = %v; want C4, C2, C5", got)
+	}
+	if got := order("Backlog"); !slices.Equal(got, []string{"C1"}) {
+		t.Errorf("backlog = %v; want C1", got)
+	}
+
+	if _, err := r.Games.PositionCard(ctx, createGame(t, r), games.Placement{CardID: ids["C1"], ColumnID: columns["Backlog"]}); !errors.Is(err, games.ErrNotFound) {
+		t.Errorf("PositionCard with a card of another game: err = %v; want %v", err, games.ErrNotFound)
+	}
+}
+
+func testPositionCardConcurrently(t *testing.T, r Repos) {
+	ctx := context.Background()
+	cfg := board()
+	for i := range 6 {
+		cfg.Cards = append(cfg.Cards, models.Card{Title: "R" + strconv.Itoa(i), ColumnTitle: "Backlog", OrderIndex: i + 1})
+	}
+	id, err := r.Games.CreateGame(ctx, cfg)
+	if err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	b, err := r.Games.GetBoard(ctx, id)
+	if err != nil {
+		t.Fatalf("GetBoard: %v", err)
+	}
+	var backlog, build uuid.UUID
+	for _, c := range b.Columns {
+		if c.Title == "Backlog" {
+			backlog = c.ID
+		}
+		for _, sub := range c.SubColumns {
+			if c.Title+" - "+sub.Title == "Build - In Progress" {
+				build = sub.ID
+			}
+		}
+	}
+	var cards []uuid.UUID
+	for _, c := range b.Cards {
+		if c.ColumnID == backlog {
+			cards = append(cards, c.ID)
+		}
+	}
+
+	// every worker reorders the backlog while one moves a card out of it
+	// and back; moves seen late by a reorder fail with ErrCardMoved
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 8 {
+				card := cards[(w+i)%len(cards)]
+				p := games.Placement{CardID: card, ColumnID: backlog, From: backlog, Index: (w * i) % len(cards)}
+				if w == 0 && card == cards[0] {
+					p.ColumnID = build
+				}
+				if _, err := r.Games.PositionCard(ctx, id, p); err != nil && !errors.Is(err, games.ErrCardMoved) {
+					errs <- err
+					return
+				}
+				if p.ColumnID == build {
+					p.ColumnID, p.From = backlog, build
+					if _, err := r.Games.PositionCard(ctx, id, p); err != nil {
+						errs <- err
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("PositionCard: %v", err)
+	}
+
+	b, err = r.Games.GetBoard(ctx, id)
+	if err != nil {
+		t.Fatalf("GetBoard: %v", err)
+	}
+	indexes := make(map[uuid.UUID][]int)
+	for _, c := range b.Cards {
+		indexes[c.ColumnID] = append(indexes[c.ColumnID], c.OrderIndex)
+	}
+	for col, got := range indexes {
+		slices.Sort(got)
+		for i, index := range got {
+			if index != i {
+				t.Errorf("column %s order indexes = %v; want them numbered from 0 without gaps", col, got)
+				break
+			}
+		}
+	}
+	if len(indexes[backlog]) != len(cards) {
+		t.Errorf("backlog has %d cards; want %d", len(indexes[backlog]), len(cards))
+	}
+}
+
+func testSearchCards(t *testing.T, r Repos) {
+	ctx := context.Background()
+	cfg := board()
+	cfg.Cards = append(cfg.Cards,
+		models.Card{Title: "C4", ColumnTitle: "Backlog", ClassOfService: "E", ValueEstimate: "high"},
+		models.Card{Title: "C5", ColumnTitle: "Build - In Progress", ClassOfService: "S", ValueEstimate: "high", SelectedDay: 3},
+		models.Card{Title: "C6", ColumnTitle: "Build - Done", ClassOfService: "S", ValueEstimate: "low", SelectedDay: 2},
+	)
+	id, err := r.Games.CreateGame(ctx, cfg)
+	if err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	if err := r.Games.UpdateGame(ctx, id, 4); err != nil {
+		t.Fatalf("UpdateGame: %v", err)
+	}
+	b, err := r.Games.GetBoard(ctx, id)
+	if err != nil {
+		t.Fatalf("GetBoard: %v", err)
+	}
+	ids := make(map[string]uuid.UUID)
+	for _, c := range b.Cards {
+		ids[c.Title] = c.ID
+	}
+	var build uuid.UUID
+	for _, c := range b.Columns {
+		if c.Title == "Build" {
+			build = c.ID
+		}
+	}
+	if _, err := r.Games.BlockCard(ctx, id, ids["C5"], uuid.Nil, models.Blocker{Reason: "On hold", Day: 4}); err != nil {
+		t.Fatalf("BlockCard: %v", err)
+	}
+
+	// search runs a search through every page, checking the pages are no
+	// longer than the limit, and returns the titles found
+	search := func(s cards.Search) []string {
+		t.Helper()
+		var titles []string
+		for range 10 {
+			list, next, err := r.Cards.SearchCards(ctx, id, s)
+			if err != nil {
+				t.Fatalf("SearchCards(%+v): %v", s, err)
+			}
+			if len(list) > s.Limit {
+				t.Fatalf("page of %d cards; want at most %d", len(list), s.Limit)
+			}
+			for _, c := range list {
+				titles = append(titles, c.Title)
+			}
+			if next == nil {
+				return titles
+			}
+			s.After = next
+		}
+		t.Fatalf("search %+v does not end", s)
+		return nil
+	}
+	query := func(sort string, limit int) cards.Search {
+		return cards.Search{CardQuery: models.CardQuery{Sort: sort, Limit: limit}}
+	}
+	blocked, unblocked := true, false
+
+	tests := []struct {
+		name   string
+		search cards.Search
+		want   []string
+	}{
+		{"board order", query(cards.SortPosition, 2), []string{"C1", "C4", "C5", "C2", "C6", "C3"}},
+		{"oldest first", cards.Search{CardQuery: models.CardQuery{Sort: cards.SortAge, Limit: 2, MinAge: 1}}, []string{"C2", "C6", "C5"}},
+		{"by title", query(cards.SortTitle, 5), []string{"C1", "C2", "C3", "C4", "C5", "C6"}},
+		{"column and its sub-columns", cards.Search{CardQuery: models.CardQuery{Sort: cards.SortPosition, Limit: 1, ColumnID: &build}}, []string{"C5", "C2", "C6"}},
+		{"class of service", cards.Search{CardQuery: models.CardQuery{Sort: cards.SortTitle, Limit: 10, ClassOfService: "S"}}, []string{"C5", "C6"}},
+		{"value", cards.Search{CardQuery: models.CardQuery{Sort: cards.SortTitle, Limit: 10, ValueEstimate: "high"}}, []string{"C2", "C4", "C5"}},
+		{"blocked", cards.Search{CardQuery: models.CardQuery{Sort: cards.SortTitle, Limit: 10, Blocked: &blocked}}, []string{"C5"}},
+		{"not blocked", cards.Search{CardQuery: models.CardQuery{Sort: cards.SortTitle, Limit: 10, Blocked: &unblocked}}, []string{"C1", "C2", "C3", "C4", "C6"}},
+		{"minimum age", cards.Search{CardQuery: models.CardQuery{Sort: cards.SortAge, Limit: 10, MinAge: 2}}, []string{"C2", "C6"}},
+		{"nothing", cards.Search{CardQuery: models.CardQuery{Sort: cards.SortAge, Limit: 10, ClassOfService: "F"}}, nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := search(tc.search); !slices.Equal(got, tc.want) {
+				t.Errorf("titles = %v; want %v", got, tc.want)
+			}
+		})
+	}
+
+	// cards come with their column, age and blocker
+	list, _, err := r.Cards.SearchCards(ctx, id, cards.Search{CardQuery: models.CardQuery{Sort: cards.SortTitle, Limit: 10, Blocked: &blocked}})
+	if err != nil {
+		t.Fatalf("SearchCards: %v", err)
+	}
+	if len(list) != 1 || list[0].ColumnTitle != "Build - In Progress" || list[0].Age != 1 || list[0].ClassOfService != "S" ||
+		list[0].Blocker == nil || list[0].Blocker.Reason != "On hold" {
+		t.Errorf("cards = %+v; want C5 with its column, age 1 and blocker", list)
+	}
+
+	if _, _, err := r.Cards.SearchCards(ctx, uuid.New(), query(cards.SortPosition, 10)); !errors.Is(err, cards.ErrNotFound) {
+		t.Errorf("SearchCards of an unknown game: err = %v; want %v", err, cards.ErrNotFound)
+	}
+}
+
+// testLeaderboard ends games through the service, which keeps their scores,
+// and one behind its back, which the leaderboard then scores itself.
+func testLeaderboard(t *testing.T, r Repos) {
+	ctx := context.Background()
+	svc := games.NewService(r.Games)
+
+	// a: small, day 5; b: large, day 3; c: small, day 4, ended without a
+	// score; d: small, not ended
+	large := board()
+	large.Scenario = "large"
+	ids := map[string]uuid.UUID{}
+	for _, g := range []struct {
+		name string
+		cfg  models.BoardConfig
+		day  int
+	}{{"a", board(), 5}, {"b", large, 3}, {"c", board(), 4}, {"d", board(), 2}} {
+		id, err := svc.CreateGame(ctx, g.cfg)
+		if err != nil {
+			t.Fatalf("CreateGame: %v", err)
+		}
+		if err := r.Games.UpdateGame(ctx, id, g.day); err != nil {
+			t.Fatalf("UpdateGame: %v", err)
+		}
+		ids[g.name] = id
+		time.Sleep(10 * time.Millisecond) // distinct created_at
+	}
+	for _, name := range []string{"a", "b"} {
+		if _, err := svc.ChangeStatus(ctx, ids[name], games.End); err != nil {
+			t.Fatalf("ChangeStatus: %v", err)
+		}
+	}
+	if err := r.Games.SetStatus(ctx, ids["c"], models.GameLobby, models.GameEnded, "game_ended"); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+
+	scored := func() map[uuid.UUID]*games.GameScore {
+		t.Helper()
+		list, err := r.Games.ScoredGames(ctx, models.GameQuery{Status: models.GameEnded})
+		if err != nil {
+			t.Fatalf("ScoredGames: %v", err)
+		}
+		scores := make(map[uuid.UUID]*games.GameScore, len(list))
+		for _, g := range list {
+			scores[g.ID] = g.Score
+		}
+		return scores
+	}
+	kept := scored()
+	if len(kept) != 3 || kept[ids["a"]] == nil || kept[ids["b"]] == nil || kept[ids["c"]] != nil {
+		t.Fatalf("scores of the ended games = %v; want a and b scored, c not", kept)
+	}
+	// C3 of the small board is deployed, and 4 days are played
+	if a := kept[ids["a"]]; a.Days != 4 || a.Deployed != 1 || a.Throughput != 0.25 {
+		t.Errorf("score of a = %+v; want 4 days and 1 card deployed", *a)
+	}
+
+	rank := func(f models.LeaderboardFilter) map[uuid.UUID]models.LeaderboardEntry {
+		t.Helper()
+		list, err := svc.Leaderboard(ctx, games.ScoreProfit, f)
+		if err != nil {
+			t.Fatalf("Leaderboard: %v", err)
+		}
+		entries := make(map[uuid.UUID]models.LeaderboardEntry, len(list))
+		for _, e := range list {
+			entries[e.GameID] = e
+		}
+		return entries
+	}
+	small := rank(models.LeaderboardFilter{Scenario: "small"})
+	// the team costs more a day than C3 earns, so the shorter game leads
+	if len(small) != 2 || small[ids["c"]].Rank != 1 || small[ids["a"]].Rank != 2 {
+		t.Fatalf("leaderboard of small = %+v; want c, then a", small)
+	}
+	c := scored()[ids["c"]]
+	if c == nil || c.Profit != small[ids["c"]].Profit || c.Days != 3 {
+		t.Errorf("score of c after ranking = %+v; want it kept as ranked, %+v", c, small[ids["c"]])
+	}
+	if large := rank(models.LeaderboardFilter{Scenario: "large"}); len(large) != 1 || large[ids["b"]].Rank != 1 {
+		t.Errorf("leaderboard of large = %+v; want b only", large)
+	}
+
+	// the kept score is ranked, not the board
+	if err := r.Games.SaveScore(ctx, ids["a"], games.GameScore{Days: 4, Profit: 1_000_000}); err != nil {
+		t.Fatalf("SaveScore: %v", err)
+	}
+	if e := rank(models.LeaderboardFilter{})[ids["a"]]; e.Rank != 1 || e.Profit != 1_000_000 {
+		t.Errorf("a after its score changed = %+v; want first with the kept profit", e)
+	}
+}
+
+func testSearchGames(t *testing.T, r Repos) {
+	ctx := context.Background()
+
+	// a: small, day 3; b: large, day 1, running; c: small, day 5; d: small,
+	// day 3; c and d play in a session
+	large := board()
+	large.Scenario = "large"
+	ids := map[string]uuid.UUID{}
+	for _, g := range []struct {
+		name string
+		cfg  models.BoardConfig
+		day  int
+	}{{"a", board(), 3}, {"b", large, 1}, {"c", board(), 5}, {"d", board(), 3}} {
+		id, err := r.Games.CreateGame(ctx, g.cfg)
+		if err != nil {
+			t.Fatalf("CreateGame: %v", err)
+		}
+		if err := r.Games.UpdateGame(ctx, id, g.day); err != nil {
+			t.Fatalf("UpdateGame: %v", err)
+		}
+		ids[g.name] = id
+		time.Sleep(10 * time.Millisecond) // distinct created_at
+	}
+	if err := r.Games.SetStatus(ctx, ids["b"], models.GameLobby, models.GameRunning, "game_started"); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	s, err := r.Sessions.CreateSession(ctx, "Tuesday training", 7)
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	for i, name := range []string{"c", "d"} {
+		if err := r.Sessions.AddGame(ctx, s.ID, models.SessionGame{Team: "Team " + strconv.Itoa(i+1), GameID: ids[name]}); err != nil {
+			t.Fatalf("AddGame: %v", err)
+		}
+	}
+	c, err := r.Games.GetGameByID(ctx, ids["c"])
+	if err != nil {
+		t.Fatalf("GetGameByID: %v", err)
+	}
+	createdC, err := time.Parse(time.RFC3339Nano, c.CreatedAt)
+	if err != nil {
+		t.Fatalf("created_at %q: %v", c.CreatedAt, err)
+	}
+
+	names := map[uuid.UUID]string{}
+	for name, id := range ids {
+		names[id] = name
+	}
+	tests := []struct {
+		name  string
+		query models.GameQuery
+		want  []string
+	}{
+		{"newest first", models.GameQuery{Sort: games.SortCreated}, []string{"d", "c", "b", "a"}},
+		{"oldest first", models.GameQuery{Sort: games.SortCreated, Ascending: true}, []string{"a", "b", "c", "d"}},
+		{"most played first", models.GameQuery{Sort: games.SortDay}, []string{"c", "d", "a", "b"}},
+		{"least played first", models.GameQuery{Sort: games.SortDay, Ascending: true}, []string{"b", "a", "d", "c"}},
+		{"status", models.GameQuery{Sort: games.SortCreated, Status: models.GameRunning}, []string{"b"}},
+		{"scenario", models.GameQuery{Sort: games.SortCreated, Scenario: "small"}, []string{"d", "c", "a"}},
+		{"session", models.GameQuery{Sort: games.SortCreated, SessionID: &s.ID}, []string{"d", "c"}},
+		{"created from", models.GameQuery{Sort: games.SortCreated, From: createdC}, []string{"d", "c"}},
+		{"created before", models.GameQuery{Sort: games.SortCreated, To: createdC}, []string{"b", "a"}},
+		{"nothing", models.GameQuery{Sort: games.SortCreated, From: time.Now().Add(time.Hour)}, nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// one game a page, to walk the cursor through the whole list
+			search := games.GameSearch{GameQuery: tc.query}
+			search.Limit = 1
+			var got []string
+			for range 10 {
+				page, total, more, err := r.Games.SearchGames(ctx, search)
+				if err != nil {
+					t.Fatalf("SearchGames: %v", err)
+				}
+				if total != len(tc.want) {
+					t.Errorf("total = %d; want %d", total, len(tc.want))
+				}
+				for _, g := range page {
+					got = append(got, names[g.ID])
+				}
+				if !more {
+					break
+				}
+				key, err := games.KeyOf(page[len(page)-1])
+				if err != nil {
+					t.Fatalf("KeyOf: %v", err)
+				}
+				search.After = &key
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("games = %v; want %v", got, tc.want)
+			}
+		})
+	}
+
+	// the list goes on after the last game of a page is deleted
+	for _, step := range []struct{ sort, deleted, next string }{
+		{games.SortCreated, "d", "c"},
+		{games.SortDay, "c", "a"},
+	} {
+		sort := step.sort
+		search := games.GameSearch{GameQuery: models.GameQuery{Sort: sort, Limit: 1}}
+		page, _, _, err := r.Games.SearchGames(ctx, search)
+		if err != nil || len(page) != 1 || names[page[0].ID] != step.deleted {
+			t.Fatalf("SearchGames by %s: %v, %d games; want %s", sort, err, len(page), step.deleted)
+		}
+		key, err := games.KeyOf(page[0])
+		if err != nil {
+			t.Fatalf("KeyOf: %v", err)
+		}
+		if err := r.Games.DeleteGame(ctx, page[0].ID); err != nil {
+			t.Fatalf("DeleteGame: %v", err)
+		}
+		search.After = &key
+		if next, _, _, err := r.Games.SearchGames(ctx, search); err != nil || len(next) != 1 || names[next[0].ID] != step.next {
+			t.Errorf("SearchGames by %s after deleted game %s: %v, %v; want %s", sort, step.deleted, err, next, step.next)
+		}
+	}
+}
+
+func testRetention(t *testing.T, r Repos) {
+	ctx := context.Background()
+	idle := createGame(t, r)
+	played := createGame(t, r)
+	time.Sleep(10 * time.Millisecond)
+	cutoff := time.Now().UTC()
+	time.Sleep(10 * time.Millisecond)
+	if err := r.Games.SetStatus(ctx, played, models.GameLobby, models.GameRunning, "game_started"); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+
+	list, err := r.Games.InactiveGames(ctx, cutoff)
+	if err != nil {
+		t.Fatalf("InactiveGames: %v", err)
+	}
+	if len(list) != 1 || list[0].ID != idle {
+		t.Errorf("InactiveGames = %+v; want the idle game only", list)
+	}
+	if list, _ := r.Games.InactiveGames(ctx, cutoff.Add(-time.Hour)); len(list) != 0 {
+		t.Errorf("InactiveGames an hour earlier = %+v; want none", list)
+	}
+
+	at := time.Now().UTC()
+	for _, tc := range []struct {
+		name string
+		id   uuid.UUID
+		want bool
+	}{
+		{"active game", played, false},
+		{"idle game", idle, true},
+		{"idle game again", idle, false},
+		{"unknown game", uuid.New(), false},
+	} {
+		done, err := r.Games.SoftDeleteGame(ctx, tc.id, cutoff, at)
+		if err != nil || done != tc.want {
+			t.Errorf("SoftDeleteGame of the %s = %v, %v; want %v", tc.name, done, err, tc.want)
+		}
+	}
+
+	// soft-deleted games are hidden, but not gone
+	if _, err := r.Games.GetGameByID(ctx, idle); !errors.Is(err, games.ErrNotFound) {
+		t.Errorf("GetGameByID of a soft-deleted game: err = %v; want %v", err, games.ErrNotFound)
+	}
+	if list, _ := r.Games.ListGames(ctx); len(list) != 1 || list[0].ID != played {
+		t.Errorf("ListGames = %+v; want the played game only", list)
+	}
+	if _, total, _, _ := r.Games.SearchGames(ctx, games.GameSearch{GameQuery: models.GameQuery{Sort: games.SortCreated, Limit: 10}}); total != 1 {
+		t.Errorf("SearchGames total = %d; want 1", total)
+	}
+	if list, _ := r.Games.InactiveGames(ctx, at.Add(time.Hour)); len(list) != 1 || list[0].ID != played {
+		t.Errorf("InactiveGames an hour later = %+v; want the played game only", list)
+	}
+
+	if list, _ := r.Games.SoftDeletedGames(ctx, at.Add(-time.Second)); len(list) != 0 {
+		t.Errorf("SoftDeletedGames before the soft delete = %+v; want none", list)
+	}
+	list, err = r.Games.SoftDeletedGames(ctx, at.Add(time.Second))
+	if err != nil {
+		t.Fatalf("SoftDeletedGames: %v", err)
+	}
+	if len(list) != 1 || list[0].ID != idle {
+		t.Errorf("SoftDeletedGames = %+v; want the idle game", list)
+	}
+	if err := r.Games.DeleteGame(ctx, idle); err != nil {
+		t.Fatalf("DeleteGame of a soft-deleted game: %v", err)
+	}
+	if list, _ := r.Games.SoftDeletedGames(ctx, at.Add(time.Second)); len(list) != 0 {
+		t.Errorf("SoftDeletedGames after the delete = %+v; want none", list)
+	}
+}
+
+// testSoftDeletedGame checks that a soft-deleted game is gone for every
+// read and change but the purge, like an unknown game.
+func testSoftDeletedGame(t *testing.T, r Repos) {
+	ctx := context.Background()
+	id := createGame(t, r)
+	alice, err := r.Players.CreatePlayer(ctx, id, "Alice")
+	if err != nil {
+		t.Fatalf("CreatePlayer: %v", err)
+	}
+	b, err := r.Games.GetBoard(ctx, id)
+	if err != nil || len(b.Cards) == 0 {
+		t.Fatalf("GetBoard = %+v, %v; want cards", b, err)
+	}
+	card, title := b.Cards[0], "Renamed"
+	time.Sleep(10 * time.Millisecond)
+	cutoff := time.Now().UTC()
+	if done, err := r.Games.SoftDeleteGame(ctx, id, cutoff, cutoff); err != nil || !done {
+		t.Fatalf("SoftDeleteGame = %v, %v; want true", done, err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"UpdateGame", r.Games.UpdateGame(ctx, id, 2), games.ErrNotFound},
+		{"SetStatus", r.Games.SetStatus(ctx, id, models.GameLobby, models.GameRunning, "game_started"), games.ErrNotFound},
+		{"SetFacilitator", r.Games.SetFacilitator(ctx, id, alice), games.ErrNotFound},
+		{"ResetGame", r.Games.ResetGame(ctx, id), games.ErrNotFound},
+		{"ApplyDay", r.Games.ApplyDay(ctx, id, 1, nil), games.ErrNotFound},
+		{"UpdatePlayer", r.Players.UpdatePlayer(ctx, alice, "Bob"), players.ErrNotFound},
+		{"DeletePlayer", r.Players.DeletePlayer(ctx, alice), players.ErrNotFound},
+	} {
+		if !errors.Is(tc.err, tc.want) {
+			t.Errorf("%s: err = %v; want %v", tc.name, tc.err, tc.want)
+		}
+	}
+	if _, err := r.Games.ForkGame(ctx, id, 0); !errors.Is(err, games.ErrNotFound) {
+		t.Errorf("ForkGame: err = %v; want %v", err, games.ErrNotFound)
+	}
+	if _, err := r.Games.ExportGame(ctx, id); !errors.Is(err, games.ErrNotFound) {
+		t.Errorf("ExportGame: err = %v; want %v", err, games.ErrNotFound)
+	}
+	if _, err := r.Games.PositionCard(ctx, id, games.Placement{CardID: card.ID, ColumnID: card.ColumnID, From: card.ColumnID}); !errors.Is(err, games.ErrNotFound) {
+		t.Errorf("PositionCard: err = %v; want %v", err, games.ErrNotFound)
+	}
+	if _, err := r.Players.CreatePlayer(ctx, id, "Carol"); !errors.Is(err, players.ErrGameNotFound) {
+		t.Errorf("CreatePlayer: err = %v; want %v", err, players.ErrGameNotFound)
+	}
+	if _, err := r.Players.GetPlayerByID(ctx, alice); !errors.Is(err, players.ErrNotFound) {
+		t.Errorf("GetPlayerByID: err = %v; want %v", err, players.ErrNotFound)
+	}
+	if list, err := r.Players.ListPlayersByGameID(ctx, id); err != nil || len(list) != 0 {
+		t.Errorf("ListPlayersByGameID = %+v, %v; want none", list, err)
+	}
+	if _, err := r.Columns.UpdateColumn(ctx, id, card.ColumnID, models.ColumnUpdate{Title: &title}); !errors.Is(err, columns.ErrNotFound) {
+		t.Errorf("UpdateColumn: err = %v; want %v", err, columns.ErrNotFound)
+	}
+	if cols, err := r.Columns.GetColumnsByGameID(ctx, id); err != nil || len(cols) != 0 {
+		t.Errorf("GetColumnsByGameID = %+v, %v; want none", cols, err)
+	}
+	if list, err := r.Cards.GetCardsByGameID(ctx, id); err != nil || len(list) != 0 {
+		t.Errorf("GetCardsByGameID = %+v, %v; want none", list, err)
+	}
+	if _, err := r.Cards.GetFlowLayout(ctx, id); !errors.Is(err, cards.ErrNotFound) {
+		t.Errorf("GetFlowLayout: err = %v; want %v", err, cards.ErrNotFound)
+	}
+	if _, _, err := r.Cards.SearchCards(ctx, id, cards.Search{CardQuery: models.CardQuery{Sort: cards.SortPosition, Limit: 10}}); !errors.Is(err, cards.ErrNotFound) {
+		t.Errorf("SearchCards: err = %v; want %v", err, cards.ErrNotFound)
+	}
+
+	// the purge still removes it
+	if err := r.Games.DeleteGame(ctx, id); err != nil {
+		t.Fatalf("DeleteGame of a soft-deleted game: %v", err)
+	}
+}
+
+func cardsByID(b models.Board) models.Board {
+	slices.SortFunc(b.Cards, func(x, y models.Card) int { return strings.Compare(x.ID.String(), y.ID.String()) })
+	return b
+}
+
+func testAPIKeys(t *testing.T, r Repos) {
+	ctx := context.Background()
+	expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	id, created, err := r.Keys.CreateKey(ctx, models.APIKey{
+		Name: "LMS", Prefix: "ksk_abcd", Scopes: []string{"read", "play"}, ExpiresAt: &expires,
+	}, "hash-1")
+	if err != nil {
+		t.Fatalf("CreateKey: %v", err)
+	}
+	if id == uuid.Nil || created.IsZero() {
+		t.Fatalf("CreateKey = %s, %v; want an ID and creation time", id, created)
+	}
+	if _, _, err := r.Keys.CreateKey(ctx, models.APIKey{Name: "Dashboard", Prefix: "ksk_efgh", Scopes: []string{"read"}}, "hash-2"); err != nil {
+		t.Fatalf("CreateKey: %v", err)
+	}
+
+	k, err := r.Keys.GetKeyByHash(ctx, "hash-1")
+	if err != nil {
+		t.Fatalf("GetKeyByHash: %v", err)
+	}
+	if k.ID != id || k.Name != "LMS" || k.Prefix != "ksk_abcd" || len(k.Scopes) != 2 || k.Scopes[1] != "play" ||
+		k.ExpiresAt == nil || !k.ExpiresAt.Equal(expires) || k.LastUsedAt != nil || k.RevokedAt != nil {
+		t.Errorf("GetKeyByHash = %+v; want the key as created", k)
+	}
+	if _, err := r.Keys.GetKeyByHash(ctx, "hash-3"); !errors.Is(err, apikeys.ErrNotFound) {
+		t.Errorf("GetKeyByHash of an unknown hash: err = %v; want %v", err, apikeys.ErrNotFound)
+	}
+
+	used := time.Date(2026, 5, 6, 7, 8, 9, 0, time.UTC)
+	if err := r.Keys.TouchKey(ctx, id, used); err != nil {
+		t.Fatalf("TouchKey: %v", err)
+	}
+	if err := r.Keys.RevokeKey(ctx, id, used.Add(time.Hour)); err != nil {
+		t.Fatalf("RevokeKey: %v", err)
+	}
+	if err := r.Keys.RevokeKey(ctx, id, used.Add(2*time.Hour)); !errors.Is(err, apikeys.ErrNotFound) {
+		t.Errorf("RevokeKey twice: err = %v; want %v", err, apikeys.ErrNotFound)
+	}
+	if err := r.Keys.RevokeKey(ctx, uuid.New(), used); !errors.Is(err, apikeys.ErrNotFound) {
+		t.Errorf("RevokeKey of an unknown key: err = %v; want %v", err, apikeys.ErrNotFound)
+	}
+
+	k, err = r.Keys.GetKeyByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetKeyByID: %v", err)
+	}
+	if k.LastUsedAt == nil || !k.LastUsedAt.Equal(used) || k.RevokedAt == nil || !k.RevokedAt.Equal(used.Add(time.Hour)) {
+		t.Errorf("key after use and revocation = %+v", k)
+	}
+
+	keys, err := r.Keys.ListKeys(ctx)
+	if err != nil {
+		t.Fatalf("ListKeys: %v", err)
+	}
+	names := map[string]bool{}
+	for _, k := range keys {
+		names[k.Name] = k.RevokedAt != nil
+	}
+	if revoked, ok := names["LMS"]; len(keys) != 2 || !ok || !revoked || names["Dashboard"] {
+		t.Errorf("ListKeys = %+v; want both keys, LMS revoked", keys)
+	}
+}
+
+func testSessions(t *testing.T, r Repos) {
+	ctx := context.Background()
+	s, err := r.Sessions.CreateSession(ctx, "Monday training", 42)
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	if s.ID == uuid.Nil || s.CreatedAt.IsZero() || s.Name != "Monday training" || s.Seed != 42 || len(s.Games) != 0 {
+		t.Fatalf("CreateSession = %+v; want a new session without games", s)
+	}
+
+	want := []models.SessionGame{
+		{Team: "Team 1", GameID: createGame(t, r)},
+		{Team: "Team 2", GameID: createGame(t, r)},
+		{Team: "Team 3", GameID: createGame(t, r)},
+	}
+	for _, g := range want {
+		if err := r.Sessions.AddGame(ctx, s.ID, g); err != nil {
+			t.Fatalf("AddGame(%s): %v", g.Team, err)
+		}
+	}
+
+	got, err := r.Sessions.GetSession(ctx, s.ID)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if got.ID != s.ID || got.Name != s.Name || got.Seed != 42 || !got.CreatedAt.Equal(s.CreatedAt) {
+		t.Errorf("GetSession = %+v; want %+v", got, s)
+	}
+	if !reflect.DeepEqual(got.Games, want) {
+		t.Errorf("session games = %+v; want %+v", got.Games, want)
+	}
+
+	// deleting a game takes it out of its session
+	if err := r.Games.DeleteGame(ctx, want[1].GameID); err != nil {
+		t.Fatalf("DeleteGame: %v", err)
+	}
+	got, err = r.Sessions.GetSession(ctx, s.ID)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if left := []models.SessionGame{want[0], want[2]}; !reflect.DeepEqual(got.Games, left) {
+		t.Errorf("session games after DeleteGame = %+v; want %+v", got.Games, left)
+	}
+
+	// deleting the session leaves its games
+	if err := r.Sessions.DeleteSession(ctx, s.ID); err != nil {
+		t.Fatalf("DeleteSession: %v", err)
+	}
+	if _, err := r.Sessions.GetSession(ctx, s.ID); !errors.Is(err, sessions.ErrNotFound) {
+		t.Errorf("GetSession after DeleteSession: err = %v; want %v", err, sessions.ErrNotFound)
+	}
+	if err := r.Sessions.DeleteSession(ctx, s.ID); !errors.Is(err, sessions.ErrNotFound) {
+		t.Errorf("DeleteSession twice: err = %v; want %v", err, sessions.ErrNotFound)
+	}
+	if _, err := r.Games.GetGameByID(ctx, want[0].GameID); err != nil {
+		t.Errorf("GetGameByID of a game of the deleted session: %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package repotest_test
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/apikeys"
+	"github.com/Germanicus1/kanban-sim/backend/internal/cards"
+	"github.com/Germanicus1/kanban-sim/backend/internal/columns"
+	"github.com/Germanicus1/kanban-sim/backend/internal/database"
+	"github.com/Germanicus1/kanban-sim/backend/internal/games"
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
+	"github.com/Germanicus1/kanban-sim/backend/internal/players"
+	"github.com/Germanicus1/kanban-sim/backend/internal/repotest"
+	"github.com/Germanicus1/kanban-sim/backend/internal/sessions"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+func TestMemory(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repos {
+		store := memstore.New()
+		return repotest.Repos{
+			Games:    games.NewMemoryRepo(store),
+			Players:  players.NewMemoryRepo(store),
+			Columns:  columns.NewMemoryRepo(store),
+			Cards:    cards.NewMemoryRepo(store),
+			Keys:     apikeys.NewMemoryRepo(store),
+			Sessions: sessions.NewMemoryRepo(store),
+		}
+	})
+}
+
+// TestPostgres runs the suite against a real database. It wipes every game,
+// so point TEST_DATABASE_URL at a database kept for tests.
+func TestPostgres(t *testing.T) {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	db, err := sql.Open("pgx", url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if err := database.Migrate(db, "../database/migrations"); err != nil {
+		t.Fatal(err)
+	}
+
+	repotest.Run(t, func(t *testing.T) repotest.Repos {
+		// deleting the games cascades to everything else but the keys and
+		// sessions
+		for _, table := range []string{"games", "api_keys", "sessions"} {
+			if _, err := db.Exec(`DELETE FROM ` + table); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return repotest.Repos{
+			Games:    games.NewSQLRepo(db),
+			Players:  players.NewSQLRepo(db),
+			Columns:  columns.NewSQLRepo(db),
+			Cards:    cards.NewSQLRepo(db),
+			Keys:     apikeys.NewSQLRepo(db),
+			Sessions: sessions.NewSQLRepo(db),
+		}
+	})
+}
+
+func TestSQLite(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repos {
+		db, err := database.OpenSQLite(filepath.Join(t.TempDir(), "test.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { db.Close() })
+		if err := database.Migrate(db, "../database/migrations"); err != nil {
+			t.Fatal(err)
+		}
+		return repotest.Repos{
+			Games:    games.NewSQLRepo(db),
+			Players:  players.NewSQLRepo(db),
+			Columns:  columns.NewSQLRepo(db),
+			Cards:    cards.NewSQLRepo(db),
+			Keys:     apikeys.NewSQLRepo(db),
+			Sessions: sessions.NewSQLRepo(db),
+		}
+	})
+}
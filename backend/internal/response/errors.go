@@ -36,6 +36,38 @@ const (
 	ErrInvalidForeignKey        = "INVALID_FOREIGN_KEY"
 	ErrInvalidPlayerData        = "INVALID_PLAYER_DATA"
 	ErrPlayersNotFound          = "PLAYERS_NOT_FOUND"
+	ErrInvalidDay               = "INVALID_DAY"
+	ErrSnapshotNotFound         = "SNAPSHOT_NOT_FOUND"
+	ErrInvalidExport            = "INVALID_EXPORT"
+	ErrInvalidStrategy          = "INVALID_STRATEGY"
+	ErrInvalidTeam              = "INVALID_TEAM"
+	ErrInvalidBoard             = "INVALID_BOARD"
+	ErrNotFacilitator           = "NOT_FACILITATOR"
+	ErrKeyNotFound              = "KEY_NOT_FOUND"
+	ErrInvalidKeyID             = "INVALID_KEY_ID"
+	ErrInvalidScope             = "INVALID_SCOPE"
+	ErrInvalidExpiry            = "INVALID_EXPIRY"
+	ErrInvalidTransition        = "INVALID_TRANSITION"
+	ErrGameNotRunning           = "GAME_NOT_RUNNING"
+	ErrGameEnded                = "GAME_ENDED"
+	ErrSessionNotFound          = "SESSION_NOT_FOUND"
+	ErrInvalidSessionID         = "INVALID_SESSION_ID"
+	ErrInvalidTeams             = "INVALID_TEAMS"
+	ErrInvalidView              = "INVALID_VIEW"
+	ErrInvalidScore             = "INVALID_SCORE"
+	ErrInvalidDate              = "INVALID_DATE"
+	ErrInvalidEffort            = "INVALID_EFFORT"
+	ErrCardBlocked              = "CARD_BLOCKED"
+	ErrCardNotBlocked           = "CARD_NOT_BLOCKED"
+	ErrCardDeployed             = "CARD_DEPLOYED"
+	ErrCardMoved                = "CARD_MOVED"
+	ErrColumnNotFound           = "COLUMN_NOT_FOUND"
+	ErrInvalidColumnID          = "INVALID_COLUMN_ID"
+	ErrInvalidColumn            = "INVALID_COLUMN"
+	ErrColumnHasCards           = "COLUMN_HAS_CARDS"
+	ErrInvalidPosition          = "INVALID_POSITION"
+	ErrInvalidQuery             = "INVALID_QUERY"
+	ErrInvalidCursor            = "INVALID_CURSOR"
 )
 
 // MapPostgresError maps PostgreSQL error codes to HTTP status codes and error messages
@@ -16,7 +16,10 @@ type APIResponse[T any] struct {
 
 // CreateGameData is the payload for a newly created game.
 type CreateGameData struct {
-	ID string `json:"id" example:"7d7881cf-8d9f-457f-ac93-aa498ea8c0af"`
+	ID   string `json:"id" example:"7d7881cf-8d9f-457f-ac93-aa498ea8c0af"`
+	Seed int64  `json:"seed,omitempty" example:"8311254879"` // only returned to the creator
+	// Facilitator is the player created to run the game, if one was asked for.
+	Facilitator *models.PlayerToken `json:"facilitator,omitempty"`
 }
 
 // CreateGameResponse is the full envelope returned by CreateGame.
@@ -40,6 +43,104 @@ type GameResponse struct {
 	Data    models.Game `json:"data"`
 }
 
+// DiceRollResponse is the envelope returned by RollDice.
+// swagger:model DiceRollResponse
+type DiceRollResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Data    models.DiceRoll `json:"data"`
+}
+
+// BotDayResponse is the envelope returned by PlayBotDay.
+// swagger:model BotDayResponse
+type BotDayResponse struct {
+	Success bool          `json:"success" example:"true"`
+	Data    models.BotDay `json:"data"`
+}
+
+// PlayerTokenResponse is the envelope returned by CreatePlayer.
+// swagger:model PlayerTokenResponse
+type PlayerTokenResponse struct {
+	Success bool               `json:"success" example:"true"`
+	Data    models.PlayerToken `json:"data"`
+}
+
+// NewAPIKeyResponse is the envelope returned by CreateKey and RotateKey.
+// swagger:model NewAPIKeyResponse
+type NewAPIKeyResponse struct {
+	Success bool             `json:"success" example:"true"`
+	Data    models.NewAPIKey `json:"data"`
+}
+
+// APIKeysResponse is the envelope returned by ListKeys.
+// swagger:model APIKeysResponse
+type APIKeysResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Data    []models.APIKey `json:"data"`
+}
+
+// SessionResponse is the envelope returned by CreateSession.
+// swagger:model SessionResponse
+type SessionResponse struct {
+	Success bool           `json:"success" example:"true"`
+	Data    models.Session `json:"data"`
+}
+
+// SessionDashboardResponse is the envelope returned by GetSessionDashboard.
+// swagger:model SessionDashboardResponse
+type SessionDashboardResponse struct {
+	Success bool                    `json:"success" example:"true"`
+	Data    models.SessionDashboard `json:"data"`
+}
+
+// BlockerResponse is the envelope returned by BlockCard.
+// swagger:model BlockerResponse
+type BlockerResponse struct {
+	Success bool           `json:"success" example:"true"`
+	Data    models.Blocker `json:"data"`
+}
+
+// CardOrderResponse is the envelope returned by PositionCard.
+// swagger:model CardOrderResponse
+type CardOrderResponse struct {
+	Success bool             `json:"success" example:"true"`
+	Data    models.CardOrder `json:"data"`
+}
+
+// ColumnResponse is the envelope returned by UpdateColumn.
+// swagger:model ColumnResponse
+type ColumnResponse struct {
+	Success bool          `json:"success" example:"true"`
+	Data    models.Column `json:"data"`
+}
+
+// CardPageResponse is the envelope returned by ListCards.
+// swagger:model CardPageResponse
+type CardPageResponse struct {
+	Success bool            `json:"success" example:"true"`
+	Data    models.CardPage `json:"data"`
+}
+
+// AgingResponse is the envelope returned by GetAging.
+// swagger:model AgingResponse
+type AgingResponse struct {
+	Success bool         `json:"success" example:"true"`
+	Data    models.Aging `json:"data"`
+}
+
+// FlowEfficiencyResponse is the envelope returned by GetFlowEfficiency.
+// swagger:model FlowEfficiencyResponse
+type FlowEfficiencyResponse struct {
+	Success bool                  `json:"success" example:"true"`
+	Data    models.FlowEfficiency `json:"data"`
+}
+
+// CFDResponse is the envelope returned by GetCFD.
+// swagger:model CFDResponse
+type CFDResponse struct {
+	Success bool       `json:"success" example:"true"`
+	Data    models.CFD `json:"data"`
+}
+
 // RespondWithError writes a JSON error response.
 func RespondWithError(w http.ResponseWriter, status int, errCode string) {
 	w.Header().Set("Content-Type", "application/json")
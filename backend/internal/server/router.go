@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	_ "github.com/Germanicus1/kanban-sim/backend/apidocs"
+	"github.com/Germanicus1/kanban-sim/backend/internal/auth"
 	"github.com/Germanicus1/kanban-sim/backend/internal/handlers"
 	"github.com/Germanicus1/kanban-sim/backend/internal/middleware"
 	httpSwagger "github.com/swaggo/http-swagger"
@@ -19,37 +20,95 @@ func NewRouter(
 	gh *handlers.GameHandler,
 	ph *handlers.PlayerHandler,
 	ch *handlers.ColumnsHandler,
+	cdh *handlers.CardsHandler,
+	kh *handlers.KeysHandler,
+	sh *handlers.SessionsHandler,
+	signer *auth.Signer,
+	players auth.PlayerLookup,
+	facilitators auth.FacilitatorLookup,
+	keys auth.KeyLookup,
 ) (mux *http.ServeMux) {
 	// public pages
 	mux = http.NewServeMux()
 
-	// ─── PRIVATE ROUTES (wrapped in APIKeyAuth) ─────────────────────────────────
-	privateRoutes := []route{
-		{"POST /games", gh.CreateGame},
-		{"GET /games", gh.ListGames},
+	// ─── KEY ROUTES (API keys with the given scope only) ────────────────────────
+	keyRoutes := []struct {
+		scope  auth.Scope
+		routes []route
+	}{
+		{auth.ScopeRead, []route{
+			{"GET /games", gh.ListGames},
+			{"GET /sessions/{id}/dashboard", sh.GetSessionDashboard},
+		}},
+		{auth.ScopeFacilitate, []route{
+			{"POST /games", gh.CreateGame},
+			{"POST /games/import", gh.ImportGame},
+			{"POST /sessions", sh.CreateSession},
+		}},
+		{auth.ScopeAdmin, []route{
+			{"POST /keys", kh.CreateKey},
+			{"GET /keys", kh.ListKeys},
+			{"DELETE /keys/{id}", kh.RevokeKey},
+			{"POST /keys/{id}/rotate", kh.RotateKey},
+		}},
+	}
+
+	// ─── GAME ROUTES (players of the game in {id}, or an API key) ───────────────
+	gameRoutes := []route{
 		{"GET /games/{id}", gh.GetGame},
 		{"GET /games/{id}/board", gh.GetBoard},
 		{"PATCH /games/{id}", gh.UpdateGame},
 		{"DELETE /games/{id}", gh.DeleteGame},
+		{"POST /games/{id}/fork", gh.ForkGame},
+		{"POST /games/{id}/roll", gh.RollDice},
+		{"POST /games/{id}/bot", gh.PlayBotDay},
+		{"PUT /games/{id}/facilitator", gh.SetFacilitator},
+		{"POST /games/{id}/start", gh.StartGame},
+		{"POST /games/{id}/pause", gh.PauseGame},
+		{"POST /games/{id}/resume", gh.ResumeGame},
+		{"POST /games/{id}/end", gh.EndGame},
+		{"POST /games/{id}/reset", gh.ResetGame},
+		{"GET /games/{id}/export", gh.ExportGame},
+		{"GET /games/{id}/players", ph.ListPlayersByGameID},
+		{"GET /games/{id}/columns", ch.GetColumnsByGameID},
+		{"PATCH /games/{id}/columns/{columnId}", ch.UpdateColumn},
+		{"GET /games/{id}/cards", cdh.ListCards},
+		{"GET /games/{id}/cards.csv", cdh.GetCardsCSV},
+		{"GET /games/{id}/cfd", cdh.GetCFD},
+		{"GET /games/{id}/metrics/aging", cdh.GetAging},
+		{"GET /games/{id}/metrics/flow-efficiency", cdh.GetFlowEfficiency},
+		{"POST /games/{id}/cards/{cardId}/block", gh.BlockCard},
+		{"DELETE /games/{id}/cards/{cardId}/block", gh.UnblockCard},
+		{"POST /games/{id}/cards/{cardId}/position", gh.PositionCard},
+	}
 
-		{"POST /players", ph.CreatePlayer},
+	// ─── PLAYER ROUTES (any caller; the handler checks the player's game) ───────
+	playerRoutes := []route{
 		{"GET /players/{id}", ph.GetPlayerByID},
 		{"PATCH /players/{id}", ph.UpdatePlayer},
 		{"DELETE /players", ph.DeletePlayer},
-		{"GET /games/{game_id}/players", ph.ListPlayersByGameID},
-
-		{"GET /games/{id}/columns", ch.GetColumnsByGameID},
 	}
 
-	for _, r := range privateRoutes {
-		// wrap each handler func in APIKeyAuth, then register with mux.Handle
-		// (http.HandlerFunc already implements http.Handler)
-		mux.Handle(r.Pattern, middleware.APIKeyAuth(http.HandlerFunc(r.Handler)))
+	// wrap each handler func in its middleware, then register with mux.Handle
+	// (http.HandlerFunc already implements http.Handler)
+	playerAuth := middleware.PlayerAuth(signer, players, facilitators, keys)
+	for _, g := range keyRoutes {
+		keyAuth := middleware.APIKeyAuth(keys, g.scope)
+		for _, r := range g.routes {
+			mux.Handle(r.Pattern, keyAuth(http.HandlerFunc(r.Handler)))
+		}
+	}
+	for _, r := range gameRoutes {
+		mux.Handle(r.Pattern, playerAuth(middleware.GameAccess(http.HandlerFunc(r.Handler))))
+	}
+	for _, r := range playerRoutes {
+		mux.Handle(r.Pattern, playerAuth(http.HandlerFunc(r.Handler)))
 	}
 
 	// ─── PUBLIC ROUTES ────────────────────────────────────────────────────────
 	mux.HandleFunc("GET /", ah.Home)
 	mux.HandleFunc("GET /ping", ah.Ping)
+	mux.HandleFunc("POST /players", ph.CreatePlayer) // joining a game hands out the player's token
 	mux.HandleFunc("GET /openapi.yaml", ah.OpenAPI)
 	mux.Handle("GET /apidocs/", httpSwagger.WrapHandler)
 
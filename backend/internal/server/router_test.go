@@ -1,19 +1,36 @@
 package server
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/Germanicus1/kanban-sim/backend/internal/auth"
+	"github.com/Germanicus1/kanban-sim/backend/internal/config"
+	"github.com/Germanicus1/kanban-sim/backend/internal/games"
 	"github.com/Germanicus1/kanban-sim/backend/internal/handlers"
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/Germanicus1/kanban-sim/backend/internal/players"
+	"github.com/Germanicus1/kanban-sim/backend/internal/response"
+	"github.com/google/uuid"
 )
 
 func TestRouter_Patterns(t *testing.T) {
 	ah := handlers.NewAppHandler()
-	gh := handlers.NewGameHandler(nil)
-	ph := handlers.NewPlayerHandler(nil)
+	signer := auth.NewSigner([]byte("test secret"))
+	gh := handlers.NewGameHandler(nil, signer)
+	ph := handlers.NewPlayerHandler(nil, signer)
 	ch := handlers.NewColumnHandler(nil)
+	cdh := handlers.NewCardsHandler(nil)
 
-	mux := NewRouter(ah, gh, ph, ch)
+	kh := handlers.NewKeysHandler(nil)
+	sh := handlers.NewSessionsHandler(nil)
+
+	mux := NewRouter(ah, gh, ph, ch, cdh, kh, sh, signer, nil, nil, nil)
 
 	publicTests := []struct {
 		name        string
@@ -24,6 +41,7 @@ func TestRouter_Patterns(t *testing.T) {
 		{"Home", "GET", "/", "GET /"},
 		{"Ping", "GET", "/ping", "GET /ping"},
 		{"OpenAPI", "GET", "/openapi.yaml", "GET /openapi.yaml"},
+		{"CreatePlayer", "POST", "/players", "POST /players"},
 	}
 
 	for _, tt := range publicTests {
@@ -51,11 +69,29 @@ func TestRouter_Patterns(t *testing.T) {
 		{"UpdateGame", "PATCH", "/games/123", "PATCH /games/{id}"},
 		{"DeleteGame", "DELETE", "/games/123", "DELETE /games/{id}"},
 		{"ListGames", "GET", "/games", "GET /games"},
-		{"CreatePlayer", "POST", "/players", "POST /players"},
+		{"ForkGame", "POST", "/games/123/fork", "POST /games/{id}/fork"},
+		{"RollDice", "POST", "/games/123/roll", "POST /games/{id}/roll"},
+		{"ExportGame", "GET", "/games/123/export", "GET /games/{id}/export"},
+		{"ImportGame", "POST", "/games/import", "POST /games/import"},
+		{"PlayBotDay", "POST", "/games/123/bot", "POST /games/{id}/bot"},
+		{"SetFacilitator", "PUT", "/games/123/facilitator", "PUT /games/{id}/facilitator"},
+		{"StartGame", "POST", "/games/123/start", "POST /games/{id}/start"},
+		{"PauseGame", "POST", "/games/123/pause", "POST /games/{id}/pause"},
+		{"ResumeGame", "POST", "/games/123/resume", "POST /games/{id}/resume"},
+		{"EndGame", "POST", "/games/123/end", "POST /games/{id}/end"},
+		{"ResetGame", "POST", "/games/123/reset", "POST /games/{id}/reset"},
+		{"ListPlayersByGameID", "GET", "/games/123/players", "GET /games/{id}/players"},
 		{"GetPlayerByID", "GET", "/players/123", "GET /players/{id}"},
 		{"UpdatePlayer", "PATCH", "/players/123", "PATCH /players/{id}"},
 		{"DeletePlayer", "DELETE", "/players", "DELETE /players"},
 		{"GetColumnsByGameID", "GET", "/games/123/columns", "GET /games/{id}/columns"},
+		{"GetCardsCSV", "GET", "/games/123/cards.csv", "GET /games/{id}/cards.csv"},
+		{"CreateKey", "POST", "/keys", "POST /keys"},
+		{"ListKeys", "GET", "/keys", "GET /keys"},
+		{"RevokeKey", "DELETE", "/keys/123", "DELETE /keys/{id}"},
+		{"RotateKey", "POST", "/keys/123/rotate", "POST /keys/{id}/rotate"},
+		{"CreateSession", "POST", "/sessions", "POST /sessions"},
+		{"GetSessionDashboard", "GET", "/sessions/123/dashboard", "GET /sessions/{id}/dashboard"},
 		// {"ListPlayers", "GET", "/players", "GET /players"},
 	}
 
@@ -72,3 +108,139 @@ func TestRouter_Patterns(t *testing.T) {
 		})
 	}
 }
+
+func TestRouter_Access(t *testing.T) {
+	signer := auth.NewSigner([]byte("test secret"))
+	keys := func(ctx context.Context, key string) (auth.Caller, error) {
+		if s, ok := strings.CutPrefix(key, auth.KeyPrefix); ok {
+			return auth.Caller{Scope: auth.Scope(s)}, nil
+		}
+		return auth.Caller{}, auth.ErrInvalidToken
+	}
+	mux := NewRouter(handlers.NewAppHandler(), handlers.NewGameHandler(nil, signer), handlers.NewPlayerHandler(nil, signer),
+		handlers.NewColumnHandler(nil), handlers.NewCardsHandler(nil), handlers.NewKeysHandler(nil),
+		handlers.NewSessionsHandler(nil), signer, nil, nil, keys)
+
+	gameID := uuid.New()
+	playerToken := signer.Issue(uuid.New(), gameID)
+	otherSecret := auth.NewSigner([]byte("other secret")).Issue(uuid.New(), gameID)
+
+	// only requests that must be refused: the handlers have no services
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		token      string
+		wantStatus int
+	}{
+		{"no token", "GET", "/games/" + gameID.String(), "", http.StatusUnauthorized},
+		{"forged token", "GET", "/games/" + gameID.String(), otherSecret, http.StatusForbidden},
+		{"other game", "GET", "/games/" + uuid.NewString(), playerToken, http.StatusForbidden},
+		{"delete other game", "DELETE", "/games/" + uuid.NewString(), playerToken, http.StatusForbidden},
+		{"advance day as player", "PATCH", "/games/" + gameID.String(), playerToken, http.StatusForbidden},
+		{"delete own game as player", "DELETE", "/games/" + gameID.String(), playerToken, http.StatusForbidden},
+		{"hand over role as player", "PUT", "/games/" + gameID.String() + "/facilitator", playerToken, http.StatusForbidden},
+		{"other game's players", "GET", "/games/" + uuid.NewString() + "/players", playerToken, http.StatusForbidden},
+		{"create game as player", "POST", "/games", playerToken, http.StatusForbidden},
+		{"list games as player", "GET", "/games", playerToken, http.StatusForbidden},
+		{"import as player", "POST", "/games/import", playerToken, http.StatusForbidden},
+		{"keys as player", "GET", "/keys", playerToken, http.StatusForbidden},
+		{"create game with a play key", "POST", "/games", "ksk_play", http.StatusForbidden},
+		{"move with a read key", "POST", "/games/" + gameID.String() + "/roll", "ksk_read", http.StatusForbidden},
+		{"advance day with a play key", "PATCH", "/games/" + gameID.String(), "ksk_play", http.StatusForbidden},
+		{"create key with a facilitate key", "POST", "/keys", "ksk_facilitate", http.StatusForbidden},
+		{"create session as player", "POST", "/sessions", playerToken, http.StatusForbidden},
+		{"create session with a play key", "POST", "/sessions", "ksk_play", http.StatusForbidden},
+		{"dashboard as player", "GET", "/sessions/" + uuid.NewString() + "/dashboard", playerToken, http.StatusForbidden},
+		{"revoke key with a facilitate key", "DELETE", "/keys/" + uuid.NewString(), "ksk_facilitate", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rr := httptest.NewRecorder()
+			mux.ServeHTTP(rr, req)
+			if rr.Code != tt.wantStatus {
+				t.Errorf("status = %d; want %d", rr.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRouter_RemovedPlayer(t *testing.T) {
+	ctx := context.Background()
+	store := memstore.New()
+	gameSvc := games.NewService(games.NewMemoryRepo(store))
+	playerSvc := players.NewService(players.NewMemoryRepo(store))
+	signer := auth.NewSigner([]byte("test secret"))
+	mux := NewRouter(handlers.NewAppHandler(), handlers.NewGameHandler(gameSvc, signer), handlers.NewPlayerHandler(playerSvc, signer),
+		handlers.NewColumnHandler(nil), handlers.NewCardsHandler(nil), handlers.NewKeysHandler(nil),
+		handlers.NewSessionsHandler(nil), signer, gameSvc.Plays, gameSvc.FacilitatorOf, nil)
+
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatalf("LoadBoardConfig: %v", err)
+	}
+	gameID, err := gameSvc.CreateGame(ctx, models.BoardConfig{EffortTypes: cfg.EffortTypes, Columns: cfg.Columns, Cards: cfg.Cards})
+	if err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+	if _, err := gameSvc.ChangeStatus(ctx, gameID, games.Start); err != nil {
+		t.Fatalf("start game: %v", err)
+	}
+	board, err := gameSvc.GetBoard(ctx, gameID)
+	if err != nil {
+		t.Fatalf("GetBoard: %v", err)
+	}
+	card := board.Cards[0].ID
+
+	serve := func(method, target, token, body string) int {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		return rr.Code
+	}
+	join := func(name string) models.PlayerToken {
+		req := httptest.NewRequest("POST", "/players", strings.NewReader(`{"game_id":"`+gameID.String()+`","name":"`+name+`"}`))
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		var resp response.APIResponse[models.PlayerToken]
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil || rr.Code != http.StatusOK {
+			t.Fatalf("join as %s: status %d, %v", name, rr.Code, err)
+		}
+		return resp.Data
+	}
+	alice, bob := join("Alice"), join("Bob")
+
+	if code := serve("DELETE", "/players", bob.Token, `{"id":"`+bob.PlayerID.String()+`"}`); code != http.StatusOK {
+		t.Fatalf("leave: status %d; want %d", code, http.StatusOK)
+	}
+
+	block := "/games/" + gameID.String() + "/cards/" + card.String() + "/block"
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		token      string
+		wantStatus int
+	}{
+		{"removed player looks at the game", "GET", "/games/" + gameID.String(), bob.Token, http.StatusUnauthorized},
+		{"removed player blocks a card", "POST", block, bob.Token, http.StatusUnauthorized},
+		{"removed player renames themselves", "PATCH", "/players/" + bob.PlayerID.String(), bob.Token, http.StatusUnauthorized},
+		{"remaining player looks at the game", "GET", "/games/" + gameID.String(), alice.Token, http.StatusOK},
+		{"remaining player blocks a card", "POST", block, alice.Token, http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := serve(tt.method, tt.target, tt.token, `{"reason":"waiting on ops"}`); code != tt.wantStatus {
+				t.Errorf("status = %d; want %d", code, tt.wantStatus)
+			}
+		})
+	}
+}
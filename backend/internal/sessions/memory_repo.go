@@ -0,0 +1,77 @@
+package sessions
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// memoryRepo implements the Repository interface on a memstore.Store.
+type memoryRepo struct {
+	store *memstore.Store
+}
+
+func (r *memoryRepo) CreateSession(ctx context.Context, name string, seed int64) (models.Session, error) {
+	row := memstore.Session{ID: uuid.New(), Name: name, Seed: seed, CreatedAt: memstore.Now()}
+	err := r.store.Update(func(t *memstore.Tables) error {
+		t.Sessions = append(t.Sessions, row)
+		return nil
+	})
+	if err != nil {
+		return models.Session{}, err
+	}
+	return models.Session{ID: row.ID, Name: name, Seed: seed, CreatedAt: row.CreatedAt, Games: []models.SessionGame{}}, nil
+}
+
+func (r *memoryRepo) AddGame(ctx context.Context, sessionID uuid.UUID, g models.SessionGame) error {
+	return r.store.Update(func(t *memstore.Tables) error {
+		if !slices.ContainsFunc(t.Sessions, func(s memstore.Session) bool { return s.ID == sessionID }) {
+			return ErrNotFound
+		}
+		n := 0
+		for _, sg := range t.SessionGames {
+			if sg.SessionID == sessionID {
+				n++
+			}
+		}
+		t.SessionGames = append(t.SessionGames, memstore.SessionGame{
+			SessionID: sessionID, GameID: g.GameID, Team: g.Team, OrderIndex: n,
+		})
+		return nil
+	})
+}
+
+func (r *memoryRepo) GetSession(ctx context.Context, id uuid.UUID) (models.Session, error) {
+	var s models.Session
+	err := r.store.View(func(t *memstore.Tables) error {
+		i := slices.IndexFunc(t.Sessions, func(s memstore.Session) bool { return s.ID == id })
+		if i < 0 {
+			return ErrNotFound
+		}
+		row := t.Sessions[i]
+		s = models.Session{ID: row.ID, Name: row.Name, Seed: row.Seed, CreatedAt: row.CreatedAt, Games: []models.SessionGame{}}
+		// rows are kept in insertion order, which is their order index
+		for _, sg := range t.SessionGames {
+			if sg.SessionID == id {
+				s.Games = append(s.Games, models.SessionGame{Team: sg.Team, GameID: sg.GameID})
+			}
+		}
+		return nil
+	})
+	return s, err
+}
+
+func (r *memoryRepo) DeleteSession(ctx context.Context, id uuid.UUID) error {
+	return r.store.Update(func(t *memstore.Tables) error {
+		i := slices.IndexFunc(t.Sessions, func(s memstore.Session) bool { return s.ID == id })
+		if i < 0 {
+			return ErrNotFound
+		}
+		t.Sessions = append(t.Sessions[:i:i], t.Sessions[i+1:]...)
+		t.SessionGames = slices.DeleteFunc(t.SessionGames, func(sg memstore.SessionGame) bool { return sg.SessionID == id })
+		return nil
+	})
+}
@@ -0,0 +1,39 @@
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// ErrNotFound is returned for sessions that do not exist.
+var ErrNotFound = errors.New("session not found")
+
+// Repository stores sessions and the games their teams play.
+type Repository interface {
+	// CreateSession stores a session without games and returns it.
+	CreateSession(ctx context.Context, name string, seed int64) (models.Session, error)
+	// AddGame adds the game a team plays to a session, after the games
+	// added before.
+	AddGame(ctx context.Context, sessionID uuid.UUID, g models.SessionGame) error
+	// GetSession returns a session with its games, in the order they were
+	// added. Deleted games are gone from it.
+	GetSession(ctx context.Context, id uuid.UUID) (models.Session, error)
+	// DeleteSession removes a session; its games stay.
+	DeleteSession(ctx context.Context, id uuid.UUID) error
+}
+
+// NewSQLRepo constructs a sessions.Repository on a SQL database.
+func NewSQLRepo(db *sql.DB) Repository {
+	return &sqlRepo{db: db}
+}
+
+// NewMemoryRepo constructs a sessions.Repository on an in-memory store.
+// Share one store between the repositories of all packages.
+func NewMemoryRepo(store *memstore.Store) Repository {
+	return &memoryRepo{store: store}
+}
@@ -0,0 +1,149 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/dice"
+	"github.com/Germanicus1/kanban-sim/backend/internal/engine"
+	"github.com/Germanicus1/kanban-sim/backend/internal/games"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// MaxTeams is the most games one session may have.
+const MaxTeams = 20
+
+// ErrInvalidTeams is returned for sessions of fewer than one or more than
+// MaxTeams teams.
+var ErrInvalidTeams = errors.New("invalid number of teams")
+
+// Games is what sessions need of the games service.
+type Games interface {
+	CreateGame(ctx context.Context, cfg models.BoardConfig) (uuid.UUID, error)
+	GetGame(ctx context.Context, id uuid.UUID) (models.Game, error)
+	GetBoard(ctx context.Context, gameID uuid.UUID) (models.Board, error)
+	DeleteGame(ctx context.Context, id uuid.UUID) error
+}
+
+type ServiceInterface interface {
+	CreateSession(ctx context.Context, name string, teams int, cfg models.BoardConfig) (models.Session, error)
+	GetDashboard(ctx context.Context, id uuid.UUID) (models.SessionDashboard, error)
+}
+
+type Service struct {
+	repo  Repository
+	games Games
+}
+
+func NewService(repo Repository, games Games) *Service {
+	return &Service{repo: repo, games: games}
+}
+
+// CreateSession creates a session and a game from cfg for each of its teams,
+// named "Team 1", "Team 2" and so on. All games get the same dice seed, a
+// random one if cfg has none, so that the teams face the same dice: dice go
+// by card title and by the order players join in, which the games share. A
+// facilitator named in cfg is not created; the games start without one. If a
+// game cannot be created, the session and the games made so far are removed.
+func (s *Service) CreateSession(ctx context.Context, name string, teams int, cfg models.BoardConfig) (models.Session, error) {
+	if teams < 1 || teams > MaxTeams {
+		return models.Session{}, fmt.Errorf("%w: %d, want 1 to %d", ErrInvalidTeams, teams, MaxTeams)
+	}
+	if cfg.Seed == 0 {
+		cfg.Seed = dice.NewSeed()
+	}
+	cfg.Facilitator = ""
+
+	session, err := s.repo.CreateSession(ctx, name, cfg.Seed)
+	if err != nil {
+		return models.Session{}, err
+	}
+	for i := 1; i <= teams; i++ {
+		g := models.SessionGame{Team: fmt.Sprintf("Team %d", i)}
+		if g.GameID, err = s.games.CreateGame(ctx, cfg); err == nil {
+			err = s.repo.AddGame(ctx, session.ID, g)
+		}
+		if err != nil {
+			s.discard(ctx, session, g.GameID)
+			return models.Session{}, fmt.Errorf("create game of %s: %w", g.Team, err)
+		}
+		session.Games = append(session.Games, g)
+	}
+	return session, nil
+}
+
+// discard removes a session that could not be set up along with its games
+// and a game not added to it yet, if any. Failures are only logged; the
+// caller reports the error that made the session fail.
+func (s *Service) discard(ctx context.Context, session models.Session, pending uuid.UUID) {
+	ids := make([]uuid.UUID, 0, len(session.Games)+1)
+	for _, g := range session.Games {
+		ids = append(ids, g.GameID)
+	}
+	if pending != uuid.Nil {
+		ids = append(ids, pending)
+	}
+	for _, id := range ids {
+		if err := s.games.DeleteGame(ctx, id); err != nil {
+			log.Printf("CreateSession: failed to delete game %s: %v", id, err)
+		}
+	}
+	if err := s.repo.DeleteSession(ctx, session.ID); err != nil {
+		log.Printf("CreateSession: failed to delete session %s: %v", session.ID, err)
+	}
+}
+
+// GetDashboard compares where the games of a session stand on their current
+// days, leaving out games soft-deleted for inactivity. Profit is worked out
+// with the standard scenario's card values and team, so that all teams are
+// measured alike.
+func (s *Service) GetDashboard(ctx context.Context, id uuid.UUID) (models.SessionDashboard, error) {
+	session, err := s.repo.GetSession(ctx, id)
+	if err != nil {
+		return models.SessionDashboard{}, err
+	}
+	team, err := engine.ParseTeam(engine.DefaultTeam)
+	if err != nil {
+		return models.SessionDashboard{}, err
+	}
+
+	d := models.SessionDashboard{
+		SessionID: session.ID,
+		Name:      session.Name,
+		Teams:     make([]models.TeamStanding, 0, len(session.Games)),
+	}
+	for _, sg := range session.Games {
+		game, err := s.games.GetGame(ctx, sg.GameID)
+		if errors.Is(err, games.ErrNotFound) {
+			continue // soft-deleted by the retention policy
+		}
+		if err != nil {
+			return models.SessionDashboard{}, fmt.Errorf("game of %s: %w", sg.Team, err)
+		}
+		state, err := s.games.GetBoard(ctx, sg.GameID)
+		if err != nil {
+			return models.SessionDashboard{}, fmt.Errorf("board of %s: %w", sg.Team, err)
+		}
+		board, err := engine.LoadBoard(state, game.Day, team)
+		if err != nil {
+			return models.SessionDashboard{}, fmt.Errorf("board of %s: %w", sg.Team, err)
+		}
+
+		p := board.Progress(nil, 0)
+		d.Teams = append(d.Teams, models.TeamStanding{
+			Team:       sg.Team,
+			GameID:     sg.GameID,
+			Status:     game.Status,
+			Day:        game.Day,
+			WIP:        p.WIP,
+			Deployed:   p.Deployed,
+			Throughput: p.Throughput,
+			LeadTime:   models.LeadTime{Mean: p.LeadTime.Mean, P85: p.LeadTime.P85},
+			Profit:     p.Financials.Profit,
+		})
+	}
+	return d, nil
+}
@@ -0,0 +1,201 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/config"
+	"github.com/Germanicus1/kanban-sim/backend/internal/engine"
+	"github.com/Germanicus1/kanban-sim/backend/internal/games"
+	"github.com/Germanicus1/kanban-sim/backend/internal/memstore"
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// newTestService returns a session service and the games service it uses,
+// both on one empty memory store.
+func newTestService(t *testing.T) (*Service, *games.Service, models.BoardConfig) {
+	t.Helper()
+	cfg, err := config.LoadBoardConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := memstore.New()
+	gs := games.NewService(games.NewMemoryRepo(store))
+	scenario := models.BoardConfig{EffortTypes: cfg.EffortTypes, Columns: cfg.Columns, Cards: cfg.Cards}
+	return NewService(NewMemoryRepo(store), gs), gs, scenario
+}
+
+func TestService_CreateSession(t *testing.T) {
+	ctx := context.Background()
+	svc, gs, cfg := newTestService(t)
+
+	cfg.Seed = 7
+	cfg.Facilitator = "Alice"
+	s, err := svc.CreateSession(ctx, "Monday training", 3, cfg)
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	if s.Name != "Monday training" || s.Seed != 7 || len(s.Games) != 3 {
+		t.Fatalf("CreateSession = %+v; want 3 games with seed 7", s)
+	}
+	for i, sg := range s.Games {
+		if want := []string{"Team 1", "Team 2", "Team 3"}[i]; sg.Team != want {
+			t.Errorf("team %d = %q; want %q", i, sg.Team, want)
+		}
+		g, err := gs.GetGame(ctx, sg.GameID)
+		if err != nil {
+			t.Fatalf("GetGame(%s): %v", sg.Team, err)
+		}
+		if g.Seed != 7 || g.FacilitatorID != nil || g.Status != models.GameLobby {
+			t.Errorf("game of %s = %+v; want seed 7, no facilitator, in the lobby", sg.Team, g)
+		}
+	}
+
+	stored, err := svc.repo.GetSession(ctx, s.ID)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if len(stored.Games) != 3 || stored.Games[2] != s.Games[2] {
+		t.Errorf("stored session = %+v; want %+v", stored, s)
+	}
+
+	// without a seed all games share a random one
+	s, err = svc.CreateSession(ctx, "Tuesday training", 2, models.BoardConfig{
+		EffortTypes: cfg.EffortTypes, Columns: cfg.Columns, Cards: cfg.Cards,
+	})
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	g1, _ := gs.GetGame(ctx, s.Games[0].GameID)
+	g2, _ := gs.GetGame(ctx, s.Games[1].GameID)
+	if s.Seed == 0 || g1.Seed != s.Seed || g2.Seed != s.Seed {
+		t.Errorf("seeds: session %d, games %d and %d; want one random seed", s.Seed, g1.Seed, g2.Seed)
+	}
+
+	for _, n := range []int{0, MaxTeams + 1} {
+		if _, err := svc.CreateSession(ctx, "Too many", n, cfg); !errors.Is(err, ErrInvalidTeams) {
+			t.Errorf("CreateSession with %d teams: err = %v; want %v", n, err, ErrInvalidTeams)
+		}
+	}
+}
+
+// failingGames fails to create a game after the first ok ones.
+type failingGames struct {
+	*games.Service
+	ok int
+}
+
+func (f *failingGames) CreateGame(ctx context.Context, cfg models.BoardConfig) (uuid.UUID, error) {
+	if f.ok == 0 {
+		return uuid.Nil, errors.New("disk full")
+	}
+	f.ok--
+	return f.Service.CreateGame(ctx, cfg)
+}
+
+func TestService_CreateSession_RollsBack(t *testing.T) {
+	ctx := context.Background()
+	svc, gs, cfg := newTestService(t)
+	svc.games = &failingGames{Service: gs, ok: 2}
+
+	if _, err := svc.CreateSession(ctx, "Monday training", 4, cfg); err == nil {
+		t.Fatal("CreateSession succeeded; want the error of the third game")
+	}
+	if left, _ := gs.ListGames(ctx); len(left) != 0 {
+		t.Errorf("games left = %+v; want none", left)
+	}
+	var sessions int
+	svc.repo.(*memoryRepo).store.View(func(t *memstore.Tables) error {
+		sessions = len(t.Sessions)
+		return nil
+	})
+	if sessions != 0 {
+		t.Errorf("%d sessions left; want none", sessions)
+	}
+}
+
+func TestService_GetDashboard(t *testing.T) {
+	ctx := context.Background()
+	svc, gs, cfg := newTestService(t)
+
+	cfg.Seed = 7
+	s, err := svc.CreateSession(ctx, "Monday training", 2, cfg)
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+
+	// team 1 plays five days, team 2 has not started
+	time.Sleep(10 * time.Millisecond)
+	idle := time.Now().UTC()
+	time.Sleep(10 * time.Millisecond)
+	team, err := engine.ParseTeam(engine.DefaultTeam)
+	if err != nil {
+		t.Fatal(err)
+	}
+	strategy, err := engine.StrategyByName("strict-wip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	played := s.Games[0].GameID
+	if _, err := gs.ChangeStatus(ctx, played, games.Start); err != nil {
+		t.Fatalf("ChangeStatus: %v", err)
+	}
+	deployed := 0
+	for i := 0; i < 5; i++ {
+		day, err := gs.PlayBotDay(ctx, played, strategy, team)
+		if err != nil {
+			t.Fatalf("PlayBotDay: %v", err)
+		}
+		deployed += day.Deployed
+	}
+
+	// a card deployed on day n earns its value on days n to 5; the team of
+	// seven costs 50 a head for each of the five days
+	board, err := gs.GetBoard(ctx, played)
+	if err != nil {
+		t.Fatalf("GetBoard: %v", err)
+	}
+	profit := -7 * 50 * 5
+	for _, c := range board.Cards {
+		if c.DeployedDay > 0 {
+			profit += engine.DefaultValues[c.ValueEstimate] * (6 - c.DeployedDay)
+		}
+	}
+
+	d, err := svc.GetDashboard(ctx, s.ID)
+	if err != nil {
+		t.Fatalf("GetDashboard: %v", err)
+	}
+	if d.SessionID != s.ID || d.Name != "Monday training" || len(d.Teams) != 2 {
+		t.Fatalf("GetDashboard = %+v; want both teams of the session", d)
+	}
+	t1, t2 := d.Teams[0], d.Teams[1]
+	if t1.Team != "Team 1" || t1.GameID != played || t1.Status != models.GameRunning || t1.Day != 6 {
+		t.Errorf("team 1 = %+v; want running on day 6", t1)
+	}
+	if t1.Profit != profit || t1.Deployed != deployed {
+		t.Errorf("team 1 = %+v; want profit %d and %d deployed", t1, profit, deployed)
+	}
+	if t1.WIP == 0 {
+		t.Errorf("team 1 WIP = 0; want cards in progress")
+	}
+	if t2.Team != "Team 2" || t2.Status != models.GameLobby || t2.Day != 1 || t2.Profit != 0 || t2.Deployed != 0 {
+		t.Errorf("team 2 = %+v; want an unplayed game in the lobby", t2)
+	}
+
+	// team 2 is soft-deleted for inactivity, and drops out
+	policy := games.RetentionPolicy{InactiveFor: time.Hour, Grace: time.Hour}
+	if _, err := gs.CleanUp(ctx, policy, idle.Add(time.Hour)); err != nil {
+		t.Fatalf("CleanUp: %v", err)
+	}
+	if d, err := svc.GetDashboard(ctx, s.ID); err != nil || len(d.Teams) != 1 || d.Teams[0].GameID != played {
+		t.Errorf("GetDashboard after cleanup = %+v, %v; want team 1 only", d, err)
+	}
+
+	if _, err := svc.GetDashboard(ctx, uuid.New()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetDashboard of an unknown session: err = %v; want %v", err, ErrNotFound)
+	}
+}
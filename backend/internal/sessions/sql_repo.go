@@ -0,0 +1,80 @@
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Germanicus1/kanban-sim/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type sqlRepo struct {
+	db *sql.DB
+}
+
+func (r *sqlRepo) CreateSession(ctx context.Context, name string, seed int64) (models.Session, error) {
+	s := models.Session{Name: name, Seed: seed, Games: []models.SessionGame{}}
+	if err := r.db.QueryRowContext(ctx,
+		`INSERT INTO sessions (name, seed) VALUES ($1, $2) RETURNING id, created_at`,
+		name, seed,
+	).Scan(&s.ID, &s.CreatedAt); err != nil {
+		return models.Session{}, fmt.Errorf("insert session: %w", err)
+	}
+	return s, nil
+}
+
+func (r *sqlRepo) AddGame(ctx context.Context, sessionID uuid.UUID, g models.SessionGame) error {
+	if _, err := r.db.ExecContext(ctx,
+		`INSERT INTO session_games (session_id, game_id, team, order_index)
+		 VALUES ($1, $2, $3, (SELECT COUNT(*) FROM session_games WHERE session_id = $1))`,
+		sessionID, g.GameID, g.Team,
+	); err != nil {
+		return fmt.Errorf("insert session game: %w", err)
+	}
+	return nil
+}
+
+func (r *sqlRepo) GetSession(ctx context.Context, id uuid.UUID) (models.Session, error) {
+	s := models.Session{Games: []models.SessionGame{}}
+	switch err := r.db.QueryRowContext(ctx,
+		`SELECT id, name, seed, created_at FROM sessions WHERE id = $1`, id,
+	).Scan(&s.ID, &s.Name, &s.Seed, &s.CreatedAt); err {
+	case nil:
+	case sql.ErrNoRows:
+		return models.Session{}, ErrNotFound
+	default:
+		return models.Session{}, fmt.Errorf("query session: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT team, game_id FROM session_games WHERE session_id = $1 ORDER BY order_index`, id)
+	if err != nil {
+		return models.Session{}, fmt.Errorf("query session games: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var g models.SessionGame
+		if err := rows.Scan(&g.Team, &g.GameID); err != nil {
+			return models.Session{}, fmt.Errorf("scan session game: %w", err)
+		}
+		s.Games = append(s.Games, g)
+	}
+	if err := rows.Err(); err != nil {
+		return models.Session{}, fmt.Errorf("iterate session games: %w", err)
+	}
+	return s, nil
+}
+
+func (r *sqlRepo) DeleteSession(ctx context.Context, id uuid.UUID) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM sessions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete session: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	} else if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}